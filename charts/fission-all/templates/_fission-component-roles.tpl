@@ -129,6 +129,7 @@ rules:
   verbs:
   - get
   - list
+  - watch
 {{- end }}
 {{- define "timer-rules" }}
 rules:
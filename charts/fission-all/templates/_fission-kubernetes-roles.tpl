@@ -29,6 +29,13 @@ rules:
   - list
   - create
   - delete
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  verbs:
+  - create
+  - delete
 - apiGroups:
   - apiextensions.k8s.io
   resources:
@@ -97,6 +104,31 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - create
+  - delete
+- apiGroups:
+  - ""
+  resources:
+  - pods/log
+  verbs:
+  - get
+- apiGroups:
+  - batch
+  resources:
+  - jobs
+  verbs:
+  - create
+  - get
+  - list
+  - watch
+  - patch
+  - delete
+  - deletecollection
 {{- if .Values.executor.serviceAccountCheck.enabled }}  
 - apiGroups:
   - ""
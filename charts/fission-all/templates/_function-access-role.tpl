@@ -17,6 +17,7 @@ rules:
   - fission.io
   resources:
   - packages
+  - environments
   verbs:
   - get
 ---
@@ -30,8 +31,16 @@ rules:
   - fission.io
   resources:
   - packages
+  - environments
   verbs:
   - get
+- apiGroups:
+  - fission.io
+  resources:
+  - packages/archive
+  verbs:
+  - get
+  - create
 - apiGroups:
   - ""
   resources:
@@ -34,6 +34,18 @@ auth:
   jwtExpiryTime: {{ .Values.authentication.jwtExpiryTime | default 120 }}
   jwtIssuer: {{ .Values.authentication.jwtIssuer | default "fission" | quote }}
   {{- end }}
+  {{- printf "\n" -}}
+quota:
+  enabled: {{ .Values.quota.enabled | default false }}
+  {{- if .Values.quota.enabled }}
+  default: {{- toYaml (.Values.quota.default | default dict) | nindent 4 }}
+  namespaces: {{- toYaml (.Values.quota.namespaces | default dict) | nindent 4 }}
+  {{- end }}
+executorPlugins:
+  enabled: {{ .Values.executorPlugins.enabled | default false }}
+  {{- if .Values.executorPlugins.enabled }}
+  plugins: {{- toYaml (.Values.executorPlugins.plugins | default list) | nindent 4 }}
+  {{- end }}
 {{- end -}}
 
 {{/*
@@ -45,6 +45,7 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 	specializePayload := flag.String("specialize-request", "", "JSON payload for specialize request")
 	secretDir := flag.String("secret-dir", "", "Path to shared secrets directory")
 	configDir := flag.String("cfgmap-dir", "", "Path to shared configmap directory")
+	jobInput := flag.String("job-input", "", "Path to the input of a job, to call the function with once specialized and exit")
 
 	flag.Parse()
 	if flag.NArg() == 0 {
@@ -89,10 +90,22 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 				logger.Fatal("error decoding specialize request", zap.Error(err))
 			}
 
-			err = f.SpecializePod(ctx, specializeReq.FetchReq, specializeReq.LoadReq)
+			_, err = f.SpecializePod(ctx, specializeReq.FetchReq, specializeReq.LoadReq)
 			if err != nil {
 				logger.Fatal("error specializing function pod", zap.Error(err))
 			}
+
+			// A job pod runs the function once, and completes once the
+			// fetcher exits.
+			if len(*jobInput) > 0 {
+				err = f.InvokeJob(ctx, *jobInput)
+				if err != nil {
+					logger.Fatal("error invoking job function", zap.Error(err))
+				}
+				logger.Info("job function done")
+				logger.Sync()
+				os.Exit(0)
+			}
 		}
 		atomic.StoreUint32(&readyToServe, 1)
 	})
@@ -100,6 +113,8 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 	mux := http.NewServeMux()
 	mux.HandleFunc("/fetch", f.FetchHandler)
 	mux.HandleFunc("/specialize", f.SpecializeHandler)
+	mux.HandleFunc("/unspecialize", f.UnspecializeHandler)
+	mux.HandleFunc("/refresh", f.RefreshHandler)
 	mux.HandleFunc("/upload", f.UploadHandler)
 	mux.HandleFunc("/version", f.VersionHandler)
 	mux.HandleFunc("/wsevent/start", f.WsStartHandler)
@@ -125,5 +140,5 @@ func Run(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *za
 }
 
 func fetcherUsage() {
-	fmt.Println("Usage: fetcher [-specialize-on-startup] [-specialize-request <json>] [-secret-dir <string>] [-cfgmap-dir <string>] <shared volume path>")
+	fmt.Println("Usage: fetcher [-specialize-on-startup] [-specialize-request <json>] [-job-input <path>] [-secret-dir <string>] [-cfgmap-dir <string>] <shared volume path>")
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cmd/archive"
 	"github.com/fission/fission/pkg/fission-cli/cmd/canaryconfig"
 	"github.com/fission/fission/pkg/fission-cli/cmd/check"
+	"github.com/fission/fission/pkg/fission-cli/cmd/dev"
 	"github.com/fission/fission/pkg/fission-cli/cmd/environment"
 	"github.com/fission/fission/pkg/fission-cli/cmd/function"
 	"github.com/fission/fission/pkg/fission-cli/cmd/httptrigger"
@@ -90,7 +91,7 @@ func App(clientOptions cmd.ClientOptions) *cobra.Command {
 	groups = append(groups, helptemplate.CreateCmdGroup("Basic Commands", environment.Commands(), _package.Commands(), function.Commands(), archive.Commands()))
 	groups = append(groups, helptemplate.CreateCmdGroup("Trigger Commands", httptrigger.Commands(), mqtrigger.Commands(), timetrigger.Commands(), kubewatch.Commands()))
 	groups = append(groups, helptemplate.CreateCmdGroup("Deploy Strategies Commands", canaryconfig.Commands()))
-	groups = append(groups, helptemplate.CreateCmdGroup("Declarative Application Commands", spec.Commands()))
+	groups = append(groups, helptemplate.CreateCmdGroup("Declarative Application Commands", spec.Commands(), dev.Commands()))
 	groups = append(groups, helptemplate.CreateCmdGroup("Other Commands", support.Commands(), version.Commands(), check.Commands()))
 	groups.Add(rootCmd)
 
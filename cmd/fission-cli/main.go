@@ -17,21 +17,26 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/fission/fission/cmd/fission-cli/app"
-	"github.com/fission/fission/pkg/fission-cli/cmd"
+	fcmd "github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/console"
 )
 
 func main() {
-	cmd := app.App(cmd.ClientOptions{})
+	cmd := app.App(fcmd.ClientOptions{})
 	cmd.SilenceErrors = true // use our own error message printer
 
 	err := cmd.Execute()
 	if err != nil {
 		// let program exit with non-zero code when error occurs
 		console.Error(err.Error())
+		var exitErr *fcmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -41,6 +41,15 @@ const (
 
 	// ArchiveTypeUrl means the package contents are at the specified URL.
 	ArchiveTypeUrl ArchiveType = "url"
+
+	// ArchiveTypeGit means the package contents are cloned from the Git
+	// repository specified in the Git field. Only supported for source
+	// archives.
+	ArchiveTypeGit ArchiveType = "git"
+
+	// ArchiveTypeOCI means the package contents are an OCI artifact,
+	// referenced by the URL field.
+	ArchiveTypeOCI ArchiveType = "oci"
 )
 
 const (
@@ -51,6 +60,32 @@ const (
 	BuildStatusNone      = "none"
 )
 
+const (
+	// BuilderModeDeployment builds packages in a long-lived builder
+	// deployment shared by the package builds of the environment.
+	BuilderModeDeployment BuilderMode = "deployment"
+	// BuilderModeJob builds every package in its own short-lived job.
+	BuilderModeJob BuilderMode = "job"
+)
+
+const (
+	// VerificationPolicyEnforce refuses to fetch packages failing
+	// signature verification.
+	VerificationPolicyEnforce VerificationPolicy = "enforce"
+	// VerificationPolicyWarn fetches packages failing signature
+	// verification with a warning.
+	VerificationPolicyWarn VerificationPolicy = "warn"
+
+	// PackageVerificationConfigMap is the name of the ConfigMap configuring
+	// the verification of package signatures in a namespace. Its "policy"
+	// key holds the VerificationPolicy, the other keys trusted PEM encoded
+	// public keys or x509 certificates.
+	PackageVerificationConfigMap = "fission-package-verification"
+	// PackageVerificationPolicyKey is the key of the policy in the
+	// package verification ConfigMap.
+	PackageVerificationPolicyKey = "policy"
+)
+
 const (
 	AllowedFunctionsPerContainerSingle   = "single"
 	AllowedFunctionsPerContainerInfinite = "infinite"
@@ -60,6 +95,11 @@ const (
 	ExecutorTypePoolmgr   ExecutorType = "poolmgr"
 	ExecutorTypeNewdeploy ExecutorType = "newdeploy"
 	ExecutorTypeContainer ExecutorType = "container"
+	ExecutorTypeJob       ExecutorType = "job"
+
+	// ExecutorTypePluginPrefix is the prefix of executor types served by
+	// out-of-tree executor plugins, e.g. "plugin-knative".
+	ExecutorTypePluginPrefix = "plugin-"
 )
 
 const (
@@ -78,6 +118,8 @@ const (
 	SharedVolumeConfigmaps = "configmaps"
 	PodInfoVolume          = "podinfo"
 	PodInfoMount           = "/etc/podinfo"
+	JobInputVolume         = "job-input"
+	JobInputMount          = "/etc/fission/job"
 )
 
 const (
@@ -132,9 +174,19 @@ const (
 	FUNCTION_UID              = "functionUid"
 	FUNCTION_RESOURCE_VERSION = "functionResourceVersion"
 	EXECUTOR_TYPE             = "executorType"
+	JOB_INVOCATION_ID         = "jobInvocationId"
 	MANAGED                   = "managed"
 )
 
+// builder kubernetes object label key
+const (
+	LABEL_ENV_NAME            = "envName"
+	LABEL_ENV_NAMESPACE       = "envNamespace"
+	LABEL_ENV_RESOURCEVERSION = "envResourceVersion"
+	LABEL_PACKAGE_NAME        = "packageName"
+	LABEL_PACKAGE_NAMESPACE   = "packageNamespace"
+)
+
 const (
 	ANNOTATION_SVC_HOST = "svcHost"
 )
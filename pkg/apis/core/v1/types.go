@@ -17,8 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	"strings"
+
 	asv2 "k8s.io/api/autoscaling/v2"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -213,7 +216,7 @@ type (
 		Sum  string       `json:"sum,omitempty"`
 	}
 
-	// ArchiveType is either literal or URL, indicating whether
+	// ArchiveType is either literal, URL, git or OCI, indicating whether
 	// the package is specified in the Archive struct or
 	// externally.
 	ArchiveType string
@@ -221,10 +224,12 @@ type (
 	// Archive contains or references a collection of sources or
 	// binary files.
 	Archive struct {
-		// Type defines how the package is specified: literal or URL.
+		// Type defines how the package is specified: literal, URL, git or OCI.
 		// Available value:
 		//  - literal
 		//  - url
+		//  - git
+		//  - oci
 		// +optional
 		Type ArchiveType `json:"type,omitempty"`
 
@@ -233,14 +238,71 @@ type (
 		// +optional
 		Literal []byte `json:"literal,omitempty"`
 
-		// URL references a package.
+		// URL references a package. OCI archives are referenced as
+		// "oci://registry/repository:tag@digest".
 		// +optional
 		URL string `json:"url,omitempty"`
 
 		// Checksum ensures the integrity of packages
-		// referenced by URL. Ignored for literals.
+		// referenced by URL. Ignored for literals and OCI archives,
+		// which are verified against the digest of their reference.
 		// +optional
 		Checksum Checksum `json:"checksum,omitempty"`
+
+		// Git references a source package in a Git repository.
+		// +optional
+		Git *GitSource `json:"git,omitempty"`
+
+		// (Optional) RegistrySecret is the name of an image pull secret in
+		// the package namespace holding the credentials of the registry of
+		// an OCI archive. Defaults to the image pull secret of the
+		// environment.
+		// +optional
+		RegistrySecret string `json:"registrySecret,omitempty"`
+
+		// Signature of the archive, or of the commit of a git source,
+		// verified by the fetcher against the trusted keys of the package
+		// namespace and environment. Deployment archives built are signed
+		// by the builder manager when it has a signing key.
+		// +optional
+		Signature *ArchiveSignature `json:"signature,omitempty"`
+	}
+
+	// ArchiveSignature is the signature of the SHA-256 digest of an archive
+	// file, or of the commit ID of a git source, made with an ed25519 key or
+	// the key of an x509 certificate.
+	ArchiveSignature struct {
+		// Signature is the base64 encoded signature.
+		Signature string `json:"signature"`
+
+		// (Optional) KeyID identifies the key used to sign the archive, the
+		// first 16 hex digits of the SHA-256 of its PKIX public key.
+		// +optional
+		KeyID string `json:"keyID,omitempty"`
+	}
+
+	// GitSource is a directory of a Git repository at a given reference.
+	GitSource struct {
+		// URL of the repository, e.g. "https://github.com/org/repo.git",
+		// "git@github.com:org/repo.git" or "file:///path/to/repo".
+		URL string `json:"url"`
+
+		// (Optional) Ref is the branch, tag or commit SHA to build. Defaults
+		// to the default branch of the repository.
+		// +optional
+		Ref string `json:"ref,omitempty"`
+
+		// (Optional) SubPath is the directory of the repository holding the
+		// source package. Defaults to the root of the repository.
+		// +optional
+		SubPath string `json:"subPath,omitempty"`
+
+		// (Optional) Secret is the name of a secret in the package namespace
+		// holding the credentials of the repository: "username" and "password"
+		// (or an access token) for HTTP(S) repositories, "ssh-privatekey" and
+		// "known_hosts" for SSH repositories.
+		// +optional
+		Secret string `json:"secret,omitempty"`
 	}
 
 	// EnvironmentReference is a reference to an environment.
@@ -283,6 +345,11 @@ type (
 		// +optional
 		BuildCommand string `json:"buildcmd,omitempty"`
 
+		// BuildTimeout is the maximum time in seconds the build of the package may run.
+		// Overrides the build timeout of the environment builder.
+		// +optional
+		BuildTimeout int `json:"buildTimeout,omitempty"`
+
 		// In the future, we can have a debug build here too
 	}
 
@@ -299,6 +366,16 @@ type (
 		// +optional
 		BuildLog string `json:"buildlog,omitempty"` // output of the build (errors etc)
 
+		// BuildLogURL is the storage service URL of the full build log.
+		// BuildLog only keeps the tail of long build logs.
+		// +optional
+		BuildLogURL string `json:"buildLogUrl,omitempty"`
+
+		// SourceCommit is the commit SHA the package was built from, for
+		// source packages in a Git repository.
+		// +optional
+		SourceCommit string `json:"sourceCommit,omitempty"`
+
 		// LastUpdateTimestamp will store the timestamp the package was last updated
 		// metav1.Time is a wrapper around time.Time which supports correct marshaling to YAML and JSON.
 		// https://github.com/kubernetes/apimachinery/blob/44bd77c24ef93cd3a5eb6fef64e514025d10d44e/pkg/apis/meta/v1/time.go#L26-L35
@@ -450,6 +527,8 @@ type (
 		//  - poolmgr
 		//  - newdeploy
 		//  - container
+		//  - job
+		//  - plugin-<name>, served by the executor plugin registered with that name
 		// +optional
 		ExecutorType ExecutorType `json:"ExecutorType"`
 
@@ -567,6 +646,24 @@ type (
 		// (Optional) Default build command to run for this build environment.
 		Command string `json:"command,omitempty"`
 
+		// (Optional) Mode is how package builds are run. With "deployment", all
+		// packages are built in one long-lived builder deployment. With "job",
+		// every package is built in its own Kubernetes Job, isolating builds
+		// from each other and running them in parallel, without idle builder
+		// pods. Defaults to "deployment".
+		// +kubebuilder:validation:Enum=deployment;job
+		// +optional
+		Mode BuilderMode `json:"mode,omitempty"`
+
+		// (Optional) BuildTimeout is the maximum time in seconds a build may run,
+		// builds running longer are killed. Zero means no limit, except in job
+		// mode where builds are killed after an hour.
+		BuildTimeout int `json:"buildTimeout,omitempty"`
+
+		// (Optional) Cache configures a dependency cache shared by the package
+		// builds of this environment.
+		Cache *BuildCache `json:"cache,omitempty"`
+
 		// (Optional) Container allows the modification of the deployed builder
 		// container using the Kubernetes Container spec. Fission overrides
 		// the following fields:
@@ -582,6 +679,29 @@ type (
 		PodSpec *apiv1.PodSpec `json:"podspec,omitempty"`
 	}
 
+	// BuildCache configures the dependency cache of an environment builder.
+	// Dependency directories are saved after a successful build and restored
+	// before the next build of a source package with the same lockfiles.
+	BuildCache struct {
+		// Paths are the dependency directories to cache, e.g. "node_modules".
+		// Relative paths are relative to the source package.
+		Paths []string `json:"paths"`
+
+		// KeyFiles are the lockfiles of the source package the cache is keyed
+		// by, e.g. "package-lock.json" or "go.sum". Builds without any of the
+		// files don't use the cache.
+		KeyFiles []string `json:"keyFiles"`
+
+		// (Optional) MaxSize is the maximum size of the cache, the least recently
+		// used entries are evicted. Defaults to 1Gi.
+		MaxSize resource.Quantity `json:"maxSize,omitempty"`
+
+		// (Optional) PersistentVolumeClaim is the name of a claim to store the cache
+		// in, so that it outlives the builder pod. By default the cache is stored
+		// in an emptyDir volume of the builder pod.
+		PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	}
+
 	// EnvironmentSpec contains with builder, runtime and some other related environment settings.
 	EnvironmentSpec struct {
 		// Version is the Environment API version
@@ -645,10 +765,44 @@ type (
 		// private registry.
 		// +optional
 		ImagePullSecret string `json:"imagepullsecret"`
+
+		// RecyclePods allows poolmgr to return idle specialized pods to the pool
+		// instead of deleting them. The runtime must implement the unspecialize
+		// endpoint; pods that can't be reset are deleted as usual.
+		// (Optional) defaults to 'false'
+		// +optional
+		RecyclePods bool `json:"recyclePods,omitempty"`
+
+		// PackageVerification is the verification of the signatures of the
+		// packages of the environment. Packages must also pass the
+		// verification configured for the package namespace, if any.
+		// (Optional) defaults to the verification of the package namespace.
+		// +optional
+		PackageVerification *PackageVerification `json:"packageVerification,omitempty"`
+	}
+
+	// PackageVerification configures the verification of package signatures.
+	PackageVerification struct {
+		// Policy is what happens to a package whose signature is missing or
+		// does not match a trusted key: either "enforce", which refuses to
+		// fetch it, or "warn", which logs a warning.
+		// +kubebuilder:validation:Enum=enforce;warn
+		Policy VerificationPolicy `json:"policy"`
+
+		// TrustedKeys is the name of a ConfigMap in the environment namespace
+		// holding the trusted PEM encoded public keys or x509 certificates.
+		TrustedKeys string `json:"trustedKeys"`
 	}
 	// AllowedFunctionsPerContainer defaults to 'single'. Related to Fission Workflows
 	AllowedFunctionsPerContainer string
 
+	// BuilderMode is how the package builds of an environment are run.
+	BuilderMode string
+
+	// VerificationPolicy is how packages failing signature verification are
+	// handled.
+	VerificationPolicy string
+
 	//
 	// Triggers
 	//
@@ -882,7 +1036,12 @@ type (
 
 // IsEmpty checks if the archive byte and litreal are of length 0
 func (a Archive) IsEmpty() bool {
-	return len(a.Literal) == 0 && len(a.URL) == 0
+	return len(a.Literal) == 0 && len(a.URL) == 0 && a.Git == nil
+}
+
+// IsPlugin checks if the executor type is served by an executor plugin
+func (et ExecutorType) IsPlugin() bool {
+	return strings.HasPrefix(string(et), ExecutorTypePluginPrefix)
 }
 
 func (fn Function) GetConcurrency() int {
@@ -17,9 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
 	"reflect"
 	"regexp"
 	"strings"
@@ -194,6 +196,16 @@ func (archive Archive) Validate() error {
 	if len(archive.Type) > 0 {
 		switch archive.Type {
 		case ArchiveTypeLiteral, ArchiveTypeUrl: // no op
+		case ArchiveTypeGit:
+			if archive.Git == nil || len(archive.Git.URL) == 0 {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.Git.URL", "", "repository URL is required for git archives"))
+			} else if path.IsAbs(archive.Git.SubPath) || strings.HasPrefix(path.Clean(archive.Git.SubPath), "..") {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.Git.SubPath", archive.Git.SubPath, "must be a relative path within the repository"))
+			}
+		case ArchiveTypeOCI:
+			if !strings.HasPrefix(archive.URL, "oci://") {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.URL", archive.URL, "OCI archives must be referenced as oci://registry/repository:tag@digest"))
+			}
 		default:
 			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "Archive.Type", archive.Type, "not a valid archive type"))
 		}
@@ -203,6 +215,15 @@ func (archive Archive) Validate() error {
 		result = multierror.Append(result, archive.Checksum.Validate())
 	}
 
+	if archive.Signature != nil {
+		if archive.Type == ArchiveTypeGit {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.Signature", "", "git archives cannot be signed"))
+		}
+		if _, err := base64.StdEncoding.DecodeString(archive.Signature.Signature); err != nil || len(archive.Signature.Signature) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "Archive.Signature.Signature", archive.Signature.Signature, "must be base64 encoded"))
+		}
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -230,11 +251,15 @@ func (spec PackageSpec) Validate() error {
 	result = multierror.Append(result, spec.Environment.Validate())
 
 	for _, r := range []Archive{spec.Source, spec.Deployment} {
-		if len(r.URL) > 0 || len(r.Literal) > 0 {
+		if !r.IsEmpty() || len(r.Type) > 0 {
 			result = multierror.Append(result, r.Validate())
 		}
 	}
 
+	if spec.Deployment.Type == ArchiveTypeGit || spec.Deployment.Git != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "PackageSpec.Deployment.Type", spec.Deployment.Type, "git archives are only supported for source packages"))
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -314,11 +339,20 @@ func (es ExecutionStrategy) Validate() error {
 	result := &multierror.Error{}
 
 	switch es.ExecutorType {
-	case ExecutorTypeNewdeploy, ExecutorTypePoolmgr, ExecutorTypeContainer: // no op
+	case ExecutorTypeNewdeploy, ExecutorTypePoolmgr, ExecutorTypeContainer, ExecutorTypeJob: // no op
 	default:
+		if es.ExecutorType.IsPlugin() {
+			name := strings.TrimPrefix(string(es.ExecutorType), ExecutorTypePluginPrefix)
+			result = multierror.Append(result, ValidateKubeName("ExecutionStrategy.ExecutorType", name))
+			break
+		}
 		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ExecutionStrategy.ExecutorType", es.ExecutorType, "not a valid executor type"))
 	}
 
+	if es.ExecutorType == ExecutorTypeJob && es.MaxScale < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.MaxScale", es.MaxScale, "maximum concurrent jobs must be greater than or equal to 0"))
+	}
+
 	if es.ExecutorType == ExecutorTypeNewdeploy || es.ExecutorType == ExecutorTypeContainer {
 		if es.MinScale < 0 {
 			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.MinScale", es.MinScale, "minimum scale must be greater than or equal to 0"))
@@ -377,8 +411,15 @@ func (runtime Runtime) Validate() error {
 }
 
 func (builder Builder) Validate() error {
-	// do nothing for now
-	return nil
+	result := &multierror.Error{}
+
+	switch builder.Mode {
+	case "", BuilderModeDeployment, BuilderModeJob: // no op
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "Builder.Mode", builder.Mode, "not a valid value"))
+	}
+
+	return result.ErrorOrNil()
 }
 
 func (spec EnvironmentSpec) Validate() error {
@@ -410,6 +451,26 @@ func (spec EnvironmentSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "EnvironmentSpec.TerminationGracePeriod", spec.TerminationGracePeriod, "must be greater than or equal to 0"))
 	}
 
+	if spec.PackageVerification != nil {
+		result = multierror.Append(result, spec.PackageVerification.Validate())
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (pv PackageVerification) Validate() error {
+	result := &multierror.Error{}
+
+	switch pv.Policy {
+	case VerificationPolicyEnforce, VerificationPolicyWarn: // no op
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "PackageVerification.Policy", pv.Policy, "not a valid verification policy"))
+	}
+
+	if len(pv.TrustedKeys) == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "PackageVerification.TrustedKeys", pv.TrustedKeys, "name of the ConfigMap holding the trusted keys is required"))
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -11,17 +11,30 @@ package v1
 // Those methods can be generated by using hack/update-swagger-docs.sh
 // AUTO-GENERATED FUNCTIONS START HERE
 var map_Archive = map[string]string{
-	"":         "Archive contains or references a collection of sources or binary files.",
-	"type":     "Type defines how the package is specified: literal or URL. Available value:\n - literal\n - url",
-	"literal":  "Literal contents of the package. Can be used for encoding packages below TODO (256 KB?) size.",
-	"url":      "URL references a package.",
-	"checksum": "Checksum ensures the integrity of packages referenced by URL. Ignored for literals.",
+	"":               "Archive contains or references a collection of sources or binary files.",
+	"type":           "Type defines how the package is specified: literal, URL, git or OCI. Available value:\n - literal\n - url\n - git\n - oci",
+	"literal":        "Literal contents of the package. Can be used for encoding packages below TODO (256 KB?) size.",
+	"url":            "URL references a package. OCI archives are referenced as \"oci://registry/repository:tag@digest\".",
+	"checksum":       "Checksum ensures the integrity of packages referenced by URL. Ignored for literals and OCI archives, which are verified against the digest of their reference.",
+	"git":            "Git references a source package in a Git repository.",
+	"registrySecret": "(Optional) RegistrySecret is the name of an image pull secret in the package namespace holding the credentials of the registry of an OCI archive. Defaults to the image pull secret of the environment.",
+	"signature":      "Signature of the archive, or of the commit of a git source, verified by the fetcher against the trusted keys of the package namespace and environment. Deployment archives built are signed by the builder manager when it has a signing key.",
 }
 
 func (Archive) SwaggerDoc() map[string]string {
 	return map_Archive
 }
 
+var map_ArchiveSignature = map[string]string{
+	"":          "ArchiveSignature is the signature of the SHA-256 digest of an archive file, or of the commit ID of a git source, made with an ed25519 key or the key of an x509 certificate.",
+	"signature": "Signature is the base64 encoded signature.",
+	"keyID":     "(Optional) KeyID identifies the key used to sign the archive, the first 16 hex digits of the SHA-256 of its PKIX public key.",
+}
+
+func (ArchiveSignature) SwaggerDoc() map[string]string {
+	return map_ArchiveSignature
+}
+
 var map_AuthLogin = map[string]string{
 	"": "AuthLogin defines the body for router login",
 }
@@ -30,12 +43,27 @@ func (AuthLogin) SwaggerDoc() map[string]string {
 	return map_AuthLogin
 }
 
+var map_BuildCache = map[string]string{
+	"":                      "BuildCache configures the dependency cache of an environment builder. Dependency directories are saved after a successful build and restored before the next build of a source package with the same lockfiles.",
+	"paths":                 "Paths are the dependency directories to cache, e.g. \"node_modules\". Relative paths are relative to the source package.",
+	"keyFiles":              "KeyFiles are the lockfiles of the source package the cache is keyed by, e.g. \"package-lock.json\" or \"go.sum\". Builds without any of the files don't use the cache.",
+	"maxSize":               "(Optional) MaxSize is the maximum size of the cache, the least recently used entries are evicted. Defaults to 1Gi.",
+	"persistentVolumeClaim": "(Optional) PersistentVolumeClaim is the name of a claim to store the cache in, so that it outlives the builder pod. By default the cache is stored in an emptyDir volume of the builder pod.",
+}
+
+func (BuildCache) SwaggerDoc() map[string]string {
+	return map_BuildCache
+}
+
 var map_Builder = map[string]string{
-	"":          "Builder is the setting for environment builder.",
-	"image":     "Image for containing the language compilation environment.",
-	"command":   "(Optional) Default build command to run for this build environment.",
-	"container": "(Optional) Container allows the modification of the deployed builder container using the Kubernetes Container spec. Fission overrides the following fields: - Name - Image; set to the Builder.Image - Command; set to the Builder.Command - TerminationMessagePath - ImagePullPolicy - ReadinessProbe",
-	"podspec":   "PodSpec will store the spec of the pod that will be applied to the pod created for the builder",
+	"":             "Builder is the setting for environment builder.",
+	"image":        "Image for containing the language compilation environment.",
+	"command":      "(Optional) Default build command to run for this build environment.",
+	"mode":         "(Optional) Mode is how package builds are run. With \"deployment\", all packages are built in one long-lived builder deployment. With \"job\", every package is built in its own Kubernetes Job, isolating builds from each other and running them in parallel, without idle builder pods. Defaults to \"deployment\".",
+	"buildTimeout": "(Optional) BuildTimeout is the maximum time in seconds a build may run, builds running longer are killed. Zero means no limit, except in job mode where builds are killed after an hour.",
+	"cache":        "(Optional) Cache configures a dependency cache shared by the package builds of this environment.",
+	"container":    "(Optional) Container allows the modification of the deployed builder container using the Kubernetes Container spec. Fission overrides the following fields: - Name - Image; set to the Builder.Image - Command; set to the Builder.Command - TerminationMessagePath - ImagePullPolicy - ReadinessProbe",
+	"podspec":      "PodSpec will store the spec of the pod that will be applied to the pod created for the builder",
 }
 
 func (Builder) SwaggerDoc() map[string]string {
@@ -132,6 +160,8 @@ var map_EnvironmentSpec = map[string]string{
 	"terminationGracePeriod":       "The grace time for pod to perform connection draining before termination. The unit is in seconds. (Optional) defaults to 360 seconds",
 	"keeparchive":                  "KeepArchive is used by fetcher to determine if the extracted archive or unarchived file should be placed, which is then used by specialize handler. (This is mainly for the JVM environment because .jar is one kind of zip archive.)",
 	"imagepullsecret":              "ImagePullSecret is the secret for Kubernetes to pull an image from a private registry.",
+	"recyclePods":                  "RecyclePods allows poolmgr to return idle specialized pods to the pool instead of deleting them. The runtime must implement the unspecialize endpoint; pods that can't be reset are deleted as usual. (Optional) defaults to 'false'",
+	"packageVerification":          "PackageVerification is the verification of the signatures of the packages of the environment. Packages must also pass the verification configured for the package namespace, if any. (Optional) defaults to the verification of the package namespace.",
 }
 
 func (EnvironmentSpec) SwaggerDoc() map[string]string {
@@ -140,7 +170,7 @@ func (EnvironmentSpec) SwaggerDoc() map[string]string {
 
 var map_ExecutionStrategy = map[string]string{
 	"":                      "ExecutionStrategy specifies low-level parameters for function execution, such as the number of instances.\n\nMinScale affects the cold start behavior for a function. If MinScale is 0 then the deployment is created on first invocation of function and is good for requests of asynchronous nature. If MinScale is greater than 0 then MinScale number of pods are created at the time of creation of function. This ensures faster response during first invocation at the cost of consuming resources.\n\nMaxScale is the maximum number of pods that function will scale to based on TargetCPUPercent and resources allocated to the function pod.",
-	"ExecutorType":          "ExecutorType is the executor type of function used. Defaults to \"poolmgr\".\n\nAvailable value:\n - poolmgr\n - newdeploy\n - container",
+	"ExecutorType":          "ExecutorType is the executor type of function used. Defaults to \"poolmgr\".\n\nAvailable value:\n - poolmgr\n - newdeploy\n - container\n - job\n - plugin-<name>, served by the executor plugin registered with that name",
 	"MinScale":              "This is only for newdeploy to set up minimum replicas of deployment.",
 	"MaxScale":              "This is only for newdeploy to set up maximum replicas of deployment.",
 	"TargetCPUPercent":      "Deprecated: use hpaMetrics instead. This is only for executor type newdeploy and container to set up target CPU utilization of HPA. Applicable for executor type newdeploy and container.",
@@ -211,6 +241,18 @@ func (FunctionSpec) SwaggerDoc() map[string]string {
 	return map_FunctionSpec
 }
 
+var map_GitSource = map[string]string{
+	"":        "GitSource is a directory of a Git repository at a given reference.",
+	"url":     "URL of the repository, e.g. \"https://github.com/org/repo.git\", \"git@github.com:org/repo.git\" or \"file:///path/to/repo\".",
+	"ref":     "(Optional) Ref is the branch, tag or commit SHA to build. Defaults to the default branch of the repository.",
+	"subPath": "(Optional) SubPath is the directory of the repository holding the source package. Defaults to the root of the repository.",
+	"secret":  "(Optional) Secret is the name of a secret in the package namespace holding the credentials of the repository: \"username\" and \"password\" (or an access token) for HTTP(S) repositories, \"ssh-privatekey\" and \"known_hosts\" for SSH repositories.",
+}
+
+func (GitSource) SwaggerDoc() map[string]string {
+	return map_GitSource
+}
+
 var map_HTTPTrigger = map[string]string{
 	"": "HTTPTrigger is the trigger invokes user functions when receiving HTTP requests.",
 }
@@ -359,11 +401,12 @@ func (PackageRef) SwaggerDoc() map[string]string {
 }
 
 var map_PackageSpec = map[string]string{
-	"":            "PackageSpec includes source/deploy archives and the reference of environment to build the package.",
-	"environment": "Environment is a reference to the environment for building source archive.",
-	"source":      "Source is the archive contains source code and dependencies file. If the package status is in PENDING state, builder manager will then notify builder to compile source and save the result as deployable archive.",
-	"deployment":  "Deployment is the deployable archive that environment runtime used to run user function.",
-	"buildcmd":    "BuildCommand is a custom build command that builder used to build the source archive.",
+	"":             "PackageSpec includes source/deploy archives and the reference of environment to build the package.",
+	"environment":  "Environment is a reference to the environment for building source archive.",
+	"source":       "Source is the archive contains source code and dependencies file. If the package status is in PENDING state, builder manager will then notify builder to compile source and save the result as deployable archive.",
+	"deployment":   "Deployment is the deployable archive that environment runtime used to run user function.",
+	"buildcmd":     "BuildCommand is a custom build command that builder used to build the source archive.",
+	"buildTimeout": "BuildTimeout is the maximum time in seconds the build of the package may run. Overrides the build timeout of the environment builder.",
 }
 
 func (PackageSpec) SwaggerDoc() map[string]string {
@@ -374,6 +417,8 @@ var map_PackageStatus = map[string]string{
 	"":                    "PackageStatus contains the build status of a package also the build log for examination.",
 	"buildstatus":         "BuildStatus is the package build status.",
 	"buildlog":            "BuildLog stores build log during the compilation.",
+	"buildLogUrl":         "BuildLogURL is the storage service URL of the full build log. BuildLog only keeps the tail of long build logs.",
+	"sourceCommit":        "SourceCommit is the commit SHA the package was built from, for source packages in a Git repository.",
 	"lastUpdateTimestamp": "LastUpdateTimestamp will store the timestamp the package was last updated metav1.Time is a wrapper around time.Time which supports correct marshaling to YAML and JSON. https://github.com/kubernetes/apimachinery/blob/44bd77c24ef93cd3a5eb6fef64e514025d10d44e/pkg/apis/meta/v1/time.go#L26-L35",
 }
 
@@ -381,6 +426,16 @@ func (PackageStatus) SwaggerDoc() map[string]string {
 	return map_PackageStatus
 }
 
+var map_PackageVerification = map[string]string{
+	"":            "PackageVerification configures the verification of package signatures.",
+	"policy":      "Policy is what happens to a package whose signature is missing or does not match a trusted key: either \"enforce\", which refuses to fetch it, or \"warn\", which logs a warning.",
+	"trustedKeys": "TrustedKeys is the name of a ConfigMap in the environment namespace holding the trusted PEM encoded public keys or x509 certificates.",
+}
+
+func (PackageVerification) SwaggerDoc() map[string]string {
+	return map_PackageVerification
+}
+
 var map_RouterAuthToken = map[string]string{
 	"": "RouterAuthToken defines the authorization token for accessing router",
 }
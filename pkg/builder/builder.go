@@ -17,9 +17,9 @@ limitations under the License.
 package builder
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,6 +28,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dchest/uniuri"
@@ -53,16 +54,33 @@ type (
 		// 1. SRC_PKG: path to source package directory
 		// 2. DEPLOY_PKG: path to deployment package directory
 		BuildCommand string `json:"command"`
+		// Timeout is the maximum time in seconds the build may run.
+		// Zero means no limit.
+		Timeout int `json:"timeout,omitempty"`
+		// Package is the namespace/name of the package being built,
+		// used to follow the logs of the build.
+		Package string `json:"package,omitempty"`
+		// Cache configures the dependency cache of the build, nil disables it.
+		Cache *BuildCache `json:"cache,omitempty"`
 	}
 
 	PackageBuildResponse struct {
 		ArtifactFilename string `json:"artifactFilename"`
-		BuildLogs        string `json:"buildLogs"`
+		// BuildLogs is the tail of the build logs, see MaxBuildLogSize.
+		BuildLogs string `json:"buildLogs"`
+		// LogFilename is the file in the shared volume holding the full build logs.
+		LogFilename string `json:"logFilename,omitempty"`
 	}
 
 	Builder struct {
 		logger           *zap.Logger
 		sharedVolumePath string
+
+		logsLock sync.Mutex
+		logs     map[string]*buildLog
+
+		cacheDir  string
+		cacheLock sync.Mutex
 	}
 )
 
@@ -70,6 +88,8 @@ func MakeBuilder(logger *zap.Logger, sharedVolumePath string) *Builder {
 	return &Builder{
 		logger:           logger.Named("builder"),
 		sharedVolumePath: sharedVolumePath,
+		logs:             make(map[string]*buildLog),
+		cacheDir:         BuildCacheMountPath,
 	}
 }
 
@@ -150,18 +170,53 @@ func (builder *Builder) Handler(w http.ResponseWriter, r *http.Request) {
 			buildArgs = append(buildArgs, args[i])
 		}
 	}
-	buildLogs, err := builder.build(r.Context(), buildCmd, buildArgs, srcPkgPath, deployPkgPath)
+
+	// the build is canceled when the build manager gives up on the request
+	ctx := r.Context()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	logFilename := req.SrcPkgFilename + ".log"
+	bl := &buildLog{
+		srcPkgFilename: req.SrcPkgFilename,
+		path:           srcPkgPath + ".log",
+		done:           make(chan struct{}),
+	}
+	if len(req.Package) > 0 {
+		builder.addBuildLog(req.Package, bl)
+	}
+
+	var cacheKey string
+	if req.Cache != nil {
+		// a broken cache only makes the build slower, never fail it
+		cacheKey, err = builder.restoreCache(ctx, req.Cache, srcPkgPath)
+		if err != nil {
+			logger.Warn("error restoring build cache", zap.Error(err))
+		}
+	}
+
+	buildLogs, err := builder.build(ctx, buildCmd, buildArgs, srcPkgPath, deployPkgPath, bl)
 	if err != nil {
 		e := "error building source package"
 		logger.Error(e, zap.Error(err))
 
 		// append error at the end of build logs
 		buildLogs += fmt.Sprintf("%s: %s\n", e, err.Error())
-		builder.reply(r.Context(), w, deployPkgFilename, buildLogs, http.StatusInternalServerError)
+		builder.replyWithLogFile(r.Context(), w, deployPkgFilename, buildLogs, logFilename, http.StatusInternalServerError)
 		return
 	}
 
-	builder.reply(r.Context(), w, deployPkgFilename, buildLogs, http.StatusOK)
+	if len(cacheKey) > 0 {
+		err = builder.saveCache(ctx, req.Cache, cacheKey, srcPkgPath, deployPkgPath)
+		if err != nil {
+			logger.Warn("error saving build cache", zap.Error(err))
+		}
+	}
+
+	builder.replyWithLogFile(r.Context(), w, deployPkgFilename, buildLogs, logFilename, http.StatusOK)
 }
 
 func (builder *Builder) Clean(w http.ResponseWriter, r *http.Request) {
@@ -193,14 +248,26 @@ func (builder *Builder) Clean(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the build log is gone once uploaded, remove it in case the upload failed
+	builder.removeBuildLogs(srcPkgFilename)
+	err = os.Remove(srcPkgPath + ".log")
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error("error deleting build log", zap.Error(err))
+	}
+
 	builder.reply(r.Context(), w, srcPkgFilename, "", http.StatusOK)
 }
 
 func (builder *Builder) reply(ctx context.Context, w http.ResponseWriter, pkgFilename string, buildLogs string, statusCode int) {
+	builder.replyWithLogFile(ctx, w, pkgFilename, buildLogs, "", statusCode)
+}
+
+func (builder *Builder) replyWithLogFile(ctx context.Context, w http.ResponseWriter, pkgFilename string, buildLogs string, logFilename string, statusCode int) {
 	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
 	resp := PackageBuildResponse{
 		ArtifactFilename: pkgFilename,
 		BuildLogs:        buildLogs,
+		LogFilename:      logFilename,
 	}
 
 	rBody, err := json.Marshal(resp)
@@ -223,10 +290,17 @@ func (builder *Builder) reply(ctx context.Context, w http.ResponseWriter, pkgFil
 	}
 }
 
-func (builder *Builder) build(ctx context.Context, command string, args []string, srcPkgPath string, deployPkgPath string) (string, error) {
+// build runs the build command. The full output is written to the build log
+// file, the returned logs only hold its tail. The build command is killed
+// once the context is done.
+func (builder *Builder) build(ctx context.Context, command string, args []string, srcPkgPath string, deployPkgPath string, bl *buildLog) (string, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
+	defer close(bl.done)
 
-	cmd := exec.Command(command, args...)
+	cmd := exec.CommandContext(ctx, command, args...)
+	// don't wait forever for processes spawned by the build command
+	// holding the output pipes open after it has been killed
+	cmd.WaitDelay = 10 * time.Second
 
 	fi, err := os.Stat(srcPkgPath)
 	if err != nil {
@@ -244,47 +318,37 @@ func (builder *Builder) build(ctx context.Context, command string, args []string
 		fmt.Sprintf("%s=%s", envDeployPkg, deployPkgPath),
 	)
 
-	stdout, err := cmd.StdoutPipe()
+	logFile, err := os.Create(bl.path)
 	if err != nil {
-		return "", fmt.Errorf("error creating stdout pipe for cmd: %w", err)
+		return "", fmt.Errorf("error creating build log file: %w", err)
 	}
+	defer logFile.Close()
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return "", fmt.Errorf("error creating stderr pipe for cmd: %w", err)
-	}
+	// Runtime logs
+	tail := newTailBuffer(MaxBuildLogSize)
+	out := io.MultiWriter(os.Stdout, logFile, tail)
+	cmd.Stdout = out
+	cmd.Stderr = out
 
 	// Init logs
 	logger.Info("building source package", zap.String("command", command), zap.Strings("args", args), zap.Strings("env", cmd.Env))
 
-	out := io.MultiReader(stdout, stderr)
-	scanner := bufio.NewScanner(out)
-
-	err = cmd.Start()
-	if err != nil {
-		return "", fmt.Errorf("error starting cmd: %w", err)
-	}
 	fmt.Printf("========= START =========\n")
 	defer fmt.Printf("========= END ===========\n")
-	var buildLogs string
-	// Runtime logs
-	for scanner.Scan() {
-		output := scanner.Text()
-		fmt.Println(output)
-		buildLogs += fmt.Sprintf("%s\n", output)
-	}
 
-	if err := scanner.Err(); err != nil {
-		scanErr := fmt.Errorf("error reading cmd output: %w", err)
-		fmt.Println(scanErr)
-		return buildLogs, scanErr
-	}
-
-	err = cmd.Wait()
+	err = cmd.Run()
 	if err != nil {
-		cmdErr := fmt.Errorf("error waiting for cmd %q: %w", command, err)
-		fmt.Println(cmdErr)
-		return buildLogs, cmdErr
+		var cmdErr error
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			cmdErr = fmt.Errorf("build command %q timed out: %w", command, ctx.Err())
+		case errors.Is(ctx.Err(), context.Canceled):
+			cmdErr = fmt.Errorf("build command %q canceled: %w", command, ctx.Err())
+		default:
+			cmdErr = fmt.Errorf("error running cmd %q: %w", command, err)
+		}
+		fmt.Fprintln(io.MultiWriter(os.Stdout, logFile), cmdErr)
+		return tail.String(), cmdErr
 	}
-	return buildLogs, nil
+	return tail.String(), nil
 }
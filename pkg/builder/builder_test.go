@@ -16,7 +16,9 @@ limitations under the License.
 package builder
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -24,6 +26,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fission/fission/pkg/utils/loggerfactory"
 )
@@ -169,6 +172,61 @@ func TestBuilder(t *testing.T) {
 		}
 	})
 
+	// Test build timeout and logs
+	t.Run("BuildTimeout", func(t *testing.T) {
+		srcFile, err := os.Create(dir + "/test-timeout")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer srcFile.Close()
+		body, err := json.Marshal(&PackageBuildRequest{
+			SrcPkgFilename: "test-timeout",
+			BuildCommand:   "sleep 30",
+			Timeout:        1,
+			Package:        "default/test-timeout",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		w := httptest.NewRecorder()
+		builder.Handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+		resp := w.Result()
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected status code %d, got %d", http.StatusInternalServerError, resp.StatusCode)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("expected build to be killed after timeout, took %v", elapsed)
+		}
+		var buildResp PackageBuildResponse
+		err = json.NewDecoder(resp.Body).Decode(&buildResp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buildResp.BuildLogs, "timed out") {
+			t.Errorf("expected build logs to contain timeout, got %s", buildResp.BuildLogs)
+		}
+		if buildResp.LogFilename != "test-timeout.log" {
+			t.Errorf("expected log filename test-timeout.log, got %s", buildResp.LogFilename)
+		}
+
+		// the log of the finished build can still be read
+		w = httptest.NewRecorder()
+		builder.LogsHandler(w, httptest.NewRequest(http.MethodGet, "/logs?package=default/test-timeout", http.NoBody))
+		if w.Result().StatusCode != http.StatusOK {
+			t.Errorf("expected status code %d, got %d", http.StatusOK, w.Result().StatusCode)
+		}
+		if !strings.Contains(w.Body.String(), "timed out") {
+			t.Errorf("expected streamed logs to contain timeout, got %s", w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		builder.LogsHandler(w, httptest.NewRequest(http.MethodGet, "/logs?package=default/unknown", http.NoBody))
+		if w.Result().StatusCode != http.StatusNotFound {
+			t.Errorf("expected status code %d, got %d", http.StatusNotFound, w.Result().StatusCode)
+		}
+	})
+
 	// Test CleanHandler
 	t.Run("CleanHandler", func(t *testing.T) {
 		for _, test := range []struct {
@@ -215,3 +273,193 @@ func TestBuilder(t *testing.T) {
 		}
 	})
 }
+
+func TestTailLog(t *testing.T) {
+	short := "short build log\n"
+	if got := TailLog(short); got != short {
+		t.Errorf("expected short logs to be kept, got %s", got)
+	}
+
+	long := strings.Repeat("a", 10) + strings.Repeat("b", MaxBuildLogSize)
+	got := TailLog(long)
+	if !strings.HasPrefix(got, "... (10 bytes truncated)\n") {
+		t.Errorf("expected truncation marker, got %s", got[:40])
+	}
+	if !strings.HasSuffix(got, strings.Repeat("b", MaxBuildLogSize)) || strings.Contains(got, "aa") {
+		t.Error("expected only the tail of the logs to be kept")
+	}
+
+	tail := newTailBuffer(MaxBuildLogSize)
+	for i := 0; i < 3*MaxBuildLogSize/len(short); i++ {
+		_, _ = tail.Write([]byte(short))
+	}
+	if got := tail.String(); got != TailLog(strings.Repeat(short, 3*MaxBuildLogSize/len(short))) {
+		t.Errorf("expected tail buffer to match TailLog, got %d bytes", len(got))
+	}
+}
+
+func TestBuildCache(t *testing.T) {
+	builder := MakeBuilder(loggerfactory.GetLogger(), t.TempDir())
+	builder.cacheDir = t.TempDir()
+	ctx := t.Context()
+
+	cache := &BuildCache{
+		Paths:    []string{"node_modules"},
+		KeyFiles: []string{"package-lock.json"},
+	}
+	newSrcPkg := func(lockfile string) string {
+		src := t.TempDir()
+		err := os.WriteFile(src+"/package-lock.json", []byte(lockfile), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return src
+	}
+
+	// the first build misses the cache and saves its dependencies
+	src := newSrcPkg("v1")
+	key, err := builder.restoreCache(ctx, cache, src)
+	if err != nil || len(key) == 0 {
+		t.Fatalf("expected cache key, got %q, %v", key, err)
+	}
+	deploy := t.TempDir()
+	err = os.MkdirAll(deploy+"/node_modules/dep", 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(deploy+"/node_modules/dep/index.js", []byte("module.exports = 1"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink("dep/index.js", deploy+"/node_modules/link.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = builder.saveCache(ctx, cache, key, src, deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a build with the same lockfile restores them
+	src = newSrcPkg("v1")
+	key2, err := builder.restoreCache(ctx, cache, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key2 != key {
+		t.Errorf("expected cache key %s, got %s", key, key2)
+	}
+	data, err := os.ReadFile(src + "/node_modules/dep/index.js")
+	if err != nil || string(data) != "module.exports = 1" {
+		t.Errorf("expected dependency to be restored, got %q, %v", data, err)
+	}
+	link, err := os.Readlink(src + "/node_modules/link.js")
+	if err != nil || link != "dep/index.js" {
+		t.Errorf("expected symlink to be restored, got %q, %v", link, err)
+	}
+
+	// a build with another lockfile misses the cache
+	src = newSrcPkg("v2")
+	key3, err := builder.restoreCache(ctx, cache, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key3 == key {
+		t.Error("expected cache key to change with the lockfile")
+	}
+	if _, err := os.Stat(src + "/node_modules"); !os.IsNotExist(err) {
+		t.Errorf("expected no dependencies to be restored, got %v", err)
+	}
+
+	// a build with another builder image misses the cache
+	imageCache := *cache
+	imageCache.Image = "node-builder:22"
+	key5, err := builder.restoreCache(ctx, &imageCache, newSrcPkg("v1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key5 == key {
+		t.Error("expected cache key to change with the builder image")
+	}
+
+	// a build without lockfile doesn't use the cache
+	key4, err := builder.restoreCache(ctx, cache, t.TempDir())
+	if err != nil || len(key4) != 0 {
+		t.Errorf("expected no cache key, got %q, %v", key4, err)
+	}
+
+	// saving past the maximum size evicts the least recently used entry
+	old := time.Now().Add(-time.Hour)
+	err = os.Chtimes(builder.cacheEntryPath(key), old, old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.MaxSize = 1
+	err = builder.saveCache(ctx, cache, key3, src, deploy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(builder.cacheEntryPath(key)); !os.IsNotExist(err) {
+		t.Errorf("expected least recently used entry to be evicted, got %v", err)
+	}
+}
+
+func TestExtractCacheEntryEscape(t *testing.T) {
+	type entry struct {
+		name     string
+		typeflag byte
+		linkname string
+	}
+	tests := []struct {
+		name    string
+		entries []entry
+	}{
+		{
+			name:    "parent path",
+			entries: []entry{{name: "0/../escaped", typeflag: tar.TypeReg}},
+		},
+		{
+			name:    "absolute symlink",
+			entries: []entry{{name: "0/link", typeflag: tar.TypeSymlink, linkname: "/etc"}},
+		},
+		{
+			name:    "relative symlink",
+			entries: []entry{{name: "0/dep/link", typeflag: tar.TypeSymlink, linkname: "../../.."}},
+		},
+		{
+			name: "file through symlink",
+			entries: []entry{
+				{name: "0/link", typeflag: tar.TypeSymlink, linkname: "dep"},
+				{name: "0/link/index.js", typeflag: tar.TypeReg},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gw)
+			for _, e := range test.entries {
+				err := tw.WriteHeader(&tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := gw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			src := t.TempDir()
+			err := extractCacheEntry(&buf, []string{"node_modules"}, src)
+			if err == nil {
+				t.Fatal("expected entry escaping the cached path to be rejected")
+			}
+			if _, err := os.Lstat(src + "/escaped"); !os.IsNotExist(err) {
+				t.Errorf("expected no file outside the cached path, got %v", err)
+			}
+		})
+	}
+}
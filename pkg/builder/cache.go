@@ -0,0 +1,426 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+const (
+	// BuildCacheMountPath is where the dependency cache volume is mounted
+	// in the builder container.
+	BuildCacheMountPath = "/build-cache"
+
+	// DefaultBuildCacheSize is the maximum size of the dependency cache if
+	// none is configured.
+	DefaultBuildCacheSize int64 = 1 << 30
+
+	cacheEntrySuffix = ".tar.gz"
+)
+
+// BuildCache configures the dependency cache of a build.
+type BuildCache struct {
+	// Paths are the dependency directories to cache. Relative paths are
+	// restored into the source package and saved from the deployment
+	// package, as builders install dependencies there; absolute paths,
+	// e.g. a package manager cache, are used as they are.
+	Paths []string `json:"paths"`
+	// KeyFiles are the lockfiles of the source package the cache is keyed by.
+	KeyFiles []string `json:"keyFiles"`
+	// MaxSize is the maximum size of the cache in bytes.
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// Image is the image of the builder, dependencies installed by
+	// another image, e.g. of another runtime version, are not restored.
+	Image string `json:"image,omitempty"`
+}
+
+// cacheKey returns the key of the cache entry of the source package, it
+// changes with the builder image, the content of the lockfiles and the
+// cached paths. It returns an empty key if the source package has none of
+// the lockfiles.
+func cacheKey(cache *BuildCache, srcPkgPath string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", cache.Image)
+	found := false
+	for _, name := range cache.KeyFiles {
+		f, err := os.Open(filepath.Join(srcPkgPath, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00", name)
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		found = true
+	}
+	if !found {
+		return "", nil
+	}
+	for _, p := range cache.Paths {
+		fmt.Fprintf(h, "\x00%s", p)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func cachePath(pkgPath string, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(pkgPath, p)
+}
+
+func (builder *Builder) cacheEntryPath(key string) string {
+	return filepath.Join(builder.cacheDir, key+cacheEntrySuffix)
+}
+
+// restoreCache restores the cached dependencies of the source package,
+// it returns the key of the cache entry to save after the build.
+func (builder *Builder) restoreCache(ctx context.Context, cache *BuildCache, srcPkgPath string) (string, error) {
+	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
+
+	fi, err := os.Stat(srcPkgPath)
+	if err != nil || !fi.IsDir() {
+		// single file source packages have no lockfiles
+		return "", nil
+	}
+	key, err := cacheKey(cache, srcPkgPath)
+	if err != nil || len(key) == 0 {
+		return "", err
+	}
+
+	entry := builder.cacheEntryPath(key)
+	f, err := os.Open(entry)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Info("build cache miss", zap.String("key", key))
+		return key, nil
+	}
+	if err != nil {
+		return key, err
+	}
+	defer f.Close()
+
+	// mark the entry as recently used for eviction
+	now := time.Now()
+	err = os.Chtimes(entry, now, now)
+	if err != nil {
+		logger.Warn("error updating build cache entry access time", zap.Error(err), zap.String("key", key))
+	}
+
+	err = extractCacheEntry(f, cache.Paths, srcPkgPath)
+	if err != nil {
+		return key, fmt.Errorf("error restoring build cache %s: %w", key, err)
+	}
+	logger.Info("build cache restored", zap.String("key", key))
+	return key, nil
+}
+
+// saveCache saves the dependencies of a successful build, unless the
+// cache already holds them, and evicts the least recently used entries
+// if the cache grows past its maximum size.
+func (builder *Builder) saveCache(ctx context.Context, cache *BuildCache, key string, srcPkgPath string, deployPkgPath string) error {
+	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
+
+	entry := builder.cacheEntryPath(key)
+	if _, err := os.Stat(entry); err == nil {
+		return nil
+	}
+
+	pkgPath := srcPkgPath
+	if fi, err := os.Stat(deployPkgPath); err == nil && fi.IsDir() {
+		pkgPath = deployPkgPath
+	}
+
+	builder.cacheLock.Lock()
+	defer builder.cacheLock.Unlock()
+
+	err := os.MkdirAll(builder.cacheDir, 0755)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file so that concurrent builds never
+	// restore a partial entry
+	tmp, err := os.CreateTemp(builder.cacheDir, key+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = writeCacheEntry(tmp, cache.Paths, pkgPath)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error saving build cache %s: %w", key, err)
+	}
+	err = os.Rename(tmp.Name(), entry)
+	if err != nil {
+		return err
+	}
+	logger.Info("build cache saved", zap.String("key", key))
+
+	maxSize := cache.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultBuildCacheSize
+	}
+	return builder.evictCache(ctx, maxSize)
+}
+
+// evictCache removes the least recently used cache entries until the cache
+// fits in maxSize.
+func (builder *Builder) evictCache(ctx context.Context, maxSize int64) error {
+	logger := otelUtils.LoggerWithTraceID(ctx, builder.logger)
+
+	dirEntries, err := os.ReadDir(builder.cacheDir)
+	if err != nil {
+		return err
+	}
+	var entries []fs.FileInfo
+	var total int64
+	for _, de := range dirEntries {
+		if !strings.HasSuffix(de.Name(), cacheEntrySuffix) {
+			continue
+		}
+		fi, err := de.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, fi)
+		total += fi.Size()
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	for _, fi := range entries {
+		if total <= maxSize {
+			break
+		}
+		err = os.Remove(filepath.Join(builder.cacheDir, fi.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		total -= fi.Size()
+		logger.Info("build cache entry evicted", zap.String("entry", fi.Name()), zap.Int64("size", fi.Size()))
+	}
+	return nil
+}
+
+// writeCacheEntry archives the cached paths, each under its index in
+// the paths list.
+func writeCacheEntry(w io.Writer, paths []string, pkgPath string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for i, p := range paths {
+		root := cachePath(pkgPath, p)
+		if _, err := os.Lstat(root); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			var link string
+			if fi.Mode()&fs.ModeSymlink != 0 {
+				link, err = os.Readlink(path)
+				if err != nil {
+					return err
+				}
+			} else if !fi.Mode().IsRegular() && !fi.IsDir() {
+				// skip sockets, devices and the like
+				return nil
+			}
+			hdr, err := tar.FileInfoHeader(fi, link)
+			if err != nil {
+				return err
+			}
+			hdr.Name = filepath.ToSlash(filepath.Join(strconv.Itoa(i), rel))
+			err = tw.WriteHeader(hdr)
+			if err != nil {
+				return err
+			}
+			if !fi.Mode().IsRegular() {
+				return nil
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(tw, f)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tw.Close()
+	if err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// extractCacheEntry restores the cached paths. Paths already present in
+// the source package are left as they are.
+func extractCacheEntry(r io.Reader, paths []string, srcPkgPath string) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	skip := make(map[int]bool)
+	for i, p := range paths {
+		if _, err := os.Lstat(cachePath(srcPkgPath, p)); err == nil {
+			skip[i] = true
+		}
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		index, rel, _ := strings.Cut(hdr.Name, "/")
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(paths) {
+			return fmt.Errorf("invalid build cache entry %q", hdr.Name)
+		}
+		if skip[i] {
+			continue
+		}
+		root := cachePath(srcPkgPath, paths[i])
+		target := filepath.Join(root, rel)
+		if !isWithin(root, target) {
+			return fmt.Errorf("invalid build cache entry %q", hdr.Name)
+		}
+		// an entry extracted earlier must not redirect this one
+		// out of the cached path
+		err = checkNoSymlink(root, target)
+		if err != nil {
+			return fmt.Errorf("invalid build cache entry %q: %w", hdr.Name, err)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, hdr.FileInfo().Mode().Perm()|0700)
+		case tar.TypeSymlink:
+			link := hdr.Linkname
+			if !filepath.IsAbs(link) {
+				link = filepath.Join(filepath.Dir(target), link)
+			}
+			if !isWithin(root, filepath.Clean(link)) {
+				return fmt.Errorf("invalid build cache entry %q: symlink to %q escapes %s", hdr.Name, hdr.Linkname, paths[i])
+			}
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err == nil {
+				err = os.Symlink(hdr.Linkname, target)
+			}
+		case tar.TypeReg:
+			err = extractFile(tr, target, hdr.FileInfo().Mode().Perm())
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// isWithin returns whether path is root or below it.
+func isWithin(root string, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+// checkNoSymlink returns an error if target, or any of its parents below
+// root, is a symlink.
+func checkNoSymlink(root string, target string) error {
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return err
+	}
+	path := root
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		if name == "." {
+			continue
+		}
+		path = filepath.Join(path, name)
+		fi, err := os.Lstat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%s is a symlink", path)
+		}
+	}
+	return nil
+}
+
+func extractFile(r io.Reader, target string, mode fs.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
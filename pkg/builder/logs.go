@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// MaxBuildLogSize is the maximum size of the build logs kept in the package
+// status. Longer logs are truncated to their tail, the full logs are
+// uploaded to the storage service.
+const MaxBuildLogSize = 32 * 1024
+
+// logPollInterval is how often a followed build log is checked for new output.
+const logPollInterval = 500 * time.Millisecond
+
+type (
+	// buildLog is the log file of a build, it can be followed while the
+	// build is running.
+	buildLog struct {
+		srcPkgFilename string
+		path           string
+		done           chan struct{}
+	}
+
+	// tailBuffer keeps the last max bytes written to it.
+	tailBuffer struct {
+		max     int
+		dropped int
+		buf     []byte
+	}
+)
+
+// TailLog truncates logs longer than MaxBuildLogSize to their tail.
+func TailLog(logs string) string {
+	if len(logs) <= MaxBuildLogSize {
+		return logs
+	}
+	dropped := len(logs) - MaxBuildLogSize
+	return truncatedLog(dropped, logs[dropped:])
+}
+
+func truncatedLog(dropped int, tail string) string {
+	return fmt.Sprintf("... (%d bytes truncated)\n%s", dropped, tail)
+}
+
+func newTailBuffer(max int) *tailBuffer {
+	return &tailBuffer{max: max}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	// trim lazily to avoid copying on every write
+	if len(t.buf) > 2*t.max {
+		n := len(t.buf) - t.max
+		t.dropped += n
+		t.buf = append(t.buf[:0], t.buf[n:]...)
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	dropped := t.dropped
+	buf := t.buf
+	if len(buf) > t.max {
+		dropped += len(buf) - t.max
+		buf = buf[len(buf)-t.max:]
+	}
+	if dropped == 0 {
+		return string(buf)
+	}
+	return truncatedLog(dropped, string(buf))
+}
+
+func (builder *Builder) addBuildLog(pkg string, bl *buildLog) {
+	builder.logsLock.Lock()
+	defer builder.logsLock.Unlock()
+	builder.logs[pkg] = bl
+}
+
+func (builder *Builder) getBuildLog(pkg string) (*buildLog, bool) {
+	builder.logsLock.Lock()
+	defer builder.logsLock.Unlock()
+	bl, ok := builder.logs[pkg]
+	return bl, ok
+}
+
+// removeBuildLogs forgets the build logs of the source package.
+func (builder *Builder) removeBuildLogs(srcPkgFilename string) {
+	builder.logsLock.Lock()
+	defer builder.logsLock.Unlock()
+	for pkg, bl := range builder.logs {
+		if bl.srcPkgFilename == srcPkgFilename {
+			delete(builder.logs, pkg)
+		}
+	}
+}
+
+// LogsHandler streams the log of the latest build of a package. If the
+// build is still running, the log is followed until the build finishes.
+func (builder *Builder) LogsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), builder.logger)
+
+	if r.Method != "GET" {
+		http.Error(w, fmt.Sprintf("method not allowed: %s", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	pkg := r.URL.Query().Get("package")
+	bl, ok := builder.getBuildLog(pkg)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no build found for package %q", pkg), http.StatusNotFound)
+		return
+	}
+
+	f, err := os.Open(bl.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("build log of package %q not found", pkg), http.StatusNotFound)
+			return
+		}
+		logger.Error("error opening build log", zap.Error(err), zap.String("package", pkg))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	flusher, _ := w.(http.Flusher)
+	for {
+		_, err = io.Copy(w, f)
+		if err != nil {
+			logger.Error("error streaming build log", zap.Error(err), zap.String("package", pkg))
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-bl.done:
+			// copy output written after the last read
+			_, err = io.Copy(w, f)
+			if err != nil {
+				logger.Error("error streaming build log", zap.Error(err), zap.String("package", pkg))
+			}
+			return
+		case <-r.Context().Done():
+			return
+		case <-time.After(logPollInterval):
+		}
+	}
+}
@@ -18,6 +18,7 @@ package buildermgr
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"os"
 	"time"
@@ -30,6 +31,7 @@ import (
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/utils"
 	"github.com/fission/fission/pkg/utils/manager"
+	"github.com/fission/fission/pkg/utils/signature"
 )
 
 // Start the buildermgr service.
@@ -66,8 +68,13 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 	}
 	envWatcher.Run(ctx, mgr)
 
+	signer, err := getSigningKey()
+	if err != nil {
+		return err
+	}
+
 	pkgWatcher := makePackageWatcher(bmLogger, fissionClient,
-		kubernetesClient, storageSvcUrl,
+		kubernetesClient, envWatcher, storageSvcUrl, signer,
 		utils.GetK8sInformersForNamespaces(kubernetesClient, time.Minute*30, fv1.Pods),
 		utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.PackagesResource))
 	err = pkgWatcher.Run(ctx, mgr)
@@ -76,3 +83,22 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 	}
 	return nil
 }
+
+// getSigningKey returns the key signing the deployment archives built, read
+// from the PEM file at PACKAGE_SIGNING_KEY. Archives aren't signed if it is
+// not set.
+func getSigningKey() (crypto.Signer, error) {
+	path := os.Getenv("PACKAGE_SIGNING_KEY")
+	if len(path) == 0 {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading package signing key: %w", err)
+	}
+	signer, err := signature.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing package signing key %s: %w", path, err)
+	}
+	return signer, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+)
+
+const (
+	// buildJobGracePeriod is the time a build job may run on top of the
+	// build timeout, to start the pod, fetch the source and upload the
+	// deployment package.
+	buildJobGracePeriod = 10 * time.Minute
+
+	// defaultBuildJobTimeout bounds the builds of build jobs without a
+	// build timeout, so that a stuck build doesn't hold its pod forever.
+	defaultBuildJobTimeout = time.Hour
+
+	// buildJobTTL is how long a build job left behind, e.g. by a restart
+	// of the builder manager, is kept once it hits its deadline.
+	buildJobTTL int32 = 300
+)
+
+// createBuildJob creates the job building a package of an environment in
+// job mode. The job runs the builder and fetcher servers, the build itself
+// is driven by the builder manager like with a builder deployment and the
+// job is deleted once done.
+func (envw *environmentWatcher) createBuildJob(ctx context.Context, env *fv1.Environment, pkg *fv1.Package, ns string) (*batchv1.Job, error) {
+	// Pods of a job carry the job name as a label value, so keep
+	// it within the 63 character limit of label values.
+	name := pkg.ObjectMeta.Name
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	name = strings.ToLower(fmt.Sprintf("build-%s-%s", strings.TrimSuffix(name, "-"), uniuri.NewLen(6)))
+
+	sel := envw.getLabels(env.ObjectMeta.Name, ns, env.ObjectMeta.ResourceVersion)
+	sel[fv1.LABEL_PACKAGE_NAME] = pkg.ObjectMeta.Name
+	sel[fv1.LABEL_PACKAGE_NAMESPACE] = pkg.ObjectMeta.Namespace
+
+	pod, err := envw.getBuilderPodTemplate(env, sel)
+	if err != nil {
+		return nil, err
+	}
+	pod.Spec.RestartPolicy = apiv1.RestartPolicyNever
+
+	var backoffLimit int32 = 0
+	ttl := buildJobTTL
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			Labels:          sel,
+			OwnerReferences: envw.getOwnerReferences(env),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template:                *pod,
+		},
+	}
+
+	buildTimeout := pkg.Spec.BuildTimeout
+	if buildTimeout == 0 {
+		buildTimeout = env.Spec.Builder.BuildTimeout
+	}
+	if buildTimeout <= 0 {
+		buildTimeout = int(defaultBuildJobTimeout.Seconds())
+	}
+	deadline := int64(buildTimeout) + int64(buildJobGracePeriod.Seconds())
+	job.Spec.ActiveDeadlineSeconds = &deadline
+
+	job, err = envw.kubernetesClient.BatchV1().Jobs(ns).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	envw.logger.Info("created build job", zap.String("job", name), zap.String("namespace", ns),
+		zap.String("package", pkg.ObjectMeta.Name), zap.String("package_namespace", pkg.ObjectMeta.Namespace))
+
+	return job, nil
+}
+
+func (envw *environmentWatcher) deleteBuildJob(ctx context.Context, job *batchv1.Job) error {
+	err := envw.kubernetesClient.BatchV1().
+		Jobs(job.Namespace).
+		Delete(ctx, job.Name, delOpt)
+	if err != nil {
+		return fmt.Errorf("error deleting build job %s.%s: %w", job.Name, job.Namespace, err)
+	}
+	return nil
+}
+
+// waitForBuildJobPod waits for the pod of a build job to be ready and
+// returns its address.
+func (pkgw *packageWatcher) waitForBuildJobPod(ctx context.Context, logger *zap.Logger, job *batchv1.Job) (string, error) {
+	backOff := utils.NewDefaultBackOff()
+	for backOff.NextExists() {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		podList, err := pkgw.k8sClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.Set{batchv1.JobNameLabel: job.Name}.AsSelector().String(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("error getting pod of build job %s: %w", job.Name, err)
+		}
+
+		for _, pod := range podList.Items {
+			if pod.Status.Phase == apiv1.PodFailed {
+				return "", fmt.Errorf("pod %s of build job %s failed: %s", pod.Name, job.Name, pod.Status.Message)
+			}
+
+			// Pod may become "Running" state but still failed at health check, so use
+			// pod.Status.ContainerStatuses instead of pod.Status.Phase to check pod readiness states.
+			podIsReady := len(pod.Status.ContainerStatuses) > 0 && len(pod.Status.PodIP) > 0
+			for _, cStatus := range pod.Status.ContainerStatuses {
+				podIsReady = podIsReady && cStatus.Ready
+			}
+			if !podIsReady {
+				continue
+			}
+
+			if strings.Contains(pod.Status.PodIP, ":") {
+				return fmt.Sprintf("[%s]", pod.Status.PodIP), nil
+			}
+			return pod.Status.PodIP, nil
+		}
+
+		logger.Info("build job pod is not ready, will retry again later", zap.String("job", job.Name))
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(backOff.GetNext()):
+		}
+	}
+	return "", fmt.Errorf("timeout waiting for pod of build job %s to be ready", job.Name)
+}
+
+// buildWithJob builds the package in a job of its own, isolated from the
+// other builds of the environment.
+func (pkgw *packageWatcher) buildWithJob(ctx context.Context, logger *zap.Logger, env *fv1.Environment, pkg *fv1.Package, builderNs string) {
+	job, err := pkgw.envWatcher.createBuildJob(ctx, env, pkg, builderNs)
+	if err != nil {
+		e := "error creating build job"
+		logger.Error(e, zap.Error(err))
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg,
+			fv1.BuildStatusFailed, fmt.Sprintf("%s: %v", e, err), "", nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
+	}
+	logger = logger.With(zap.String("job", job.Name))
+
+	defer func() {
+		// delete the job even if the build was canceled
+		err := pkgw.envWatcher.deleteBuildJob(context.WithoutCancel(ctx), job)
+		if err != nil {
+			logger.Error("error deleting build job", zap.Error(err))
+		}
+	}()
+
+	builderAddress, err := pkgw.waitForBuildJobPod(ctx, logger, job)
+	if ctx.Err() != nil {
+		logger.Info("package build canceled")
+		return
+	}
+	if err != nil {
+		logger.Error("error waiting for build job", zap.Error(err))
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg,
+			fv1.BuildStatusFailed, fmt.Sprintf("Build failed due to build job not ready: %v", err), "", nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
+	}
+
+	pkgw.buildAndUpdatePackage(ctx, logger, builderAddress, pkg)
+}
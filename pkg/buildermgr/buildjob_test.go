@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/builder"
+	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+const builderNamespace = "fission-builder"
+
+func newTestWatchers(t *testing.T) (*environmentWatcher, *packageWatcher, *fake.Clientset) {
+	t.Helper()
+	logger := loggerfactory.GetLogger()
+	kubernetesClient := fake.NewSimpleClientset()
+
+	fetcherConfig, err := fetcherConfig.MakeFetcherConfig("/packages")
+	require.NoError(t, err)
+
+	envw := &environmentWatcher{
+		logger:           logger,
+		cache:            make(map[types.UID]*builderInfo),
+		kubernetesClient: kubernetesClient,
+		nsResolver:       &utils.NamespaceResolver{BuilderNamespace: builderNamespace},
+		fetcherConfig:    fetcherConfig,
+	}
+	pkgw := &packageWatcher{
+		logger:     logger,
+		k8sClient:  kubernetesClient,
+		envWatcher: envw,
+	}
+	return envw, pkgw, kubernetesClient
+}
+
+func newTestEnvironment() *fv1.Environment {
+	return &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "nodejs",
+			Namespace:       metav1.NamespaceDefault,
+			ResourceVersion: "42",
+			UID:             "4b9d52a2-4a0a-4ce4-a4ae-0c7dbd2bbd26",
+		},
+		Spec: fv1.EnvironmentSpec{
+			Version: 2,
+			Builder: fv1.Builder{
+				Image:        "ghcr.io/fission/node-builder",
+				Mode:         fv1.BuilderModeJob,
+				BuildTimeout: 60,
+				Cache: &fv1.BuildCache{
+					Paths:                 []string{"node_modules"},
+					KeyFiles:              []string{"package-lock.json"},
+					PersistentVolumeClaim: "build-cache",
+				},
+			},
+		},
+	}
+}
+
+func TestCreateBuildJob(t *testing.T) {
+	envw, _, kubernetesClient := newTestWatchers(t)
+	env := newTestEnvironment()
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hello-pkg",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	job, err := envw.createBuildJob(t.Context(), env, pkg, builderNamespace)
+	require.NoError(t, err)
+	require.Regexp(t, "^build-hello-pkg-[a-z0-9]{6}$", job.Name)
+	require.Equal(t, "hello-pkg", job.Labels[fv1.LABEL_PACKAGE_NAME])
+	require.Equal(t, env.Name, job.Spec.Template.Labels[fv1.LABEL_ENV_NAME])
+	require.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	require.Equal(t, int64(60)+int64(buildJobGracePeriod.Seconds()), *job.Spec.ActiveDeadlineSeconds)
+
+	// build jobs without a build timeout still have a deadline
+	env.Spec.Builder.BuildTimeout = 0
+	noTimeoutJob, err := envw.createBuildJob(t.Context(), env, pkg, builderNamespace)
+	require.NoError(t, err)
+	require.Equal(t, int64((defaultBuildJobTimeout + buildJobGracePeriod).Seconds()), *noTimeoutJob.Spec.ActiveDeadlineSeconds)
+	env.Spec.Builder.BuildTimeout = 60
+
+	podSpec := job.Spec.Template.Spec
+	require.Equal(t, apiv1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Len(t, podSpec.Containers, 2)
+	require.Equal(t, fv1.BuilderContainerName, podSpec.Containers[0].Name)
+	require.Equal(t, env.Spec.Builder.Image, podSpec.Containers[0].Image)
+	require.Contains(t, podSpec.Containers[0].VolumeMounts, apiv1.VolumeMount{
+		Name:      buildCacheVolumeName,
+		MountPath: builder.BuildCacheMountPath,
+	})
+
+	_, err = kubernetesClient.BatchV1().Jobs(builderNamespace).Get(t.Context(), job.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	err = envw.deleteBuildJob(t.Context(), job)
+	require.NoError(t, err)
+	_, err = kubernetesClient.BatchV1().Jobs(builderNamespace).Get(t.Context(), job.Name, metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func TestWaitForBuildJobPod(t *testing.T) {
+	_, pkgw, kubernetesClient := newTestWatchers(t)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "build-hello-pkg-abcdef",
+			Namespace: builderNamespace,
+		},
+	}
+
+	_, err := kubernetesClient.CoreV1().Pods(builderNamespace).Create(t.Context(), &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "build-hello-pkg-abcdef-xyz",
+			Namespace: builderNamespace,
+			Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+		},
+		Status: apiv1.PodStatus{
+			Phase: apiv1.PodRunning,
+			PodIP: "10.0.0.12",
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{Name: fv1.BuilderContainerName, Ready: true},
+				{Name: "fetcher", Ready: true},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	address, err := pkgw.waitForBuildJobPod(t.Context(), loggerfactory.GetLogger(), job)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.12", address)
+}
+
+func TestAddUpdateBuilderJobMode(t *testing.T) {
+	envw, _, kubernetesClient := newTestWatchers(t)
+
+	envw.AddUpdateBuilder(t.Context(), newTestEnvironment())
+
+	deployments, err := kubernetesClient.AppsV1().Deployments(builderNamespace).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, deployments.Items)
+	require.Empty(t, envw.cache)
+}
@@ -18,6 +18,8 @@ package buildermgr
 
 import (
 	"context"
+	"crypto"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
@@ -34,34 +36,39 @@ import (
 	"github.com/fission/fission/pkg/fetcher"
 	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
+	"github.com/fission/fission/pkg/utils/signature"
 )
 
-// buildPackage helps to build source package into deployment package.
+// buildPackage helps to build source package into deployment package with the
+// builder and fetcher at builderAddress, either the builder service of the
+// environment or the pod of a build job.
 // Following is the steps buildPackage function takes to complete the whole process.
 // 1. Send fetch request to fetcher to fetch source package.
 // 2. Send build request to builder to start a build.
 // 3. Send upload request to fetcher to upload deployment package.
-// 4. Return upload response and build logs.
+// 4. Send upload request to fetcher to upload the full build logs.
+// 5. Return upload response, build logs, the URL of the full build logs and
+// the commit SHA of a source package fetched from a Git repository.
 // *. Return build logs and error if any one of steps above failed.
-func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface, envBuilderNamespace string,
-	storageSvcUrl string, pkg *fv1.Package) (uploadResp *fetcher.ArchiveUploadResponse, buildLogs string, err error) {
+func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface, builderAddress string,
+	storageSvcUrl string, pkg *fv1.Package) (uploadResp *fetcher.ArchiveUploadResponse, buildLogs string, buildLogURL string, sourceCommit string, err error) {
 
 	env, err := fissionClient.CoreV1().Environments(pkg.Spec.Environment.Namespace).Get(ctx, pkg.Spec.Environment.Name, metav1.GetOptions{})
 	if err != nil {
 		e := "error getting environment CRD info"
 		logger.Error(e, zap.Error(err))
 		e = fmt.Sprintf("%s: %v", e, err)
-		return nil, e, ferror.MakeError(http.StatusInternalServerError, e)
+		return nil, e, "", "", ferror.MakeError(http.StatusInternalServerError, e)
 	}
 
-	svcName := fmt.Sprintf("%s-%s.%s", env.Name, env.ResourceVersion, envBuilderNamespace)
 	srcPkgFilename := fmt.Sprintf("%s-%s", pkg.Name, strings.ToLower(uniuri.NewLen(6)))
-	fetcherC := fetcherClient.MakeClient(logger, fmt.Sprintf("http://%s:8000", svcName))
-	builderC := builderClient.MakeClient(logger, fmt.Sprintf("http://%s:8001", svcName))
+	fetcherC := fetcherClient.MakeClient(logger, fmt.Sprintf("http://%s:8000", builderAddress))
+	builderC := builderClient.MakeClient(logger, fmt.Sprintf("http://%s:8001", builderAddress))
 
 	defer func() {
 		logger.Info("cleaning src pkg from builder storage", zap.String("source_package", srcPkgFilename))
-		errC := cleanPackage(ctx, builderC, srcPkgFilename)
+		// clean up even if the build was canceled
+		errC := cleanPackage(context.WithoutCancel(ctx), builderC, srcPkgFilename)
 		if errC != nil {
 			m := "error cleaning src pkg from builder storage"
 			logger.Error(m, zap.Error(errC))
@@ -76,22 +83,43 @@ func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient version
 	}
 
 	// send fetch request to fetcher
-	err = fetcherC.Fetch(ctx, fetchReq)
+	fetchResp, err := fetcherC.Fetch(ctx, fetchReq)
 	if err != nil {
 		e := "error fetching source package"
 		logger.Error(e, zap.Error(err))
 		e = fmt.Sprintf("%s: %v", e, err)
-		return nil, e, ferror.MakeError(http.StatusInternalServerError, e)
+		return nil, e, "", "", ferror.MakeError(http.StatusInternalServerError, e)
 	}
+	sourceCommit = fetchResp.SourceCommit
 
 	buildCmd := pkg.Spec.BuildCommand
 	if len(buildCmd) == 0 {
 		buildCmd = env.Spec.Builder.Command
 	}
 
+	buildTimeout := pkg.Spec.BuildTimeout
+	if buildTimeout == 0 {
+		buildTimeout = env.Spec.Builder.BuildTimeout
+	}
+
 	pkgBuildReq := &builder.PackageBuildRequest{
 		SrcPkgFilename: srcPkgFilename,
 		BuildCommand:   buildCmd,
+		Timeout:        buildTimeout,
+		Package:        fmt.Sprintf("%s/%s", pkg.Namespace, pkg.Name),
+	}
+	if cache := env.Spec.Builder.Cache; cache != nil {
+		// the image of the builder container overrides the builder image
+		image := env.Spec.Builder.Image
+		if c := env.Spec.Builder.Container; c != nil && len(c.Image) > 0 {
+			image = c.Image
+		}
+		pkgBuildReq.Cache = &builder.BuildCache{
+			Paths:    cache.Paths,
+			KeyFiles: cache.KeyFiles,
+			MaxSize:  cache.MaxSize.Value(),
+			Image:    image,
+		}
 	}
 
 	logger.Info("started building with source package", zap.String("source_package", srcPkgFilename))
@@ -102,11 +130,14 @@ func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient version
 		var buildLogs string
 		if buildResp != nil {
 			buildLogs = buildResp.BuildLogs
+			buildLogURL = uploadBuildLog(ctx, logger, fetcherC, storageSvcUrl, pkg.Namespace, buildResp.LogFilename)
 		}
 		buildLogs += fmt.Sprintf("%v\n", e)
-		return nil, buildLogs, ferror.MakeError(http.StatusInternalServerError, e)
+		return nil, buildLogs, buildLogURL, sourceCommit, ferror.MakeError(http.StatusInternalServerError, e)
 	}
 
+	buildLogURL = uploadBuildLog(ctx, logger, fetcherC, storageSvcUrl, pkg.Namespace, buildResp.LogFilename)
+
 	logger.Info("build succeed", zap.String("source_package", srcPkgFilename), zap.String("deployment_package", buildResp.ArtifactFilename))
 
 	archivePackage := !env.Spec.KeepArchive
@@ -114,6 +145,7 @@ func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient version
 	uploadReq := &fetcher.ArchiveUploadRequest{
 		Filename:       buildResp.ArtifactFilename,
 		StorageSvcUrl:  storageSvcUrl,
+		Namespace:      pkg.Namespace,
 		ArchivePackage: archivePackage,
 	}
 
@@ -123,10 +155,54 @@ func buildPackage(ctx context.Context, logger *zap.Logger, fissionClient version
 	if err != nil {
 		e := fmt.Sprintf("Error uploading deployment package: %v", err)
 		buildResp.BuildLogs += fmt.Sprintf("%v\n", e)
-		return nil, buildResp.BuildLogs, ferror.MakeError(http.StatusInternalServerError, e)
+		return nil, buildResp.BuildLogs, buildLogURL, sourceCommit, ferror.MakeError(http.StatusInternalServerError, e)
+	}
+
+	return uploadResp, buildResp.BuildLogs, buildLogURL, sourceCommit, nil
+}
+
+// uploadBuildLog asks fetcher to upload the full build logs to the storage
+// service and returns their download URL. Failing to upload the logs doesn't
+// fail the build, the package status still holds the tail of the logs.
+func uploadBuildLog(ctx context.Context, logger *zap.Logger, fetcherC fetcherClient.ClientInterface, storageSvcUrl string, namespace string, logFilename string) string {
+	if len(logFilename) == 0 || ctx.Err() != nil {
+		return ""
+	}
+
+	uploadResp, err := fetcherC.Upload(ctx, &fetcher.ArchiveUploadRequest{
+		Filename:       logFilename,
+		StorageSvcUrl:  storageSvcUrl,
+		Namespace:      namespace,
+		ArchivePackage: false,
+	})
+	if err != nil {
+		logger.Error("error uploading build logs", zap.Error(err), zap.String("build_log", logFilename))
+		return ""
 	}
+	return uploadResp.ArchiveDownloadUrl
+}
 
-	return uploadResp, buildResp.BuildLogs, nil
+// deploymentArchive returns the deployment archive of a package uploaded to
+// the storage service. The archive is signed with the signing key of the
+// builder manager, if any, so that it passes signature verification.
+func deploymentArchive(signer crypto.Signer, uploadResp *fetcher.ArchiveUploadResponse) (*fv1.Archive, error) {
+	archive := &fv1.Archive{
+		Type:     fv1.ArchiveTypeUrl,
+		URL:      uploadResp.ArchiveDownloadUrl,
+		Checksum: uploadResp.Checksum,
+	}
+	if signer == nil {
+		return archive, nil
+	}
+	digest, err := hex.DecodeString(uploadResp.Checksum.Sum)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding checksum of deployment archive: %w", err)
+	}
+	archive.Signature, err = signature.Sign(signer, digest)
+	if err != nil {
+		return nil, fmt.Errorf("error signing deployment archive: %w", err)
+	}
+	return archive, nil
 }
 
 func cleanPackage(ctx context.Context, builderClient builderClient.ClientInterface, srcPkgFileName string) error {
@@ -138,22 +214,30 @@ func cleanPackage(ctx context.Context, builderClient builderClient.ClientInterfa
 	return nil
 }
 
+// updatePackage updates the build status of a package and, if given, its
+// deployment archive. The source commit
+// recorded in the status is kept, except when a new build starts.
 func updatePackage(ctx context.Context, logger *zap.Logger, fissionClient versioned.Interface,
-	pkg *fv1.Package, status fv1.BuildStatus, buildLogs string,
-	uploadResp *fetcher.ArchiveUploadResponse) (*fv1.Package, error) {
+	pkg *fv1.Package, status fv1.BuildStatus, buildLogs string, buildLogURL string,
+	deployment *fv1.Archive) (*fv1.Package, error) {
+
+	sourceCommit := pkg.Status.SourceCommit
+	if status == fv1.BuildStatusRunning {
+		sourceCommit = ""
+	}
 
 	pkg.Status = fv1.PackageStatus{
-		BuildStatus:         status,
-		BuildLog:            buildLogs,
+		BuildStatus: status,
+		// keep the package small enough to be stored,
+		// the full build logs are in the storage service
+		BuildLog:            builder.TailLog(buildLogs),
+		BuildLogURL:         buildLogURL,
+		SourceCommit:        sourceCommit,
 		LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
 	}
 
-	if uploadResp != nil {
-		pkg.Spec.Deployment = fv1.Archive{
-			Type:     fv1.ArchiveTypeUrl,
-			URL:      uploadResp.ArchiveDownloadUrl,
-			Checksum: uploadResp.Checksum,
-		}
+	if deployment != nil {
+		pkg.Spec.Deployment = *deployment
 	}
 
 	// update package spec
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildermgr
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fetcher"
+	"github.com/fission/fission/pkg/utils/signature"
+)
+
+func TestDeploymentArchive(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("archive"))
+	uploadResp := &fetcher.ArchiveUploadResponse{
+		ArchiveDownloadUrl: "http://storagesvc/v1/archive?id=hello",
+		Checksum:           fv1.Checksum{Type: fv1.ChecksumTypeSHA256, Sum: hex.EncodeToString(digest[:])},
+	}
+
+	archive, err := deploymentArchive(nil, uploadResp)
+	require.NoError(t, err)
+	require.Equal(t, fv1.ArchiveTypeUrl, archive.Type)
+	require.Equal(t, uploadResp.ArchiveDownloadUrl, archive.URL)
+	require.Nil(t, archive.Signature)
+
+	archive, err = deploymentArchive(key, uploadResp)
+	require.NoError(t, err)
+	require.NotNil(t, archive.Signature)
+	_, err = signature.Verify([]crypto.PublicKey{pub}, digest[:], archive.Signature)
+	require.NoError(t, err)
+}
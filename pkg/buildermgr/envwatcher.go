@@ -35,6 +35,7 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/builder"
 	"github.com/fission/fission/pkg/crd"
 	"github.com/fission/fission/pkg/executor/util"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
@@ -44,11 +45,10 @@ import (
 )
 
 const (
-	LABEL_ENV_NAME            = "envName"
-	LABEL_ENV_NAMESPACE       = "envNamespace"
-	LABEL_ENV_RESOURCEVERSION = "envResourceVersion"
-	LABEL_DEPLOYMENT_OWNER    = "owner"
-	BUILDER_MGR               = "buildermgr"
+	LABEL_DEPLOYMENT_OWNER = "owner"
+	BUILDER_MGR            = "buildermgr"
+
+	buildCacheVolumeName = "build-cache"
 )
 
 var (
@@ -122,16 +122,16 @@ func makeEnvironmentWatcher(
 func (env *environmentWatcher) getDeploymentLabels(envName string) map[string]string {
 	return map[string]string{
 		LABEL_DEPLOYMENT_OWNER: BUILDER_MGR,
-		LABEL_ENV_NAME:         envName,
+		fv1.LABEL_ENV_NAME:     envName,
 	}
 }
 
 func (envw *environmentWatcher) getLabels(envName string, envNamespace string, envResourceVersion string) map[string]string {
 	return map[string]string{
-		LABEL_ENV_NAME:            envName,
-		LABEL_ENV_NAMESPACE:       envNamespace,
-		LABEL_ENV_RESOURCEVERSION: envResourceVersion,
-		LABEL_DEPLOYMENT_OWNER:    BUILDER_MGR,
+		fv1.LABEL_ENV_NAME:            envName,
+		fv1.LABEL_ENV_NAMESPACE:       envNamespace,
+		fv1.LABEL_ENV_RESOURCEVERSION: envResourceVersion,
+		LABEL_DEPLOYMENT_OWNER:        BUILDER_MGR,
 	}
 }
 
@@ -166,6 +166,12 @@ func (envw *environmentWatcher) EnvWatchEventHandlers(ctx context.Context) error
 }
 
 func (envw *environmentWatcher) AddUpdateBuilder(ctx context.Context, env *fv1.Environment) {
+	// packages of environments in job mode are built in their own jobs,
+	// remove the builder left from a previous mode if any
+	if env.Spec.Builder.Mode == fv1.BuilderModeJob {
+		envw.DeleteBuilder(ctx, env)
+		return
+	}
 	// builder is not supported with v1 interface and ignore env without builder image
 	if env.Spec.Version != 1 && len(env.Spec.Builder.Image) != 0 {
 		if _, ok := envw.cache[crd.CacheKeyUIDFromMeta(&env.ObjectMeta)]; !ok {
@@ -206,7 +212,7 @@ func (envw *environmentWatcher) DeleteBuilderService(ctx context.Context, env *f
 		envw.logger.Error("error getting the builder service list", zap.Error(err))
 	}
 	for _, svc := range svcList {
-		envName := svc.ObjectMeta.Labels[LABEL_ENV_NAME]
+		envName := svc.ObjectMeta.Labels[fv1.LABEL_ENV_NAME]
 		if _, ok := envw.cache[crd.CacheKeyUIDFromMeta(&env.ObjectMeta)]; ok {
 			err := envw.deleteBuilderServiceByName(ctx, svc.ObjectMeta.Name, svc.ObjectMeta.Namespace)
 			if err != nil {
@@ -327,22 +333,12 @@ func (envw *environmentWatcher) getBuilderServiceList(ctx context.Context, sel m
 func (envw *environmentWatcher) createBuilderService(ctx context.Context, env *fv1.Environment, ns string) (*apiv1.Service, error) {
 	name := fmt.Sprintf("%v-%v", env.ObjectMeta.Name, env.ObjectMeta.ResourceVersion)
 	sel := envw.getLabels(env.ObjectMeta.Name, ns, env.ObjectMeta.ResourceVersion)
-	var ownerReferences []metav1.OwnerReference
-	if envw.enableOwnerReferences {
-		ownerReferences = []metav1.OwnerReference{
-			*metav1.NewControllerRef(env, schema.GroupVersionKind{
-				Group:   "fission.io",
-				Version: "v1",
-				Kind:    "Environment",
-			}),
-		}
-	}
 	service := apiv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:       ns,
 			Name:            name,
 			Labels:          sel,
-			OwnerReferences: ownerReferences,
+			OwnerReferences: envw.getOwnerReferences(env),
 		},
 		Spec: apiv1.ServiceSpec{
 			Selector: sel,
@@ -394,6 +390,40 @@ func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env
 	sel := envw.getLabels(env.ObjectMeta.Name, ns, env.ObjectMeta.ResourceVersion)
 	var replicas int32 = 1
 
+	pod, err := envw.getBuilderPodTemplate(env, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            name,
+			Labels:          sel,
+			OwnerReferences: envw.getOwnerReferences(env),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: sel,
+			},
+			Template: *pod,
+		},
+	}
+
+	_, err = envw.kubernetesClient.AppsV1().Deployments(ns).Create(ctx, deployment, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	envw.logger.Info("creating builder deployment", zap.String("deployment", name))
+
+	return deployment, nil
+}
+
+// getBuilderPodTemplate returns the template of the pods building the
+// packages of the environment, made of the builder and fetcher containers.
+func (envw *environmentWatcher) getBuilderPodTemplate(env *fv1.Environment, sel map[string]string) (*apiv1.PodTemplateSpec, error) {
 	podAnnotations := env.ObjectMeta.Annotations
 	if podAnnotations == nil {
 		podAnnotations = make(map[string]string)
@@ -426,7 +456,7 @@ func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env
 		return nil, err
 	}
 
-	pod := apiv1.PodTemplateSpec{
+	pod := &apiv1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels:      sel,
 			Annotations: podAnnotations,
@@ -449,52 +479,70 @@ func (envw *environmentWatcher) createBuilderDeployment(ctx context.Context, env
 
 	pod.Spec = *(util.ApplyImagePullSecret(env.Spec.ImagePullSecret, pod.Spec))
 
-	var ownerReferences []metav1.OwnerReference
-	if envw.enableOwnerReferences {
-		ownerReferences = []metav1.OwnerReference{
-			*metav1.NewControllerRef(env, schema.GroupVersionKind{
-				Group:   "fission.io",
-				Version: "v1",
-				Kind:    "Environment",
-			}),
-		}
-	}
-
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace:       ns,
-			Name:            name,
-			Labels:          sel,
-			OwnerReferences: ownerReferences,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: sel,
-			},
-			Template: pod,
-		},
-	}
-
-	err = envw.fetcherConfig.AddFetcherToPodSpec(&deployment.Spec.Template.Spec, "builder")
+	err = envw.fetcherConfig.AddFetcherToPodSpec(&pod.Spec, "builder")
 	if err != nil {
 		return nil, err
 	}
 
+	if env.Spec.Builder.Cache != nil {
+		addBuildCacheToPodSpec(&pod.Spec, env.Spec.Builder.Cache)
+	}
+
 	if env.Spec.Builder.PodSpec != nil {
-		newPodSpec, err := util.MergePodSpec(&deployment.Spec.Template.Spec, env.Spec.Builder.PodSpec)
+		newPodSpec, err := util.MergePodSpec(&pod.Spec, env.Spec.Builder.PodSpec)
 		if err != nil {
 			return nil, err
 		}
-		deployment.Spec.Template.Spec = *newPodSpec
+		pod.Spec = *newPodSpec
 	}
 
-	_, err = envw.kubernetesClient.AppsV1().Deployments(ns).Create(ctx, deployment, metav1.CreateOptions{})
-	if err != nil {
-		return nil, err
+	return pod, nil
+}
+
+func (envw *environmentWatcher) getOwnerReferences(env *fv1.Environment) []metav1.OwnerReference {
+	if !envw.enableOwnerReferences {
+		return nil
+	}
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(env, schema.GroupVersionKind{
+			Group:   "fission.io",
+			Version: "v1",
+			Kind:    "Environment",
+		}),
 	}
+}
 
-	envw.logger.Info("creating builder deployment", zap.String("deployment", name))
+// addBuildCacheToPodSpec mounts the dependency cache volume in the builder
+// container.
+func addBuildCacheToPodSpec(podSpec *apiv1.PodSpec, cache *fv1.BuildCache) {
+	volume := apiv1.Volume{
+		Name: buildCacheVolumeName,
+	}
+	if len(cache.PersistentVolumeClaim) > 0 {
+		volume.VolumeSource = apiv1.VolumeSource{
+			PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: cache.PersistentVolumeClaim,
+			},
+		}
+	} else {
+		emptyDir := &apiv1.EmptyDirVolumeSource{}
+		if !cache.MaxSize.IsZero() {
+			// leave room for the entry being written before eviction
+			sizeLimit := cache.MaxSize.DeepCopy()
+			sizeLimit.Add(cache.MaxSize)
+			emptyDir.SizeLimit = &sizeLimit
+		}
+		volume.VolumeSource = apiv1.VolumeSource{EmptyDir: emptyDir}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
 
-	return deployment, nil
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != fv1.BuilderContainerName {
+			continue
+		}
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, apiv1.VolumeMount{
+			Name:      buildCacheVolumeName,
+			MountPath: builder.BuildCacheMountPath,
+		})
+	}
 }
@@ -18,13 +18,16 @@ package buildermgr
 
 import (
 	"context"
+	"crypto"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	k8sCache "k8s.io/client-go/tools/cache"
 
@@ -47,11 +50,23 @@ type (
 		pkgInformer   map[string]k8sCache.SharedIndexInformer
 		storageSvcUrl string
 		buildCache    *cache.Cache[crd.CacheKeyUR, *fv1.Package]
+		envWatcher    *environmentWatcher
+		// signer signs the deployment archives built, if set
+		signer crypto.Signer
+
+		buildsLock sync.Mutex
+		builds     map[k8stypes.UID]*runningBuild
+	}
+
+	// runningBuild is a build in progress of a package version.
+	runningBuild struct {
+		resourceVersion string
+		cancel          context.CancelFunc
 	}
 )
 
 func makePackageWatcher(logger *zap.Logger, fissionClient versioned.Interface, k8sClientSet kubernetes.Interface,
-	storageSvcUrl string, podInformer,
+	envWatcher *environmentWatcher, storageSvcUrl string, signer crypto.Signer, podInformer,
 	pkgInformer map[string]k8sCache.SharedIndexInformer) *packageWatcher {
 	pkgw := &packageWatcher{
 		logger:        logger.Named("package_watcher"),
@@ -62,6 +77,9 @@ func makePackageWatcher(logger *zap.Logger, fissionClient versioned.Interface, k
 		pkgInformer:   pkgInformer,
 		storageSvcUrl: storageSvcUrl,
 		buildCache:    cache.MakeCache[crd.CacheKeyUR, *fv1.Package](0, 0),
+		envWatcher:    envWatcher,
+		signer:        signer,
+		builds:        make(map[k8stypes.UID]*runningBuild),
 	}
 	return pkgw
 }
@@ -80,20 +98,68 @@ func (pkgw *packageWatcher) buildWithCache(ctx context.Context, srcpkg *fv1.Pack
 	go pkgw.build(ctx, srcpkg)
 }
 
+// trackBuild registers the build of a package version and cancels the build
+// of an older version of the package still in progress, whose result would
+// be outdated. The returned function must be called once the build is done.
+func (pkgw *packageWatcher) trackBuild(ctx context.Context, pkg *fv1.Package) (context.Context, func()) {
+	pkgw.buildsLock.Lock()
+	defer pkgw.buildsLock.Unlock()
+
+	if rb, ok := pkgw.builds[pkg.UID]; ok {
+		pkgw.logger.Info("canceling outdated package build",
+			zap.String("package", pkg.Name), zap.String("namespace", pkg.Namespace),
+			zap.String("resource_version", rb.resourceVersion))
+		rb.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	rb := &runningBuild{
+		resourceVersion: pkg.ResourceVersion,
+		cancel:          cancel,
+	}
+	pkgw.builds[pkg.UID] = rb
+
+	return ctx, func() {
+		pkgw.buildsLock.Lock()
+		defer pkgw.buildsLock.Unlock()
+		if pkgw.builds[pkg.UID] == rb {
+			delete(pkgw.builds, pkg.UID)
+		}
+		cancel()
+	}
+}
+
+// cancelBuild cancels the build of a package in progress, if any.
+func (pkgw *packageWatcher) cancelBuild(pkg *fv1.Package) {
+	pkgw.buildsLock.Lock()
+	defer pkgw.buildsLock.Unlock()
+
+	if rb, ok := pkgw.builds[pkg.UID]; ok {
+		pkgw.logger.Info("canceling package build",
+			zap.String("package", pkg.Name), zap.String("namespace", pkg.Namespace))
+		rb.cancel()
+		delete(pkgw.builds, pkg.UID)
+	}
+}
+
 // build helps to update package status, checks environment builder pod status and
 // dispatches buildPackage to build source package into deployment package.
 // Following is the steps build function takes to complete the whole process.
 // 1. Check package status
 // 2. Update package status to running state
-// 3. Check environment builder pod status
+// 3. Check environment builder pod status, or create a build job in job mode
 // 4. Call buildPackage to build package
 // 5. Update package resource in package ref of functions that share the same package
 // 6. Update package status to succeed state
 // *. Update package status to failed state,if any one of steps above failed/time out
+// The build is canceled if a newer version of the package has to be built.
 func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 	key := pkgw.buildCacheKey(srcpkg.ObjectMeta)
 	logger := pkgw.logger.With(zap.String("package", srcpkg.Name), zap.String("namespace", srcpkg.Namespace), zap.String("resource_version", srcpkg.ResourceVersion), zap.String("key", key.String()))
 
+	ctx, done := pkgw.trackBuild(ctx, srcpkg)
+	defer done()
+
 	defer func() {
 		err := pkgw.buildCache.Delete(key)
 		if err != nil {
@@ -103,7 +169,7 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 
 	logger.Info("starting build for package")
 
-	pkg, err := updatePackage(ctx, logger, pkgw.fissionClient, srcpkg, fv1.BuildStatusRunning, "", nil)
+	pkg, err := updatePackage(ctx, logger, pkgw.fissionClient, srcpkg, fv1.BuildStatusRunning, "", "", nil)
 	if err != nil {
 		logger.Error("error setting package pending state", zap.Error(err))
 		return
@@ -114,7 +180,7 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 		e := "environment does not exist"
 		logger.Error(e, zap.String("environment", pkg.Spec.Environment.Name))
 		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-			fv1.BuildStatusFailed, fmt.Sprintf("%s: %q", e, pkg.Spec.Environment.Name), nil)
+			fv1.BuildStatusFailed, fmt.Sprintf("%s: %q", e, pkg.Spec.Environment.Name), "", nil)
 		if er != nil {
 			logger.Error(
 				"error updating package",
@@ -130,11 +196,21 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 
 	logger = logger.With(zap.String("environment", env.Name), zap.String("builder_namespace", builderNs), zap.String("environment_namespace", env.Namespace))
 
+	if env.Spec.Builder.Mode == fv1.BuilderModeJob {
+		pkgw.buildWithJob(ctx, logger, env, pkg, builderNs)
+		return
+	}
+
 	// if err != nil {
 	//	pkgw.logger.Error("Unable to create BackOff for Health Check", zap.Error(err))
 	//}
 	// Do health check for environment builder pod
 	for healthCheckBackOff.NextExists() {
+		if ctx.Err() != nil {
+			logger.Info("package build canceled")
+			return
+		}
+
 		// Informer store is not able to use label to find the pod,
 		// iterate all available environment builders.
 		items := pkgw.podInformer[builderNs].GetStore().List()
@@ -153,9 +229,9 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 			pod := item.(*apiv1.Pod)
 
 			// Filter non-matching pods
-			if pod.ObjectMeta.Labels[LABEL_ENV_NAME] != env.ObjectMeta.Name ||
-				pod.ObjectMeta.Labels[LABEL_ENV_NAMESPACE] != builderNs ||
-				pod.ObjectMeta.Labels[LABEL_ENV_RESOURCEVERSION] != env.ObjectMeta.ResourceVersion {
+			if pod.ObjectMeta.Labels[fv1.LABEL_ENV_NAME] != env.ObjectMeta.Name ||
+				pod.ObjectMeta.Labels[fv1.LABEL_ENV_NAMESPACE] != builderNs ||
+				pod.ObjectMeta.Labels[fv1.LABEL_ENV_RESOURCEVERSION] != env.ObjectMeta.ResourceVersion {
 				continue
 			}
 
@@ -173,79 +249,103 @@ func (pkgw *packageWatcher) build(ctx context.Context, srcpkg *fv1.Package) {
 				break
 			}
 
-			uploadResp, buildLogs, err := buildPackage(ctx, pkgw.logger, pkgw.fissionClient, builderNs, pkgw.storageSvcUrl, pkg)
-			if err != nil {
-				logger.Error("error building package", zap.Error(err))
-				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-				if er != nil {
-					logger.Error("error updating package", zap.Error(er))
-				}
-				return
-			}
+			builderAddress := fmt.Sprintf("%s-%s.%s", env.ObjectMeta.Name, env.ObjectMeta.ResourceVersion, builderNs)
+			pkgw.buildAndUpdatePackage(ctx, logger, builderAddress, pkg)
+			return
+		}
+		time.Sleep(healthCheckBackOff.GetNext())
+	}
+	// build timeout
+	_, err = updatePackage(ctx, logger, pkgw.fissionClient, pkg,
+		fv1.BuildStatusFailed, "Build timeout due to environment builder not ready", "", nil)
+	if err != nil {
+		logger.Error("error updating package", zap.Error(err))
+	}
 
-			logger.Info("starting package info update")
+	logger.Error("max retries exceeded in building source package, timeout due to environment builder not ready")
+}
 
-			fnList, err := pkgw.fissionClient.CoreV1().
-				Functions(pkg.Namespace).List(ctx, metav1.ListOptions{})
-			if err != nil {
-				e := "error getting function list"
-				pkgw.logger.Error(e, zap.Error(err))
-				buildLogs += fmt.Sprintf("%s: %v\n", e, err)
-				_, er := updatePackage(ctx, pkgw.logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-				if er != nil {
-					pkgw.logger.Error(
-						"error updating package",
-						zap.Error(er),
-					)
-				}
-			}
+// buildAndUpdatePackage builds the package with the builder at the given
+// address, updates the functions using the package and the package status.
+func (pkgw *packageWatcher) buildAndUpdatePackage(ctx context.Context, logger *zap.Logger, builderAddress string, pkg *fv1.Package) {
+	uploadResp, buildLogs, buildLogURL, sourceCommit, err := buildPackage(ctx, pkgw.logger, pkgw.fissionClient, builderAddress, pkgw.storageSvcUrl, pkg)
+	if ctx.Err() != nil {
+		// a newer version of the package is being built
+		logger.Info("package build canceled")
+		return
+	}
+	pkg.Status.SourceCommit = sourceCommit
+	if err != nil {
+		logger.Error("error building package", zap.Error(err))
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, buildLogURL, nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
+	}
 
-			// A package may be used by multiple functions. Update
-			// functions with old package resource version
-			for _, fn := range fnList.Items {
-				if fn.Spec.Package.PackageRef.Name == pkg.ObjectMeta.Name &&
-					fn.Spec.Package.PackageRef.Namespace == pkg.ObjectMeta.Namespace &&
-					fn.Spec.Package.PackageRef.ResourceVersion != pkg.ObjectMeta.ResourceVersion {
-					fn.Spec.Package.PackageRef.ResourceVersion = pkg.ObjectMeta.ResourceVersion
-					// update CRD
-					_, err = pkgw.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).Update(ctx, &fn, metav1.UpdateOptions{})
-					if err != nil {
-						e := "error updating function package resource version"
-						logger.Error(e, zap.Error(err))
-						buildLogs += fmt.Sprintf("%s: %v\n", e, err)
-						_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
-						if er != nil {
-							logger.Error("error updating package", zap.Error(er))
-						}
-						return
-					}
-				}
-			}
+	deployment, err := deploymentArchive(pkgw.signer, uploadResp)
+	if err != nil {
+		logger.Error("error making deployment archive", zap.Error(err))
+		buildLogs += fmt.Sprintf("%v\n", err)
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, buildLogURL, nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
+	}
+
+	logger.Info("starting package info update")
 
-			_, err = updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-				fv1.BuildStatusSucceeded, buildLogs, uploadResp)
+	fnList, err := pkgw.fissionClient.CoreV1().
+		Functions(pkg.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		e := "error getting function list"
+		pkgw.logger.Error(e, zap.Error(err))
+		buildLogs += fmt.Sprintf("%s: %v\n", e, err)
+		_, er := updatePackage(ctx, pkgw.logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, buildLogURL, nil)
+		if er != nil {
+			pkgw.logger.Error(
+				"error updating package",
+				zap.Error(er),
+			)
+		}
+	}
+
+	// A package may be used by multiple functions. Update
+	// functions with old package resource version
+	for _, fn := range fnList.Items {
+		if fn.Spec.Package.PackageRef.Name == pkg.ObjectMeta.Name &&
+			fn.Spec.Package.PackageRef.Namespace == pkg.ObjectMeta.Namespace &&
+			fn.Spec.Package.PackageRef.ResourceVersion != pkg.ObjectMeta.ResourceVersion {
+			fn.Spec.Package.PackageRef.ResourceVersion = pkg.ObjectMeta.ResourceVersion
+			// update CRD
+			_, err = pkgw.fissionClient.CoreV1().Functions(fn.ObjectMeta.Namespace).Update(ctx, &fn, metav1.UpdateOptions{})
 			if err != nil {
-				logger.Error("error updating package info", zap.Error(err))
-				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, nil)
+				e := "error updating function package resource version"
+				logger.Error(e, zap.Error(err))
+				buildLogs += fmt.Sprintf("%s: %v\n", e, err)
+				_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, buildLogURL, nil)
 				if er != nil {
 					logger.Error("error updating package", zap.Error(er))
 				}
 				return
 			}
-
-			logger.Info("completed package build request")
-			return
 		}
-		time.Sleep(healthCheckBackOff.GetNext())
 	}
-	// build timeout
+
 	_, err = updatePackage(ctx, logger, pkgw.fissionClient, pkg,
-		fv1.BuildStatusFailed, "Build timeout due to environment builder not ready", nil)
+		fv1.BuildStatusSucceeded, buildLogs, buildLogURL, deployment)
 	if err != nil {
-		logger.Error("error updating package", zap.Error(err))
+		logger.Error("error updating package info", zap.Error(err))
+		_, er := updatePackage(ctx, logger, pkgw.fissionClient, pkg, fv1.BuildStatusFailed, buildLogs, buildLogURL, nil)
+		if er != nil {
+			logger.Error("error updating package", zap.Error(er))
+		}
+		return
 	}
 
-	logger.Error("max retries exceeded in building source package, timeout due to environment builder not ready")
+	logger.Info("completed package build request")
 }
 
 func (pkgw *packageWatcher) packageInformerHandler(ctx context.Context) k8sCache.ResourceEventHandlerFuncs {
@@ -286,6 +386,16 @@ func (pkgw *packageWatcher) packageInformerHandler(ctx context.Context) k8sCache
 			}
 			processPkg(ctx, pkg)
 		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			pkg, ok := obj.(*fv1.Package)
+			if !ok {
+				return
+			}
+			pkgw.cancelBuild(pkg)
+		},
 	}
 }
 
@@ -154,4 +154,5 @@ var errorDescriptions = []string{
 	"Checksum verification failed",
 	"Size limit exceeded",
 	"Request time limit exceeded",
+	"Too many requests",
 }
@@ -24,14 +24,18 @@ import (
 	"html"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/executor/executortype/jobmgr"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/utils/httpserver"
 	"github.com/fission/fission/pkg/utils/manager"
@@ -92,7 +96,8 @@ func (executor *Executor) getServiceForFunctionAPI(w http.ResponseWriter, r *htt
 			}
 		}
 
-	} else if t == fv1.ExecutorTypeNewdeploy || t == fv1.ExecutorTypeContainer {
+	} else if t == fv1.ExecutorTypeNewdeploy || t == fv1.ExecutorTypeContainer || t == fv1.ExecutorTypeJob ||
+		(t.IsPlugin() && et != nil) {
 		fsvc, err := et.GetFuncSvcFromCache(ctx, fn)
 		if err == nil {
 			if et.IsValid(ctx, fsvc) {
@@ -273,6 +278,107 @@ func (executor *Executor) dumpDebugInfo(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 }
 
+func (executor *Executor) getJobFunction(w http.ResponseWriter, r *http.Request) (*jobmgr.JobManager, *fv1.Function, bool) {
+	et, ok := executor.executorTypes[fv1.ExecutorTypeJob]
+	if !ok {
+		http.Error(w, "job executor is not enabled", http.StatusNotFound)
+		return nil, nil, false
+	}
+	jobm := et.(*jobmgr.JobManager)
+
+	vars := mux.Vars(r)
+	fn, err := executor.fissionClient.CoreV1().Functions(vars["namespace"]).Get(r.Context(), vars["function"], metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "function not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "error getting function", http.StatusInternalServerError)
+		}
+		return nil, nil, false
+	}
+	return jobm, fn, true
+}
+
+// submitJob runs a job function with the request body as input. It responds
+// with the invocation as soon as the job is submitted.
+func (executor *Executor) submitJob(w http.ResponseWriter, r *http.Request) {
+	jobm, fn, ok := executor.getJobFunction(w, r)
+	if !ok {
+		return
+	}
+
+	input, err := io.ReadAll(io.LimitReader(r.Body, jobmgr.MaxInputSize+1))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusInternalServerError)
+		return
+	}
+
+	invocation, err := jobm.Invoke(r.Context(), fn, input)
+	if err != nil {
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", strings.TrimSuffix(r.URL.Path, "/"), invocation.ID))
+	executor.writeJSON(w, http.StatusAccepted, invocation)
+}
+
+// getJobInvocation returns the status and result of a job invocation.
+func (executor *Executor) getJobInvocation(w http.ResponseWriter, r *http.Request) {
+	jobm, fn, ok := executor.getJobFunction(w, r)
+	if !ok {
+		return
+	}
+
+	invocation, err := jobm.GetInvocation(r.Context(), fn, mux.Vars(r)["id"])
+	if err != nil {
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+	executor.writeJSON(w, http.StatusOK, invocation)
+}
+
+// getJobInvocationLogs streams the logs of a job invocation.
+func (executor *Executor) getJobInvocationLogs(w http.ResponseWriter, r *http.Request) {
+	jobm, fn, ok := executor.getJobFunction(w, r)
+	if !ok {
+		return
+	}
+
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+	w.Header().Set("Content-Type", "text/plain")
+	err := jobm.StreamInvocationLogs(r.Context(), fn, mux.Vars(r)["id"], follow, w)
+	if err != nil {
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+}
+
+// getColdStarts returns the recent specializations, optionally filtered
+// by the namespace and function query parameters.
+func (executor *Executor) getColdStarts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	records := executor.coldStarts.List(query.Get("namespace"), query.Get("function"))
+	executor.writeJSON(w, http.StatusOK, records)
+}
+
+func (executor *Executor) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, err = w.Write(body)
+	if err != nil {
+		executor.logger.Error("error writing HTTP response", zap.Error(err))
+	}
+}
+
 // GetHandler returns an http.Handler.
 func (executor *Executor) GetHandler() http.Handler {
 	r := mux.NewRouter()
@@ -283,6 +389,10 @@ func (executor *Executor) GetHandler() http.Handler {
 	r.HandleFunc("/healthz", executor.healthHandler).Methods("GET")
 	r.HandleFunc("/v2/unTapService", executor.unTapService).Methods("POST")
 	r.HandleFunc("/v2/debugInfo", executor.dumpDebugInfo).Methods("GET")
+	r.HandleFunc("/v2/coldstarts", executor.getColdStarts).Methods("GET")
+	r.HandleFunc("/v2/jobs/{namespace}/{function}/{id}", executor.getJobInvocation).Methods("GET")
+	r.HandleFunc("/v2/jobs/{namespace}/{function}/{id}/logs", executor.getJobInvocationLogs).Methods("GET")
+	r.HandleFunc("/v2/jobs/{namespace}/{function}", executor.submitJob).Methods("POST")
 	return r
 }
 
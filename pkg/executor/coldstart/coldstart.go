@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coldstart
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+// Cold start phases timed by the executor. PhaseChoosePod covers waiting
+// for a ready pool pod including its relabelling. The phases of the fetcher
+// are defined in the fetcher package and reported back by the specialize call.
+const (
+	PhaseChoosePod = "choosePod"
+	PhaseRelabel   = "relabel"
+)
+
+type (
+	// Record describes one specialization of a function.
+	Record struct {
+		Function     string                   `json:"function"`
+		Namespace    string                   `json:"namespace"`
+		Environment  string                   `json:"environment"`
+		ExecutorType fv1.ExecutorType         `json:"executorType"`
+		Timestamp    time.Time                `json:"timestamp"`
+		Duration     time.Duration            `json:"duration"`
+		Phases       map[string]time.Duration `json:"phases,omitempty"`
+		Error        string                   `json:"error,omitempty"`
+	}
+
+	// Tracker collects the phase durations of an ongoing specialization.
+	Tracker struct {
+		lock   sync.Mutex
+		start  time.Time
+		record Record
+	}
+
+	trackerKey struct{}
+
+	// Summary aggregates the specializations of a function.
+	Summary struct {
+		Function     string           `json:"function"`
+		Namespace    string           `json:"namespace"`
+		ExecutorType fv1.ExecutorType `json:"executorType"`
+		Count        int              `json:"count"`
+		Errors       int              `json:"errors"`
+		Average      time.Duration    `json:"average"`
+		Max          time.Duration    `json:"max"`
+		Last         time.Time        `json:"last"`
+		// Phases is the average duration of each phase.
+		Phases map[string]time.Duration `json:"phases,omitempty"`
+	}
+
+	// Recorder keeps the most recent specialization records in memory.
+	Recorder struct {
+		lock    sync.RWMutex
+		records []Record
+		next    int
+		full    bool
+	}
+)
+
+// NewTracker starts tracking a specialization of the function.
+func NewTracker(fn *fv1.Function) *Tracker {
+	return &Tracker{
+		start: time.Now(),
+		record: Record{
+			Function:     fn.ObjectMeta.Name,
+			Namespace:    fn.ObjectMeta.Namespace,
+			Environment:  fn.Spec.Environment.Name,
+			ExecutorType: fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
+			Timestamp:    time.Now(),
+			Phases:       make(map[string]time.Duration),
+		},
+	}
+}
+
+// WithTracker returns a context carrying the tracker, so that executor
+// types can report phase durations with TrackPhase and AddPhases.
+func WithTracker(ctx context.Context, tracker *Tracker) context.Context {
+	return context.WithValue(ctx, trackerKey{}, tracker)
+}
+
+// TrackPhase records the time elapsed since start for the phase on the
+// tracker of the context, if any, and adds a matching span event.
+func TrackPhase(ctx context.Context, phase string, start time.Time) {
+	elapsed := time.Since(start)
+	otelUtils.SpanTrackEvent(ctx, phase+"Done", attribute.Int64("duration_ms", elapsed.Milliseconds()))
+	AddPhases(ctx, map[string]time.Duration{phase: elapsed})
+}
+
+// AddPhases adds phase durations measured elsewhere, e.g. by the fetcher,
+// to the tracker of the context, if any.
+func AddPhases(ctx context.Context, phases map[string]time.Duration) {
+	tracker, ok := ctx.Value(trackerKey{}).(*Tracker)
+	if !ok {
+		return
+	}
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+	for phase, d := range phases {
+		tracker.record.Phases[phase] += d
+	}
+}
+
+// Finish stops tracking and returns the record of the specialization.
+func (tracker *Tracker) Finish(err error) Record {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+	record := tracker.record
+	record.Duration = time.Since(tracker.start)
+	record.Phases = make(map[string]time.Duration, len(tracker.record.Phases))
+	for phase, d := range tracker.record.Phases {
+		record.Phases[phase] = d
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// MakeRecorder returns a recorder keeping up to size records.
+func MakeRecorder(size int) *Recorder {
+	return &Recorder{records: make([]Record, size)}
+}
+
+// Add stores a record, evicting the oldest one if the recorder is full.
+func (r *Recorder) Add(record Record) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.records) == 0 {
+		return
+	}
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// List returns the records of the given namespace and function, newest
+// first. Empty filters match everything.
+func (r *Recorder) List(namespace, function string) []Record {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	count := r.next
+	if r.full {
+		count = len(r.records)
+	}
+	records := make([]Record, 0, count)
+	for i := 1; i <= count; i++ {
+		record := r.records[(r.next-i+len(r.records))%len(r.records)]
+		if len(namespace) > 0 && record.Namespace != namespace {
+			continue
+		}
+		if len(function) > 0 && record.Function != function {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Phases returns the names of the phases found in the records, sorted.
+func Phases(records []Record) []string {
+	seen := make(map[string]bool)
+	for _, record := range records {
+		for phase := range record.Phases {
+			seen[phase] = true
+		}
+	}
+	phases := make([]string, 0, len(seen))
+	for phase := range seen {
+		phases = append(phases, phase)
+	}
+	sort.Strings(phases)
+	return phases
+}
+
+// Summarize aggregates the records per function, sorted by namespace and
+// function name. Failed specializations are counted but left out of the
+// durations.
+func Summarize(records []Record) []Summary {
+	type key struct{ namespace, function string }
+	summaries := make(map[key]*Summary)
+	phaseCounts := make(map[key]map[string]int)
+
+	for _, record := range records {
+		k := key{record.Namespace, record.Function}
+		summary, ok := summaries[k]
+		if !ok {
+			summary = &Summary{
+				Function:     record.Function,
+				Namespace:    record.Namespace,
+				ExecutorType: record.ExecutorType,
+				Phases:       make(map[string]time.Duration),
+			}
+			summaries[k] = summary
+			phaseCounts[k] = make(map[string]int)
+		}
+		summary.Count++
+		if record.Timestamp.After(summary.Last) {
+			summary.Last = record.Timestamp
+		}
+		if len(record.Error) > 0 {
+			summary.Errors++
+			continue
+		}
+		summary.Average += record.Duration
+		if record.Duration > summary.Max {
+			summary.Max = record.Duration
+		}
+		for phase, d := range record.Phases {
+			summary.Phases[phase] += d
+			phaseCounts[k][phase]++
+		}
+	}
+
+	result := make([]Summary, 0, len(summaries))
+	for k, summary := range summaries {
+		if succeeded := summary.Count - summary.Errors; succeeded > 0 {
+			summary.Average /= time.Duration(succeeded)
+		}
+		for phase, count := range phaseCounts[k] {
+			summary.Phases[phase] /= time.Duration(count)
+		}
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Function < result[j].Function
+	})
+	return result
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coldstart
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestTracker(t *testing.T) {
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: fv1.FunctionSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: "default"},
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypePoolmgr},
+			},
+		},
+	}
+	tracker := NewTracker(fn)
+	ctx := WithTracker(t.Context(), tracker)
+
+	TrackPhase(ctx, PhaseChoosePod, time.Now().Add(-time.Second))
+	AddPhases(ctx, map[string]time.Duration{"download": 2 * time.Second})
+	// phases reported without a tracker are dropped
+	AddPhases(t.Context(), map[string]time.Duration{"unarchive": time.Second})
+
+	record := tracker.Finish(nil)
+	require.Equal(t, "hello", record.Function)
+	require.Equal(t, "nodejs", record.Environment)
+	require.Equal(t, fv1.ExecutorTypePoolmgr, record.ExecutorType)
+	require.Len(t, record.Phases, 2)
+	require.GreaterOrEqual(t, record.Phases[PhaseChoosePod], time.Second)
+	require.Equal(t, 2*time.Second, record.Phases["download"])
+	require.Empty(t, record.Error)
+
+	require.Equal(t, "failed", tracker.Finish(errors.New("failed")).Error)
+}
+
+func TestRecorder(t *testing.T) {
+	r := MakeRecorder(3)
+	for i, name := range []string{"a", "b", "a", "c"} {
+		r.Add(Record{Function: name, Namespace: "default", Duration: time.Duration(i)})
+	}
+
+	records := r.List("", "")
+	require.Len(t, records, 3)
+	require.Equal(t, "c", records[0].Function, "newest record first")
+	require.Equal(t, "b", records[2].Function, "oldest record evicted")
+
+	require.Len(t, r.List("default", "a"), 1)
+	require.Empty(t, r.List("other", ""))
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Now()
+	records := []Record{
+		{Function: "b", Namespace: "default", Duration: 3 * time.Second, Timestamp: now,
+			Phases: map[string]time.Duration{"download": 2 * time.Second}},
+		{Function: "b", Namespace: "default", Duration: time.Second, Timestamp: now.Add(-time.Minute),
+			Phases: map[string]time.Duration{"download": time.Second, PhaseChoosePod: time.Second}},
+		{Function: "b", Namespace: "default", Duration: time.Minute, Timestamp: now.Add(-time.Hour), Error: "timeout"},
+		{Function: "a", Namespace: "default", Duration: time.Second, Timestamp: now},
+	}
+
+	summaries := Summarize(records)
+	require.Len(t, summaries, 2)
+	require.Equal(t, "a", summaries[0].Function)
+
+	b := summaries[1]
+	require.Equal(t, 3, b.Count)
+	require.Equal(t, 1, b.Errors)
+	require.Equal(t, 2*time.Second, b.Average)
+	require.Equal(t, 3*time.Second, b.Max)
+	require.Equal(t, now, b.Last)
+	require.Equal(t, 1500*time.Millisecond, b.Phases["download"])
+	require.Equal(t, time.Second, b.Phases[PhaseChoosePod])
+
+	require.Equal(t, []string{"choosePod", "download"}, Phases(records))
+}
@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -27,17 +28,25 @@ import (
 
 	"github.com/dchest/uniuri"
 	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/cms"
+	"github.com/fission/fission/pkg/executor/coldstart"
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/executortype/container"
+	"github.com/fission/fission/pkg/executor/executortype/jobmgr"
 	"github.com/fission/fission/pkg/executor/executortype/newdeploy"
+	"github.com/fission/fission/pkg/executor/executortype/plugin"
 	"github.com/fission/fission/pkg/executor/executortype/poolmgr"
 	"github.com/fission/fission/pkg/executor/fscache"
+	executorMetrics "github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/featureconfig"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
@@ -47,6 +56,10 @@ import (
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
 
+// maxColdStartRecords is the number of recent specializations kept for
+// cold start reports.
+const maxColdStartRecords = 1000
+
 type (
 	// Executor defines a fission function executor.
 	Executor struct {
@@ -54,6 +67,8 @@ type (
 
 		executorTypes map[fv1.ExecutorType]executortype.ExecutorType
 		cms           *cms.ConfigSecretController
+		quota         *quota.Enforcer
+		coldStarts    *coldstart.Recorder
 
 		fissionClient versioned.Interface
 
@@ -74,11 +89,13 @@ type (
 
 // MakeExecutor returns an Executor for given ExecutorType(s).
 func MakeExecutor(ctx context.Context, logger *zap.Logger, mgr manager.Interface, cms *cms.ConfigSecretController,
-	fissionClient versioned.Interface, types map[fv1.ExecutorType]executortype.ExecutorType,
+	quota *quota.Enforcer, fissionClient versioned.Interface, types map[fv1.ExecutorType]executortype.ExecutorType,
 	informers ...k8sCache.SharedIndexInformer) (*Executor, error) {
 	executor := &Executor{
 		logger:        logger.Named("executor"),
 		cms:           cms,
+		quota:         quota,
+		coldStarts:    coldstart.MakeRecorder(maxColdStartRecords),
 		fissionClient: fissionClient,
 		executorTypes: types,
 
@@ -235,7 +252,27 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 		return nil, fmt.Errorf("unknown executor type '%s'", t)
 	}
 
-	fsvc, fsvcErr := e.GetFuncSvc(ctx, fn)
+	var podSpec quota.PodSpecFunc
+	if p, ok := e.(executortype.PodSpecProvider); ok {
+		podSpec = func(ctx context.Context) (*apiv1.PodSpec, error) {
+			return p.GetFuncPodSpec(ctx, fn)
+		}
+	}
+	release, err := executor.quota.Acquire(ctx, fn, podSpec)
+	if err != nil {
+		if code, _ := ferror.GetHTTPError(err); code == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("[%s] quota exceeded: %w", fn.ObjectMeta.Name, err)
+		}
+		return nil, fmt.Errorf("[%s] error checking quota: %w", fn.ObjectMeta.Name, err)
+	}
+	defer release()
+
+	tracker := coldstart.NewTracker(fn)
+	fsvc, fsvcErr := e.GetFuncSvc(coldstart.WithTracker(ctx, tracker), fn)
+	// job functions aren't specialized, GetFuncSvc only returns the job API
+	if t != fv1.ExecutorTypeJob {
+		executor.recordColdStart(tracker.Finish(fsvcErr))
+	}
 	if fsvcErr != nil {
 		e := "error creating service for function"
 		logger.Error(e,
@@ -248,6 +285,18 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 	return fsvc, fsvcErr
 }
 
+// recordColdStart keeps the record of a specialization for cold start
+// reports and updates the phase duration metrics.
+func (executor *Executor) recordColdStart(record coldstart.Record) {
+	executor.coldStarts.Add(record)
+	if len(record.Error) > 0 {
+		return
+	}
+	for phase, d := range record.Phases {
+		executorMetrics.ColdStartPhaseDuration.WithLabelValues(phase, record.Environment, string(record.ExecutorType)).Observe(d.Seconds())
+	}
+}
+
 func (executor *Executor) getFunctionServiceFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	otelUtils.SpanTrackEvent(ctx, "getFunctionServiceFromCache", otelUtils.GetAttributesForFunction(fn)...)
 	t := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
@@ -314,6 +363,12 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		return fmt.Errorf("pool manager creation failed: %w", err)
 	}
 
+	featureConfig, err := featureconfig.GetFeatureConfig(logger)
+	if err != nil {
+		return fmt.Errorf("error reading feature config: %w", err)
+	}
+	quotaEnforcer := quota.MakeEnforcer(logger, kubernetesClient, featureConfig.QuotaConfig)
+
 	executorLabel, err = utils.GetInformerLabelByExecutor(fv1.ExecutorTypeNewdeploy)
 	if err != nil {
 		return err
@@ -324,7 +379,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		fissionClient, kubernetesClient,
 		fetcherConfig, executorInstanceID,
 		finformerFactory,
-		ndmInformerFactory, podSpecPatch)
+		ndmInformerFactory, podSpecPatch, quotaEnforcer)
 	if err != nil {
 		return fmt.Errorf("new deploy manager creation failed: %w", err)
 	}
@@ -343,10 +398,34 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		return fmt.Errorf("container manager creation failed: %w", err)
 	}
 
+	jobm, err := jobmgr.MakeJobManager(
+		ctx, logger,
+		fissionClient, kubernetesClient,
+		fetcherConfig, executorInstanceID,
+		finformerFactory, podSpecPatch)
+	if err != nil {
+		return fmt.Errorf("job manager creation failed: %w", err)
+	}
+
 	executorTypes := make(map[fv1.ExecutorType]executortype.ExecutorType)
 	executorTypes[gpm.GetTypeName(ctx)] = gpm
 	executorTypes[ndm.GetTypeName(ctx)] = ndm
 	executorTypes[cnm.GetTypeName(ctx)] = cnm
+	executorTypes[jobm.GetTypeName(ctx)] = jobm
+
+	if featureConfig.ExecutorPluginConfig.IsEnabled {
+		for _, p := range featureConfig.ExecutorPluginConfig.Plugins {
+			pe, err := plugin.MakeExecutor(logger, p)
+			if err != nil {
+				return fmt.Errorf("executor plugin %s creation failed: %w", p.Name, err)
+			}
+			if _, ok := executorTypes[pe.GetTypeName(ctx)]; ok {
+				return fmt.Errorf("executor plugin %s registered more than once", p.Name)
+			}
+			executorTypes[pe.GetTypeName(ctx)] = pe
+			logger.Info("registered executor plugin", zap.String("plugin", p.Name), zap.String("address", p.Address))
+		}
+	}
 
 	adoptExistingResources, _ := strconv.ParseBool(os.Getenv("ADOPT_EXISTING_RESOURCES"))
 
@@ -392,7 +471,7 @@ func StartExecutor(ctx context.Context, clientGen crd.ClientGeneratorInterface,
 		informerFactory.Start(ctx.Done())
 	}
 
-	api, err := MakeExecutor(ctx, logger, mgr, cms, fissionClient, executorTypes,
+	api, err := MakeExecutor(ctx, logger, mgr, cms, quotaEnforcer, fissionClient, executorTypes,
 		fissionInformers...,
 	)
 	if err != nil {
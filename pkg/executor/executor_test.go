@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/quota"
+	"github.com/fission/fission/pkg/featureconfig"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// podSpecExecutorType is an executor type only telling the pod spec of
+// functions, the quota is checked before any other method is called.
+type podSpecExecutorType struct {
+	executortype.ExecutorType
+	podSpecErr error
+}
+
+func (et *podSpecExecutorType) GetFuncPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, error) {
+	if et.podSpecErr != nil {
+		return nil, et.podSpecErr
+	}
+	return &apiv1.PodSpec{Containers: []apiv1.Container{{Name: "main"}}}, nil
+}
+
+func TestCreateServiceForFunctionQuotaErrors(t *testing.T) {
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "tenant"},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeNewdeploy},
+			},
+		},
+	}
+	runningPod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "running",
+			Namespace: "tenant",
+			Labels:    map[string]string{fv1.FUNCTION_NAMESPACE: "tenant"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		podSpecErr error
+		listErr    error
+		pods       []runtime.Object
+		wantErr    string
+		wantCode   int
+	}{
+		{
+			name:       "pod spec error",
+			podSpecErr: errors.New("package not found"),
+			wantErr:    "[fn] error checking quota: error getting pod spec of function fn.tenant: package not found",
+			wantCode:   http.StatusInternalServerError,
+		},
+		{
+			name:     "usage error",
+			listErr:  errors.New("apiserver unavailable"),
+			wantErr:  "[fn] error checking quota: error computing quota usage of namespace tenant: apiserver unavailable",
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "quota exceeded",
+			pods:     []runtime.Object{runningPod},
+			wantErr:  "[fn] quota exceeded: Too many requests - namespace 'tenant' function pod limit of 1 reached",
+			wantCode: http.StatusTooManyRequests,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubernetesClient := kubefake.NewClientset(test.pods...)
+			if test.listErr != nil {
+				kubernetesClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.listErr
+				})
+			}
+			logger := loggerfactory.GetLogger()
+			executor := &Executor{
+				logger: logger,
+				executorTypes: map[fv1.ExecutorType]executortype.ExecutorType{
+					fv1.ExecutorTypeNewdeploy: &podSpecExecutorType{podSpecErr: test.podSpecErr},
+				},
+				quota: quota.MakeEnforcer(logger, kubernetesClient, featureconfig.QuotaFeatureConfig{
+					IsEnabled:  true,
+					Namespaces: map[string]featureconfig.NamespaceQuota{"tenant": {MaxPods: 1}},
+				}),
+			}
+
+			_, err := executor.createServiceForFunction(t.Context(), fn)
+			require.EqualError(t, err, test.wantErr)
+			code, _ := ferror.GetHTTPError(err)
+			require.Equal(t, test.wantCode, code)
+		})
+	}
+}
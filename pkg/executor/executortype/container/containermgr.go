@@ -57,7 +57,8 @@ import (
 )
 
 var (
-	_ executortype.ExecutorType = &Container{}
+	_ executortype.ExecutorType    = &Container{}
+	_ executortype.PodSpecProvider = &Container{}
 )
 
 type (
@@ -196,6 +197,17 @@ func (caaf *Container) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 	return caaf.createFunction(ctx, fn)
 }
 
+// GetFuncPodSpec returns the spec of the pods of the function deployment.
+func (caaf *Container) GetFuncPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, error) {
+	depl, err := caaf.getDeploymentSpec(ctx, fn, nil, caaf.getObjName(fn),
+		caaf.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace),
+		caaf.getDeployLabels(fn.ObjectMeta), caaf.getDeployAnnotations(fn.ObjectMeta))
+	if err != nil {
+		return nil, err
+	}
+	return &depl.Spec.Template.Spec, nil
+}
+
 // GetFuncSvcFromCache returns a function service from cache; error otherwise.
 func (caaf *Container) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	otelUtils.SpanTrackEvent(ctx, "GetFuncSvcFromCache", otelUtils.GetAttributesForFunction(fn)...)
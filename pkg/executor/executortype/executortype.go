@@ -21,6 +21,7 @@ import (
 
 	"go.uber.org/zap"
 
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -70,3 +71,11 @@ type ExecutorType interface {
 	// CleanupOldExecutorObjects cleans up resources created by old executor instances
 	CleanupOldExecutorObjects(context.Context)
 }
+
+// PodSpecProvider is implemented by executor types which can tell the spec
+// of the pods a function is run with, to estimate the resources a new pod
+// of the function requests.
+type PodSpecProvider interface {
+	// GetFuncPodSpec returns the spec of the pods of the function.
+	GetFuncPodSpec(context.Context, *fv1.Function) (*apiv1.PodSpec, error)
+}
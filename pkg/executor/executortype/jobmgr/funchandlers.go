@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobmgr
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func (jobm *JobManager) FuncInformerHandler(ctx context.Context) k8sCache.ResourceEventHandlerFuncs {
+	return k8sCache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			fn, ok := obj.(*fv1.Function)
+			if !ok || fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob {
+				return
+			}
+			go func() {
+				log := jobm.logger.With(zap.String("function_name", fn.ObjectMeta.Name), zap.String("function_namespace", fn.ObjectMeta.Namespace))
+				log.Debug("start function delete handler")
+				err := jobm.deleteFunction(ctx, fn)
+				if err != nil {
+					log.Error("error deleting function", zap.Error(err))
+				}
+				log.Debug("end function delete handler")
+			}()
+		},
+		UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+			oldFn := oldObj.(*fv1.Function)
+			newFn := newObj.(*fv1.Function)
+			// Executor type is no longer job, drop any job left behind
+			if oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob ||
+				newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeJob {
+				return
+			}
+			go func() {
+				log := jobm.logger.With(zap.String("function_name", newFn.ObjectMeta.Name), zap.String("function_namespace", newFn.ObjectMeta.Namespace))
+				log.Info("function does not use job executor anymore, deleting jobs")
+				err := jobm.deleteFunction(ctx, oldFn)
+				if err != nil {
+					log.Error("error deleting function jobs", zap.Error(err))
+				}
+			}()
+		},
+	}
+}
@@ -0,0 +1,540 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobmgr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dchest/uniuri"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/reaper"
+	"github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/utils/maps"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+const (
+	// MaxInputSize is the maximum size of a job request body, bounded
+	// by the size limit of the secret the body is stored in.
+	MaxInputSize = 1024 * 1024
+
+	// jobInputFile is the file under fv1.JobInputMount holding the request body.
+	jobInputFile = "input"
+
+	// jobMainContainerAnnotation records the name of the container
+	// whose exit completes the job, to read its result.
+	jobMainContainerAnnotation = "fission.io/job-main-container"
+
+	// jobLogsContainerAnnotation records the name of the container
+	// running the function, to read its logs. Defaults to the main
+	// container.
+	jobLogsContainerAnnotation = "fission.io/job-logs-container"
+
+	// fetcherContainer is the name of the fetcher container of
+	// environment-based jobs.
+	fetcherContainer = "fetcher"
+)
+
+var jobInputPath = filepath.Join(fv1.JobInputMount, jobInputFile)
+
+const (
+	InvocationStatusQueued    = "queued"
+	InvocationStatusRunning   = "running"
+	InvocationStatusSucceeded = "succeeded"
+	InvocationStatusFailed    = "failed"
+)
+
+type (
+	// Invocation describes a single run of a job function.
+	Invocation struct {
+		ID             string       `json:"id"`
+		Function       string       `json:"function"`
+		Namespace      string       `json:"namespace"`
+		Status         string       `json:"status"`
+		Result         string       `json:"result,omitempty"`
+		ExitCode       *int32       `json:"exitCode,omitempty"`
+		CreationTime   metav1.Time  `json:"creationTime"`
+		StartTime      *metav1.Time `json:"startTime,omitempty"`
+		CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	}
+)
+
+// Invoke submits a new job for the function with the given request body as
+// input. The body is stored in a secret mounted at fv1.JobInputMount. A
+// container function reads it at the path in the FISSION_JOB_INPUT
+// environment variable, an environment function gets it as the body of the
+// single request made to it. The job is created suspended and queued until
+// the function's concurrency limit allows it to run.
+func (jobm *JobManager) Invoke(ctx context.Context, fn *fv1.Function, input []byte) (*Invocation, error) {
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob {
+		return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("function %s/%s is not of executor type %s", fn.ObjectMeta.Namespace, fn.ObjectMeta.Name, fv1.ExecutorTypeJob))
+	}
+	if len(input) > MaxInputSize {
+		return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("job input of %d bytes exceeds the limit of %d bytes", len(input), MaxInputSize))
+	}
+
+	logger := otelUtils.LoggerWithTraceID(ctx, jobm.logger)
+	otelUtils.SpanTrackEvent(ctx, "invokeJob", otelUtils.GetAttributesForFunction(fn)...)
+
+	invocationID := strings.ToLower(uniuri.NewLen(8))
+	jobName := jobm.getObjName(fn, invocationID)
+	ns := jobm.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+
+	job, err := jobm.getJobSpec(ctx, fn, jobName, ns, invocationID)
+	if err != nil {
+		return nil, fmt.Errorf("error building job for function %s: %w", fn.ObjectMeta.Name, err)
+	}
+
+	job, err = jobm.kubernetesClient.BatchV1().Jobs(ns).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating job %s: %w", jobName, err)
+	}
+
+	// The input secret is owned by the job, so that it's garbage
+	// collected along with the job once reaped.
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   jobName,
+			Labels: job.Labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job")),
+			},
+		},
+		Data: map[string][]byte{
+			jobInputFile: input,
+		},
+	}
+	_, err = jobm.kubernetesClient.CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		reaper.CleanupKubeObject(ctx, logger, jobm.kubernetesClient, &apiv1.ObjectReference{
+			Kind:      "job",
+			Name:      job.Name,
+			Namespace: job.Namespace,
+		})
+		return nil, fmt.Errorf("error creating input secret for job %s: %w", jobName, err)
+	}
+
+	err = jobm.dispatch(ctx, fn)
+	if err != nil {
+		// The job stays queued and is dispatched by the reaper later on.
+		logger.Error("error dispatching job", zap.Error(err), zap.String("job", jobName))
+	}
+
+	logger.Info("submitted job",
+		zap.String("job", jobName),
+		zap.String("function_name", fn.ObjectMeta.Name),
+		zap.String("function_namespace", fn.ObjectMeta.Namespace))
+
+	return &Invocation{
+		ID:           invocationID,
+		Function:     fn.ObjectMeta.Name,
+		Namespace:    fn.ObjectMeta.Namespace,
+		Status:       InvocationStatusQueued,
+		CreationTime: job.CreationTimestamp,
+	}, nil
+}
+
+// GetInvocation returns the status and, once finished, the result of an
+// invocation. The result is the termination message of the function
+// container, or the tail of its logs if it failed without writing one.
+func (jobm *JobManager) GetInvocation(ctx context.Context, fn *fv1.Function, id string) (*Invocation, error) {
+	job, err := jobm.getJob(ctx, fn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	invocation := &Invocation{
+		ID:             id,
+		Function:       fn.ObjectMeta.Name,
+		Namespace:      fn.ObjectMeta.Namespace,
+		CreationTime:   job.CreationTimestamp,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: reaper.JobFinishTime(job),
+	}
+
+	switch {
+	case isQueued(job):
+		invocation.Status = InvocationStatusQueued
+		return invocation, nil
+	case job.Status.Succeeded > 0:
+		invocation.Status = InvocationStatusSucceeded
+	case job.Status.Failed > 0:
+		invocation.Status = InvocationStatusFailed
+	default:
+		invocation.Status = InvocationStatusRunning
+		return invocation, nil
+	}
+
+	pod, err := jobm.getJobPod(ctx, job)
+	if err != nil {
+		// Result is not available anymore, but the status is still useful.
+		jobm.logger.Debug("error getting pod of finished job", zap.Error(err), zap.String("job", job.Name))
+		return invocation, nil
+	}
+	mainContainer := job.Annotations[jobMainContainerAnnotation]
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != mainContainer || status.State.Terminated == nil {
+			continue
+		}
+		exitCode := status.State.Terminated.ExitCode
+		invocation.ExitCode = &exitCode
+		invocation.Result = status.State.Terminated.Message
+	}
+	return invocation, nil
+}
+
+// StreamInvocationLogs copies the logs of the function container of an
+// invocation to w. When follow is set, it keeps streaming until the
+// container exits or the context is canceled.
+func (jobm *JobManager) StreamInvocationLogs(ctx context.Context, fn *fv1.Function, id string, follow bool, w io.Writer) error {
+	job, err := jobm.getJob(ctx, fn, id)
+	if err != nil {
+		return err
+	}
+	if isQueued(job) {
+		return ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("invocation %s is queued and has no logs yet", id))
+	}
+
+	pod, err := jobm.getJobPod(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	container := job.Annotations[jobLogsContainerAnnotation]
+	if len(container) == 0 {
+		container = job.Annotations[jobMainContainerAnnotation]
+	}
+	stream, err := jobm.kubernetesClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &apiv1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error streaming logs of job %s: %w", job.Name, err)
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(w, stream)
+	return err
+}
+
+func (jobm *JobManager) getJob(ctx context.Context, fn *fv1.Function, id string) (*batchv1.Job, error) {
+	ns := jobm.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+	jobList, err := jobm.kubernetesClient.BatchV1().Jobs(ns).List(ctx, jobm.listOptions(map[string]string{
+		fv1.FUNCTION_UID:      string(fn.ObjectMeta.UID),
+		fv1.JOB_INVOCATION_ID: id,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(jobList.Items) == 0 {
+		return nil, ferror.MakeError(ferror.ErrorNotFound,
+			fmt.Sprintf("invocation %s of function %s/%s not found", id, fn.ObjectMeta.Namespace, fn.ObjectMeta.Name))
+	}
+	return &jobList.Items[0], nil
+}
+
+// getJobPod returns the most recently created pod of a job.
+func (jobm *JobManager) getJobPod(ctx context.Context, job *batchv1.Job) (*apiv1.Pod, error) {
+	podList, err := jobm.kubernetesClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{batchv1.JobNameLabel: job.Name}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(podList.Items) == 0 {
+		return nil, ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("no pod found for job %s", job.Name))
+	}
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[j].CreationTimestamp.Before(&podList.Items[i].CreationTimestamp)
+	})
+	return &podList.Items[0], nil
+}
+
+// getObjName returns a unique name for the job of an invocation.
+func (jobm *JobManager) getObjName(fn *fv1.Function, invocationID string) string {
+	// Pods of a job carry the job name as a label value, so keep
+	// it within the 63 character limit of label values.
+	name := fn.ObjectMeta.Name
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return strings.ToLower(fmt.Sprintf("job-%s-%s", strings.TrimSuffix(name, "-"), invocationID))
+}
+
+func (jobm *JobManager) getJobLabels(fnMeta metav1.ObjectMeta, invocationID string) map[string]string {
+	jobLabels := maps.CopyStringMap(fnMeta.Labels)
+	jobLabels[fv1.EXECUTOR_TYPE] = string(fv1.ExecutorTypeJob)
+	jobLabels[fv1.FUNCTION_NAME] = fnMeta.Name
+	jobLabels[fv1.FUNCTION_NAMESPACE] = fnMeta.Namespace
+	jobLabels[fv1.FUNCTION_UID] = string(fnMeta.UID)
+	jobLabels[fv1.JOB_INVOCATION_ID] = invocationID
+	return jobLabels
+}
+
+func (jobm *JobManager) getJobAnnotations(fnMeta metav1.ObjectMeta, mainContainer string, logsContainer string) map[string]string {
+	jobAnnotations := maps.CopyStringMap(fnMeta.Annotations)
+	jobAnnotations[fv1.EXECUTOR_INSTANCEID_LABEL] = jobm.instanceID
+	jobAnnotations[fv1.FUNCTION_RESOURCE_VERSION] = fnMeta.ResourceVersion
+	jobAnnotations[jobMainContainerAnnotation] = mainContainer
+	jobAnnotations[jobLogsContainerAnnotation] = logsContainer
+	return jobAnnotations
+}
+
+// getJobSpec builds the job of an invocation either from the function's
+// container spec, or from its environment. Environment-based jobs run the
+// runtime as a sidecar, and the fetcher as the main container that
+// specializes the runtime, calls the function once with the input and exits.
+func (jobm *JobManager) getJobSpec(ctx context.Context, fn *fv1.Function, jobName string, ns string, invocationID string) (*batchv1.Job, error) {
+	var (
+		podSpec       *apiv1.PodSpec
+		mainContainer string
+		logsContainer string
+		err           error
+	)
+	if fn.Spec.PodSpec != nil {
+		podSpec, mainContainer, err = jobm.getContainerPodSpec(ctx, fn)
+		logsContainer = mainContainer
+	} else {
+		podSpec, logsContainer, err = jobm.getEnvironmentPodSpec(ctx, fn)
+		mainContainer = fetcherContainer
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, apiv1.Volume{
+		Name: fv1.JobInputVolume,
+		VolumeSource: apiv1.VolumeSource{
+			Secret: &apiv1.SecretVolumeSource{
+				SecretName: jobName,
+			},
+		},
+	})
+	for i, container := range podSpec.Containers {
+		if container.Name != mainContainer {
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts, apiv1.VolumeMount{
+			Name:      fv1.JobInputVolume,
+			MountPath: fv1.JobInputMount,
+			ReadOnly:  true,
+		})
+		container.Env = append(container.Env,
+			apiv1.EnvVar{Name: "FISSION_JOB_ID", Value: invocationID},
+			apiv1.EnvVar{Name: "FISSION_JOB_INPUT", Value: jobInputPath},
+		)
+		// The function reports its result by writing to the termination log.
+		container.TerminationMessagePath = "/dev/termination-log"
+		container.TerminationMessagePolicy = apiv1.TerminationMessageFallbackToLogsOnError
+		podSpec.Containers[i] = container
+	}
+	if podSpec.RestartPolicy != apiv1.RestartPolicyOnFailure {
+		podSpec.RestartPolicy = apiv1.RestartPolicyNever
+	}
+
+	jobLabels := jobm.getJobLabels(fn.ObjectMeta, invocationID)
+	podLabels := maps.CopyStringMap(jobLabels)
+
+	var ownerReferences []metav1.OwnerReference
+	if jobm.enableOwnerReferences {
+		ownerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(fn, schema.GroupVersionKind{
+				Group:   "fission.io",
+				Version: "v1",
+				Kind:    "Function",
+			}),
+		}
+	}
+
+	// Jobs are always created suspended, so that the input
+	// secret exists by the time the pod starts.
+	suspend := true
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            jobName,
+			Namespace:       ns,
+			Labels:          jobLabels,
+			Annotations:     jobm.getJobAnnotations(fn.ObjectMeta, mainContainer, logsContainer),
+			OwnerReferences: ownerReferences,
+		},
+		Spec: batchv1.JobSpec{
+			Suspend:      &suspend,
+			BackoffLimit: &backoffLimit,
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+func (jobm *JobManager) getContainerPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, string, error) {
+	// Other executor types rely on Environments to add configmaps and secrets
+	envFromSources, err := util.ConvertConfigSecrets(ctx, fn, jobm.kubernetesClient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	container := apiv1.Container{
+		Name:            fn.ObjectMeta.Name,
+		ImagePullPolicy: jobm.runtimeImagePullPolicy,
+		EnvFrom:         envFromSources,
+		Resources:       fn.Spec.Resources,
+	}
+	podSpec, err := util.MergePodSpec(&apiv1.PodSpec{
+		Containers: []apiv1.Container{container},
+	}, fn.Spec.PodSpec.DeepCopy())
+	if err != nil {
+		return nil, "", err
+	}
+	podSpec = util.ApplyImagePullSecret("", *podSpec)
+	return podSpec, fn.ObjectMeta.Name, nil
+}
+
+// getEnvironmentPodSpec returns the pod spec of an environment-based job and
+// the name of its runtime container.
+func (jobm *JobManager) getEnvironmentPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, string, error) {
+	env, err := jobm.fissionClient.CoreV1().Environments(fn.Spec.Environment.Namespace).
+		Get(ctx, fn.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting environment %s/%s: %w", fn.Spec.Environment.Namespace, fn.Spec.Environment.Name, err)
+	}
+
+	container, err := util.MergeContainer(&apiv1.Container{
+		Name:            env.ObjectMeta.Name,
+		Image:           env.Spec.Runtime.Image,
+		ImagePullPolicy: jobm.runtimeImagePullPolicy,
+		Resources:       getResources(env, fn),
+	}, env.Spec.Runtime.Container)
+	if err != nil {
+		return nil, "", err
+	}
+
+	podSpec := &apiv1.PodSpec{
+		Containers:         []apiv1.Container{*container},
+		ServiceAccountName: fv1.FissionFetcherSA,
+	}
+	if jobm.podSpecPatch != nil {
+		podSpec, err = util.MergePodSpec(podSpec, jobm.podSpecPatch.DeepCopy())
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	podSpec = util.ApplyImagePullSecret(env.Spec.ImagePullSecret, *podSpec)
+
+	// If custom runtime container name - default env name
+	runtimeContainer := env.ObjectMeta.Name
+	if env.Spec.Runtime.Container != nil && env.Spec.Runtime.Container.Name != "" && env.Spec.Runtime.PodSpec != nil {
+		if !util.DoesContainerExistInPodSpec(env.Spec.Runtime.Container.Name, env.Spec.Runtime.PodSpec) {
+			return nil, "", fmt.Errorf("runtime container %s not found in pod spec", env.Spec.Runtime.Container.Name)
+		}
+		runtimeContainer = env.Spec.Runtime.Container.Name
+	}
+
+	// Order of merging is important here - first fetcher, then containers and lastly pod spec
+	err = jobm.fetcherConfig.AddJobFetcherToPodSpec(podSpec, runtimeContainer, fn, env, jobInputPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if env.Spec.Runtime.PodSpec != nil {
+		podSpec, err = util.MergePodSpec(podSpec, env.Spec.Runtime.PodSpec.DeepCopy())
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// The fetcher is the only regular container, so that the pod
+	// completes once it has called the function and exited. The runtime
+	// and any other container run as sidecar init containers, which
+	// Kubernetes stops once the fetcher is done.
+	sidecarRestartPolicy := apiv1.ContainerRestartPolicyAlways
+	containers := make([]apiv1.Container, 0, 1)
+	for _, c := range podSpec.Containers {
+		if c.Name == fetcherContainer {
+			c.Lifecycle = nil
+			containers = append(containers, c)
+			continue
+		}
+		c.RestartPolicy = &sidecarRestartPolicy
+		if c.Name == runtimeContainer && c.StartupProbe == nil {
+			// The fetcher only starts once the runtime is up, so
+			// that it specializes a runtime ready to serve.
+			c.StartupProbe = runtimeStartupProbe()
+		}
+		podSpec.InitContainers = append(podSpec.InitContainers, c)
+	}
+	podSpec.Containers = containers
+
+	return podSpec, runtimeContainer, nil
+}
+
+// runtimeStartupProbe checks that the runtime of an environment listens on
+// its port.
+func runtimeStartupProbe() *apiv1.Probe {
+	return &apiv1.Probe{
+		PeriodSeconds:    1,
+		FailureThreshold: 300,
+		ProbeHandler: apiv1.ProbeHandler{
+			TCPSocket: &apiv1.TCPSocketAction{
+				Port: intstr.FromInt32(8888),
+			},
+		},
+	}
+}
+
+// getResources returns the environment resources overridden by the ones
+// specified at function level.
+func getResources(env *fv1.Environment, fn *fv1.Function) apiv1.ResourceRequirements {
+	resources := *env.Spec.Resources.DeepCopy()
+	if resources.Requests == nil {
+		resources.Requests = make(apiv1.ResourceList)
+	}
+	if resources.Limits == nil {
+		resources.Limits = make(apiv1.ResourceList)
+	}
+	for name, val := range fn.Spec.Resources.Requests {
+		if !val.IsZero() {
+			resources.Requests[name] = val
+		}
+	}
+	for name, val := range fn.Spec.Resources.Limits {
+		if !val.IsZero() {
+			resources.Limits[name] = val
+		}
+	}
+	return resources
+}
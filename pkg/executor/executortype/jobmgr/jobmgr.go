@@ -0,0 +1,348 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobmgr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/reaper"
+	executorUtils "github.com/fission/fission/pkg/executor/util"
+	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
+	"github.com/fission/fission/pkg/generated/clientset/versioned"
+	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/manager"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+var (
+	_ executortype.ExecutorType = &JobManager{}
+)
+
+type (
+	// JobManager is an executor type that runs every function invocation
+	// as a Kubernetes Job instead of serving requests over HTTP. It is meant
+	// for long-running batch functions that don't fit the request/response
+	// model and the FunctionTimeout of the other executor types.
+	//
+	// The router forwards requests for job functions to the executor job API,
+	// which submits a Job and returns immediately with an invocation ID. The
+	// result and logs of an invocation can be retrieved until the finished
+	// Job is reaped.
+	JobManager struct {
+		logger *zap.Logger
+
+		kubernetesClient kubernetes.Interface
+		fissionClient    versioned.Interface
+		instanceID       string
+		nsResolver       *utils.NamespaceResolver
+		fetcherConfig    *fetcherConfig.Config
+		podSpecPatch     *apiv1.PodSpec
+
+		runtimeImagePullPolicy apiv1.PullPolicy
+
+		// gatewayAddress is the address of the executor job API, it's
+		// handed to the router as the service address of job functions.
+		gatewayAddress string
+
+		// jobTTL is the time a finished job is kept around for result and
+		// log retrieval before being reaped.
+		jobTTL                     time.Duration
+		objectReaperIntervalSecond time.Duration
+
+		enableOwnerReferences bool
+	}
+)
+
+// MakeJobManager initializes and returns an instance of JobManager
+func MakeJobManager(
+	ctx context.Context,
+	logger *zap.Logger,
+	fissionClient versioned.Interface,
+	kubernetesClient kubernetes.Interface,
+	fetcherConfig *fetcherConfig.Config,
+	instanceID string,
+	finformerFactory map[string]genInformer.SharedInformerFactory,
+	podSpecPatch *apiv1.PodSpec,
+) (executortype.ExecutorType, error) {
+	jobLogger := logger.Named("job_manager")
+
+	gatewayAddress := os.Getenv("JOB_GATEWAY_ADDRESS")
+	if len(gatewayAddress) == 0 {
+		gatewayAddress = "executor.fission"
+	}
+
+	jobTTLStr := os.Getenv("JOB_TTL")
+	jobTTL, err := time.ParseDuration(jobTTLStr)
+	if err != nil {
+		jobTTL = time.Hour
+		if len(jobTTLStr) > 0 {
+			jobLogger.Error("failed to parse job ttl from 'JOB_TTL' - set to the default value",
+				zap.Error(err),
+				zap.String("value", jobTTLStr),
+				zap.Duration("default", jobTTL))
+		}
+	}
+
+	jobm := &JobManager{
+		logger: jobLogger,
+
+		fissionClient:    fissionClient,
+		kubernetesClient: kubernetesClient,
+		instanceID:       instanceID,
+		nsResolver:       utils.DefaultNSResolver(),
+		fetcherConfig:    fetcherConfig,
+		podSpecPatch:     podSpecPatch,
+
+		runtimeImagePullPolicy: utils.GetImagePullPolicy(os.Getenv("RUNTIME_IMAGE_PULL_POLICY")),
+		gatewayAddress:         gatewayAddress,
+
+		jobTTL:                     jobTTL,
+		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypeJob, 5)) * time.Second,
+
+		enableOwnerReferences: utils.IsOwnerReferencesEnabled(),
+	}
+
+	for _, factory := range finformerFactory {
+		_, err := factory.Core().V1().Functions().Informer().AddEventHandler(jobm.FuncInformerHandler(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to add event handler for function informer: %w", err)
+		}
+	}
+	return jobm, nil
+}
+
+// Run starts the job reaper which dispatches queued jobs and
+// removes finished ones.
+func (jobm *JobManager) Run(ctx context.Context, mgr manager.Interface) {
+	mgr.Add(ctx, func(ctx context.Context) {
+		jobm.idleObjectReaper(ctx)
+	})
+}
+
+// GetTypeName returns the executor type name.
+func (jobm *JobManager) GetTypeName(ctx context.Context) fv1.ExecutorType {
+	return fv1.ExecutorTypeJob
+}
+
+// GetFuncSvc returns the job API address as the function service. No
+// kubernetes objects are created until a request is submitted.
+func (jobm *JobManager) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob {
+		return nil, fmt.Errorf("function %s is not of executor type %s", k8sCache.MetaObjectToName(fn), fv1.ExecutorTypeJob)
+	}
+	return &fscache.FuncSvc{
+		Name:     fn.ObjectMeta.Name,
+		Function: &fn.ObjectMeta,
+		Address:  jobm.gatewayAddress,
+		Executor: fv1.ExecutorTypeJob,
+	}, nil
+}
+
+// GetFuncSvcFromCache returns the job API address; job functions never
+// need specialization so there's nothing to cache.
+func (jobm *JobManager) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	otelUtils.SpanTrackEvent(ctx, "GetFuncSvcFromCache", otelUtils.GetAttributesForFunction(fn)...)
+	return jobm.GetFuncSvc(ctx, fn)
+}
+
+// DeleteFuncSvcFromCache has not been implemented for JobManager.
+func (jobm *JobManager) DeleteFuncSvcFromCache(ctx context.Context, fsvc *fscache.FuncSvc) {
+	// Not Implemented for JobManager.
+}
+
+// DumpDebugInfo has not been implemented for JobManager.
+func (jobm *JobManager) DumpDebugInfo(ctx context.Context) error {
+	return nil
+}
+
+// TapService has not been implemented for JobManager, jobs
+// are reaped once finished regardless of access time.
+func (jobm *JobManager) TapService(ctx context.Context, svcHost string) error {
+	return nil
+}
+
+// UnTapService has not been implemented for JobManager.
+func (jobm *JobManager) UnTapService(ctx context.Context, fnMeta *metav1.ObjectMeta, svcHost string) {
+	// Not Implemented for JobManager.
+}
+
+// MarkSpecializationFailure has not been implemented for JobManager.
+func (jobm *JobManager) MarkSpecializationFailure(ctx context.Context, fnMeta *metav1.ObjectMeta) {
+	// Not Implemented for JobManager.
+}
+
+// IsValid always returns true since the job API address never changes.
+func (jobm *JobManager) IsValid(ctx context.Context, fsvc *fscache.FuncSvc) bool {
+	return fsvc.Address == jobm.gatewayAddress
+}
+
+// RefreshFuncPods is a no-op for JobManager. Every invocation creates
+// a new pod, so updated secrets/configmaps are picked up by the next job.
+func (jobm *JobManager) RefreshFuncPods(ctx context.Context, logger *zap.Logger, f fv1.Function) error {
+	return nil
+}
+
+// AdoptExistingResources is a no-op for JobManager. Jobs are not bound to
+// an executor instance and keep running across executor restarts; queued
+// jobs are picked up by the reaper of the new instance.
+func (jobm *JobManager) AdoptExistingResources(ctx context.Context) {
+	// Not Implemented for JobManager.
+}
+
+// CleanupOldExecutorObjects removes finished jobs that have outlived the TTL.
+func (jobm *JobManager) CleanupOldExecutorObjects(ctx context.Context) {
+	jobm.logger.Info("JobManager starts to clean finished jobs", zap.String("instanceID", jobm.instanceID))
+	err := reaper.CleanupFinishedJobs(ctx, jobm.logger, jobm.kubernetesClient, jobm.jobTTL, jobm.listOptions(nil))
+	if err != nil {
+		jobm.logger.Error("Failed to cleanup finished jobs", zap.Error(err))
+	}
+}
+
+func (jobm *JobManager) deleteFunction(ctx context.Context, fn *fv1.Function) error {
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob {
+		return nil
+	}
+
+	ns := jobm.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+	deletePropagation := metav1.DeletePropagationBackground
+	err := jobm.kubernetesClient.BatchV1().Jobs(ns).DeleteCollection(ctx,
+		metav1.DeleteOptions{PropagationPolicy: &deletePropagation},
+		jobm.listOptions(map[string]string{fv1.FUNCTION_UID: string(fn.ObjectMeta.UID)}))
+	if err != nil {
+		return fmt.Errorf("error deleting jobs of function %s: %w", k8sCache.MetaObjectToName(fn), err)
+	}
+	return nil
+}
+
+// listOptions returns list options selecting jobs created by this executor
+// type, narrowed down by the given extra labels.
+func (jobm *JobManager) listOptions(extra map[string]string) metav1.ListOptions {
+	sel := map[string]string{fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeJob)}
+	for k, v := range extra {
+		sel[k] = v
+	}
+	return metav1.ListOptions{
+		LabelSelector: labels.Set(sel).AsSelector().String(),
+	}
+}
+
+// idleObjectReaper dispatches queued jobs and reaps finished jobs at a regular interval
+func (jobm *JobManager) idleObjectReaper(ctx context.Context) {
+	wait.UntilWithContext(ctx, jobm.doIdleObjectReaper, jobm.objectReaperIntervalSecond)
+}
+
+func (jobm *JobManager) doIdleObjectReaper(ctx context.Context) {
+	for _, ns := range reaper.GetReaperNamespace() {
+		jobList, err := jobm.kubernetesClient.BatchV1().Jobs(ns).List(ctx, jobm.listOptions(nil))
+		if err != nil {
+			jobm.logger.Error("error listing jobs", zap.Error(err), zap.String("namespace", ns))
+			continue
+		}
+
+		queued := make(map[string]bool)
+		for _, job := range jobList.Items {
+			if isQueued(&job) {
+				queued[job.Labels[fv1.FUNCTION_UID]] = true
+			}
+		}
+		for _, job := range jobList.Items {
+			uid := job.Labels[fv1.FUNCTION_UID]
+			if !queued[uid] {
+				continue
+			}
+			delete(queued, uid)
+			fn, err := jobm.fissionClient.CoreV1().Functions(job.Labels[fv1.FUNCTION_NAMESPACE]).
+				Get(ctx, job.Labels[fv1.FUNCTION_NAME], metav1.GetOptions{})
+			if err != nil {
+				jobm.logger.Error("error getting function of queued job", zap.Error(err), zap.String("job", job.Name))
+				continue
+			}
+			err = jobm.dispatch(ctx, fn)
+			if err != nil {
+				jobm.logger.Error("error dispatching queued jobs", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+			}
+		}
+	}
+
+	err := reaper.CleanupFinishedJobs(ctx, jobm.logger, jobm.kubernetesClient, jobm.jobTTL, jobm.listOptions(nil))
+	if err != nil {
+		jobm.logger.Error("error reaping finished jobs", zap.Error(err))
+	}
+}
+
+// dispatch resumes queued jobs of a function, oldest first, as long as the
+// number of running jobs stays within the function's MaxScale. A MaxScale
+// of 0 means no concurrency limit.
+func (jobm *JobManager) dispatch(ctx context.Context, fn *fv1.Function) error {
+	ns := jobm.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace)
+	jobList, err := jobm.kubernetesClient.BatchV1().Jobs(ns).List(ctx,
+		jobm.listOptions(map[string]string{fv1.FUNCTION_UID: string(fn.ObjectMeta.UID)}))
+	if err != nil {
+		return err
+	}
+
+	running := 0
+	queued := make([]batchv1.Job, 0)
+	for _, job := range jobList.Items {
+		switch {
+		case isQueued(&job):
+			queued = append(queued, job)
+		case reaper.JobFinishTime(&job) == nil:
+			running++
+		}
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		return queued[i].CreationTimestamp.Before(&queued[j].CreationTimestamp)
+	})
+
+	maxScale := fn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale
+	for _, job := range queued {
+		if maxScale > 0 && running >= maxScale {
+			break
+		}
+		_, err = jobm.kubernetesClient.BatchV1().Jobs(job.Namespace).Patch(ctx, job.Name,
+			k8sTypes.MergePatchType, []byte(`{"spec":{"suspend":false}}`), metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error resuming job %s: %w", job.Name, err)
+		}
+		jobm.logger.Debug("resumed queued job", zap.String("job", job.Name), zap.String("function", fn.ObjectMeta.Name))
+		running++
+	}
+	return nil
+}
+
+func isQueued(job *batchv1.Job) bool {
+	return job.Spec.Suspend != nil && *job.Spec.Suspend && reaper.JobFinishTime(job) == nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
+	fClient "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+const (
+	defaultNamespace  string = "default"
+	functionNamespace string = "fission-function"
+	builderNamespace  string = "fission-builder"
+	functionName      string = "job-test-func"
+)
+
+func newTestJobManager(t *testing.T) (*JobManager, *fake.Clientset) {
+	t.Helper()
+	kubernetesClient := fake.NewSimpleClientset()
+	fissionClient := fClient.NewSimpleClientset()
+	factory := map[string]genInformer.SharedInformerFactory{
+		metav1.NamespaceAll: genInformer.NewSharedInformerFactory(fissionClient, time.Minute*30),
+	}
+
+	fetcherConfig, err := fetcherConfig.MakeFetcherConfig("/userfunc")
+	require.NoError(t, err)
+
+	et, err := MakeJobManager(t.Context(), loggerfactory.GetLogger(), fissionClient, kubernetesClient,
+		fetcherConfig, "test", factory, nil)
+	require.NoError(t, err)
+
+	jobm := et.(*JobManager)
+	jobm.nsResolver = &utils.NamespaceResolver{
+		FunctionNamespace: functionNamespace,
+		BuilderNamespace:  builderNamespace,
+		DefaultNamespace:  defaultNamespace,
+	}
+	return jobm, kubernetesClient
+}
+
+func newTestFunction(maxScale int) *fv1.Function {
+	return &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      functionName,
+			Namespace: defaultNamespace,
+			UID:       "83c82da2-81e9-4ebd-867e-f383d65e603f",
+		},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType: fv1.ExecutorTypeJob,
+					MaxScale:     maxScale,
+				},
+			},
+			PodSpec: &apiv1.PodSpec{
+				Containers: []apiv1.Container{
+					{
+						Name:  functionName,
+						Image: "busybox",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvokeRespectsMaxScale(t *testing.T) {
+	jobm, kubernetesClient := newTestJobManager(t)
+	ctx := t.Context()
+	fn := newTestFunction(1)
+
+	first, err := jobm.Invoke(ctx, fn, []byte("first"))
+	require.NoError(t, err)
+	second, err := jobm.Invoke(ctx, fn, []byte("second"))
+	require.NoError(t, err)
+
+	jobs, err := kubernetesClient.BatchV1().Jobs(functionNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, jobs.Items, 2)
+
+	suspended := make(map[string]bool)
+	for _, job := range jobs.Items {
+		suspended[job.Labels[fv1.JOB_INVOCATION_ID]] = *job.Spec.Suspend
+		require.Equal(t, apiv1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+		secret, err := kubernetesClient.CoreV1().Secrets(functionNamespace).Get(ctx, job.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, job.Name, secret.OwnerReferences[0].Name)
+	}
+	require.False(t, suspended[first.ID], "first job should be dispatched")
+	require.True(t, suspended[second.ID], "second job should be queued")
+
+	invocation, err := jobm.GetInvocation(ctx, fn, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, InvocationStatusQueued, invocation.Status)
+}
+
+func TestGetInvocationResult(t *testing.T) {
+	jobm, kubernetesClient := newTestJobManager(t)
+	ctx := t.Context()
+	fn := newTestFunction(0)
+
+	submitted, err := jobm.Invoke(ctx, fn, []byte("input"))
+	require.NoError(t, err)
+
+	jobs, err := kubernetesClient.BatchV1().Jobs(functionNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, jobs.Items, 1)
+	job := jobs.Items[0]
+
+	job.Status.Succeeded = 1
+	job.Status.Conditions = []batchv1.JobCondition{
+		{Type: batchv1.JobComplete, Status: apiv1.ConditionTrue, LastTransitionTime: metav1.Now()},
+	}
+	_, err = kubernetesClient.BatchV1().Jobs(functionNamespace).UpdateStatus(ctx, &job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	_, err = kubernetesClient.CoreV1().Pods(functionNamespace).Create(ctx, &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   job.Name + "-abcde",
+			Labels: map[string]string{batchv1.JobNameLabel: job.Name},
+		},
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{
+					Name: functionName,
+					State: apiv1.ContainerState{
+						Terminated: &apiv1.ContainerStateTerminated{ExitCode: 0, Message: "done"},
+					},
+				},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	invocation, err := jobm.GetInvocation(ctx, fn, submitted.ID)
+	require.NoError(t, err)
+	require.Equal(t, InvocationStatusSucceeded, invocation.Status)
+	require.Equal(t, "done", invocation.Result)
+	require.NotNil(t, invocation.ExitCode)
+	require.NotNil(t, invocation.CompletionTime)
+}
+
+func TestEnvironmentJobSpec(t *testing.T) {
+	jobm, _ := newTestJobManager(t)
+	ctx := t.Context()
+
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: defaultNamespace},
+		Spec: fv1.EnvironmentSpec{
+			Version: 2,
+			Runtime: fv1.Runtime{Image: "fission/node-env"},
+		},
+	}
+	_, err := jobm.fissionClient.CoreV1().Environments(defaultNamespace).Create(ctx, env, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	fn := newTestFunction(0)
+	fn.Spec.PodSpec = nil
+	fn.Spec.Environment = fv1.EnvironmentReference{Name: env.Name, Namespace: env.Namespace}
+
+	job, err := jobm.getJobSpec(ctx, fn, "job-test", functionNamespace, "abcd")
+	require.NoError(t, err)
+	require.Equal(t, fetcherContainer, job.Annotations[jobMainContainerAnnotation])
+	require.Equal(t, env.Name, job.Annotations[jobLogsContainerAnnotation])
+
+	podSpec := job.Spec.Template.Spec
+	// the fetcher is the one-shot entrypoint calling the function
+	require.Len(t, podSpec.Containers, 1)
+	fetcher := podSpec.Containers[0]
+	require.Equal(t, fetcherContainer, fetcher.Name)
+	require.Contains(t, fetcher.Command, "-job-input")
+	require.Contains(t, fetcher.Command, jobInputPath)
+	require.Nil(t, fetcher.Lifecycle)
+
+	// the runtime is a sidecar that has to be up before the fetcher starts
+	require.Len(t, podSpec.InitContainers, 1)
+	runtime := podSpec.InitContainers[0]
+	require.Equal(t, env.Name, runtime.Name)
+	require.Equal(t, apiv1.ContainerRestartPolicyAlways, *runtime.RestartPolicy)
+	require.NotNil(t, runtime.StartupProbe)
+}
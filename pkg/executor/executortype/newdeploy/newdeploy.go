@@ -56,6 +56,11 @@ func (deploy *NewDeploy) createOrGetDeployment(ctx context.Context, fn *fv1.Func
 		return nil, err
 	}
 
+	err = deploy.quota.CheckScale(ctx, fn, &deployment.Spec.Template.Spec, int(minScale))
+	if err != nil {
+		return nil, err
+	}
+
 	existingDepl, err := deploy.kubernetesClient.AppsV1().Deployments(deployNamespace).Get(ctx, deployName, metav1.GetOptions{})
 	if err == nil {
 		// Try to adopt orphan deployment created by the old executor.
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
@@ -45,6 +47,7 @@ import (
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/metrics"
+	"github.com/fission/fission/pkg/executor/quota"
 	"github.com/fission/fission/pkg/executor/reaper"
 	executorUtils "github.com/fission/fission/pkg/executor/util"
 	hpautils "github.com/fission/fission/pkg/executor/util/hpa"
@@ -59,7 +62,8 @@ import (
 )
 
 var (
-	_ executortype.ExecutorType = &NewDeploy{}
+	_ executortype.ExecutorType    = &NewDeploy{}
+	_ executortype.PodSpecProvider = &NewDeploy{}
 )
 
 type (
@@ -75,6 +79,7 @@ type (
 
 		runtimeImagePullPolicy apiv1.PullPolicy
 		useIstio               bool
+		cmsRefresh             executorUtils.CMSRefreshConfig
 
 		fsCache *fscache.FunctionServiceCache // cache funcSvc's by function, address and pod name
 
@@ -89,6 +94,7 @@ type (
 		svcListerSynced  map[string]k8sCache.InformerSynced
 
 		hpaops *hpautils.HpaOperations
+		quota  *quota.Enforcer
 
 		podSpecPatch               *apiv1.PodSpec
 		objectReaperIntervalSecond time.Duration
@@ -108,6 +114,7 @@ func MakeNewDeploy(
 	finformerFactory map[string]genInformer.SharedInformerFactory,
 	ndmInformerFactory map[string]k8sInformers.SharedInformerFactory,
 	podSpecPatch *apiv1.PodSpec,
+	quotaEnforcer *quota.Enforcer,
 ) (executortype.ExecutorType, error) {
 	enableIstio := false
 	if len(os.Getenv("ENABLE_ISTIO")) > 0 {
@@ -131,10 +138,12 @@ func MakeNewDeploy(
 		fetcherConfig:          fetcherConfig,
 		runtimeImagePullPolicy: utils.GetImagePullPolicy(os.Getenv("RUNTIME_IMAGE_PULL_POLICY")),
 		useIstio:               enableIstio,
+		cmsRefresh:             executorUtils.GetCMSRefreshConfig(logger),
 
 		defaultIdlePodReapTime:     2 * time.Minute,
 		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypeNewdeploy, 5)) * time.Second,
 		hpaops:                     hpautils.NewHpaOperations(logger, kubernetesClient, instanceID),
+		quota:                      quotaEnforcer,
 
 		podSpecPatch:     podSpecPatch,
 		deplLister:       make(map[string]appslisters.DeploymentLister),
@@ -194,6 +203,23 @@ func (deploy *NewDeploy) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fsc
 	return deploy.createFunction(ctx, fn)
 }
 
+// GetFuncPodSpec returns the spec of the pods of the function deployment.
+func (deploy *NewDeploy) GetFuncPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, error) {
+	env, err := deploy.fissionClient.CoreV1().
+		Environments(fn.Spec.Environment.Namespace).
+		Get(ctx, fn.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	depl, err := deploy.getDeploymentSpec(ctx, fn, env, nil, deploy.getObjName(fn),
+		deploy.nsResolver.GetFunctionNS(fn.ObjectMeta.Namespace),
+		deploy.getDeployLabels(fn.ObjectMeta, env.ObjectMeta), deploy.getDeployAnnotations(fn.ObjectMeta, env.ObjectMeta))
+	if err != nil {
+		return nil, err
+	}
+	return &depl.Spec.Template.Spec, nil
+}
+
 // GetFuncSvcFromCache returns a function service from cache; error otherwise.
 func (deploy *NewDeploy) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	otelUtils.SpanTrackEvent(ctx, "GetFuncSvcFromCache")
@@ -290,6 +316,16 @@ func (deploy *NewDeploy) RefreshFuncPods(ctx context.Context, logger *zap.Logger
 
 	// Ideally there should be only one deployment but for now we rely on label/selector to ensure that condition
 	for _, deployment := range dep.Items {
+		if deploy.cmsRefresh.Mode == executorUtils.CMSRefreshInPlace {
+			refreshed, err := deploy.refreshDeploymentPodsInPlace(ctx, logger, &f, &deployment)
+			if err != nil {
+				return err
+			}
+			if refreshed {
+				continue
+			}
+		}
+
 		rvCount, err := referencedResourcesRVSum(ctx, deploy.kubernetesClient, f.ObjectMeta.Namespace, f.Spec.Secrets, f.Spec.ConfigMaps)
 		if err != nil {
 			return err
@@ -308,6 +344,35 @@ func (deploy *NewDeploy) RefreshFuncPods(ctx context.Context, logger *zap.Logger
 	return nil
 }
 
+// refreshDeploymentPodsInPlace asks the fetcher of the pods of the deployment
+// to fetch the secrets and configmaps of the function again. It returns false
+// if a pod couldn't be refreshed, in which case the deployment is rolled out.
+func (deploy *NewDeploy) refreshDeploymentPodsInPlace(ctx context.Context, logger *zap.Logger, f *fv1.Function, deployment *appsv1.Deployment) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+	podList, err := deploy.kubernetesClient.CoreV1().Pods(deployment.ObjectMeta.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return false, err
+	}
+	failed := executorUtils.RefreshPodsInPlace(ctx, logger, f, podList.Items, deploy.cmsRefresh.ReloadRuntime, getFetcherURL)
+	if len(failed) > 0 {
+		logger.Info("rolling out deployment, pods couldn't be refreshed in place",
+			zap.String("deployment", deployment.ObjectMeta.Name),
+			zap.Int("failed_pods", len(failed)))
+		return false, nil
+	}
+	return true, nil
+}
+
+// getFetcherURL returns the URL of the fetcher of a pod.
+func getFetcherURL(podIP string) string {
+	return fmt.Sprintf("http://%s/", net.JoinHostPort(podIP, "8000"))
+}
+
 // AdoptExistingResources attempts to adopt resources for functions in all namespaces.
 func (deploy *NewDeploy) AdoptExistingResources(ctx context.Context) {
 	wg := &sync.WaitGroup{}
@@ -470,7 +535,12 @@ func (deploy *NewDeploy) fnCreate(ctx context.Context, fn *fv1.Function) (*fscac
 		return nil, fmt.Errorf("error creating deployment %s: %w", objName, err)
 	}
 
-	hpa, err := deploy.hpaops.CreateOrGetHpa(ctx, fn, objName, &fn.Spec.InvokeStrategy.ExecutionStrategy, depl, deployLabels, deployAnnotations)
+	strategy, err := deploy.quotaExecutionStrategy(ctx, fn, depl)
+	if err != nil {
+		go cleanupFunc(context.Background(), ns, objName)
+		return nil, err
+	}
+	hpa, err := deploy.hpaops.CreateOrGetHpa(ctx, fn, objName, strategy, depl, deployLabels, deployAnnotations)
 	if err != nil {
 		deploy.logger.Error("error creating HPA", zap.Error(err), zap.String("hpa", objName))
 		go cleanupFunc(context.Background(), ns, objName)
@@ -579,16 +649,32 @@ func (deploy *NewDeploy) updateFunction(ctx context.Context, oldFn *fv1.Function
 			return err
 		}
 
+		depl, err := deploy.kubernetesClient.AppsV1().Deployments(ns).Get(ctx, fsvc.Name, metav1.GetOptions{})
+		if err != nil {
+			deploy.updateStatus(oldFn, err, "error getting deployment while updating function")
+			return err
+		}
+
 		hpaChanged := false
 
 		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale {
 			replicas := int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+			err = deploy.quota.CheckScale(ctx, newFn, &depl.Spec.Template.Spec, int(replicas))
+			if err != nil {
+				deploy.updateStatus(oldFn, err, "MinScale of function exceeds the quota of its namespace")
+				return err
+			}
 			hpa.Spec.MinReplicas = &replicas
 			hpaChanged = true
 		}
 
 		if newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale != oldFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale {
-			hpa.Spec.MaxReplicas = int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale)
+			strategy, err := deploy.quotaExecutionStrategy(ctx, newFn, depl)
+			if err != nil {
+				deploy.updateStatus(oldFn, err, "error computing quota of function while updating HPA")
+				return err
+			}
+			hpa.Spec.MaxReplicas = int32(strategy.MaxScale)
 			hpaChanged = true
 		}
 
@@ -827,6 +913,8 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 			continue
 		}
 
+		deploy.syncHpaMaxReplicas(ctx, fn, fsvc)
+
 		idlePodReapTime := deploy.defaultIdlePodReapTime
 		if fn.Spec.IdleTimeout != nil {
 			idlePodReapTime = time.Duration(*fn.Spec.IdleTimeout) * time.Second
@@ -865,6 +953,60 @@ func (deploy *NewDeploy) doIdleObjectReaper(ctx context.Context) {
 	}
 }
 
+// quotaExecutionStrategy returns the execution strategy of the function with
+// MaxScale capped to the pods the quota of its namespace leaves to it, so
+// that the HPA doesn't scale the deployment beyond the quota.
+func (deploy *NewDeploy) quotaExecutionStrategy(ctx context.Context, fn *fv1.Function, depl *appsv1.Deployment) (*fv1.ExecutionStrategy, error) {
+	strategy := fn.Spec.InvokeStrategy.ExecutionStrategy
+	maxScale, err := deploy.quota.MaxReplicas(ctx, fn, &depl.Spec.Template.Spec, strategy.MaxScale)
+	if err != nil {
+		return nil, fmt.Errorf("error computing quota of function %s: %w", k8sCache.MetaObjectToName(fn), err)
+	}
+	if maxScale < strategy.MaxScale {
+		// MinScale is checked against the quota on its own
+		strategy.MaxScale = max(maxScale, strategy.MinScale)
+	}
+	return &strategy, nil
+}
+
+// syncHpaMaxReplicas updates the HPA of the function with the maximum
+// replicas the quota of its namespace leaves to it, as the pods of the other
+// functions of the namespace come and go.
+func (deploy *NewDeploy) syncHpaMaxReplicas(ctx context.Context, fn *fv1.Function, fsvc *fscache.FuncSvc) {
+	if !deploy.quota.LimitsPods(fn) {
+		return
+	}
+	deployObj := getDeploymentObj(fsvc.KubernetesObjects)
+	if deployObj == nil {
+		return
+	}
+	logger := deploy.logger.With(zap.String("function", fsvc.Function.Name))
+
+	depl, err := deploy.kubernetesClient.AppsV1().Deployments(deployObj.Namespace).Get(ctx, deployObj.Name, metav1.GetOptions{})
+	if err != nil {
+		logger.Error("error getting function deployment", zap.Error(err))
+		return
+	}
+	strategy, err := deploy.quotaExecutionStrategy(ctx, fn, depl)
+	if err != nil {
+		logger.Error("error computing quota of function", zap.Error(err))
+		return
+	}
+	hpa, err := deploy.hpaops.GetHpa(ctx, deployObj.Namespace, fsvc.Name)
+	if err != nil {
+		logger.Error("error getting function HPA", zap.Error(err))
+		return
+	}
+	if hpa.Spec.MaxReplicas == int32(strategy.MaxScale) || strategy.MaxScale <= 0 {
+		return
+	}
+	hpa.Spec.MaxReplicas = int32(strategy.MaxScale)
+	err = deploy.hpaops.UpdateHpa(ctx, hpa)
+	if err != nil {
+		logger.Error("error updating function HPA", zap.Error(err))
+	}
+}
+
 func getDeploymentObj(kubeobjs []apiv1.ObjectReference) *apiv1.ObjectReference {
 	for _, kubeobj := range kubeobjs {
 		switch strings.ToLower(kubeobj.Kind) {
@@ -16,6 +16,8 @@ import (
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/quota"
+	"github.com/fission/fission/pkg/featureconfig"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	fClient "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
 	genInformer "github.com/fission/fission/pkg/generated/informers/externalversions"
@@ -58,7 +60,7 @@ func TestRefreshFuncPods(t *testing.T) {
 	}
 
 	executor, err := MakeNewDeploy(ctx, logger, fissionClient, kubernetesClient, fetcherConfig, "test",
-		factory, ndmInformerFactory, nil)
+		factory, ndmInformerFactory, nil, quota.MakeEnforcer(logger, kubernetesClient, featureconfig.QuotaFeatureConfig{}))
 	if err != nil {
 		t.Fatalf("new deploy manager creation failed: %s", err)
 	}
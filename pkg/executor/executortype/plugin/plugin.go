@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/protobuf/types/known/emptypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/executortype/plugin/pluginpb"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/featureconfig"
+	"github.com/fission/fission/pkg/utils/manager"
+)
+
+var _ executortype.ExecutorType = &Executor{}
+
+// Executor is an executor type served by an out-of-tree plugin. Every call
+// is forwarded to the plugin over gRPC.
+type Executor struct {
+	logger       *zap.Logger
+	executorType fv1.ExecutorType
+	conn         *grpc.ClientConn
+	client       pluginpb.ExecutorPluginClient
+}
+
+// MakeExecutor returns the executor type of a plugin. Functions use it with
+// executor type "plugin-<name>".
+//
+// Plugins served on a unix socket are trusted as local. Plugins served over
+// TCP are connected to with TLS, unless the plugin config explicitly allows
+// an insecure connection.
+func MakeExecutor(logger *zap.Logger, cfg featureconfig.ExecutorPlugin, opts ...grpc.DialOption) (executortype.ExecutorType, error) {
+	executorType := fv1.ExecutorType(fv1.ExecutorTypePluginPrefix + cfg.Name)
+	err := fv1.ExecutionStrategy{ExecutorType: executorType}.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid executor plugin name %q: %w", cfg.Name, err)
+	}
+
+	creds, err := transportCredentials(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring connection to executor plugin %s: %w", cfg.Name, err)
+	}
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
+	conn, err := grpc.NewClient(cfg.Address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client for executor plugin %s: %w", cfg.Name, err)
+	}
+	return &Executor{
+		logger:       logger.Named("executor_plugin").With(zap.String("plugin", cfg.Name)),
+		executorType: executorType,
+		conn:         conn,
+		client:       pluginpb.NewExecutorPluginClient(conn),
+	}, nil
+}
+
+// transportCredentials returns the credentials of the connection to a
+// plugin.
+func transportCredentials(cfg featureconfig.ExecutorPlugin) (credentials.TransportCredentials, error) {
+	switch {
+	case cfg.TLS != nil:
+		tlsConfig, err := clientTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		return credentials.NewTLS(tlsConfig), nil
+	case strings.HasPrefix(cfg.Address, "unix:"):
+		return local.NewCredentials(), nil
+	case cfg.Insecure:
+		return insecure.NewCredentials(), nil
+	default:
+		return nil, errors.New("plugins served over TCP require a TLS config, or insecure to be set")
+	}
+}
+
+func clientTLSConfig(cfg *featureconfig.ExecutorPluginTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+	if cfg.CAFile != "" {
+		ca, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in CA file %s", cfg.CAFile)
+		}
+	}
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Run closes the connection to the plugin once the context is done, the
+// plugin runs its own background jobs.
+func (e *Executor) Run(ctx context.Context, mgr manager.Interface) {
+	<-ctx.Done()
+	err := e.conn.Close()
+	if err != nil {
+		e.logger.Error("error closing connection to executor plugin", zap.Error(err))
+	}
+}
+
+func (e *Executor) GetTypeName(ctx context.Context) fv1.ExecutorType {
+	return e.executorType
+}
+
+func (e *Executor) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	req, err := functionRequest(fn)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.client.GetFuncSvc(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return fromFuncSvc(resp)
+}
+
+func (e *Executor) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	req, err := functionRequest(fn)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.client.GetFuncSvcFromCache(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return fromFuncSvc(resp)
+}
+
+func (e *Executor) DeleteFuncSvcFromCache(ctx context.Context, fsvc *fscache.FuncSvc) {
+	req, err := toFuncSvc(fsvc)
+	if err == nil {
+		_, err = e.client.DeleteFuncSvcFromCache(ctx, req)
+	}
+	if err != nil {
+		e.logger.Error("error deleting function service from cache", zap.Error(err), zap.String("address", fsvc.Address))
+	}
+}
+
+func (e *Executor) TapService(ctx context.Context, serviceURL string) error {
+	_, err := e.client.TapService(ctx, &pluginpb.TapServiceRequest{ServiceUrl: serviceURL})
+	return fromStatus(err)
+}
+
+func (e *Executor) UnTapService(ctx context.Context, fnMeta *metav1.ObjectMeta, svcHost string) {
+	fn, err := marshal(fnMeta)
+	if err == nil {
+		_, err = e.client.UnTapService(ctx, &pluginpb.UnTapServiceRequest{Function: fn, ServiceHost: svcHost})
+	}
+	if err != nil {
+		e.logger.Error("error untapping service", zap.Error(err), zap.String("host", svcHost))
+	}
+}
+
+func (e *Executor) MarkSpecializationFailure(ctx context.Context, fnMeta *metav1.ObjectMeta) {
+	fn, err := marshal(fnMeta)
+	if err == nil {
+		_, err = e.client.MarkSpecializationFailure(ctx, &pluginpb.FunctionMetaRequest{Function: fn})
+	}
+	if err != nil {
+		e.logger.Error("error marking specialization failure", zap.Error(err), zap.String("function", fnMeta.Name))
+	}
+}
+
+func (e *Executor) IsValid(ctx context.Context, fsvc *fscache.FuncSvc) bool {
+	req, err := toFuncSvc(fsvc)
+	if err != nil {
+		e.logger.Error("error encoding function service", zap.Error(err), zap.String("address", fsvc.Address))
+		return false
+	}
+	resp, err := e.client.IsValid(ctx, req)
+	if err != nil {
+		e.logger.Error("error validating function service", zap.Error(err), zap.String("address", fsvc.Address))
+		return false
+	}
+	return resp.GetValid()
+}
+
+func (e *Executor) RefreshFuncPods(ctx context.Context, logger *zap.Logger, f fv1.Function) error {
+	req, err := functionRequest(&f)
+	if err != nil {
+		return err
+	}
+	_, err = e.client.RefreshFuncPods(ctx, req)
+	return fromStatus(err)
+}
+
+func (e *Executor) AdoptExistingResources(ctx context.Context) {
+	_, err := e.client.AdoptExistingResources(ctx, &emptypb.Empty{})
+	if err != nil {
+		e.logger.Error("error adopting existing resources", zap.Error(err))
+	}
+}
+
+func (e *Executor) CleanupOldExecutorObjects(ctx context.Context) {
+	_, err := e.client.CleanupOldExecutorObjects(ctx, &emptypb.Empty{})
+	if err != nil {
+		e.logger.Error("error cleaning up old executor objects", zap.Error(err))
+	}
+}
+
+func (e *Executor) DumpDebugInfo(ctx context.Context) error {
+	_, err := e.client.DumpDebugInfo(ctx, &emptypb.Empty{})
+	return fromStatus(err)
+}
+
+func functionRequest(fn *fv1.Function) (*pluginpb.FunctionRequest, error) {
+	data, err := marshal(fn)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding function: %w", err)
+	}
+	return &pluginpb.FunctionRequest{Function: data}, nil
+}
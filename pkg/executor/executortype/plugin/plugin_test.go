@@ -0,0 +1,244 @@
+package plugin
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/local"
+	"google.golang.org/grpc/test/bufconn"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/featureconfig"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+	"github.com/fission/fission/pkg/utils/manager"
+)
+
+// fakeExecutor serves a single function service and records taps.
+type fakeExecutor struct {
+	fsvc   *fscache.FuncSvc
+	tapped []string
+}
+
+func (f *fakeExecutor) Run(context.Context, manager.Interface) {}
+
+func (f *fakeExecutor) GetTypeName(context.Context) fv1.ExecutorType {
+	return "plugin-fake"
+}
+
+func (f *fakeExecutor) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	f.fsvc = &fscache.FuncSvc{
+		Name:     fn.Name,
+		Function: &fn.ObjectMeta,
+		Address:  "10.0.0.1:8888",
+		Executor: "plugin-fake",
+		CPULimit: resource.MustParse("500m"),
+		Ctime:    time.Unix(1700000000, 0).UTC(),
+	}
+	return f.fsvc, nil
+}
+
+func (f *fakeExecutor) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	if f.fsvc == nil {
+		return nil, ferror.MakeError(ferror.ErrorNotFound, "function service not found")
+	}
+	return f.fsvc, nil
+}
+
+func (f *fakeExecutor) DumpDebugInfo(context.Context) error { return nil }
+
+func (f *fakeExecutor) DeleteFuncSvcFromCache(context.Context, *fscache.FuncSvc) {
+	f.fsvc = nil
+}
+
+func (f *fakeExecutor) TapService(ctx context.Context, serviceURL string) error {
+	f.tapped = append(f.tapped, serviceURL)
+	return nil
+}
+
+func (f *fakeExecutor) UnTapService(context.Context, *metav1.ObjectMeta, string) {}
+
+func (f *fakeExecutor) MarkSpecializationFailure(context.Context, *metav1.ObjectMeta) {}
+
+func (f *fakeExecutor) IsValid(ctx context.Context, fsvc *fscache.FuncSvc) bool {
+	return f.fsvc != nil && fsvc.Address == f.fsvc.Address
+}
+
+func (f *fakeExecutor) RefreshFuncPods(context.Context, *zap.Logger, fv1.Function) error {
+	return ferror.MakeError(ferror.ErrorNotImplemented, "refresh not supported")
+}
+
+func (f *fakeExecutor) AdoptExistingResources(context.Context) {}
+
+func (f *fakeExecutor) CleanupOldExecutorObjects(context.Context) {}
+
+func TestPluginExecutor(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	ctx := t.Context()
+
+	lis := bufconn.Listen(1024 * 1024)
+	fake := &fakeExecutor{}
+	srv := NewServer(logger, fake)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	defer srv.Stop()
+
+	et, err := MakeExecutor(logger, featureconfig.ExecutorPlugin{
+		Name:     "fake",
+		Address:  "passthrough:///bufnet",
+		Insecure: true,
+	},
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	require.NoError(t, err)
+	require.Equal(t, fv1.ExecutorType("plugin-fake"), et.GetTypeName(ctx))
+
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+	}
+
+	_, err = et.GetFuncSvcFromCache(ctx, fn)
+	require.Error(t, err)
+	require.True(t, ferror.IsNotFound(err))
+
+	fsvc, err := et.GetFuncSvc(ctx, fn)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:8888", fsvc.Address)
+	require.Equal(t, "hello", fsvc.Function.Name)
+	require.Equal(t, "500m", fsvc.CPULimit.String())
+	require.True(t, fake.fsvc.Ctime.Equal(fsvc.Ctime))
+	require.True(t, et.IsValid(ctx, fsvc))
+
+	require.NoError(t, et.TapService(ctx, "http://10.0.0.1:8888"))
+	require.Equal(t, []string{"http://10.0.0.1:8888"}, fake.tapped)
+
+	err = et.RefreshFuncPods(ctx, logger, *fn)
+	require.Error(t, err)
+	code, _ := ferror.GetHTTPError(err)
+	require.Equal(t, ferror.MakeError(ferror.ErrorNotImplemented, "").HTTPStatus(), code)
+
+	et.DeleteFuncSvcFromCache(ctx, fsvc)
+	require.False(t, et.IsValid(ctx, fsvc))
+}
+
+// serveFake serves a fakeExecutor on lis and checks that an executor type
+// configured with cfg can get a function service from it.
+func serveFake(t *testing.T, lis net.Listener, cfg featureconfig.ExecutorPlugin, opts ...grpc.ServerOption) {
+	t.Helper()
+	logger := loggerfactory.GetLogger()
+	srv := NewServer(logger, &fakeExecutor{}, opts...)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	et, err := MakeExecutor(logger, cfg)
+	require.NoError(t, err)
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+	}
+	fsvc, err := et.GetFuncSvc(t.Context(), fn)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:8888", fsvc.Address)
+}
+
+func TestPluginExecutorUnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "plugin")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "plugin.sock")
+	lis, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	serveFake(t, lis, featureconfig.ExecutorPlugin{
+		Name:    "fake",
+		Address: "unix://" + socket,
+	}, grpc.Creds(local.NewCredentials()))
+}
+
+func TestPluginExecutorTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "plugin.fission"},
+		DNSNames:              []string{"plugin.fission"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	cert, err := tls.X509KeyPair(certPEM, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0600))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serveFake(t, lis, featureconfig.ExecutorPlugin{
+		Name:    "fake",
+		Address: lis.Addr().String(),
+		TLS: &featureconfig.ExecutorPluginTLS{
+			CAFile:     caFile,
+			ServerName: "plugin.fission",
+		},
+	}, grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+}
+
+func TestMakeExecutorTransport(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+
+	_, err := MakeExecutor(logger, featureconfig.ExecutorPlugin{Name: "fake", Address: "localhost:9000"})
+	require.Error(t, err, "TCP without TLS must be explicitly allowed")
+
+	_, err = MakeExecutor(logger, featureconfig.ExecutorPlugin{Name: "fake", Address: "localhost:9000", Insecure: true})
+	require.NoError(t, err)
+
+	_, err = MakeExecutor(logger, featureconfig.ExecutorPlugin{Name: "fake", Address: "unix:///run/plugin.sock"})
+	require.NoError(t, err)
+
+	_, err = MakeExecutor(logger, featureconfig.ExecutorPlugin{
+		Name:    "fake",
+		Address: "localhost:9000",
+		TLS:     &featureconfig.ExecutorPluginTLS{CAFile: "/nonexistent/ca.crt"},
+	})
+	require.Error(t, err)
+}
+
+func TestMakeExecutorInvalidName(t *testing.T) {
+	_, err := MakeExecutor(loggerfactory.GetLogger(), featureconfig.ExecutorPlugin{
+		Name:     "Not_Valid",
+		Address:  "localhost:9000",
+		Insecure: true,
+	})
+	require.Error(t, err)
+}
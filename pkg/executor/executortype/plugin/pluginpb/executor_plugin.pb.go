@@ -0,0 +1,608 @@
+// Copyright 2025 The Fission Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: executor_plugin.proto
+
+package pluginpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FunctionRequest carries the function a call applies to.
+type FunctionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON encoded fission.io/v1 Function.
+	Function      []byte `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionRequest) Reset() {
+	*x = FunctionRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionRequest) ProtoMessage() {}
+
+func (x *FunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionRequest.ProtoReflect.Descriptor instead.
+func (*FunctionRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FunctionRequest) GetFunction() []byte {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+// FunctionMetaRequest carries the metadata of the function a call applies to.
+type FunctionMetaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON encoded Kubernetes ObjectMeta of the function.
+	Function      []byte `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionMetaRequest) Reset() {
+	*x = FunctionMetaRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionMetaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionMetaRequest) ProtoMessage() {}
+
+func (x *FunctionMetaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionMetaRequest.ProtoReflect.Descriptor instead.
+func (*FunctionMetaRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FunctionMetaRequest) GetFunction() []byte {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+// ObjectReference references a Kubernetes object of a function service.
+type ObjectReference struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Kind            string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace       string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Uid             string                 `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	ApiVersion      string                 `protobuf:"bytes,5,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	ResourceVersion string                 `protobuf:"bytes,6,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ObjectReference) Reset() {
+	*x = ObjectReference{}
+	mi := &file_executor_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectReference) ProtoMessage() {}
+
+func (x *ObjectReference) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectReference.ProtoReflect.Descriptor instead.
+func (*ObjectReference) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ObjectReference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ObjectReference) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ObjectReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ObjectReference) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *ObjectReference) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ObjectReference) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+// FuncSvc is a function service, the pods serving a function.
+type FuncSvc struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the function service.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// JSON encoded Kubernetes ObjectMeta of the function.
+	Function []byte `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	// JSON encoded fission.io/v1 Environment of the function.
+	Environment []byte `protobuf:"bytes,3,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Address the function service can be reached at, host:port.
+	Address string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	// Kubernetes objects of the function service.
+	KubernetesObjects []*ObjectReference `protobuf:"bytes,5,rep,name=kubernetes_objects,json=kubernetesObjects,proto3" json:"kubernetes_objects,omitempty"`
+	// Executor type of the function service.
+	Executor string `protobuf:"bytes,6,opt,name=executor,proto3" json:"executor,omitempty"`
+	// CPU limit of the function pods, a Kubernetes quantity.
+	CpuLimit string `protobuf:"bytes,7,opt,name=cpu_limit,json=cpuLimit,proto3" json:"cpu_limit,omitempty"`
+	// Creation time of the function service.
+	Ctime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=ctime,proto3" json:"ctime,omitempty"`
+	// Last access time of the function service.
+	Atime         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=atime,proto3" json:"atime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FuncSvc) Reset() {
+	*x = FuncSvc{}
+	mi := &file_executor_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FuncSvc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FuncSvc) ProtoMessage() {}
+
+func (x *FuncSvc) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FuncSvc.ProtoReflect.Descriptor instead.
+func (*FuncSvc) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FuncSvc) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FuncSvc) GetFunction() []byte {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *FuncSvc) GetEnvironment() []byte {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *FuncSvc) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *FuncSvc) GetKubernetesObjects() []*ObjectReference {
+	if x != nil {
+		return x.KubernetesObjects
+	}
+	return nil
+}
+
+func (x *FuncSvc) GetExecutor() string {
+	if x != nil {
+		return x.Executor
+	}
+	return ""
+}
+
+func (x *FuncSvc) GetCpuLimit() string {
+	if x != nil {
+		return x.CpuLimit
+	}
+	return ""
+}
+
+func (x *FuncSvc) GetCtime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ctime
+	}
+	return nil
+}
+
+func (x *FuncSvc) GetAtime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Atime
+	}
+	return nil
+}
+
+// TapServiceRequest is the argument of TapService.
+type TapServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceUrl    string                 `protobuf:"bytes,1,opt,name=service_url,json=serviceUrl,proto3" json:"service_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TapServiceRequest) Reset() {
+	*x = TapServiceRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TapServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TapServiceRequest) ProtoMessage() {}
+
+func (x *TapServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TapServiceRequest.ProtoReflect.Descriptor instead.
+func (*TapServiceRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TapServiceRequest) GetServiceUrl() string {
+	if x != nil {
+		return x.ServiceUrl
+	}
+	return ""
+}
+
+// UnTapServiceRequest is the argument of UnTapService.
+type UnTapServiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON encoded Kubernetes ObjectMeta of the function.
+	Function      []byte `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	ServiceHost   string `protobuf:"bytes,2,opt,name=service_host,json=serviceHost,proto3" json:"service_host,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnTapServiceRequest) Reset() {
+	*x = UnTapServiceRequest{}
+	mi := &file_executor_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnTapServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnTapServiceRequest) ProtoMessage() {}
+
+func (x *UnTapServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnTapServiceRequest.ProtoReflect.Descriptor instead.
+func (*UnTapServiceRequest) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UnTapServiceRequest) GetFunction() []byte {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+func (x *UnTapServiceRequest) GetServiceHost() string {
+	if x != nil {
+		return x.ServiceHost
+	}
+	return ""
+}
+
+// IsValidResponse is the result of IsValid.
+type IsValidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsValidResponse) Reset() {
+	*x = IsValidResponse{}
+	mi := &file_executor_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsValidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsValidResponse) ProtoMessage() {}
+
+func (x *IsValidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_executor_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsValidResponse.ProtoReflect.Descriptor instead.
+func (*IsValidResponse) Descriptor() ([]byte, []int) {
+	return file_executor_plugin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IsValidResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+var File_executor_plugin_proto protoreflect.FileDescriptor
+
+const file_executor_plugin_proto_rawDesc = "" +
+	"\n" +
+	"\x15executor_plugin.proto\x12\x13fission.executor.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"-\n" +
+	"\x0fFunctionRequest\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\fR\bfunction\"1\n" +
+	"\x13FunctionMetaRequest\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\fR\bfunction\"\xb5\x01\n" +
+	"\x0fObjectReference\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x04 \x01(\tR\x03uid\x12\x1f\n" +
+	"\vapi_version\x18\x05 \x01(\tR\n" +
+	"apiVersion\x12)\n" +
+	"\x10resource_version\x18\x06 \x01(\tR\x0fresourceVersion\"\xe7\x02\n" +
+	"\aFuncSvc\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bfunction\x18\x02 \x01(\fR\bfunction\x12 \n" +
+	"\venvironment\x18\x03 \x01(\fR\venvironment\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12S\n" +
+	"\x12kubernetes_objects\x18\x05 \x03(\v2$.fission.executor.v1.ObjectReferenceR\x11kubernetesObjects\x12\x1a\n" +
+	"\bexecutor\x18\x06 \x01(\tR\bexecutor\x12\x1b\n" +
+	"\tcpu_limit\x18\a \x01(\tR\bcpuLimit\x120\n" +
+	"\x05ctime\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05ctime\x120\n" +
+	"\x05atime\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x05atime\"4\n" +
+	"\x11TapServiceRequest\x12\x1f\n" +
+	"\vservice_url\x18\x01 \x01(\tR\n" +
+	"serviceUrl\"T\n" +
+	"\x13UnTapServiceRequest\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\fR\bfunction\x12!\n" +
+	"\fservice_host\x18\x02 \x01(\tR\vserviceHost\"'\n" +
+	"\x0fIsValidResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid2\x84\a\n" +
+	"\x0eExecutorPlugin\x12P\n" +
+	"\n" +
+	"GetFuncSvc\x12$.fission.executor.v1.FunctionRequest\x1a\x1c.fission.executor.v1.FuncSvc\x12Y\n" +
+	"\x13GetFuncSvcFromCache\x12$.fission.executor.v1.FunctionRequest\x1a\x1c.fission.executor.v1.FuncSvc\x12N\n" +
+	"\x16DeleteFuncSvcFromCache\x12\x1c.fission.executor.v1.FuncSvc\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\n" +
+	"TapService\x12&.fission.executor.v1.TapServiceRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\fUnTapService\x12(.fission.executor.v1.UnTapServiceRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
+	"\x19MarkSpecializationFailure\x12(.fission.executor.v1.FunctionMetaRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\aIsValid\x12\x1c.fission.executor.v1.FuncSvc\x1a$.fission.executor.v1.IsValidResponse\x12O\n" +
+	"\x0fRefreshFuncPods\x12$.fission.executor.v1.FunctionRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x16AdoptExistingResources\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\x19CleanupOldExecutorObjects\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\rDumpDebugInfo\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.EmptyBFZDgithub.com/fission/fission/pkg/executor/executortype/plugin/pluginpbb\x06proto3"
+
+var (
+	file_executor_plugin_proto_rawDescOnce sync.Once
+	file_executor_plugin_proto_rawDescData []byte
+)
+
+func file_executor_plugin_proto_rawDescGZIP() []byte {
+	file_executor_plugin_proto_rawDescOnce.Do(func() {
+		file_executor_plugin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_executor_plugin_proto_rawDesc), len(file_executor_plugin_proto_rawDesc)))
+	})
+	return file_executor_plugin_proto_rawDescData
+}
+
+var file_executor_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_executor_plugin_proto_goTypes = []any{
+	(*FunctionRequest)(nil),       // 0: fission.executor.v1.FunctionRequest
+	(*FunctionMetaRequest)(nil),   // 1: fission.executor.v1.FunctionMetaRequest
+	(*ObjectReference)(nil),       // 2: fission.executor.v1.ObjectReference
+	(*FuncSvc)(nil),               // 3: fission.executor.v1.FuncSvc
+	(*TapServiceRequest)(nil),     // 4: fission.executor.v1.TapServiceRequest
+	(*UnTapServiceRequest)(nil),   // 5: fission.executor.v1.UnTapServiceRequest
+	(*IsValidResponse)(nil),       // 6: fission.executor.v1.IsValidResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+}
+var file_executor_plugin_proto_depIdxs = []int32{
+	2,  // 0: fission.executor.v1.FuncSvc.kubernetes_objects:type_name -> fission.executor.v1.ObjectReference
+	7,  // 1: fission.executor.v1.FuncSvc.ctime:type_name -> google.protobuf.Timestamp
+	7,  // 2: fission.executor.v1.FuncSvc.atime:type_name -> google.protobuf.Timestamp
+	0,  // 3: fission.executor.v1.ExecutorPlugin.GetFuncSvc:input_type -> fission.executor.v1.FunctionRequest
+	0,  // 4: fission.executor.v1.ExecutorPlugin.GetFuncSvcFromCache:input_type -> fission.executor.v1.FunctionRequest
+	3,  // 5: fission.executor.v1.ExecutorPlugin.DeleteFuncSvcFromCache:input_type -> fission.executor.v1.FuncSvc
+	4,  // 6: fission.executor.v1.ExecutorPlugin.TapService:input_type -> fission.executor.v1.TapServiceRequest
+	5,  // 7: fission.executor.v1.ExecutorPlugin.UnTapService:input_type -> fission.executor.v1.UnTapServiceRequest
+	1,  // 8: fission.executor.v1.ExecutorPlugin.MarkSpecializationFailure:input_type -> fission.executor.v1.FunctionMetaRequest
+	3,  // 9: fission.executor.v1.ExecutorPlugin.IsValid:input_type -> fission.executor.v1.FuncSvc
+	0,  // 10: fission.executor.v1.ExecutorPlugin.RefreshFuncPods:input_type -> fission.executor.v1.FunctionRequest
+	8,  // 11: fission.executor.v1.ExecutorPlugin.AdoptExistingResources:input_type -> google.protobuf.Empty
+	8,  // 12: fission.executor.v1.ExecutorPlugin.CleanupOldExecutorObjects:input_type -> google.protobuf.Empty
+	8,  // 13: fission.executor.v1.ExecutorPlugin.DumpDebugInfo:input_type -> google.protobuf.Empty
+	3,  // 14: fission.executor.v1.ExecutorPlugin.GetFuncSvc:output_type -> fission.executor.v1.FuncSvc
+	3,  // 15: fission.executor.v1.ExecutorPlugin.GetFuncSvcFromCache:output_type -> fission.executor.v1.FuncSvc
+	8,  // 16: fission.executor.v1.ExecutorPlugin.DeleteFuncSvcFromCache:output_type -> google.protobuf.Empty
+	8,  // 17: fission.executor.v1.ExecutorPlugin.TapService:output_type -> google.protobuf.Empty
+	8,  // 18: fission.executor.v1.ExecutorPlugin.UnTapService:output_type -> google.protobuf.Empty
+	8,  // 19: fission.executor.v1.ExecutorPlugin.MarkSpecializationFailure:output_type -> google.protobuf.Empty
+	6,  // 20: fission.executor.v1.ExecutorPlugin.IsValid:output_type -> fission.executor.v1.IsValidResponse
+	8,  // 21: fission.executor.v1.ExecutorPlugin.RefreshFuncPods:output_type -> google.protobuf.Empty
+	8,  // 22: fission.executor.v1.ExecutorPlugin.AdoptExistingResources:output_type -> google.protobuf.Empty
+	8,  // 23: fission.executor.v1.ExecutorPlugin.CleanupOldExecutorObjects:output_type -> google.protobuf.Empty
+	8,  // 24: fission.executor.v1.ExecutorPlugin.DumpDebugInfo:output_type -> google.protobuf.Empty
+	14, // [14:25] is the sub-list for method output_type
+	3,  // [3:14] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_executor_plugin_proto_init() }
+func file_executor_plugin_proto_init() {
+	if File_executor_plugin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_executor_plugin_proto_rawDesc), len(file_executor_plugin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_executor_plugin_proto_goTypes,
+		DependencyIndexes: file_executor_plugin_proto_depIdxs,
+		MessageInfos:      file_executor_plugin_proto_msgTypes,
+	}.Build()
+	File_executor_plugin_proto = out.File
+	file_executor_plugin_proto_goTypes = nil
+	file_executor_plugin_proto_depIdxs = nil
+}
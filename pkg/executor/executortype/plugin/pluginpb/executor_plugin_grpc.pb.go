@@ -0,0 +1,562 @@
+// Copyright 2025 The Fission Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: executor_plugin.proto
+
+package pluginpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExecutorPlugin_GetFuncSvc_FullMethodName                = "/fission.executor.v1.ExecutorPlugin/GetFuncSvc"
+	ExecutorPlugin_GetFuncSvcFromCache_FullMethodName       = "/fission.executor.v1.ExecutorPlugin/GetFuncSvcFromCache"
+	ExecutorPlugin_DeleteFuncSvcFromCache_FullMethodName    = "/fission.executor.v1.ExecutorPlugin/DeleteFuncSvcFromCache"
+	ExecutorPlugin_TapService_FullMethodName                = "/fission.executor.v1.ExecutorPlugin/TapService"
+	ExecutorPlugin_UnTapService_FullMethodName              = "/fission.executor.v1.ExecutorPlugin/UnTapService"
+	ExecutorPlugin_MarkSpecializationFailure_FullMethodName = "/fission.executor.v1.ExecutorPlugin/MarkSpecializationFailure"
+	ExecutorPlugin_IsValid_FullMethodName                   = "/fission.executor.v1.ExecutorPlugin/IsValid"
+	ExecutorPlugin_RefreshFuncPods_FullMethodName           = "/fission.executor.v1.ExecutorPlugin/RefreshFuncPods"
+	ExecutorPlugin_AdoptExistingResources_FullMethodName    = "/fission.executor.v1.ExecutorPlugin/AdoptExistingResources"
+	ExecutorPlugin_CleanupOldExecutorObjects_FullMethodName = "/fission.executor.v1.ExecutorPlugin/CleanupOldExecutorObjects"
+	ExecutorPlugin_DumpDebugInfo_FullMethodName             = "/fission.executor.v1.ExecutorPlugin/DumpDebugInfo"
+)
+
+// ExecutorPluginClient is the client API for ExecutorPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExecutorPlugin is served by out-of-tree executor types. It mirrors the
+// executor type interface of the Fission executor, which forwards the calls
+// for the functions of executor type "plugin-<name>" to the plugin.
+//
+// Kubernetes and Fission objects are carried in their JSON encoding, the
+// one of the Kubernetes API.
+type ExecutorPluginClient interface {
+	// GetFuncSvc specializes function pod(s) and returns the function service.
+	GetFuncSvc(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*FuncSvc, error)
+	// GetFuncSvcFromCache returns the cached function service of a function,
+	// or a NOT_FOUND error.
+	GetFuncSvcFromCache(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*FuncSvc, error)
+	// DeleteFuncSvcFromCache deletes a function service from the cache.
+	DeleteFuncSvcFromCache(ctx context.Context, in *FuncSvc, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TapService marks the function service at a URL as used, so that its
+	// pods are not reaped as idle.
+	TapService(ctx context.Context, in *TapServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UnTapService marks the function service at a host as inactive.
+	UnTapService(ctx context.Context, in *UnTapServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// MarkSpecializationFailure records a failed specialization of a function.
+	MarkSpecializationFailure(ctx context.Context, in *FunctionMetaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// IsValid returns whether a function service is still valid.
+	IsValid(ctx context.Context, in *FuncSvc, opts ...grpc.CallOption) (*IsValidResponse, error)
+	// RefreshFuncPods refreshes the pods of a function after a secret or
+	// configmap they reference changed.
+	RefreshFuncPods(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AdoptExistingResources adopts the resources created by a previous
+	// executor instance.
+	AdoptExistingResources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CleanupOldExecutorObjects cleans up the resources created by previous
+	// executor instances.
+	CleanupOldExecutorObjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DumpDebugInfo dumps the function service cache for debugging.
+	DumpDebugInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type executorPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutorPluginClient(cc grpc.ClientConnInterface) ExecutorPluginClient {
+	return &executorPluginClient{cc}
+}
+
+func (c *executorPluginClient) GetFuncSvc(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*FuncSvc, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FuncSvc)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_GetFuncSvc_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) GetFuncSvcFromCache(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*FuncSvc, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FuncSvc)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_GetFuncSvcFromCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) DeleteFuncSvcFromCache(ctx context.Context, in *FuncSvc, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_DeleteFuncSvcFromCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) TapService(ctx context.Context, in *TapServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_TapService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) UnTapService(ctx context.Context, in *UnTapServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_UnTapService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) MarkSpecializationFailure(ctx context.Context, in *FunctionMetaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_MarkSpecializationFailure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) IsValid(ctx context.Context, in *FuncSvc, opts ...grpc.CallOption) (*IsValidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsValidResponse)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_IsValid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) RefreshFuncPods(ctx context.Context, in *FunctionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_RefreshFuncPods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) AdoptExistingResources(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_AdoptExistingResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) CleanupOldExecutorObjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_CleanupOldExecutorObjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorPluginClient) DumpDebugInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ExecutorPlugin_DumpDebugInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutorPluginServer is the server API for ExecutorPlugin service.
+// All implementations must embed UnimplementedExecutorPluginServer
+// for forward compatibility.
+//
+// ExecutorPlugin is served by out-of-tree executor types. It mirrors the
+// executor type interface of the Fission executor, which forwards the calls
+// for the functions of executor type "plugin-<name>" to the plugin.
+//
+// Kubernetes and Fission objects are carried in their JSON encoding, the
+// one of the Kubernetes API.
+type ExecutorPluginServer interface {
+	// GetFuncSvc specializes function pod(s) and returns the function service.
+	GetFuncSvc(context.Context, *FunctionRequest) (*FuncSvc, error)
+	// GetFuncSvcFromCache returns the cached function service of a function,
+	// or a NOT_FOUND error.
+	GetFuncSvcFromCache(context.Context, *FunctionRequest) (*FuncSvc, error)
+	// DeleteFuncSvcFromCache deletes a function service from the cache.
+	DeleteFuncSvcFromCache(context.Context, *FuncSvc) (*emptypb.Empty, error)
+	// TapService marks the function service at a URL as used, so that its
+	// pods are not reaped as idle.
+	TapService(context.Context, *TapServiceRequest) (*emptypb.Empty, error)
+	// UnTapService marks the function service at a host as inactive.
+	UnTapService(context.Context, *UnTapServiceRequest) (*emptypb.Empty, error)
+	// MarkSpecializationFailure records a failed specialization of a function.
+	MarkSpecializationFailure(context.Context, *FunctionMetaRequest) (*emptypb.Empty, error)
+	// IsValid returns whether a function service is still valid.
+	IsValid(context.Context, *FuncSvc) (*IsValidResponse, error)
+	// RefreshFuncPods refreshes the pods of a function after a secret or
+	// configmap they reference changed.
+	RefreshFuncPods(context.Context, *FunctionRequest) (*emptypb.Empty, error)
+	// AdoptExistingResources adopts the resources created by a previous
+	// executor instance.
+	AdoptExistingResources(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// CleanupOldExecutorObjects cleans up the resources created by previous
+	// executor instances.
+	CleanupOldExecutorObjects(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// DumpDebugInfo dumps the function service cache for debugging.
+	DumpDebugInfo(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	mustEmbedUnimplementedExecutorPluginServer()
+}
+
+// UnimplementedExecutorPluginServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExecutorPluginServer struct{}
+
+func (UnimplementedExecutorPluginServer) GetFuncSvc(context.Context, *FunctionRequest) (*FuncSvc, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuncSvc not implemented")
+}
+func (UnimplementedExecutorPluginServer) GetFuncSvcFromCache(context.Context, *FunctionRequest) (*FuncSvc, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFuncSvcFromCache not implemented")
+}
+func (UnimplementedExecutorPluginServer) DeleteFuncSvcFromCache(context.Context, *FuncSvc) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFuncSvcFromCache not implemented")
+}
+func (UnimplementedExecutorPluginServer) TapService(context.Context, *TapServiceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TapService not implemented")
+}
+func (UnimplementedExecutorPluginServer) UnTapService(context.Context, *UnTapServiceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnTapService not implemented")
+}
+func (UnimplementedExecutorPluginServer) MarkSpecializationFailure(context.Context, *FunctionMetaRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkSpecializationFailure not implemented")
+}
+func (UnimplementedExecutorPluginServer) IsValid(context.Context, *FuncSvc) (*IsValidResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsValid not implemented")
+}
+func (UnimplementedExecutorPluginServer) RefreshFuncPods(context.Context, *FunctionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshFuncPods not implemented")
+}
+func (UnimplementedExecutorPluginServer) AdoptExistingResources(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdoptExistingResources not implemented")
+}
+func (UnimplementedExecutorPluginServer) CleanupOldExecutorObjects(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupOldExecutorObjects not implemented")
+}
+func (UnimplementedExecutorPluginServer) DumpDebugInfo(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpDebugInfo not implemented")
+}
+func (UnimplementedExecutorPluginServer) mustEmbedUnimplementedExecutorPluginServer() {}
+func (UnimplementedExecutorPluginServer) testEmbeddedByValue()                        {}
+
+// UnsafeExecutorPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecutorPluginServer will
+// result in compilation errors.
+type UnsafeExecutorPluginServer interface {
+	mustEmbedUnimplementedExecutorPluginServer()
+}
+
+func RegisterExecutorPluginServer(s grpc.ServiceRegistrar, srv ExecutorPluginServer) {
+	// If the following call pancis, it indicates UnimplementedExecutorPluginServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExecutorPlugin_ServiceDesc, srv)
+}
+
+func _ExecutorPlugin_GetFuncSvc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).GetFuncSvc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_GetFuncSvc_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).GetFuncSvc(ctx, req.(*FunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_GetFuncSvcFromCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).GetFuncSvcFromCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_GetFuncSvcFromCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).GetFuncSvcFromCache(ctx, req.(*FunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_DeleteFuncSvcFromCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSvc)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).DeleteFuncSvcFromCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_DeleteFuncSvcFromCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).DeleteFuncSvcFromCache(ctx, req.(*FuncSvc))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_TapService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TapServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).TapService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_TapService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).TapService(ctx, req.(*TapServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_UnTapService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnTapServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).UnTapService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_UnTapService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).UnTapService(ctx, req.(*UnTapServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_MarkSpecializationFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunctionMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).MarkSpecializationFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_MarkSpecializationFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).MarkSpecializationFailure(ctx, req.(*FunctionMetaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_IsValid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSvc)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).IsValid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_IsValid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).IsValid(ctx, req.(*FuncSvc))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_RefreshFuncPods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).RefreshFuncPods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_RefreshFuncPods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).RefreshFuncPods(ctx, req.(*FunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_AdoptExistingResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).AdoptExistingResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_AdoptExistingResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).AdoptExistingResources(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_CleanupOldExecutorObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).CleanupOldExecutorObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_CleanupOldExecutorObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).CleanupOldExecutorObjects(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorPlugin_DumpDebugInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorPluginServer).DumpDebugInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorPlugin_DumpDebugInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorPluginServer).DumpDebugInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExecutorPlugin_ServiceDesc is the grpc.ServiceDesc for ExecutorPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecutorPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fission.executor.v1.ExecutorPlugin",
+	HandlerType: (*ExecutorPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFuncSvc",
+			Handler:    _ExecutorPlugin_GetFuncSvc_Handler,
+		},
+		{
+			MethodName: "GetFuncSvcFromCache",
+			Handler:    _ExecutorPlugin_GetFuncSvcFromCache_Handler,
+		},
+		{
+			MethodName: "DeleteFuncSvcFromCache",
+			Handler:    _ExecutorPlugin_DeleteFuncSvcFromCache_Handler,
+		},
+		{
+			MethodName: "TapService",
+			Handler:    _ExecutorPlugin_TapService_Handler,
+		},
+		{
+			MethodName: "UnTapService",
+			Handler:    _ExecutorPlugin_UnTapService_Handler,
+		},
+		{
+			MethodName: "MarkSpecializationFailure",
+			Handler:    _ExecutorPlugin_MarkSpecializationFailure_Handler,
+		},
+		{
+			MethodName: "IsValid",
+			Handler:    _ExecutorPlugin_IsValid_Handler,
+		},
+		{
+			MethodName: "RefreshFuncPods",
+			Handler:    _ExecutorPlugin_RefreshFuncPods_Handler,
+		},
+		{
+			MethodName: "AdoptExistingResources",
+			Handler:    _ExecutorPlugin_AdoptExistingResources_Handler,
+		},
+		{
+			MethodName: "CleanupOldExecutorObjects",
+			Handler:    _ExecutorPlugin_CleanupOldExecutorObjects_Handler,
+		},
+		{
+			MethodName: "DumpDebugInfo",
+			Handler:    _ExecutorPlugin_DumpDebugInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "executor_plugin.proto",
+}
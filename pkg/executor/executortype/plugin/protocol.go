@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements the executor plugin protocol, which lets
+// executor types run out of tree as gRPC services.
+//
+// The protocol is defined in pluginpb/executor_plugin.proto and mirrors
+// the executortype.ExecutorType interface. Go plugins can serve an
+// ExecutorType implementation directly with NewServer; plugins in other
+// languages generate their server from the proto file.
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/executortype/plugin/pluginpb"
+	"github.com/fission/fission/pkg/executor/fscache"
+)
+
+// marshal encodes an API object of a message, nil objects are left empty.
+func marshal[T any](obj *T) ([]byte, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	return json.Marshal(obj)
+}
+
+// unmarshal decodes an API object of a message, empty objects are nil.
+func unmarshal[T any](data []byte) (*T, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	obj := new(T)
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func toFuncSvc(fsvc *fscache.FuncSvc) (*pluginpb.FuncSvc, error) {
+	fn, err := marshal(fsvc.Function)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding function: %w", err)
+	}
+	env, err := marshal(fsvc.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding environment: %w", err)
+	}
+	msg := &pluginpb.FuncSvc{
+		Name:        fsvc.Name,
+		Function:    fn,
+		Environment: env,
+		Address:     fsvc.Address,
+		Executor:    string(fsvc.Executor),
+	}
+	for _, ref := range fsvc.KubernetesObjects {
+		msg.KubernetesObjects = append(msg.KubernetesObjects, &pluginpb.ObjectReference{
+			Kind:            ref.Kind,
+			Namespace:       ref.Namespace,
+			Name:            ref.Name,
+			Uid:             string(ref.UID),
+			ApiVersion:      ref.APIVersion,
+			ResourceVersion: ref.ResourceVersion,
+		})
+	}
+	if !fsvc.CPULimit.IsZero() {
+		msg.CpuLimit = fsvc.CPULimit.String()
+	}
+	if !fsvc.Ctime.IsZero() {
+		msg.Ctime = timestamppb.New(fsvc.Ctime)
+	}
+	if !fsvc.Atime.IsZero() {
+		msg.Atime = timestamppb.New(fsvc.Atime)
+	}
+	return msg, nil
+}
+
+func fromFuncSvc(msg *pluginpb.FuncSvc) (*fscache.FuncSvc, error) {
+	fn, err := unmarshal[metav1.ObjectMeta](msg.GetFunction())
+	if err != nil {
+		return nil, fmt.Errorf("error decoding function: %w", err)
+	}
+	env, err := unmarshal[fv1.Environment](msg.GetEnvironment())
+	if err != nil {
+		return nil, fmt.Errorf("error decoding environment: %w", err)
+	}
+	fsvc := &fscache.FuncSvc{
+		Name:        msg.GetName(),
+		Function:    fn,
+		Environment: env,
+		Address:     msg.GetAddress(),
+		Executor:    fv1.ExecutorType(msg.GetExecutor()),
+	}
+	for _, ref := range msg.GetKubernetesObjects() {
+		fsvc.KubernetesObjects = append(fsvc.KubernetesObjects, apiv1.ObjectReference{
+			Kind:            ref.GetKind(),
+			Namespace:       ref.GetNamespace(),
+			Name:            ref.GetName(),
+			UID:             types.UID(ref.GetUid()),
+			APIVersion:      ref.GetApiVersion(),
+			ResourceVersion: ref.GetResourceVersion(),
+		})
+	}
+	if msg.GetCpuLimit() != "" {
+		fsvc.CPULimit, err = resource.ParseQuantity(msg.GetCpuLimit())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CPU limit: %w", err)
+		}
+	}
+	if msg.Ctime != nil {
+		fsvc.Ctime = msg.Ctime.AsTime()
+	}
+	if msg.Atime != nil {
+		fsvc.Atime = msg.Atime.AsTime()
+	}
+	return fsvc, nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/executortype/plugin/pluginpb"
+	"github.com/fission/fission/pkg/executor/fscache"
+)
+
+// executorTypeServer serves an executortype.ExecutorType over the plugin
+// protocol.
+type executorTypeServer struct {
+	pluginpb.UnimplementedExecutorPluginServer
+
+	logger *zap.Logger
+	et     executortype.ExecutorType
+}
+
+// NewServer returns a gRPC server serving the executor type over the
+// plugin protocol. Pass grpc.Creds to serve over TLS. The caller is
+// responsible for running the executor type, see
+// executortype.ExecutorType.Run.
+func NewServer(logger *zap.Logger, et executortype.ExecutorType, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	pluginpb.RegisterExecutorPluginServer(s, &executorTypeServer{
+		logger: logger.Named("executor_plugin_server"),
+		et:     et,
+	})
+	return s
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func (s *executorTypeServer) function(req interface{ GetFunction() []byte }) (*fv1.Function, error) {
+	fn, err := unmarshal[fv1.Function](req.GetFunction())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if fn == nil {
+		return nil, status.Error(codes.InvalidArgument, "function is required")
+	}
+	return fn, nil
+}
+
+func (s *executorTypeServer) functionMeta(req interface{ GetFunction() []byte }) (*metav1.ObjectMeta, error) {
+	fnMeta, err := unmarshal[metav1.ObjectMeta](req.GetFunction())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if fnMeta == nil {
+		return nil, status.Error(codes.InvalidArgument, "function is required")
+	}
+	return fnMeta, nil
+}
+
+func (s *executorTypeServer) funcSvc(req *pluginpb.FuncSvc) (*fscache.FuncSvc, error) {
+	fsvc, err := fromFuncSvc(req)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	return fsvc, nil
+}
+
+func (s *executorTypeServer) GetFuncSvc(ctx context.Context, req *pluginpb.FunctionRequest) (*pluginpb.FuncSvc, error) {
+	fn, err := s.function(req)
+	if err != nil {
+		return nil, err
+	}
+	fsvc, err := s.et.GetFuncSvc(ctx, fn)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toFuncSvc(fsvc)
+}
+
+func (s *executorTypeServer) GetFuncSvcFromCache(ctx context.Context, req *pluginpb.FunctionRequest) (*pluginpb.FuncSvc, error) {
+	fn, err := s.function(req)
+	if err != nil {
+		return nil, err
+	}
+	fsvc, err := s.et.GetFuncSvcFromCache(ctx, fn)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toFuncSvc(fsvc)
+}
+
+func (s *executorTypeServer) DeleteFuncSvcFromCache(ctx context.Context, req *pluginpb.FuncSvc) (*emptypb.Empty, error) {
+	fsvc, err := s.funcSvc(req)
+	if err != nil {
+		return nil, err
+	}
+	s.et.DeleteFuncSvcFromCache(ctx, fsvc)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *executorTypeServer) TapService(ctx context.Context, req *pluginpb.TapServiceRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, toStatus(s.et.TapService(ctx, req.GetServiceUrl()))
+}
+
+func (s *executorTypeServer) UnTapService(ctx context.Context, req *pluginpb.UnTapServiceRequest) (*emptypb.Empty, error) {
+	fnMeta, err := s.functionMeta(req)
+	if err != nil {
+		return nil, err
+	}
+	s.et.UnTapService(ctx, fnMeta, req.GetServiceHost())
+	return &emptypb.Empty{}, nil
+}
+
+func (s *executorTypeServer) MarkSpecializationFailure(ctx context.Context, req *pluginpb.FunctionMetaRequest) (*emptypb.Empty, error) {
+	fnMeta, err := s.functionMeta(req)
+	if err != nil {
+		return nil, err
+	}
+	s.et.MarkSpecializationFailure(ctx, fnMeta)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *executorTypeServer) IsValid(ctx context.Context, req *pluginpb.FuncSvc) (*pluginpb.IsValidResponse, error) {
+	fsvc, err := s.funcSvc(req)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginpb.IsValidResponse{Valid: s.et.IsValid(ctx, fsvc)}, nil
+}
+
+func (s *executorTypeServer) RefreshFuncPods(ctx context.Context, req *pluginpb.FunctionRequest) (*emptypb.Empty, error) {
+	fn, err := s.function(req)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, toStatus(s.et.RefreshFuncPods(ctx, s.logger, *fn))
+}
+
+func (s *executorTypeServer) AdoptExistingResources(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	s.et.AdoptExistingResources(ctx)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *executorTypeServer) CleanupOldExecutorObjects(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	s.et.CleanupOldExecutorObjects(ctx)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *executorTypeServer) DumpDebugInfo(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, toStatus(s.et.DumpDebugInfo(ctx))
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ferror "github.com/fission/fission/pkg/error"
+)
+
+var errorCodes = map[int]codes.Code{
+	ferror.ErrorNotAuthorized:     codes.PermissionDenied,
+	ferror.ErrorNotFound:          codes.NotFound,
+	ferror.ErrorNameExists:        codes.AlreadyExists,
+	ferror.ErrorInvalidArgument:   codes.InvalidArgument,
+	ferror.ErrorNotImplemented:    codes.Unimplemented,
+	ferror.ErrorRequestTimeout:    codes.DeadlineExceeded,
+	ferror.ErrorTooManyRequests:   codes.ResourceExhausted,
+	ferror.ErrorSizeLimitExceeded: codes.OutOfRange,
+}
+
+// toStatus converts a Fission error returned by a plugin to a gRPC status
+// error, so that its kind survives the trip to the executor.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var fe ferror.Error
+	if errors.As(err, &fe) {
+		code, ok := errorCodes[int(fe.Code)]
+		if !ok {
+			code = codes.Internal
+		}
+		return status.Error(code, fe.Message)
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// fromStatus converts a gRPC status error returned by a plugin to a
+// Fission error.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for fissionCode, code := range errorCodes {
+		if s.Code() == code {
+			return ferror.MakeError(fissionCode, s.Message())
+		}
+	}
+	return ferror.MakeError(ferror.ErrorInternal, s.Message())
+}
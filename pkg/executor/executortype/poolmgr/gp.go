@@ -46,6 +46,7 @@ import (
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/coldstart"
 	"github.com/fission/fission/pkg/executor/fscache"
 	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
@@ -82,9 +83,18 @@ type (
 		enableOwnerReferences    bool
 		// TODO: move this field into fsCache
 		podFSVCMap sync.Map
+		// recycledPods are the names of the unspecialized pods recycled into
+		// the pool, which are chosen before the pods of the deployment
+		recycledPods     []string
+		recycledPodsLock sync.Mutex
 	}
 )
 
+// recycledPodLabel is the value of the managed label of recycled pods. The
+// selector of the pool deployment doesn't match it, so that its replica set
+// doesn't adopt recycled pods and delete one of its own pods in return.
+const recycledPodLabel = "recycled"
+
 // MakeGenericPool returns an instance of GenericPool
 func MakeGenericPool(
 	logger *zap.Logger,
@@ -315,7 +325,9 @@ func (gp *GenericPool) choosePod(ctx context.Context, newLabels map[string]strin
 			}
 			patchBytes, _ := json.Marshal(patch)
 			logger.Info("relabel pod", zap.String("pod", string((patchBytes))))
+			relabelStart := time.Now()
 			newPod, err := gp.kubernetesClient.CoreV1().Pods(chosenPod.Namespace).Patch(ctx, chosenPod.Name, k8sTypes.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+			coldstart.TrackPhase(ctx, coldstart.PhaseRelabel, relabelStart)
 			if err != nil && errors.Is(err, context.Canceled) {
 				// ending retry loop when the request canceled
 				gp.readyPodQueue.Done(key)
@@ -379,6 +391,145 @@ func (gp *GenericPool) scheduleDeletePod(ctx context.Context, name string) {
 	}
 }
 
+// recyclePod resets the specialized pod of an idle function service and
+// adds it to the recycled pods of the pool. An error is returned if the pod
+// can't be reused safely, in which case the caller should delete it.
+func (gp *GenericPool) recyclePod(ctx context.Context, fsvc *fscache.FuncSvc) error {
+	if gp.useSvc || gp.useIstio {
+		return errors.New("pod recycling is not supported for pods behind a function service")
+	}
+	if gp.env.Spec.AllowedFunctionsPerContainer == fv1.AllowedFunctionsPerContainerInfinite {
+		return errors.New("pod recycling is not supported for pods shared by functions")
+	}
+	if fsvc.Environment.ObjectMeta.ResourceVersion != gp.env.ObjectMeta.ResourceVersion {
+		return errors.New("environment was updated since the pod was specialized")
+	}
+	// the recycled pods are kept on top of the pool, so keep at most as
+	// many as the pool size
+	gp.recycledPodsLock.Lock()
+	full := len(gp.recycledPods) >= gp.env.Spec.Poolsize
+	gp.recycledPodsLock.Unlock()
+	if full {
+		return errors.New("pool has enough recycled pods")
+	}
+
+	pod, err := gp.kubernetesClient.CoreV1().Pods(gp.fnNamespace).Get(ctx, fsvc.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting pod: %w", err)
+	}
+	err = checkPodRecyclable(pod)
+	if err != nil {
+		return err
+	}
+
+	err = fetcherClient.MakeClient(gp.logger, gp.getFetcherURL(pod.Status.PodIP)).Unspecialize(ctx)
+	if err != nil {
+		return fmt.Errorf("error unspecializing pod: %w", err)
+	}
+
+	_, err = gp.kubernetesClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8sTypes.MergePatchType, gp.recycledPodPatch(), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error relabelling pod: %w", err)
+	}
+	gp.fsCache.PodToFsvc.Delete(pod.Name)
+	gp.podFSVCMap.Delete(pod.Name)
+
+	gp.recycledPodsLock.Lock()
+	gp.recycledPods = append(gp.recycledPods, pod.Name)
+	gp.recycledPodsLock.Unlock()
+	otelUtils.SpanTrackEvent(ctx, "recycledPod", otelUtils.GetAttributesForPod(pod)...)
+	return nil
+}
+
+// chooseRecycledPod relabels a recycled pod for a function and returns it,
+// or returns nil if the pool has no recycled pod ready. Recycled pods that
+// can't be used anymore are deleted.
+func (gp *GenericPool) chooseRecycledPod(ctx context.Context, newLabels map[string]string) *apiv1.Pod {
+	logger := otelUtils.LoggerWithTraceID(ctx, gp.logger)
+	for {
+		gp.recycledPodsLock.Lock()
+		if len(gp.recycledPods) == 0 {
+			gp.recycledPodsLock.Unlock()
+			return nil
+		}
+		name := gp.recycledPods[0]
+		gp.recycledPods = gp.recycledPods[1:]
+		gp.recycledPodsLock.Unlock()
+
+		pod, err := gp.kubernetesClient.CoreV1().Pods(gp.fnNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			err = checkPodRecyclable(pod)
+		}
+		if err != nil {
+			logger.Info("recycled pod can't be used, deleting it", zap.String("pod", name), zap.Error(err))
+			go gp.scheduleDeletePod(context.Background(), name)
+			continue
+		}
+
+		// the pod was taken from the recycled pods, so unlike the pods of
+		// the deployment no other executor goroutine can relabel it
+		patch := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": gp.getDeployAnnotations(gp.env),
+				"labels":      newLabels,
+			},
+		}
+		patchBytes, _ := json.Marshal(patch)
+		relabelStart := time.Now()
+		pod, err = gp.kubernetesClient.CoreV1().Pods(gp.fnNamespace).Patch(ctx, name, k8sTypes.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+		coldstart.TrackPhase(ctx, coldstart.PhaseRelabel, relabelStart)
+		if err != nil {
+			logger.Error("failed to relabel recycled pod, deleting it", zap.String("pod", name), zap.Error(err))
+			go gp.scheduleDeletePod(context.Background(), name)
+			continue
+		}
+		otelUtils.SpanTrackEvent(ctx, "recycledPodRelabel", otelUtils.GetAttributesForPod(pod)...)
+		logger.Info("chose recycled pod", zap.Any("labels", newLabels), zap.String("pod", name))
+		return pod
+	}
+}
+
+// checkPodRecyclable returns an error if the pod isn't in a state where it
+// can be handed to another function.
+func checkPodRecyclable(pod *apiv1.Pod) error {
+	if !utils.IsReadyPod(pod) {
+		return errors.New("pod is not ready")
+	}
+	// A restarted container may have lost the environment's state, e.g.
+	// the fetcher mounts, so don't take the risk.
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.RestartCount > 0 {
+			return fmt.Errorf("container %s of pod has restarted", status.Name)
+		}
+	}
+	return nil
+}
+
+// recycledPodPatch returns a merge patch setting back the pool labels of a
+// specialized pod, with the managed label of recycled pods, and removing the
+// function ones.
+func (gp *GenericPool) recycledPodPatch() []byte {
+	labels := make(map[string]interface{})
+	for k, v := range gp.getEnvironmentPoolLabels(gp.env) {
+		labels[k] = v
+	}
+	labels["managed"] = recycledPodLabel
+	for _, k := range []string{fv1.FUNCTION_NAME, fv1.FUNCTION_UID, fv1.FUNCTION_NAMESPACE} {
+		labels[k] = nil
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": labels,
+			"annotations": map[string]interface{}{
+				fv1.ANNOTATION_SVC_HOST:       nil,
+				fv1.FUNCTION_RESOURCE_VERSION: nil,
+			},
+		},
+	}
+	patchBytes, _ := json.Marshal(patch)
+	return patchBytes
+}
+
 // IsIPv6 validates if the podIP follows to IPv6 protocol
 func IsIPv6(podIP string) bool {
 	ip := net.ParseIP(podIP)
@@ -464,10 +615,11 @@ func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv
 
 	// Fetcher will download user function to share volume of pod, and
 	// invoke environment specialize api for pod specialization.
-	err := fetcherClient.MakeClient(gp.logger, fetcherURL).Specialize(ctx, &specializeReq)
+	resp, err := fetcherClient.MakeClient(gp.logger, fetcherURL).Specialize(ctx, &specializeReq)
 	if err != nil {
 		return err
 	}
+	coldstart.AddPhases(ctx, resp.PhaseDurations)
 	otelUtils.SpanTrackEvent(ctx, "specializedPod", otelUtils.GetAttributesForPod(pod)...)
 	return nil
 }
@@ -545,11 +697,19 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 		}
 	}
 
-	key, pod, err := gp.choosePod(ctx, funcLabels)
-	if err != nil {
-		return nil, err
+	choosePodStart := time.Now()
+	var err error
+	pod := gp.chooseRecycledPod(ctx, funcLabels)
+	if pod == nil {
+		var key string
+		key, pod, err = gp.choosePod(ctx, funcLabels)
+		if err != nil {
+			coldstart.TrackPhase(ctx, coldstart.PhaseChoosePod, choosePodStart)
+			return nil, err
+		}
+		gp.readyPodQueue.Done(key)
 	}
-	gp.readyPodQueue.Done(key)
+	coldstart.TrackPhase(ctx, coldstart.PhaseChoosePod, choosePodStart)
 	err = gp.specializePod(ctx, pod, fn)
 	if err != nil {
 		go gp.scheduleDeletePod(context.Background(), pod.ObjectMeta.Name)
@@ -665,6 +825,17 @@ func (gp *GenericPool) destroy(ctx context.Context) error {
 		PropagationPolicy: &deletePropagation,
 	}
 
+	// the recycled pods aren't owned by the deployment anymore
+	gp.recycledPodsLock.Lock()
+	for _, name := range gp.recycledPods {
+		err := gp.kubernetesClient.CoreV1().Pods(gp.fnNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !k8s_err.IsNotFound(err) {
+			gp.logger.Error("error deleting recycled pod", zap.Error(err), zap.String("pod", name))
+		}
+	}
+	gp.recycledPods = nil
+	gp.recycledPodsLock.Unlock()
+
 	err := gp.kubernetesClient.AppsV1().
 		Deployments(gp.fnNamespace).Delete(ctx, gp.deployment.ObjectMeta.Name, delOpt)
 	if err != nil {
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestCheckPodRecyclable(t *testing.T) {
+	readyPod := func() *apiv1.Pod {
+		return &apiv1.Pod{
+			Status: apiv1.PodStatus{
+				PodIP:             "10.0.0.1",
+				Conditions:        []apiv1.PodCondition{{Type: apiv1.PodReady, Status: apiv1.ConditionTrue}},
+				ContainerStatuses: []apiv1.ContainerStatus{{Name: "fetcher", Ready: true}, {Name: "nodejs", Ready: true}},
+			},
+		}
+	}
+
+	require.NoError(t, checkPodRecyclable(readyPod()))
+
+	terminating := readyPod()
+	terminating.DeletionTimestamp = &metav1.Time{}
+	require.Error(t, checkPodRecyclable(terminating))
+
+	restarted := readyPod()
+	restarted.Status.ContainerStatuses[1].RestartCount = 1
+	require.Error(t, checkPodRecyclable(restarted))
+}
+
+func TestRecycledPodPatch(t *testing.T) {
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nodejs",
+			Namespace: "default",
+			UID:       "7e1bda4c-5b63-4a5c-9c3c-62e3f2b2a0f9",
+		},
+	}
+	gp := &GenericPool{env: env}
+
+	fnLabels := gp.labelsForFunction(&metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "b8c4b6a5"})
+	kubernetesClient := fake.NewSimpleClientset(&apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "poolmgr-nodejs-default-abc",
+			Namespace: "fission-function",
+			Labels:    fnLabels,
+			Annotations: map[string]string{
+				fv1.ANNOTATION_SVC_HOST:       "10.0.0.1:8888",
+				fv1.FUNCTION_RESOURCE_VERSION: "42",
+			},
+		},
+	})
+
+	pod, err := kubernetesClient.CoreV1().Pods("fission-function").Patch(t.Context(), "poolmgr-nodejs-default-abc",
+		k8sTypes.MergePatchType, gp.recycledPodPatch(), metav1.PatchOptions{})
+	require.NoError(t, err)
+	require.Empty(t, pod.Annotations)
+	require.Equal(t, recycledPodLabel, pod.Labels["managed"])
+	// the replica set of the pool must not adopt the recycled pod
+	poolLabels := gp.getEnvironmentPoolLabels(env)
+	require.False(t, labels.SelectorFromSet(poolLabels).Matches(labels.Set(pod.Labels)))
+	pod.Labels["managed"] = poolLabels["managed"]
+	require.Equal(t, poolLabels, pod.Labels)
+}
+
+func TestChooseRecycledPod(t *testing.T) {
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: "default", UID: "7e1bda4c"}}
+	gp := &GenericPool{env: env, logger: zap.NewNop(), fnNamespace: "fission-function", instanceID: "executor"}
+	recycled := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "recycled", Namespace: "fission-function"},
+		Status: apiv1.PodStatus{
+			PodIP:             "10.0.0.1",
+			Conditions:        []apiv1.PodCondition{{Type: apiv1.PodReady, Status: apiv1.ConditionTrue}},
+			ContainerStatuses: []apiv1.ContainerStatus{{Name: "nodejs", Ready: true}},
+		},
+	}
+	gp.kubernetesClient = fake.NewSimpleClientset(recycled)
+
+	require.Nil(t, gp.chooseRecycledPod(t.Context(), nil))
+
+	// pods gone since they were recycled are skipped
+	gp.recycledPods = []string{"deleted", "recycled"}
+	fnLabels := gp.labelsForFunction(&metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "b8c4b6a5"})
+	pod := gp.chooseRecycledPod(t.Context(), fnLabels)
+	require.NotNil(t, pod)
+	require.Equal(t, "recycled", pod.Name)
+	require.Equal(t, fnLabels, pod.Labels)
+	require.Equal(t, "executor", pod.Annotations[fv1.EXECUTOR_INSTANCEID_LABEL])
+	require.Empty(t, gp.recycledPods)
+}
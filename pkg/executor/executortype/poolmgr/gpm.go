@@ -58,7 +58,8 @@ import (
 )
 
 var (
-	_ executortype.ExecutorType = &GenericPoolManager{}
+	_ executortype.ExecutorType    = &GenericPoolManager{}
+	_ executortype.PodSpecProvider = &GenericPoolManager{}
 )
 
 type requestType int
@@ -84,6 +85,7 @@ type (
 		requestChannel chan *request
 
 		enableIstio   bool
+		cmsRefresh    executorUtils.CMSRefreshConfig
 		fetcherConfig *fetcherConfig.Config
 
 		// podLister can list/get pods from the shared informer's store
@@ -154,6 +156,7 @@ func MakeGenericPoolManager(ctx context.Context,
 		defaultIdlePodReapTime:     2 * time.Minute,
 		fetcherConfig:              fetcherConfig,
 		enableIstio:                enableIstio,
+		cmsRefresh:                 executorUtils.GetCMSRefreshConfig(gpmLogger),
 		poolPodC:                   poolPodC,
 		podSpecPatch:               podSpecPatch,
 		objectReaperIntervalSecond: time.Duration(executorUtils.GetObjectReaperInterval(logger, fv1.ExecutorTypePoolmgr, 5)) * time.Second,
@@ -243,6 +246,24 @@ func (gpm *GenericPoolManager) GetFuncSvc(ctx context.Context, fn *fv1.Function)
 	return fnSvc, fErr
 }
 
+// GetFuncPodSpec returns the spec of the pool pods the function is
+// specialized in.
+func (gpm *GenericPoolManager) GetFuncPodSpec(ctx context.Context, fn *fv1.Function) (*apiv1.PodSpec, error) {
+	env, err := gpm.getFunctionEnv(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	pool, _, err := gpm.getPool(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := pool.genDeploymentSpec(env)
+	if err != nil {
+		return nil, err
+	}
+	return &spec.Template.Spec, nil
+}
+
 func (gpm *GenericPoolManager) GetFuncSvcFromCache(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	otelUtils.SpanTrackEvent(ctx, "GetFuncSvcFromCache", otelUtils.GetAttributesForFunction(fn)...)
 	return gpm.fsCache.GetFuncSvc(ctx, &fn.ObjectMeta, fn.GetRequestPerPod(), fn.GetConcurrency())
@@ -323,13 +344,6 @@ func (gpm *GenericPoolManager) RefreshFuncPods(ctx context.Context, logger *zap.
 		gpm.logger.Info("created pool for the environment", zap.String("env", env.ObjectMeta.Name), zap.String("namespace", gpm.nsResolver.ResolveNamespace(gpm.nsResolver.FunctionNamespace)))
 	}
 
-	funcSvc, err := gp.fsCache.GetByFunction(&f.ObjectMeta)
-
-	// delete function service address from cache only when function service address found in cache
-	if err == nil {
-		gp.fsCache.DeleteEntry(funcSvc)
-	}
-
 	funcLabels := gp.labelsForFunction(&f.ObjectMeta)
 
 	podList, err := gpm.kubernetesClient.CoreV1().Pods(f.Spec.Environment.Namespace).List(ctx, metav1.ListOptions{
@@ -340,7 +354,21 @@ func (gpm *GenericPoolManager) RefreshFuncPods(ctx context.Context, logger *zap.
 		return err
 	}
 
-	for _, po := range podList.Items {
+	pods := podList.Items
+	if gpm.cmsRefresh.Mode == executorUtils.CMSRefreshInPlace {
+		// only the pods that couldn't be refreshed are deleted, the pod
+		// controller removes them from the function service cache.
+		pods = executorUtils.RefreshPodsInPlace(ctx, logger, &f, pods, gpm.cmsRefresh.ReloadRuntime, gp.getFetcherURL)
+	} else {
+		funcSvc, err := gp.fsCache.GetByFunction(&f.ObjectMeta)
+
+		// delete function service address from cache only when function service address found in cache
+		if err == nil {
+			gp.fsCache.DeleteEntry(funcSvc)
+		}
+	}
+
+	for _, po := range pods {
 		err := gpm.kubernetesClient.CoreV1().Pods(po.ObjectMeta.Namespace).Delete(ctx, po.ObjectMeta.Name, metav1.DeleteOptions{})
 		if k8serrors.IsNotFound(err) {
 			return nil
@@ -414,6 +442,17 @@ func (gpm *GenericPoolManager) AdoptExistingResources(ctx context.Context) {
 				// avoid too many requests arrive Kubernetes API server at the same time.
 				time.Sleep(time.Duration(rand.Intn(30)) * time.Millisecond)
 
+				// the recycled pods of the pools are only known to the
+				// executor that recycled them
+				if pod.Labels["managed"] == recycledPodLabel {
+					err := gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+					if err != nil {
+						gpm.logger.Warn("error deleting recycled pod", zap.Error(err),
+							zap.String("pod", pod.Name), zap.String("ns", pod.Namespace))
+					}
+					return
+				}
+
 				patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%s"}}}`, fv1.EXECUTOR_INSTANCEID_LABEL, gpm.instanceID)
 				pod, err = gpm.kubernetesClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8sTypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
 				if err != nil {
@@ -671,7 +710,8 @@ func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
 		}
 		// For function with the environment that no longer exists, executor
 		// cleanups the idle pod as usual and prints log to notify user.
-		if _, ok := envList[fsvc.Environment.ObjectMeta.UID]; !ok {
+		_, envExists := envList[fsvc.Environment.ObjectMeta.UID]
+		if !envExists {
 			gpm.logger.Warn("function environment no longer exists",
 				zap.String("environment", fsvc.Environment.ObjectMeta.Name),
 				zap.String("function", fsvc.Name))
@@ -699,6 +739,9 @@ func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
 					zap.Error(err),
 					zap.Any("service", fsvc))
 			}
+			if deleted && envExists && fsvc.Environment.Spec.RecyclePods && gpm.recyclePod(ctx, fsvc) {
+				return
+			}
 			if deleted {
 				for i := range fsvc.KubernetesObjects {
 					gpm.logger.Info("release idle function resources",
@@ -715,6 +758,27 @@ func (gpm *GenericPoolManager) doIdleObjectReaper(ctx context.Context) {
 	}
 }
 
+// recyclePod returns the pod of an idle function service to its pool. It
+// returns false if the pod couldn't be recycled and must be deleted.
+func (gpm *GenericPoolManager) recyclePod(ctx context.Context, fsvc *fscache.FuncSvc) bool {
+	logger := gpm.logger.With(zap.String("function", fsvc.Function.Name),
+		zap.String("environment", fsvc.Environment.ObjectMeta.Name),
+		zap.String("pod", fsvc.Name))
+
+	pool, _, err := gpm.getPool(ctx, fsvc.Environment)
+	if err != nil {
+		logger.Error("error getting pool to recycle pod", zap.Error(err))
+		return false
+	}
+	err = pool.recyclePod(ctx, fsvc)
+	if err != nil {
+		logger.Info("could not recycle idle pod, deleting it", zap.Error(err))
+		return false
+	}
+	logger.Info("recycled idle function pod into pool")
+	return true
+}
+
 // WebsocketStartEventChecker checks if the pod has emitted a websocket connection start event
 func (gpm *GenericPoolManager) WebsocketStartEventChecker(ctx context.Context, kubeClient kubernetes.Interface) error {
 	var wg wait.Group
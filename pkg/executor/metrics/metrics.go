@@ -49,6 +49,17 @@ var (
 		},
 		functionLabels,
 	)
+	// phase: the cold start phase, e.g. choosePod or download
+	// environment: the environment's name
+	// executor_type: the executor type of the function
+	ColdStartPhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_function_cold_start_phase_seconds",
+			Help:    "Time spent in each phase of a cold start by phase, environment and executor_type.",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"phase", "environment", "executor_type"},
+	)
 )
 
 func init() {
@@ -56,4 +67,5 @@ func init() {
 	registry.MustRegister(ColdStarts)
 	registry.MustRegister(FuncRunningSummary)
 	registry.MustRegister(ColdStartsError)
+	registry.MustRegister(ColdStartPhaseDuration)
 }
@@ -0,0 +1,327 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/featureconfig"
+	"github.com/fission/fission/pkg/utils"
+)
+
+type (
+	// Enforcer enforces per-namespace quotas on function pods, so that the
+	// functions of one namespace can't consume every pool pod and all
+	// node capacity of a multi-tenant cluster.
+	Enforcer struct {
+		logger           *zap.Logger
+		kubernetesClient kubernetes.Interface
+		nsResolver       *utils.NamespaceResolver
+		config           featureconfig.QuotaFeatureConfig
+
+		lock         sync.Mutex
+		specializing map[string]int
+	}
+
+	// usage is the amount of resources consumed by the function pods of a namespace.
+	usage struct {
+		pods   int
+		cpu    resource.Quantity
+		memory resource.Quantity
+	}
+)
+
+// MakeEnforcer returns a quota enforcer for the given quota config.
+func MakeEnforcer(logger *zap.Logger, kubernetesClient kubernetes.Interface, config featureconfig.QuotaFeatureConfig) *Enforcer {
+	return &Enforcer{
+		logger:           logger.Named("quota"),
+		kubernetesClient: kubernetesClient,
+		nsResolver:       utils.DefaultNSResolver(),
+		config:           config,
+		specializing:     make(map[string]int),
+	}
+}
+
+// PodSpecFunc returns the spec of the pods a function is run with.
+type PodSpecFunc func(ctx context.Context) (*apiv1.PodSpec, error)
+
+// Acquire checks that specializing the function doesn't exceed the quota of
+// its namespace and reserves a specialization slot. The returned release
+// function must be called once the specialization has finished. An error of
+// type ErrorTooManyRequests is returned if any limit is exceeded.
+//
+// The resources of the new pod are estimated from the pod spec returned by
+// podSpec, or from the requests of the function if podSpec is nil.
+//
+// Job functions are not subject to quotas, their concurrency is bounded by
+// MaxScale instead.
+func (e *Enforcer) Acquire(ctx context.Context, fn *fv1.Function, podSpec PodSpecFunc) (func(), error) {
+	release := func() {}
+	if !e.enforced(fn) {
+		return release, nil
+	}
+
+	ns := fn.ObjectMeta.Namespace
+	quota := e.config.ForNamespace(ns)
+
+	e.lock.Lock()
+	if quota.MaxConcurrentSpecializations > 0 && e.specializing[ns] >= quota.MaxConcurrentSpecializations {
+		e.lock.Unlock()
+		return nil, ferror.MakeError(ferror.ErrorTooManyRequests,
+			fmt.Sprintf("namespace '%s' concurrent specialization limit of %d reached", ns, quota.MaxConcurrentSpecializations))
+	}
+	e.specializing[ns]++
+	e.lock.Unlock()
+
+	release = func() {
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		e.specializing[ns]--
+		if e.specializing[ns] <= 0 {
+			delete(e.specializing, ns)
+		}
+	}
+
+	if !hasLimits(quota) {
+		return release, nil
+	}
+
+	var pod *apiv1.PodSpec
+	if podSpec != nil {
+		var err error
+		pod, err = podSpec(ctx)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("error getting pod spec of function %s.%s: %w", fn.ObjectMeta.Name, ns, err)
+		}
+	}
+
+	used, _, err := e.getUsage(ctx, fn)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("error computing quota usage of namespace %s: %w", ns, err)
+	}
+
+	err = checkQuota(ns, quota, used, estimatePod(fn, pod), 1)
+	if err != nil {
+		release()
+		e.logger.Info("function specialization rejected by quota",
+			zap.String("function_name", fn.ObjectMeta.Name),
+			zap.String("function_namespace", ns),
+			zap.Error(err))
+		return nil, err
+	}
+	return release, nil
+}
+
+// CheckScale returns an error of type ErrorTooManyRequests if running
+// replicas pods of the given spec for the function, on top of the pods of
+// the other functions of its namespace, would exceed the quota.
+func (e *Enforcer) CheckScale(ctx context.Context, fn *fv1.Function, pod *apiv1.PodSpec, replicas int) error {
+	if !e.LimitsPods(fn) {
+		return nil
+	}
+	ns := fn.ObjectMeta.Namespace
+	quota := e.config.ForNamespace(ns)
+
+	used, fnUsed, err := e.getUsage(ctx, fn)
+	if err != nil {
+		return fmt.Errorf("error computing quota usage of namespace %s: %w", ns, err)
+	}
+	if replicas <= fnUsed.pods {
+		return nil
+	}
+	return checkQuota(ns, quota, used, estimatePod(fn, pod), replicas-fnUsed.pods)
+}
+
+// MaxReplicas returns how many pods of the given spec the function may run
+// within the quota of its namespace, up to maxScale, given the pods of the
+// other functions of the namespace. It returns maxScale when no quota
+// applies, and at least one.
+func (e *Enforcer) MaxReplicas(ctx context.Context, fn *fv1.Function, pod *apiv1.PodSpec, maxScale int) (int, error) {
+	if !e.LimitsPods(fn) || maxScale <= 0 {
+		return maxScale, nil
+	}
+	ns := fn.ObjectMeta.Namespace
+	quota := e.config.ForNamespace(ns)
+
+	used, fnUsed, err := e.getUsage(ctx, fn)
+	if err != nil {
+		return 0, fmt.Errorf("error computing quota usage of namespace %s: %w", ns, err)
+	}
+	others := &usage{pods: used.pods - fnUsed.pods}
+	others.cpu = used.cpu.DeepCopy()
+	others.cpu.Sub(fnUsed.cpu)
+	others.memory = used.memory.DeepCopy()
+	others.memory.Sub(fnUsed.memory)
+
+	newPod := estimatePod(fn, pod)
+	replicas := maxScale
+	if quota.MaxPods > 0 {
+		replicas = min(replicas, quota.MaxPods-others.pods)
+	}
+	if !quota.MaxCPU.IsZero() && newPod.cpu.MilliValue() > 0 {
+		replicas = min(replicas, int((quota.MaxCPU.MilliValue()-others.cpu.MilliValue())/newPod.cpu.MilliValue()))
+	}
+	if !quota.MaxMemory.IsZero() && newPod.memory.Value() > 0 {
+		replicas = min(replicas, int((quota.MaxMemory.Value()-others.memory.Value())/newPod.memory.Value()))
+	}
+	return max(replicas, 1), nil
+}
+
+// LimitsPods returns whether the quota of the namespace of the function
+// limits its pods or their resources.
+func (e *Enforcer) LimitsPods(fn *fv1.Function) bool {
+	return e.enforced(fn) && hasLimits(e.config.ForNamespace(fn.ObjectMeta.Namespace))
+}
+
+// enforced returns whether quotas apply to the function.
+func (e *Enforcer) enforced(fn *fv1.Function) bool {
+	return e != nil && e.config.IsEnabled && fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob
+}
+
+// hasLimits returns whether the quota limits the pods or their resources.
+func hasLimits(quota featureconfig.NamespaceQuota) bool {
+	return quota.MaxPods > 0 || !quota.MaxCPU.IsZero() || !quota.MaxMemory.IsZero()
+}
+
+// getUsage sums up the specialized pods of the namespace of the function and
+// their requests, and separately those of the function itself.
+func (e *Enforcer) getUsage(ctx context.Context, fn *fv1.Function) (*usage, *usage, error) {
+	namespace := fn.ObjectMeta.Namespace
+	podList, err := e.kubernetesClient.CoreV1().Pods(e.nsResolver.GetFunctionNS(namespace)).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{fv1.FUNCTION_NAMESPACE: namespace}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	used, fnUsed := &usage{}, &usage{}
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed {
+			continue
+		}
+		// Job pods are not counted, see Acquire.
+		if pod.Labels[fv1.EXECUTOR_TYPE] == string(fv1.ExecutorTypeJob) {
+			continue
+		}
+		podUsed := podRequests(&pod.Spec)
+		used.add(podUsed, 1)
+		if len(fn.ObjectMeta.UID) > 0 && pod.Labels[fv1.FUNCTION_UID] == string(fn.ObjectMeta.UID) {
+			fnUsed.add(podUsed, 1)
+		}
+	}
+	return used, fnUsed, nil
+}
+
+// podRequests returns the requests of a pod, summed over all its containers,
+// the fetcher included.
+func podRequests(spec *apiv1.PodSpec) *usage {
+	u := &usage{pods: 1}
+	for _, c := range spec.Containers {
+		u.cpu.Add(c.Resources.Requests[apiv1.ResourceCPU])
+		u.memory.Add(c.Resources.Requests[apiv1.ResourceMemory])
+	}
+	return u
+}
+
+// estimatePod returns the requests of a new pod of the function, from its
+// pod spec if known, else from the requests set on the function.
+func estimatePod(fn *fv1.Function, pod *apiv1.PodSpec) *usage {
+	if pod != nil {
+		return podRequests(pod)
+	}
+	return &usage{
+		pods:   1,
+		cpu:    fn.Spec.Resources.Requests[apiv1.ResourceCPU],
+		memory: fn.Spec.Resources.Requests[apiv1.ResourceMemory],
+	}
+}
+
+// add adds n times the usage of other.
+func (u *usage) add(other *usage, n int) {
+	u.pods += other.pods * n
+	for i := 0; i < n; i++ {
+		u.cpu.Add(other.cpu)
+		u.memory.Add(other.memory)
+	}
+}
+
+// checkQuota returns an error if n more pods using newPod each would exceed
+// the quota.
+func checkQuota(namespace string, quota featureconfig.NamespaceQuota, used *usage, newPod *usage, n int) error {
+	total := &usage{pods: used.pods, cpu: used.cpu.DeepCopy(), memory: used.memory.DeepCopy()}
+	total.add(newPod, n)
+
+	if quota.MaxPods > 0 && total.pods > quota.MaxPods {
+		return ferror.MakeError(ferror.ErrorTooManyRequests,
+			fmt.Sprintf("namespace '%s' function pod limit of %d reached", namespace, quota.MaxPods))
+	}
+	if !quota.MaxCPU.IsZero() && total.cpu.Cmp(quota.MaxCPU) > 0 {
+		return ferror.MakeError(ferror.ErrorTooManyRequests,
+			fmt.Sprintf("namespace '%s' function CPU limit of %s reached", namespace, quota.MaxCPU.String()))
+	}
+	if !quota.MaxMemory.IsZero() && total.memory.Cmp(quota.MaxMemory) > 0 {
+		return ferror.MakeError(ferror.ErrorTooManyRequests,
+			fmt.Sprintf("namespace '%s' function memory limit of %s reached", namespace, quota.MaxMemory.String()))
+	}
+	return nil
+}
+
+// MaxScaleWarnings returns warnings for a function whose MaxScale can't be
+// reached within the quota of its namespace.
+func MaxScaleWarnings(config featureconfig.QuotaFeatureConfig, fn *fv1.Function) []string {
+	maxScale := fn.Spec.InvokeStrategy.ExecutionStrategy.MaxScale
+	if !config.IsEnabled || maxScale <= 0 || fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeJob {
+		return nil
+	}
+
+	quota := config.ForNamespace(fn.ObjectMeta.Namespace)
+	warnings := make([]string, 0)
+	if quota.MaxPods > 0 && maxScale > quota.MaxPods {
+		warnings = append(warnings, fmt.Sprintf("MaxScale %d exceeds the function pod quota of %d for namespace %s",
+			maxScale, quota.MaxPods, fn.ObjectMeta.Namespace))
+	}
+
+	if cpu, ok := fn.Spec.Resources.Requests[apiv1.ResourceCPU]; ok && !quota.MaxCPU.IsZero() {
+		total := resource.NewMilliQuantity(cpu.MilliValue()*int64(maxScale), cpu.Format)
+		if total.Cmp(quota.MaxCPU) > 0 {
+			warnings = append(warnings, fmt.Sprintf("MaxScale %d with CPU request %s exceeds the CPU quota of %s for namespace %s",
+				maxScale, cpu.String(), quota.MaxCPU.String(), fn.ObjectMeta.Namespace))
+		}
+	}
+
+	if memory, ok := fn.Spec.Resources.Requests[apiv1.ResourceMemory]; ok && !quota.MaxMemory.IsZero() {
+		total := resource.NewQuantity(memory.Value()*int64(maxScale), memory.Format)
+		if total.Cmp(quota.MaxMemory) > 0 {
+			warnings = append(warnings, fmt.Sprintf("MaxScale %d with memory request %s exceeds the memory quota of %s for namespace %s",
+				maxScale, memory.String(), quota.MaxMemory.String(), fn.ObjectMeta.Namespace))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/featureconfig"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+const tenantNamespace = "tenant"
+
+func makeFunction(maxScale int, cpu string) *fv1.Function {
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: tenantNamespace},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType: fv1.ExecutorTypeNewdeploy,
+					MaxScale:     maxScale,
+				},
+			},
+		},
+	}
+	if cpu != "" {
+		fn.Spec.Resources.Requests = apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	return fn
+}
+
+func makePod(name string, cpu string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tenantNamespace,
+			Labels:    map[string]string{fv1.FUNCTION_NAMESPACE: tenantNamespace},
+		},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{
+				{
+					Name: "main",
+					Resources: apiv1.ResourceRequirements{
+						Requests: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    featureconfig.NamespaceQuota
+		pods     []*apiv1.Pod
+		fn       *fv1.Function
+		rejected bool
+	}{
+		{
+			name:  "no limits",
+			quota: featureconfig.NamespaceQuota{},
+			pods:  []*apiv1.Pod{makePod("a", "1")},
+			fn:    makeFunction(1, ""),
+		},
+		{
+			name:     "pod limit reached",
+			quota:    featureconfig.NamespaceQuota{MaxPods: 1},
+			pods:     []*apiv1.Pod{makePod("a", "1")},
+			fn:       makeFunction(1, ""),
+			rejected: true,
+		},
+		{
+			name:  "within cpu limit",
+			quota: featureconfig.NamespaceQuota{MaxCPU: resource.MustParse("2")},
+			pods:  []*apiv1.Pod{makePod("a", "1")},
+			fn:    makeFunction(1, "500m"),
+		},
+		{
+			name:     "cpu limit exceeded",
+			quota:    featureconfig.NamespaceQuota{MaxCPU: resource.MustParse("2")},
+			pods:     []*apiv1.Pod{makePod("a", "1"), makePod("b", "800m")},
+			fn:       makeFunction(1, "500m"),
+			rejected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubernetesClient := fake.NewSimpleClientset()
+			for _, pod := range test.pods {
+				_, err := kubernetesClient.CoreV1().Pods(tenantNamespace).Create(t.Context(), pod, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			e := MakeEnforcer(loggerfactory.GetLogger(), kubernetesClient, featureconfig.QuotaFeatureConfig{
+				IsEnabled:  true,
+				Namespaces: map[string]featureconfig.NamespaceQuota{tenantNamespace: test.quota},
+			})
+			e.nsResolver = &utils.NamespaceResolver{}
+
+			release, err := e.Acquire(t.Context(), test.fn, nil)
+			if test.rejected {
+				require.Error(t, err)
+				code, _ := ferror.GetHTTPError(err)
+				require.Equal(t, http.StatusTooManyRequests, code)
+				return
+			}
+			require.NoError(t, err)
+			release()
+		})
+	}
+}
+
+func TestAcquireConcurrentSpecializations(t *testing.T) {
+	e := MakeEnforcer(loggerfactory.GetLogger(), fake.NewSimpleClientset(), featureconfig.QuotaFeatureConfig{
+		IsEnabled: true,
+		Default:   featureconfig.NamespaceQuota{MaxConcurrentSpecializations: 1},
+	})
+
+	release, err := e.Acquire(t.Context(), makeFunction(1, ""), nil)
+	require.NoError(t, err)
+
+	_, err = e.Acquire(t.Context(), makeFunction(1, ""), nil)
+	require.Error(t, err)
+
+	release()
+	release, err = e.Acquire(t.Context(), makeFunction(1, ""), nil)
+	require.NoError(t, err)
+	release()
+}
+
+func TestMaxScaleWarnings(t *testing.T) {
+	config := featureconfig.QuotaFeatureConfig{
+		IsEnabled: true,
+		Default: featureconfig.NamespaceQuota{
+			MaxPods: 5,
+			MaxCPU:  resource.MustParse("2"),
+		},
+	}
+
+	require.Empty(t, MaxScaleWarnings(config, makeFunction(4, "500m")))
+	require.Len(t, MaxScaleWarnings(config, makeFunction(6, "")), 1)
+	require.Len(t, MaxScaleWarnings(config, makeFunction(6, "500m")), 2)
+
+	config.IsEnabled = false
+	require.Empty(t, MaxScaleWarnings(config, makeFunction(6, "500m")))
+}
+
+// makePodSpec returns the spec of a function pod whose function and fetcher
+// containers each request cpu.
+func makePodSpec(cpu string) *apiv1.PodSpec {
+	requests := apiv1.ResourceRequirements{
+		Requests: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse(cpu)},
+	}
+	return &apiv1.PodSpec{
+		Containers: []apiv1.Container{
+			{Name: "main", Resources: requests},
+			{Name: "fetcher", Resources: requests},
+		},
+	}
+}
+
+func makeEnforcer(t *testing.T, quota featureconfig.NamespaceQuota, pods ...*apiv1.Pod) *Enforcer {
+	kubernetesClient := fake.NewSimpleClientset()
+	for _, pod := range pods {
+		_, err := kubernetesClient.CoreV1().Pods(tenantNamespace).Create(t.Context(), pod, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	e := MakeEnforcer(loggerfactory.GetLogger(), kubernetesClient, featureconfig.QuotaFeatureConfig{
+		IsEnabled:  true,
+		Namespaces: map[string]featureconfig.NamespaceQuota{tenantNamespace: quota},
+	})
+	e.nsResolver = &utils.NamespaceResolver{}
+	return e
+}
+
+func TestAcquirePodSpec(t *testing.T) {
+	e := makeEnforcer(t, featureconfig.NamespaceQuota{MaxCPU: resource.MustParse("2")}, makePod("a", "1"))
+	fn := makeFunction(1, "300m")
+
+	// the function and fetcher containers of the new pod are both counted
+	podSpec := func(ctx context.Context) (*apiv1.PodSpec, error) {
+		return makePodSpec("600m"), nil
+	}
+	_, err := e.Acquire(t.Context(), fn, podSpec)
+	require.Error(t, err)
+
+	podSpec = func(ctx context.Context) (*apiv1.PodSpec, error) {
+		return makePodSpec("500m"), nil
+	}
+	release, err := e.Acquire(t.Context(), fn, podSpec)
+	require.NoError(t, err)
+	release()
+}
+
+func TestCheckScale(t *testing.T) {
+	fn := makeFunction(5, "")
+	fn.ObjectMeta.UID = "fn-uid"
+	fnPod := makePod("fn-a", "1")
+	fnPod.Labels[fv1.FUNCTION_UID] = string(fn.ObjectMeta.UID)
+	e := makeEnforcer(t, featureconfig.NamespaceQuota{MaxPods: 4, MaxCPU: resource.MustParse("4")}, fnPod, makePod("a", "1"))
+
+	// the running pod of the function counts towards its replicas
+	require.NoError(t, e.CheckScale(t.Context(), fn, makePodSpec("500m"), 3))
+	err := e.CheckScale(t.Context(), fn, makePodSpec("500m"), 4)
+	require.Error(t, err)
+	code, _ := ferror.GetHTTPError(err)
+	require.Equal(t, http.StatusTooManyRequests, code)
+	require.Error(t, e.CheckScale(t.Context(), fn, makePodSpec("1"), 3))
+
+	// the pod of the other function leaves room for 3 pods of 1 CPU
+	replicas, err := e.MaxReplicas(t.Context(), fn, makePodSpec("500m"), 5)
+	require.NoError(t, err)
+	require.Equal(t, 3, replicas)
+	replicas, err = e.MaxReplicas(t.Context(), fn, makePodSpec("1"), 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, replicas)
+	replicas, err = e.MaxReplicas(t.Context(), fn, makePodSpec("100m"), 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, replicas)
+
+	// without quota the function scales to MaxScale
+	e.config.IsEnabled = false
+	require.NoError(t, e.CheckScale(t.Context(), fn, makePodSpec("1"), 10))
+	replicas, err = e.MaxReplicas(t.Context(), fn, makePodSpec("1"), 5)
+	require.NoError(t, err)
+	require.Equal(t, 5, replicas)
+}
@@ -19,8 +19,10 @@ package reaper
 import (
 	"context"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,6 +58,12 @@ func CleanupKubeObject(ctx context.Context, logger *zap.Logger, kubeClient kuber
 			logger.Error("error cleaning up deployment", zap.Error(err), zap.String("deployment", kubeobj.Name))
 		}
 
+	case "job":
+		err := kubeClient.BatchV1().Jobs(kubeobj.Namespace).Delete(ctx, kubeobj.Name, delOpt)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error("error cleaning up job", zap.Error(err), zap.String("job", kubeobj.Name))
+		}
+
 	case "horizontalpodautoscaler":
 		err := kubeClient.AutoscalingV2().HorizontalPodAutoscalers(kubeobj.Namespace).Delete(ctx, kubeobj.Name, metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
@@ -216,6 +224,56 @@ func CleanupHpa(ctx context.Context, logger *zap.Logger, client kubernetes.Inter
 	return nil
 }
 
+// CleanupFinishedJobs deletes job(s) that completed or failed more than ttl ago.
+// Unlike other objects, jobs are not tied to an executor instance: a running
+// job survives executor restarts and is only reaped once it has finished.
+func CleanupFinishedJobs(ctx context.Context, logger *zap.Logger, client kubernetes.Interface, ttl time.Duration, listOps metav1.ListOptions) error {
+	cleanupJobs := func(namespace string) error {
+		jobList, err := client.BatchV1().Jobs(namespace).List(ctx, listOps)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobList.Items {
+			finishedAt := JobFinishTime(&job)
+			if finishedAt == nil || time.Since(finishedAt.Time) < ttl {
+				continue
+			}
+			logger.Info("cleaning up finished job", zap.String("job", job.ObjectMeta.Name))
+			err := client.BatchV1().Jobs(job.ObjectMeta.Namespace).Delete(ctx, job.ObjectMeta.Name, delOpt)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				logger.Error("error cleaning up job",
+					zap.Error(err),
+					zap.String("job_name", job.ObjectMeta.Name),
+					zap.String("job_namespace", job.ObjectMeta.Namespace))
+			}
+			// ignore err
+		}
+		return nil
+	}
+
+	for _, namespace := range GetReaperNamespace() {
+		if err := cleanupJobs(namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// JobFinishTime returns the time at which a job completed or failed,
+// or nil if the job is still pending or running.
+func JobFinishTime(job *batchv1.Job) *metav1.Time {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != apiv1.ConditionTrue {
+			continue
+		}
+		if cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed {
+			return &cond.LastTransitionTime
+		}
+	}
+	return nil
+}
+
 func GetReaperNamespace() map[string]string {
 	ns := utils.DefaultNSResolver()
 	// to support backward compatibility we need to cleanup deployment and rolebinding created in function, buidler and default namespace as well
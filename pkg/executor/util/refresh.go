@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fetcher"
+	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
+	"github.com/fission/fission/pkg/utils"
+)
+
+// CMSRefreshMode is how the pods of a function are refreshed when a secret
+// or configmap of the function changes.
+type CMSRefreshMode string
+
+const (
+	// CMSRefreshRestart replaces the pods of the function.
+	CMSRefreshRestart CMSRefreshMode = "restart"
+	// CMSRefreshInPlace asks the fetcher of each pod to fetch the secrets
+	// and configmaps again, and replaces the pods it fails for.
+	CMSRefreshInPlace CMSRefreshMode = "inplace"
+)
+
+// CMSRefreshConfig configures the refresh of function pods.
+type CMSRefreshConfig struct {
+	Mode CMSRefreshMode
+	// ReloadRuntime notifies the environment through its reload
+	// endpoint after an in-place refresh.
+	ReloadRuntime bool
+}
+
+// GetCMSRefreshConfig reads the refresh configuration from the
+// CMS_REFRESH_MODE and CMS_RELOAD_RUNTIME environment variables.
+func GetCMSRefreshConfig(logger *zap.Logger) CMSRefreshConfig {
+	cfg := CMSRefreshConfig{Mode: CMSRefreshRestart}
+
+	switch mode := CMSRefreshMode(os.Getenv("CMS_REFRESH_MODE")); mode {
+	case "", CMSRefreshRestart:
+	case CMSRefreshInPlace:
+		cfg.Mode = mode
+	default:
+		logger.Error("unknown 'CMS_REFRESH_MODE', set to restart", zap.String("mode", string(mode)))
+	}
+
+	if len(os.Getenv("CMS_RELOAD_RUNTIME")) > 0 {
+		reload, err := strconv.ParseBool(os.Getenv("CMS_RELOAD_RUNTIME"))
+		if err != nil {
+			logger.Error("failed to parse 'CMS_RELOAD_RUNTIME', set to false", zap.Error(err))
+		}
+		cfg.ReloadRuntime = reload
+	}
+	return cfg
+}
+
+// RefreshPodsInPlace asks the fetcher of each pod, reached at the URL
+// returned by fetcherURL for the pod IP, to fetch the secrets and configmaps
+// of the function again. It returns the pods that couldn't be refreshed,
+// including the pods that aren't ready.
+func RefreshPodsInPlace(ctx context.Context, logger *zap.Logger, fn *fv1.Function, pods []apiv1.Pod,
+	reload bool, fetcherURL func(podIP string) string) []apiv1.Pod {
+	req := &fetcher.FunctionRefreshRequest{
+		Secrets:    fn.Spec.Secrets,
+		ConfigMaps: fn.Spec.ConfigMaps,
+		Reload:     reload,
+	}
+
+	var mu sync.Mutex
+	var failed []apiv1.Pod
+	wg := &sync.WaitGroup{}
+	for _, pod := range pods {
+		if !utils.IsReadyPod(&pod) || len(pod.Status.PodIP) == 0 {
+			failed = append(failed, pod)
+			continue
+		}
+		wg.Add(1)
+		go func(pod apiv1.Pod) {
+			defer wg.Done()
+			resp, err := fetcherClient.MakeClient(logger, fetcherURL(pod.Status.PodIP)).Refresh(ctx, req)
+			if err != nil {
+				logger.Warn("error refreshing pod in place",
+					zap.Error(err),
+					zap.String("function", fn.ObjectMeta.Name),
+					zap.String("pod", pod.ObjectMeta.Name))
+				mu.Lock()
+				failed = append(failed, pod)
+				mu.Unlock()
+				return
+			}
+			logger.Info("refreshed pod in place",
+				zap.String("function", fn.ObjectMeta.Name),
+				zap.String("pod", pod.ObjectMeta.Name),
+				zap.Bool("reloaded", resp.Reloaded))
+		}(pod)
+	}
+	wg.Wait()
+	return failed
+}
@@ -155,3 +155,24 @@ func TestGetObjectReaperInterval(t *testing.T) {
 		t.Fatalf(`%d %d`, want, got)
 	}
 }
+
+func TestGetCMSRefreshConfig(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+
+	for _, test := range []struct {
+		mode   string
+		reload string
+		want   CMSRefreshConfig
+	}{
+		{want: CMSRefreshConfig{Mode: CMSRefreshRestart}},
+		{mode: "inplace", reload: "true", want: CMSRefreshConfig{Mode: CMSRefreshInPlace, ReloadRuntime: true}},
+		{mode: "unknown", reload: "maybe", want: CMSRefreshConfig{Mode: CMSRefreshRestart}},
+	} {
+		t.Setenv("CMS_REFRESH_MODE", test.mode)
+		t.Setenv("CMS_RELOAD_RUNTIME", test.reload)
+		got := GetCMSRefreshConfig(logger)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Fatalf("unexpected config for mode %q (-want +got):\n%s", test.mode, diff)
+		}
+	}
+}
@@ -16,11 +16,18 @@ limitations under the License.
 
 package featureconfig
 
-import "time"
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
 
 const (
 	FeatureConfigFile = "/etc/config/config.yaml"
 	CanaryFeature     = "canary"
+	QuotaFeature      = "quota"
+
+	ExecutorPluginFeature = "executorPlugins"
 )
 
 type (
@@ -34,6 +41,9 @@ type (
 		// In the future more such feature configs can be added here for each optional feature
 		CanaryConfig CanaryFeatureConfig `json:"canary"`
 		AuthConfig   AuthFeatureConfig   `json:"auth"`
+		QuotaConfig  QuotaFeatureConfig  `json:"quota"`
+
+		ExecutorPluginConfig ExecutorPluginFeatureConfig `json:"executorPlugins"`
 	}
 
 	// specific feature config
@@ -48,4 +58,74 @@ type (
 		JWTExpiryTime time.Duration `json:"jwtExpiryTime"`
 		JWTIssuer     string        `json:"jwtIssuer"`
 	}
+
+	// QuotaFeatureConfig caps the resources functions of a namespace
+	// can consume. Namespaces without an entry get the default quota.
+	QuotaFeatureConfig struct {
+		IsEnabled  bool                      `json:"enabled"`
+		Default    NamespaceQuota            `json:"default"`
+		Namespaces map[string]NamespaceQuota `json:"namespaces"`
+	}
+
+	// NamespaceQuota holds the limits for a single namespace; zero values
+	// mean unlimited.
+	NamespaceQuota struct {
+		// MaxPods is the maximum number of specialized function pods.
+		MaxPods int `json:"maxPods"`
+		// MaxConcurrentSpecializations is the maximum number of function
+		// specializations in progress at the same time.
+		MaxConcurrentSpecializations int `json:"maxConcurrentSpecializations"`
+		// MaxCPU is the maximum total CPU requested by function pods.
+		MaxCPU resource.Quantity `json:"maxCPU"`
+		// MaxMemory is the maximum total memory requested by function pods.
+		MaxMemory resource.Quantity `json:"maxMemory"`
+	}
+
+	// ExecutorPluginFeatureConfig registers out-of-tree executor types.
+	// Functions select a plugin with executor type "plugin-<name>".
+	ExecutorPluginFeatureConfig struct {
+		IsEnabled bool             `json:"enabled"`
+		Plugins   []ExecutorPlugin `json:"plugins"`
+	}
+
+	// ExecutorPlugin is an external executor service speaking the
+	// executor plugin gRPC protocol.
+	ExecutorPlugin struct {
+		Name string `json:"name"`
+
+		// Address of the plugin, host:port or unix:///path/to/socket.
+		Address string `json:"address"`
+
+		// TLS configures the connection to plugins served over TCP.
+		TLS *ExecutorPluginTLS `json:"tls,omitempty"`
+
+		// Insecure allows plain text connections to plugins served over
+		// TCP without TLS, e.g. behind a service mesh.
+		Insecure bool `json:"insecure,omitempty"`
+	}
+
+	// ExecutorPluginTLS holds the files of the TLS configuration of an
+	// executor plugin connection.
+	ExecutorPluginTLS struct {
+		// CAFile verifies the plugin certificate. Defaults to the system
+		// roots.
+		CAFile string `json:"caFile,omitempty"`
+
+		// CertFile and KeyFile are the client certificate presented to
+		// plugins requiring mutual TLS.
+		CertFile string `json:"certFile,omitempty"`
+		KeyFile  string `json:"keyFile,omitempty"`
+
+		// ServerName overrides the name the plugin certificate is
+		// verified against. Defaults to the host of the address.
+		ServerName string `json:"serverName,omitempty"`
+	}
 )
+
+// ForNamespace returns the quota applied to functions of the given namespace.
+func (cfg QuotaFeatureConfig) ForNamespace(namespace string) NamespaceQuota {
+	if quota, ok := cfg.Namespaces[namespace]; ok {
+		return quota
+	}
+	return cfg.Default
+}
@@ -20,8 +20,10 @@ import (
 
 type (
 	ClientInterface interface {
-		Specialize(context.Context, *fetcher.FunctionSpecializeRequest) error
-		Fetch(context.Context, *fetcher.FunctionFetchRequest) error
+		Specialize(context.Context, *fetcher.FunctionSpecializeRequest) (*fetcher.FunctionSpecializeResponse, error)
+		Unspecialize(context.Context) error
+		Refresh(context.Context, *fetcher.FunctionRefreshRequest) (*fetcher.FunctionRefreshResponse, error)
+		Fetch(context.Context, *fetcher.FunctionFetchRequest) (*fetcher.FunctionFetchResponse, error)
 		Upload(context.Context, *fetcher.ArchiveUploadRequest) (*fetcher.ArchiveUploadResponse, error)
 	}
 	client struct {
@@ -44,6 +46,14 @@ func (c *client) getSpecializeUrl() string {
 	return c.url + "/specialize"
 }
 
+func (c *client) getUnspecializeUrl() string {
+	return c.url + "/unspecialize"
+}
+
+func (c *client) getRefreshUrl() string {
+	return c.url + "/refresh"
+}
+
 func (c *client) getFetchUrl() string {
 	return c.url + "/fetch"
 }
@@ -52,14 +62,80 @@ func (c *client) getUploadUrl() string {
 	return c.url + "/upload"
 }
 
-func (c *client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) error {
-	_, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
-	return err
+func (c *client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) (*fetcher.FunctionSpecializeResponse, error) {
+	body, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	specializeResp := fetcher.FunctionSpecializeResponse{}
+	// fetchers of older releases reply with an empty body
+	if len(body) == 0 {
+		return &specializeResp, nil
+	}
+	err = json.Unmarshal(body, &specializeResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &specializeResp, nil
+}
+
+// Unspecialize resets a specialized pod. Unlike the other requests it isn't
+// retried, a failure means the pod can't be reused.
+func (c *client) Unspecialize(ctx context.Context) error {
+	resp, err := ctxhttp.Post(ctx, c.httpClient, c.getUnspecializeUrl(), "application/json", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ferror.MakeErrorFromHTTP(resp)
+	}
+	return nil
 }
 
-func (c *client) Fetch(ctx context.Context, fr *fetcher.FunctionFetchRequest) error {
-	_, err := sendRequest(c.logger, ctx, c.httpClient, fr, c.getFetchUrl())
-	return err
+// Refresh asks a specialized pod to fetch its secrets and configmaps again.
+// It isn't retried either, the caller restarts the pod on failure.
+func (c *client) Refresh(ctx context.Context, req *fetcher.FunctionRefreshRequest) (*fetcher.FunctionRefreshResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ctxhttp.Post(ctx, c.httpClient, c.getRefreshUrl(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	refreshResp := fetcher.FunctionRefreshResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&refreshResp)
+	if err != nil {
+		return nil, err
+	}
+	return &refreshResp, nil
+}
+
+func (c *client) Fetch(ctx context.Context, fr *fetcher.FunctionFetchRequest) (*fetcher.FunctionFetchResponse, error) {
+	body, err := sendRequest(c.logger, ctx, c.httpClient, fr, c.getFetchUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	fetchResp := fetcher.FunctionFetchResponse{}
+	// fetchers of older releases reply with an empty body
+	if len(body) == 0 {
+		return &fetchResp, nil
+	}
+	err = json.Unmarshal(body, &fetchResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fetchResp, nil
 }
 
 func (c *client) Upload(ctx context.Context, fr *fetcher.ArchiveUploadRequest) (*fetcher.ArchiveUploadResponse, error) {
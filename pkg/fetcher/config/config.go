@@ -133,19 +133,30 @@ func (cfg *Config) AddFetcherToPodSpec(podSpec *apiv1.PodSpec, mainContainerName
 }
 
 func (cfg *Config) AddSpecializingFetcherToPodSpec(podSpec *apiv1.PodSpec, mainContainerName string, fn *fv1.Function, env *fv1.Environment) error {
+	return cfg.addSpecializingFetcherToPodSpec(podSpec, mainContainerName, fn, env)
+}
+
+// AddJobFetcherToPodSpec adds a fetcher that specializes the pod on startup,
+// then calls the function once with the job input at inputPath and exits.
+func (cfg *Config) AddJobFetcherToPodSpec(podSpec *apiv1.PodSpec, mainContainerName string, fn *fv1.Function, env *fv1.Environment, inputPath string) error {
+	return cfg.addSpecializingFetcherToPodSpec(podSpec, mainContainerName, fn, env, "-job-input", inputPath)
+}
+
+func (cfg *Config) addSpecializingFetcherToPodSpec(podSpec *apiv1.PodSpec, mainContainerName string, fn *fv1.Function, env *fv1.Environment, extraArgs ...string) error {
 	specializeReq := cfg.NewSpecializeRequest(fn, env)
 	specializePayload, err := json.Marshal(specializeReq)
 	if err != nil {
 		return err
 	}
 
+	args := []string{
+		"-specialize-on-startup",
+		"-specialize-request", string(specializePayload),
+	}
 	return cfg.addFetcherToPodSpecWithCommand(
 		podSpec,
 		mainContainerName,
-		cfg.fetcherCommand(
-			"-specialize-on-startup",
-			"-specialize-request", string(specializePayload),
-		),
+		cfg.fetcherCommand(append(args, extraArgs...)...),
 	)
 }
 
@@ -27,6 +27,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/reference"
 
@@ -48,8 +50,10 @@ import (
 	"github.com/fission/fission/pkg/error/network"
 	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/info"
+	"github.com/fission/fission/pkg/storagesvc"
 	storageSvcClient "github.com/fission/fission/pkg/storagesvc/client"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
 	otelUtils "github.com/fission/fission/pkg/utils/otel"
 )
 
@@ -62,7 +66,11 @@ type (
 		fissionClient    versioned.Interface
 		kubeClient       kubernetes.Interface
 		httpClient       *http.Client
-		Info             PodInfo
+		// restConfig holds the credentials sent to the storage service
+		restConfig *rest.Config
+		// cmsLock serializes the writes of secrets and configmaps
+		cmsLock sync.Mutex
+		Info    PodInfo
 	}
 	PodInfo struct {
 		Name      string
@@ -98,6 +106,10 @@ func MakeFetcher(logger *zap.Logger, clientGen crd.ClientGeneratorInterface, sha
 	if err != nil {
 		return nil, fmt.Errorf("error making the kube client: %w", err)
 	}
+	restConfig, err := clientGen.GetRestConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the kube client config: %w", err)
+	}
 
 	name, err := os.ReadFile(podInfoMountDir + "/name")
 	if err != nil {
@@ -122,6 +134,7 @@ func MakeFetcher(logger *zap.Logger, clientGen crd.ClientGeneratorInterface, sha
 			Namespace: string(namespace),
 		},
 		httpClient: hc,
+		restConfig: restConfig,
 	}, nil
 }
 
@@ -135,14 +148,64 @@ func verifyChecksum(fileChecksum, checksum *fv1.Checksum) error {
 	return nil
 }
 
+// writeSecretOrConfigMap writes the data to a new directory, then points the
+// dirPath symlink to it. The symlink is swapped atomically, so that a running
+// function never reads a mix of old and new values, and keys removed from the
+// data don't linger.
 func writeSecretOrConfigMap(dataMap map[string][]byte, dirPath string) error {
+	dirPath = filepath.Clean(dirPath)
+	parent, name := filepath.Split(dirPath)
+
+	dataDir, err := os.MkdirTemp(parent, "."+name+"-")
+	if err != nil {
+		return fmt.Errorf("failed to create directory in %s: %w", parent, err)
+	}
+	err = os.Chmod(dataDir, os.ModeDir|0750)
+	if err != nil {
+		os.RemoveAll(dataDir)
+		return fmt.Errorf("failed to set permissions of %s: %w", dataDir, err)
+	}
 	for key, val := range dataMap {
-		writeFilePath := filepath.Join(dirPath, key)
+		writeFilePath := filepath.Join(dataDir, key)
 		err := os.WriteFile(writeFilePath, val, 0750)
 		if err != nil {
+			os.RemoveAll(dataDir)
 			return fmt.Errorf("failed to write file %s: %w", writeFilePath, err)
 		}
 	}
+
+	previous, err := os.Readlink(dirPath)
+	if err != nil {
+		previous = ""
+		// directories written by older fetchers are replaced
+		if fi, statErr := os.Lstat(dirPath); statErr == nil && fi.IsDir() {
+			err = os.RemoveAll(dirPath)
+			if err != nil {
+				os.RemoveAll(dataDir)
+				return fmt.Errorf("failed to remove %s: %w", dirPath, err)
+			}
+		}
+	}
+
+	link := dataDir + ".link"
+	err = os.Symlink(filepath.Base(dataDir), link)
+	if err != nil {
+		os.RemoveAll(dataDir)
+		return fmt.Errorf("failed to create symlink %s: %w", link, err)
+	}
+	err = os.Rename(link, dirPath)
+	if err != nil {
+		os.Remove(link)
+		os.RemoveAll(dataDir)
+		return fmt.Errorf("failed to swap %s: %w", dirPath, err)
+	}
+
+	if len(previous) > 0 && previous != filepath.Base(dataDir) {
+		err = os.RemoveAll(filepath.Join(parent, previous))
+		if err != nil {
+			return fmt.Errorf("failed to remove previous data of %s: %w", dirPath, err)
+		}
+	}
 	return nil
 }
 
@@ -192,7 +255,7 @@ func (fetcher *Fetcher) FetchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code, err := fetcher.Fetch(ctx, pkg, req)
+	resp, code, err := fetcher.Fetch(ctx, pkg, req)
 	if err != nil {
 		logger.Error("error fetching", zap.Error(err))
 		http.Error(w, err.Error(), code)
@@ -209,7 +272,12 @@ func (fetcher *Fetcher) FetchHandler(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("completed fetch request")
 	// all done
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		logger.Error("error encoding fetch response", zap.Error(err))
+	}
 }
 
 func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request) {
@@ -236,7 +304,7 @@ func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = fetcher.SpecializePod(ctx, req.FetchReq, req.LoadReq)
+	resp, err := fetcher.SpecializePod(ctx, req.FetchReq, req.LoadReq)
 	if err != nil {
 		logger.Error("error specializing pod", zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -244,18 +312,23 @@ func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	// all done
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		logger.Error("error encoding specialize response", zap.Error(err))
+	}
 }
 
 // Fetch takes FetchRequest and makes the fetch call
-// It returns the HTTP code and error if any
-func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest) (int, error) {
+// It returns the fetch response, the HTTP code and error if any
+func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req FunctionFetchRequest) (*FunctionFetchResponse, int, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
 
 	storePath, err := utils.SanitizeFilePath(filepath.Join(fetcher.sharedVolumePath, req.Filename), fetcher.sharedVolumePath)
 	if err != nil {
 		logger.Error(err.Error(), zap.String("filename", req.Filename))
-		return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
+		return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
 	}
 
 	// verify first if the file already exists.
@@ -264,27 +337,56 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 			zap.String("requested_file", req.Filename),
 			zap.String("shared_volume_path", fetcher.sharedVolumePath))
 		otelUtils.SpanTrackEvent(ctx, "packageAlreadyExists", otelUtils.GetAttributesForPackage(pkg)...)
-		return http.StatusOK, nil
+		return &FunctionFetchResponse{}, http.StatusOK, nil
 	}
 
 	tmpPath, err := utils.SanitizeFilePath(storePath+".tmp", fetcher.sharedVolumePath)
 	if err != nil {
 		logger.Error(err.Error(), zap.String("filename", req.Filename))
-		return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
+		return nil, http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, req)
 	}
 
-	if req.FetchType == fv1.FETCH_URL {
+	resp := &FunctionFetchResponse{}
+	if req.FetchType == fv1.FETCH_SOURCE && pkg.Spec.Source.Type == fv1.ArchiveTypeGit {
+		if pkg.Spec.Source.Git == nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("git source of package %s.%s has no repository", pkg.Name, pkg.Namespace)
+		}
+		otelUtils.SpanTrackEvent(ctx, "fetch_git", otelUtils.MapToAttributes(map[string]string{
+			"package-name":      pkg.Name,
+			"package-namespace": pkg.Namespace,
+			"git-url":           pkg.Spec.Source.Git.URL,
+			"git-ref":           pkg.Spec.Source.Git.Ref,
+		})...)
+		downloadStart := time.Now()
+		commit, err := fetcher.fetchGitSource(ctx, pkg, pkg.Spec.Source.Git, tmpPath)
+		trackPhase(ctx, PhaseDownload, downloadStart)
+		if err != nil {
+			e := "failed to fetch git source"
+			logger.Error(e, zap.Error(err), zap.String("url", pkg.Spec.Source.Git.URL), zap.String("ref", pkg.Spec.Source.Git.Ref))
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+		}
+		logger.Info("fetched git source", zap.String("url", pkg.Spec.Source.Git.URL), zap.String("commit", commit))
+		err = fetcher.verifyGitCommit(ctx, logger, pkg, commit)
+		if err != nil {
+			e := "failed to verify package signature"
+			logger.Error(e, zap.Error(err))
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+		}
+		resp.SourceCommit = commit
+	} else if req.FetchType == fv1.FETCH_URL {
 		otelUtils.SpanTrackEvent(ctx, "fetch_url", otelUtils.MapToAttributes(map[string]string{
 			"package-name":      pkg.Name,
 			"package-namespace": pkg.Namespace,
 			"fetch-url":         req.Url,
 		})...)
 		// fetch the file and save it to the tmp path
+		downloadStart := time.Now()
 		err := utils.DownloadUrl(ctx, fetcher.httpClient, req.Url, tmpPath)
+		trackPhase(ctx, PhaseDownload, downloadStart)
 		if err != nil {
 			e := "failed to download url"
 			logger.Error(e, zap.Error(err), zap.String("url", req.Url))
-			return http.StatusBadRequest, fmt.Errorf("%s: %s: %w", e, req.Url, err)
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %s: %w", e, req.Url, err)
 		}
 	} else {
 		var archive *fv1.Archive
@@ -301,11 +403,11 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 					zap.String("package_name", pkg.Name),
 					zap.String("package_namespace", pkg.Namespace),
 					zap.Any("package_build_status", pkg.Status.BuildStatus))
-				return http.StatusInternalServerError, fmt.Errorf("%s: pkg %s.%s has a status of %s", e, pkg.Name, pkg.Namespace, pkg.Status.BuildStatus)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%s: pkg %s.%s has a status of %s", e, pkg.Name, pkg.Namespace, pkg.Status.BuildStatus)
 			}
 			archive = &pkg.Spec.Deployment
 		} else {
-			return http.StatusBadRequest, fmt.Errorf("unknown fetch type: %v", req.FetchType)
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown fetch type: %v", req.FetchType)
 		}
 
 		// get package data as literal or by url
@@ -315,9 +417,30 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 			if err != nil {
 				e := "failed to write file"
 				logger.Error(e, zap.Error(err), zap.String("location", tmpPath))
-				return http.StatusInternalServerError, fmt.Errorf("%s %s: %w", e, tmpPath, err)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%s %s: %w", e, tmpPath, err)
 			}
 			otelUtils.SpanTrackEvent(ctx, "archiveLiteral", otelUtils.GetAttributesForPackage(pkg)...)
+		} else if archive.Type == fv1.ArchiveTypeOCI {
+			// the digest of the reference takes the place of the checksum
+			otelUtils.SpanTrackEvent(ctx, "pullArchiveOCI", otelUtils.MapToAttributes(map[string]string{
+				"package-name":      pkg.Name,
+				"package-namespace": pkg.Namespace,
+				"archive-url":       archive.URL,
+			})...)
+			credential, err := fetcher.getOCICredential(ctx, pkg, archive)
+			if err != nil {
+				e := "failed to get OCI registry credentials"
+				logger.Error(e, zap.Error(err), zap.String("url", archive.URL))
+				return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+			}
+			downloadStart := time.Now()
+			err = oci.PullFile(ctx, archive.URL, tmpPath, credential)
+			trackPhase(ctx, PhaseDownload, downloadStart)
+			if err != nil {
+				e := "failed to pull OCI archive"
+				logger.Error(e, zap.Error(err), zap.String("url", archive.URL))
+				return nil, http.StatusBadRequest, fmt.Errorf("%s %s: %w", e, archive.URL, err)
+			}
 		} else {
 			// download and verify
 			otelUtils.SpanTrackEvent(ctx, "dowloadArchieveLiteral", otelUtils.MapToAttributes(map[string]string{
@@ -325,42 +448,55 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 				"package-namespace": pkg.Namespace,
 				"archive-url":       archive.URL,
 			})...)
+			downloadStart := time.Now()
 			err := utils.DownloadUrl(ctx, fetcher.httpClient, archive.URL, tmpPath)
+			trackPhase(ctx, PhaseDownload, downloadStart)
 			if err != nil {
 				e := "failed to download url"
 				logger.Error(e, zap.Error(err), zap.String("url", req.Url))
-				return http.StatusBadRequest, fmt.Errorf("%s %s: %w", e, req.Url, err)
+				return nil, http.StatusBadRequest, fmt.Errorf("%s %s: %w", e, req.Url, err)
 			}
 
 			// check file integrity only if checksum is not empty.
 			if len(archive.Checksum.Sum) > 0 {
+				checksumStart := time.Now()
 				checksum, err := utils.GetFileChecksum(tmpPath)
 				if err != nil {
 					e := "failed to get checksum"
 					logger.Error(e, zap.Error(err))
-					return http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+					return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
 				}
 				err = verifyChecksum(checksum, &archive.Checksum)
+				trackPhase(ctx, PhaseChecksum, checksumStart)
 				if err != nil {
 					e := "failed to verify checksum"
 					logger.Error(e, zap.Error(err))
-					return http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+					return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
 				}
 			}
 		}
+
+		err := fetcher.verifyArchive(ctx, logger, pkg, archive, tmpPath)
+		if err != nil {
+			e := "failed to verify package signature"
+			logger.Error(e, zap.Error(err))
+			return nil, http.StatusBadRequest, fmt.Errorf("%s: %w", e, err)
+		}
 	}
 
 	// checking if file is a zip
 	if match, _ := utils.IsZip(ctx, tmpPath); match && !req.KeepArchive {
 		// unarchive tmp file to a tmp unarchive path
 		tmpUnarchivePath := filepath.Join(fetcher.sharedVolumePath, uuid.NewString())
+		unarchiveStart := time.Now()
 		err := utils.Unarchive(ctx, tmpPath, tmpUnarchivePath)
+		trackPhase(ctx, PhaseUnarchive, unarchiveStart)
 		if err != nil {
 			logger.Error("error unarchive",
 				zap.Error(err),
 				zap.String("archive_location", tmpPath),
 				zap.String("target_location", tmpUnarchivePath))
-			return http.StatusInternalServerError, err
+			return nil, http.StatusInternalServerError, err
 		}
 
 		tmpPath = tmpUnarchivePath
@@ -373,18 +509,23 @@ func (fetcher *Fetcher) Fetch(ctx context.Context, pkg *fv1.Package, req Functio
 			zap.Error(err),
 			zap.String("original_path", tmpPath),
 			zap.String("rename_path", storePath))
-		return http.StatusInternalServerError, fmt.Errorf("error renaming file: %w", err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("error renaming file: %w", err)
 	}
 
 	otelUtils.SpanTrackEvent(ctx, "packageFetched", otelUtils.GetAttributesForPackage(pkg)...)
 	logger.Info("successfully placed", zap.String("location", storePath))
-	return http.StatusOK, nil
+	return resp, http.StatusOK, nil
 }
 
 // FetchSecretsAndCfgMaps fetches secrets and configmaps specified by user
 // It returns the HTTP code and error if any
 func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv1.SecretReference, cfgmaps []fv1.ConfigMapReference) (int, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+	defer trackPhase(ctx, PhaseSecretsConfigMaps, time.Now())
+
+	// a refresh may race with a fetch of the same secrets and configmaps
+	fetcher.cmsLock.Lock()
+	defer fetcher.cmsLock.Unlock()
 
 	if len(secrets) > 0 {
 		for _, secret := range secrets {
@@ -412,7 +553,7 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 				return http.StatusBadRequest, fmt.Errorf("%s, request: %v", err, secret)
 			}
 
-			err = os.MkdirAll(secretDir, os.ModeDir|0750)
+			err = os.MkdirAll(filepath.Dir(secretDir), os.ModeDir|0750)
 			if err != nil {
 				e := "failed to create directory for secret"
 				logger.Error(e,
@@ -465,7 +606,7 @@ func (fetcher *Fetcher) FetchSecretsAndCfgMaps(ctx context.Context, secrets []fv
 					config)
 			}
 
-			err = os.MkdirAll(configDir, os.ModeDir|0750)
+			err = os.MkdirAll(filepath.Dir(configDir), os.ModeDir|0750)
 			if err != nil {
 				e := "failed to create directory for configmap"
 				logger.Error(e,
@@ -571,9 +712,22 @@ func (fetcher *Fetcher) UploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info("starting upload...")
-	ssClient := storageSvcClient.MakeClient(req.StorageSvcUrl)
+	ssClient, err := storageSvcClient.MakeAuthenticatedClient(req.StorageSvcUrl, fetcher.restConfig)
+	if err != nil {
+		e := "error creating storage service client"
+		logger.Error(e, zap.Error(err))
+		http.Error(w, fmt.Sprintf("%s: %v", e, err), http.StatusInternalServerError)
+		return
+	}
 
-	fileID, err := ssClient.Upload(ctx, dstFilepath, nil)
+	uploader, err := os.Hostname()
+	if err != nil {
+		uploader = "fetcher"
+	}
+	fileID, err := ssClient.Upload(ctx, dstFilepath, &map[string]string{
+		storagesvc.MetadataUploader:  uploader,
+		storagesvc.MetadataNamespace: req.Namespace,
+	})
 	if err != nil {
 		e := "error uploading zip file"
 		logger.Error(e, zap.Error(err), zap.String("file", dstFilepath))
@@ -589,8 +743,18 @@ func (fetcher *Fetcher) UploadHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the signed URL lets the fetchers of the package download the
+	// archive without credentials.
+	downloadURL, err := ssClient.GetSignedUrl(ctx, fileID)
+	if err != nil {
+		e := "error getting download url of zip file"
+		logger.Error(e, zap.Error(err), zap.String("file", dstFilepath))
+		http.Error(w, fmt.Sprintf("%s: %v", e, err), http.StatusInternalServerError)
+		return
+	}
+
 	resp := ArchiveUploadResponse{
-		ArchiveDownloadUrl: ssClient.GetUrl(fileID),
+		ArchiveDownloadUrl: downloadURL,
 		Checksum:           *sum,
 	}
 
@@ -663,27 +827,31 @@ func (fetcher *Fetcher) getPkgInformation(ctx context.Context, req FunctionFetch
 	return nil, err
 }
 
-func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) error {
+// SpecializePod fetches the function package, secrets and configmaps and
+// loads the function into the environment container. The returned response
+// holds the time spent in each specialization phase.
+func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) (*FunctionSpecializeResponse, error) {
 	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+	ctx, timer := withPhaseTimer(ctx)
 	startTime := time.Now()
 	defer func() {
 		elapsed := time.Since(startTime)
-		logger.Info("specialize request done", zap.Duration("elapsed_time", elapsed))
+		logger.Info("specialize request done", zap.Duration("elapsed_time", elapsed), zap.Any("phases", timer.snapshot()))
 	}()
 
 	pkg, err := fetcher.getPkgInformation(ctx, fetchReq)
 	if err != nil {
-		return fmt.Errorf("error getting package information: %w", err)
+		return nil, fmt.Errorf("error getting package information: %w", err)
 	}
 
-	_, err = fetcher.Fetch(ctx, pkg, fetchReq)
+	_, _, err = fetcher.Fetch(ctx, pkg, fetchReq)
 	if err != nil {
-		return fmt.Errorf("error fetching deploy package: %w", err)
+		return nil, fmt.Errorf("error fetching deploy package: %w", err)
 	}
 
 	_, err = fetcher.FetchSecretsAndCfgMaps(ctx, fetchReq.Secrets, fetchReq.ConfigMaps)
 	if err != nil {
-		return fmt.Errorf("error fetching secrets/configs: %w", err)
+		return nil, fmt.Errorf("error fetching secrets/configs: %w", err)
 	}
 
 	// Specialize the pod
@@ -695,7 +863,7 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 
 	loadPayload, err := json.Marshal(loadReq)
 	if err != nil {
-		return fmt.Errorf("error encoding load request: %w", err)
+		return nil, fmt.Errorf("error encoding load request: %w", err)
 	}
 
 	// Instead of using "localhost", here we use "127.0.0.1" for
@@ -713,6 +881,7 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 		logger.Info("calling environment v1 specialization endpoint")
 	}
 
+	specializeStart := time.Now()
 	for i := 0; i < maxRetries; i++ {
 		otelUtils.SpanTrackEvent(ctx, "specializeCall", otelUtils.MapToAttributes(map[string]string{
 			"url": specializeURL,
@@ -721,7 +890,8 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 		if err == nil && resp.StatusCode < 300 {
 			// Success
 			resp.Body.Close()
-			return nil
+			trackPhase(ctx, PhaseSpecialize, specializeStart)
+			return &FunctionSpecializeResponse{PhaseDurations: timer.snapshot()}, nil
 		}
 
 		netErr := network.Adapter(err)
@@ -739,10 +909,12 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 			err = ferror.MakeErrorFromHTTP(resp)
 		}
 
-		return fmt.Errorf("error specializing function pod: %w", err)
+		trackPhase(ctx, PhaseSpecialize, specializeStart)
+		return nil, fmt.Errorf("error specializing function pod: %w", err)
 	}
 
-	return fmt.Errorf("error specializing function pod after %v times: %w", maxRetries, err)
+	trackPhase(ctx, PhaseSpecialize, specializeStart)
+	return nil, fmt.Errorf("error specializing function pod after %v times: %w", maxRetries, err)
 }
 
 // WsStartHandler is used to generate websocket events in Kubernetes
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+)
+
+// Keys of the secret holding the credentials of a Git repository.
+const (
+	GitSecretUsername      = "username"
+	GitSecretPassword      = "password"
+	GitSecretSSHPrivateKey = "ssh-privatekey"
+	GitSecretKnownHosts    = "known_hosts"
+)
+
+// fetchGitSource clones the Git source of a package at its reference and
+// places the source directory at path. It returns the SHA of the commit
+// checked out.
+func (fetcher *Fetcher) fetchGitSource(ctx context.Context, pkg *fv1.Package, src *fv1.GitSource, path string) (string, error) {
+	auth, cleanup, err := fetcher.getGitAuth(ctx, pkg.ObjectMeta.Namespace, src)
+	defer cleanup()
+	if err != nil {
+		return "", err
+	}
+
+	cloneDir := path + ".clone"
+	defer os.RemoveAll(cloneDir)
+
+	cloneOpts := &git.CloneOptions{
+		URL:        src.URL,
+		Auth:       auth,
+		NoCheckout: true,
+	}
+	if len(src.Ref) == 0 {
+		// the default branch is all we need
+		cloneOpts.Depth = 1
+		cloneOpts.SingleBranch = true
+	}
+	repo, err := git.PlainCloneContext(ctx, cloneDir, false, cloneOpts)
+	if err != nil {
+		return "", fmt.Errorf("error cloning git repository %s: %w", src.URL, err)
+	}
+
+	hash, err := resolveGitRef(repo, src.Ref)
+	if err != nil {
+		return "", fmt.Errorf("error resolving reference %q of git repository %s: %w", src.Ref, src.URL, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: hash, Force: true})
+	if err != nil {
+		return "", fmt.Errorf("error checking out commit %s of git repository %s: %w", hash, src.URL, err)
+	}
+
+	// the repository metadata is not part of the source package
+	err = os.RemoveAll(filepath.Join(cloneDir, git.GitDirName))
+	if err != nil {
+		return "", err
+	}
+
+	srcDir := cloneDir
+	if len(src.SubPath) > 0 {
+		srcDir, err = utils.SanitizeFilePath(filepath.Join(cloneDir, src.SubPath), cloneDir)
+		if err != nil {
+			return "", err
+		}
+		fi, err := os.Stat(srcDir)
+		if err != nil || !fi.IsDir() {
+			return "", fmt.Errorf("directory %q not found in git repository %s at %s", src.SubPath, src.URL, hash)
+		}
+	}
+
+	err = os.Rename(srcDir, path)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// resolveGitRef resolves a branch, tag or commit SHA of a cloned repository
+// to a commit. An empty reference is the default branch.
+func resolveGitRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	if len(ref) == 0 {
+		head, err := repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return head.Hash(), nil
+	}
+
+	var err error
+	// branches other than the default one only exist as remote branches
+	for _, rev := range []string{ref, fmt.Sprintf("%s/%s", git.DefaultRemoteName, ref)} {
+		var hash *plumbing.Hash
+		hash, err = repo.ResolveRevision(plumbing.Revision(rev))
+		if err == nil {
+			return *hash, nil
+		}
+	}
+	return plumbing.ZeroHash, err
+}
+
+// getGitAuth returns the credentials of a Git repository from its secret.
+// The returned function removes the temporary files holding them.
+func (fetcher *Fetcher) getGitAuth(ctx context.Context, namespace string, src *fv1.GitSource) (transport.AuthMethod, func(), error) {
+	cleanup := func() {}
+	if len(src.Secret) == 0 {
+		return nil, cleanup, nil
+	}
+
+	secret, err := fetcher.kubeClient.CoreV1().Secrets(namespace).Get(ctx, src.Secret, metav1.GetOptions{})
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("error getting git credentials secret %s.%s: %w", src.Secret, namespace, err)
+	}
+
+	username := string(secret.Data[GitSecretUsername])
+	if len(username) == 0 {
+		// hosting services accept any user name along with an access token
+		username = "git"
+	}
+
+	if key, ok := secret.Data[GitSecretSSHPrivateKey]; ok {
+		knownHosts, ok := secret.Data[GitSecretKnownHosts]
+		if !ok {
+			return nil, cleanup, fmt.Errorf("git credentials secret %s.%s has no %q to verify the SSH host key", src.Secret, namespace, GitSecretKnownHosts)
+		}
+		auth, err := gitssh.NewPublicKeys(username, key, "")
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("error parsing SSH private key of secret %s.%s: %w", src.Secret, namespace, err)
+		}
+
+		f, err := os.CreateTemp("", "known_hosts")
+		if err != nil {
+			return nil, cleanup, err
+		}
+		cleanup = func() { os.Remove(f.Name()) }
+		_, err = f.Write(knownHosts)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, cleanup, err
+		}
+		auth.HostKeyCallback, err = gitssh.NewKnownHostsCallback(f.Name())
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("error parsing known hosts of secret %s.%s: %w", src.Secret, namespace, err)
+		}
+		return auth, cleanup, nil
+	}
+
+	if password, ok := secret.Data[GitSecretPassword]; ok {
+		return &githttp.BasicAuth{
+			Username: username,
+			Password: string(password),
+		}, cleanup, nil
+	}
+
+	return nil, cleanup, errors.New("git credentials secret must contain either \"ssh-privatekey\" or \"password\"")
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// commitFile writes a file to the worktree of the repository and commits it.
+func commitFile(t *testing.T, repo *git.Repository, dir, name, content string) plumbing.Hash {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	require.NoError(t, err)
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add(name)
+	require.NoError(t, err)
+	hash, err := worktree.Commit("update "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: "fission", Email: "fission@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func TestFetchGitSource(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+
+	first := commitFile(t, repo, repoDir, "hello/hello.js", "module.exports = 1")
+	_, err = repo.CreateTag("v1", first, nil)
+	require.NoError(t, err)
+
+	// a branch other than the default one
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true})
+	require.NoError(t, err)
+	feature := commitFile(t, repo, repoDir, "hello/hello.js", "module.exports = 2")
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: head.Name()})
+	require.NoError(t, err)
+	latest := commitFile(t, repo, repoDir, "hello/hello.js", "module.exports = 3")
+
+	kubeClient := fake.NewSimpleClientset()
+	f := &Fetcher{
+		logger:     loggerfactory.GetLogger(),
+		kubeClient: kubeClient,
+	}
+	pkg := &fv1.Package{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault}}
+
+	for _, test := range []struct {
+		name    string
+		src     fv1.GitSource
+		commit  plumbing.Hash
+		content string
+		err     bool
+	}{
+		{name: "default branch", src: fv1.GitSource{}, commit: latest, content: "module.exports = 3"},
+		{name: "branch", src: fv1.GitSource{Ref: "feature"}, commit: feature, content: "module.exports = 2"},
+		{name: "tag", src: fv1.GitSource{Ref: "v1"}, commit: first, content: "module.exports = 1"},
+		{name: "commit", src: fv1.GitSource{Ref: first.String()}, commit: first, content: "module.exports = 1"},
+		{name: "unknown ref", src: fv1.GitSource{Ref: "missing"}, err: true},
+		{name: "subpath outside of repository", src: fv1.GitSource{SubPath: "../.."}, err: true},
+		{name: "missing subpath", src: fv1.GitSource{SubPath: "world"}, err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			src := test.src
+			src.URL = repoDir
+			dest := filepath.Join(t.TempDir(), "src")
+
+			commit, err := f.fetchGitSource(t.Context(), pkg, &src, dest)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.commit.String(), commit)
+
+			content, err := os.ReadFile(filepath.Join(dest, "hello", "hello.js"))
+			require.NoError(t, err)
+			require.Equal(t, test.content, string(content))
+			require.NoDirExists(t, filepath.Join(dest, git.GitDirName))
+			require.NoDirExists(t, dest+".clone")
+		})
+	}
+
+	t.Run("subpath", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "src")
+		_, err := f.fetchGitSource(t.Context(), pkg, &fv1.GitSource{URL: repoDir, SubPath: "hello"}, dest)
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(dest, "hello.js"))
+	})
+
+	t.Run("remote branch of a bare repository", func(t *testing.T) {
+		bareDir := t.TempDir()
+		_, err := git.PlainClone(bareDir, true, &git.CloneOptions{URL: repoDir})
+		require.NoError(t, err)
+		remote, err := repo.CreateRemote(&config.RemoteConfig{Name: "bare", URLs: []string{bareDir}})
+		require.NoError(t, err)
+		err = remote.Push(&git.PushOptions{RemoteName: "bare", RefSpecs: []config.RefSpec{"refs/heads/feature:refs/heads/feature"}})
+		require.NoError(t, err)
+
+		dest := filepath.Join(t.TempDir(), "src")
+		commit, err := f.fetchGitSource(t.Context(), pkg, &fv1.GitSource{URL: "file://" + bareDir, Ref: "feature"}, dest)
+		require.NoError(t, err)
+		require.Equal(t, feature.String(), commit)
+	})
+
+	t.Run("credentials secret", func(t *testing.T) {
+		_, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(t.Context(), &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: metav1.NamespaceDefault},
+			Data:       map[string][]byte{GitSecretSSHPrivateKey: []byte("key")},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		_, _, err = f.getGitAuth(t.Context(), metav1.NamespaceDefault, &fv1.GitSource{URL: repoDir, Secret: "git-creds"})
+		require.ErrorContains(t, err, GitSecretKnownHosts)
+	})
+}
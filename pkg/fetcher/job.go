@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context/ctxhttp"
+
+	ferror "github.com/fission/fission/pkg/error"
+)
+
+const (
+	// functionURL is the address the runtime of a specialized pod
+	// serves the function at.
+	functionURL = "http://127.0.0.1:8888/"
+
+	// jobResultPath is the termination log of the fetcher container,
+	// which the job executor reads the result of a job from.
+	jobResultPath = "/dev/termination-log"
+
+	// maxJobResultSize is the size limit of termination messages.
+	maxJobResultSize = 4096
+)
+
+// InvokeJob calls the function of a specialized job pod once, with the
+// content of the file at inputPath as request body, and writes the response
+// to the termination log as the result of the job. An error is returned if
+// the function fails, so that the job does too.
+func (fetcher *Fetcher) InvokeJob(ctx context.Context, inputPath string) error {
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("error opening job input: %w", err)
+	}
+	defer input.Close()
+
+	resp, err := ctxhttp.Post(ctx, fetcher.httpClient, functionURL, "application/octet-stream", input)
+	if err != nil {
+		return fmt.Errorf("error calling function: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		err = ferror.MakeErrorFromHTTP(resp)
+		writeJobResult(fetcher.logger, []byte(err.Error()))
+		return fmt.Errorf("function failed: %w", err)
+	}
+
+	result, err := io.ReadAll(io.LimitReader(resp.Body, maxJobResultSize))
+	if err != nil {
+		return fmt.Errorf("error reading function response: %w", err)
+	}
+	writeJobResult(fetcher.logger, result)
+	return nil
+}
+
+func writeJobResult(logger *zap.Logger, result []byte) {
+	err := os.WriteFile(jobResultPath, result, 0644)
+	if err != nil {
+		logger.Error("error writing job result", zap.Error(err))
+	}
+}
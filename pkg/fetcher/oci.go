@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/oci"
+)
+
+// getOCICredential returns the registry credentials of an OCI archive from
+// the image pull secret of the archive, or else of the environment of the
+// package. Without a secret, the fetcher falls back to its docker config.
+func (fetcher *Fetcher) getOCICredential(ctx context.Context, pkg *fv1.Package, archive *fv1.Archive) (auth.CredentialFunc, error) {
+	name, namespace := archive.RegistrySecret, pkg.Namespace
+	if len(name) == 0 {
+		envNamespace := pkg.Spec.Environment.Namespace
+		if len(envNamespace) == 0 {
+			envNamespace = pkg.Namespace
+		}
+		env, err := fetcher.fissionClient.CoreV1().Environments(envNamespace).Get(ctx, pkg.Spec.Environment.Name, metav1.GetOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error getting environment %s.%s: %w", pkg.Spec.Environment.Name, envNamespace, err)
+		}
+		if err == nil {
+			name, namespace = env.Spec.ImagePullSecret, envNamespace
+		}
+	}
+	if len(name) == 0 {
+		return nil, nil
+	}
+
+	secret, err := fetcher.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting registry secret %s.%s: %w", name, namespace, err)
+	}
+	config, ok := secret.Data[apiv1.DockerConfigJsonKey]
+	if !ok {
+		config, ok = secret.Data[apiv1.DockerConfigKey]
+	}
+	if !ok {
+		return nil, fmt.Errorf("registry secret %s.%s has no %q", name, namespace, apiv1.DockerConfigJsonKey)
+	}
+	credential, err := oci.DockerConfigCredential(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry secret %s.%s: %w", name, namespace, err)
+	}
+	return credential, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestGetOCICredential(t *testing.T) {
+	registrySecret := func(name, namespace, user string) *apiv1.Secret {
+		return &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Type:       apiv1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				apiv1.DockerConfigJsonKey: []byte(`{"auths": {"registry.example.com": {"username": "` + user + `", "password": "pass"}}}`),
+			},
+		}
+	}
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: "envs"},
+		Spec:       fv1.EnvironmentSpec{ImagePullSecret: "env-registry"},
+	}
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: "envs"},
+		},
+	}
+	f := &Fetcher{
+		logger: loggerfactory.GetLogger(),
+		kubeClient: fake.NewSimpleClientset(
+			registrySecret("pkg-registry", metav1.NamespaceDefault, "pkg"),
+			registrySecret("env-registry", "envs", "env"),
+		),
+		fissionClient: fissionfake.NewSimpleClientset(env),
+	}
+
+	for _, test := range []struct {
+		name     string
+		archive  fv1.Archive
+		username string
+		err      bool
+	}{
+		{
+			name:     "archive secret",
+			archive:  fv1.Archive{Type: fv1.ArchiveTypeOCI, RegistrySecret: "pkg-registry"},
+			username: "pkg",
+		},
+		{
+			name:     "environment secret",
+			archive:  fv1.Archive{Type: fv1.ArchiveTypeOCI},
+			username: "env",
+		},
+		{
+			name:    "missing secret",
+			archive: fv1.Archive{Type: fv1.ArchiveTypeOCI, RegistrySecret: "missing"},
+			err:     true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			credential, err := f.getOCICredential(t.Context(), pkg, &test.archive)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			cred, err := credential(t.Context(), "registry.example.com")
+			require.NoError(t, err)
+			require.Equal(t, auth.Credential{Username: test.username, Password: "pass"}, cred)
+		})
+	}
+
+	// without any secret the docker config of the fetcher is used
+	f.fissionClient = fissionfake.NewSimpleClientset()
+	credential, err := f.getOCICredential(t.Context(), pkg, &fv1.Archive{Type: fv1.ArchiveTypeOCI})
+	require.NoError(t, err)
+	require.Nil(t, credential)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+type (
+	// phaseTimer collects the time spent in each phase of a specialization.
+	// The fetcher runs as a sidecar and isn't scraped by Prometheus, so the
+	// durations are sent back to the executor which records the metrics.
+	phaseTimer struct {
+		lock      sync.Mutex
+		durations map[string]time.Duration
+	}
+
+	phaseTimerKey struct{}
+)
+
+func withPhaseTimer(ctx context.Context) (context.Context, *phaseTimer) {
+	timer := &phaseTimer{durations: make(map[string]time.Duration)}
+	return context.WithValue(ctx, phaseTimerKey{}, timer), timer
+}
+
+// trackPhase records the time elapsed since start for the phase on the
+// timer of the context, if any, and adds a matching span event.
+func trackPhase(ctx context.Context, phase string, start time.Time) {
+	elapsed := time.Since(start)
+	otelUtils.SpanTrackEvent(ctx, phase+"Done", attribute.Int64("duration_ms", elapsed.Milliseconds()))
+
+	timer, ok := ctx.Value(phaseTimerKey{}).(*phaseTimer)
+	if !ok {
+		return
+	}
+	timer.lock.Lock()
+	defer timer.lock.Unlock()
+	timer.durations[phase] += elapsed
+}
+
+func (timer *phaseTimer) snapshot() map[string]time.Duration {
+	timer.lock.Lock()
+	defer timer.lock.Unlock()
+	durations := make(map[string]time.Duration, len(timer.durations))
+	for phase, d := range timer.durations {
+		durations[phase] = d
+	}
+	return durations
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context/ctxhttp"
+
+	ferror "github.com/fission/fission/pkg/error"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+const reloadURL = "http://127.0.0.1:8888/v2/reload"
+
+// RefreshHandler fetches the secrets and configmaps of a specialized pod
+// again, so that their changes reach the function without restarting the
+// pod. The files under the secret and config volumes are swapped atomically.
+func (fetcher *Fetcher) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != "POST" {
+		http.Error(w, fmt.Sprintf("only POST is supported on this endpoint, %v received", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	var req FunctionRefreshRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		logger.Error("error parsing request body", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, code, err := fetcher.RefreshPod(ctx, req)
+	if err != nil {
+		logger.Error("error refreshing pod", zap.Error(err))
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	logger.Info("pod refreshed", zap.Bool("reloaded", resp.Reloaded))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		logger.Error("error encoding refresh response", zap.Error(err))
+	}
+}
+
+// RefreshPod fetches the secrets and configmaps of the request, then asks the
+// environment to reload them if requested. Environments without a reload
+// endpoint read the new files the next time the function opens them.
+// It returns the refresh response, the HTTP code and error if any
+func (fetcher *Fetcher) RefreshPod(ctx context.Context, req FunctionRefreshRequest) (*FunctionRefreshResponse, int, error) {
+	code, err := fetcher.FetchSecretsAndCfgMaps(ctx, req.Secrets, req.ConfigMaps)
+	if err != nil {
+		return nil, code, err
+	}
+	if !req.Reload {
+		return &FunctionRefreshResponse{}, http.StatusOK, nil
+	}
+
+	otelUtils.SpanTrackEvent(ctx, "reloadCall", otelUtils.MapToAttributes(map[string]string{
+		"url": reloadURL,
+	})...)
+	resp, err := ctxhttp.Post(ctx, fetcher.httpClient, reloadURL, "application/json", nil)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error calling environment reload endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return &FunctionRefreshResponse{}, http.StatusOK, nil
+	}
+	if resp.StatusCode >= 300 {
+		return nil, http.StatusInternalServerError, fmt.Errorf("environment failed to reload: %w", ferror.MakeErrorFromHTTP(resp))
+	}
+	return &FunctionRefreshResponse{Reloaded: true}, http.StatusOK, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestWriteSecretOrConfigMap(t *testing.T) {
+	parent := t.TempDir()
+	dirPath := filepath.Join(parent, "creds")
+
+	// directories written by older fetchers are replaced
+	require.NoError(t, os.MkdirAll(dirPath, 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dirPath, "stale"), []byte("stale"), 0750))
+
+	require.NoError(t, writeSecretOrConfigMap(map[string][]byte{"user": []byte("alice"), "password": []byte("one")}, dirPath))
+	require.NoFileExists(t, filepath.Join(dirPath, "stale"))
+	data, err := os.ReadFile(filepath.Join(dirPath, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "one", string(data))
+	first, err := os.Readlink(dirPath)
+	require.NoError(t, err)
+
+	require.NoError(t, writeSecretOrConfigMap(map[string][]byte{"password": []byte("two")}, dirPath))
+	data, err = os.ReadFile(filepath.Join(dirPath, "password"))
+	require.NoError(t, err)
+	require.Equal(t, "two", string(data))
+	require.NoFileExists(t, filepath.Join(dirPath, "user"))
+	second, err := os.Readlink(dirPath)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	// the previous data is removed once swapped
+	entries, err := os.ReadDir(parent)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.ElementsMatch(t, []string{"creds", second}, names)
+}
+
+func TestRefreshHandler(t *testing.T) {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"password": []byte("one")},
+	}
+	kubeClient := fake.NewClientset(secret)
+	secretPath := t.TempDir()
+	f := &Fetcher{
+		logger:           loggerfactory.GetLogger(),
+		sharedSecretPath: secretPath,
+		sharedConfigPath: t.TempDir(),
+		kubeClient:       kubeClient,
+		httpClient:       http.DefaultClient,
+	}
+	server := httptest.NewServer(http.HandlerFunc(f.RefreshHandler))
+	t.Cleanup(server.Close)
+
+	refresh := func(req FunctionRefreshRequest) *http.Response {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	req := FunctionRefreshRequest{
+		Secrets: []fv1.SecretReference{{Name: "creds", Namespace: metav1.NamespaceDefault}},
+	}
+	passwordPath := filepath.Join(secretPath, metav1.NamespaceDefault, "creds", "password")
+
+	require.Equal(t, http.StatusOK, refresh(req).StatusCode)
+	data, err := os.ReadFile(passwordPath)
+	require.NoError(t, err)
+	require.Equal(t, "one", string(data))
+
+	secret.Data["password"] = []byte("two")
+	_, err = kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Update(t.Context(), secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	resp := refresh(req)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var refreshResp FunctionRefreshResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&refreshResp))
+	require.False(t, refreshResp.Reloaded)
+	data, err = os.ReadFile(passwordPath)
+	require.NoError(t, err)
+	require.Equal(t, "two", string(data))
+
+	req.Secrets = []fv1.SecretReference{{Name: "missing", Namespace: metav1.NamespaceDefault}}
+	require.Equal(t, http.StatusNotFound, refresh(req).StatusCode)
+}
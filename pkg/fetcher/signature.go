@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"crypto"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/signature"
+)
+
+// packageVerification is a signature verification policy of a package and
+// its trusted keys.
+type packageVerification struct {
+	policy fv1.VerificationPolicy
+	keys   []crypto.PublicKey
+	// source is where the policy is configured, for logs and errors
+	source string
+}
+
+// getPackageVerifications returns the signature verification policies of
+// a package: the one of the package namespace and the one of the
+// environment of the package. Packages must pass both, so that the policy
+// of an environment can't weaken the one of the namespace.
+func (fetcher *Fetcher) getPackageVerifications(ctx context.Context, pkg *fv1.Package) ([]packageVerification, error) {
+	var verifications []packageVerification
+
+	cm, err := fetcher.kubeClient.CoreV1().ConfigMaps(pkg.Namespace).Get(ctx, fv1.PackageVerificationConfigMap, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting configmap %s.%s: %w", fv1.PackageVerificationConfigMap, pkg.Namespace, err)
+	}
+	if err == nil {
+		policy := fv1.VerificationPolicy(cm.Data[fv1.PackageVerificationPolicyKey])
+		switch policy {
+		case "":
+			policy = fv1.VerificationPolicyEnforce
+		case fv1.VerificationPolicyEnforce, fv1.VerificationPolicyWarn:
+		default:
+			return nil, fmt.Errorf("invalid verification policy %q in configmap %s.%s", policy, cm.Name, cm.Namespace)
+		}
+		keys, err := parseTrustedKeys(cm.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing trusted keys of configmap %s.%s: %w", cm.Name, cm.Namespace, err)
+		}
+		verifications = append(verifications, packageVerification{
+			policy: policy,
+			keys:   keys,
+			source: fmt.Sprintf("namespace %s", pkg.Namespace),
+		})
+	}
+
+	envNamespace := pkg.Spec.Environment.Namespace
+	if len(envNamespace) == 0 {
+		envNamespace = pkg.Namespace
+	}
+	env, err := fetcher.fissionClient.CoreV1().Environments(envNamespace).Get(ctx, pkg.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting environment %s.%s: %w", pkg.Spec.Environment.Name, envNamespace, err)
+	}
+	if err == nil && env.Spec.PackageVerification != nil {
+		keys, err := fetcher.getTrustedKeys(ctx, envNamespace, env.Spec.PackageVerification.TrustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, packageVerification{
+			policy: env.Spec.PackageVerification.Policy,
+			keys:   keys,
+			source: fmt.Sprintf("environment %s.%s", env.Name, envNamespace),
+		})
+	}
+	return verifications, nil
+}
+
+func (fetcher *Fetcher) getTrustedKeys(ctx context.Context, namespace, name string) ([]crypto.PublicKey, error) {
+	cm, err := fetcher.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting trusted keys configmap %s.%s: %w", name, namespace, err)
+	}
+	keys, err := parseTrustedKeys(cm.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing trusted keys of configmap %s.%s: %w", name, namespace, err)
+	}
+	return keys, nil
+}
+
+func parseTrustedKeys(data map[string]string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for k, v := range data {
+		if k == fv1.PackageVerificationPolicyKey {
+			continue
+		}
+		parsed, err := signature.ParsePublicKeys([]byte(v))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		keys = append(keys, parsed...)
+	}
+	return keys, nil
+}
+
+// verifyArchive verifies the signature of the archive file of a package
+// at path, according to the verification policies of the package.
+func (fetcher *Fetcher) verifyArchive(ctx context.Context, logger *zap.Logger, pkg *fv1.Package, archive *fv1.Archive, path string) error {
+	return fetcher.verifySignature(ctx, logger, pkg, archive.Signature, func() ([]byte, error) {
+		checksum, err := utils.GetFileChecksum(path)
+		if err != nil {
+			return nil, err
+		}
+		return hex.DecodeString(checksum.Sum)
+	})
+}
+
+// verifyGitCommit verifies the signature of the commit fetched from the git
+// source of a package, according to the verification policies of the
+// package.
+func (fetcher *Fetcher) verifyGitCommit(ctx context.Context, logger *zap.Logger, pkg *fv1.Package, commit string) error {
+	return fetcher.verifySignature(ctx, logger, pkg, pkg.Spec.Source.Signature, func() ([]byte, error) {
+		return signature.GitCommitDigest(commit), nil
+	})
+}
+
+// verifySignature verifies the signature of the digest of a package source
+// or deployment. The digest is only computed if the package is verified.
+func (fetcher *Fetcher) verifySignature(ctx context.Context, logger *zap.Logger, pkg *fv1.Package, sig *fv1.ArchiveSignature, digest func() ([]byte, error)) error {
+	defer trackPhase(ctx, PhaseChecksum, time.Now())
+
+	verifications, err := fetcher.getPackageVerifications(ctx, pkg)
+	if err != nil {
+		return err
+	}
+	if len(verifications) == 0 {
+		return nil
+	}
+
+	d, err := digest()
+	if err != nil {
+		return err
+	}
+	for _, v := range verifications {
+		keyID, err := signature.Verify(v.keys, d, sig)
+		if err != nil {
+			if v.policy == fv1.VerificationPolicyWarn {
+				logger.Warn("package signature verification failed",
+					zap.String("package_name", pkg.Name),
+					zap.String("package_namespace", pkg.Namespace),
+					zap.String("policy_of", v.source),
+					zap.Error(err))
+				continue
+			}
+			return fmt.Errorf("package %s.%s failed signature verification of %s: %w", pkg.Name, pkg.Namespace, v.source, err)
+		}
+		logger.Info("verified package signature",
+			zap.String("package_name", pkg.Name),
+			zap.String("package_namespace", pkg.Namespace),
+			zap.String("policy_of", v.source),
+			zap.String("key_id", keyID))
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+	"github.com/fission/fission/pkg/utils/signature"
+)
+
+func TestVerifyArchive(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	trustedKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	path := filepath.Join(t.TempDir(), "archive.zip")
+	err = os.WriteFile(path, []byte("archive"), 0644)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("archive"))
+	sig, err := signature.Sign(key, digest[:])
+	require.NoError(t, err)
+
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+		},
+	}
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+	}
+	verificationConfig := func(name string, data map[string]string) *apiv1.ConfigMap {
+		return &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Data:       data,
+		}
+	}
+
+	for _, test := range []struct {
+		name         string
+		verification *fv1.PackageVerification
+		configMaps   []*apiv1.ConfigMap
+		signature    *fv1.ArchiveSignature
+		err          bool
+	}{
+		{
+			name:      "no verification",
+			signature: nil,
+		},
+		{
+			name: "namespace enforces signed package",
+			configMaps: []*apiv1.ConfigMap{verificationConfig(fv1.PackageVerificationConfigMap, map[string]string{
+				"signer.pem": trustedKey,
+			})},
+			signature: sig,
+		},
+		{
+			name: "namespace enforces unsigned package",
+			configMaps: []*apiv1.ConfigMap{verificationConfig(fv1.PackageVerificationConfigMap, map[string]string{
+				"signer.pem": trustedKey,
+			})},
+			err: true,
+		},
+		{
+			name: "namespace warns about unsigned package",
+			configMaps: []*apiv1.ConfigMap{verificationConfig(fv1.PackageVerificationConfigMap, map[string]string{
+				fv1.PackageVerificationPolicyKey: string(fv1.VerificationPolicyWarn),
+				"signer.pem":                     trustedKey,
+			})},
+		},
+		{
+			name: "environment enforces when namespace warns",
+			verification: &fv1.PackageVerification{
+				Policy:      fv1.VerificationPolicyEnforce,
+				TrustedKeys: "nodejs-keys",
+			},
+			configMaps: []*apiv1.ConfigMap{
+				verificationConfig(fv1.PackageVerificationConfigMap, map[string]string{
+					fv1.PackageVerificationPolicyKey: string(fv1.VerificationPolicyWarn),
+					"signer.pem":                     trustedKey,
+				}),
+				verificationConfig("nodejs-keys", map[string]string{}),
+			},
+			signature: sig,
+			err:       true,
+		},
+		{
+			name: "namespace enforces when environment warns",
+			verification: &fv1.PackageVerification{
+				Policy:      fv1.VerificationPolicyWarn,
+				TrustedKeys: "nodejs-keys",
+			},
+			configMaps: []*apiv1.ConfigMap{
+				verificationConfig(fv1.PackageVerificationConfigMap, map[string]string{
+					"signer.pem": trustedKey,
+				}),
+				verificationConfig("nodejs-keys", map[string]string{
+					"signer.pem": trustedKey,
+				}),
+			},
+			err: true,
+		},
+		{
+			name: "missing trusted keys of environment",
+			verification: &fv1.PackageVerification{
+				Policy:      fv1.VerificationPolicyWarn,
+				TrustedKeys: "nodejs-keys",
+			},
+			signature: sig,
+			err:       true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			env := env.DeepCopy()
+			env.Spec.PackageVerification = test.verification
+			kubeClient := fake.NewSimpleClientset()
+			for _, cm := range test.configMaps {
+				_, err := kubeClient.CoreV1().ConfigMaps(cm.Namespace).Create(t.Context(), cm, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			f := &Fetcher{
+				logger:        loggerfactory.GetLogger(),
+				kubeClient:    kubeClient,
+				fissionClient: fissionfake.NewSimpleClientset(env),
+			}
+
+			err := f.verifyArchive(t.Context(), f.logger, pkg, &fv1.Archive{Signature: test.signature}, path)
+			if test.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyGitCommit(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	commit := "9fceb02d0ae598e95dc970b74767f19372d61af8"
+	sig, err := signature.Sign(key, signature.GitCommitDigest(commit))
+	require.NoError(t, err)
+
+	pkg := &fv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+			Source: fv1.Archive{
+				Type:      fv1.ArchiveTypeGit,
+				Git:       &fv1.GitSource{URL: "https://example.com/hello.git", Ref: commit},
+				Signature: sig,
+			},
+		},
+	}
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: fv1.PackageVerificationConfigMap, Namespace: metav1.NamespaceDefault},
+		Data: map[string]string{
+			"signer.pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+	}
+	f := &Fetcher{
+		logger:        loggerfactory.GetLogger(),
+		kubeClient:    fake.NewSimpleClientset(cm),
+		fissionClient: fissionfake.NewSimpleClientset(),
+	}
+
+	require.NoError(t, f.verifyGitCommit(t.Context(), f.logger, pkg, commit))
+	require.Error(t, f.verifyGitCommit(t.Context(), f.logger, pkg, "1c002dd4b536e7479fe34593e72e6c6c1819e53b"))
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package fetcher
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -33,6 +35,14 @@ type (
 		LoadReq  FunctionLoadRequest
 	}
 
+	// FunctionSpecializeResponse is returned by the fetcher once a pod
+	// has been specialized.
+	FunctionSpecializeResponse struct {
+		// PhaseDurations is the time spent in each specialization
+		// phase, keyed by phase name.
+		PhaseDurations map[string]time.Duration `json:"phaseDurations"`
+	}
+
 	FunctionFetchRequest struct {
 		FetchType     FetchRequestType         `json:"fetchType"`
 		Package       metav1.ObjectMeta        `json:"package"`
@@ -44,6 +54,14 @@ type (
 		KeepArchive   bool                     `json:"keeparchive"`
 	}
 
+	// FunctionFetchResponse is returned by the fetcher once a package
+	// has been fetched.
+	FunctionFetchResponse struct {
+		// SourceCommit is the commit SHA of a source package fetched
+		// from a Git repository.
+		SourceCommit string `json:"sourceCommit,omitempty"`
+	}
+
 	FunctionLoadRequest struct {
 		// FilePath is an absolute filesystem path to the
 		// function. What exactly is stored here is
@@ -69,8 +87,10 @@ type (
 	// ArchiveUploadRequest send from builder manager describes which
 	// deployment package should be upload to storage service.
 	ArchiveUploadRequest struct {
-		Filename       string `json:"filename"`
-		StorageSvcUrl  string `json:"storagesvcurl"`
+		Filename      string `json:"filename"`
+		StorageSvcUrl string `json:"storagesvcurl"`
+		// Namespace is the namespace of the package the archive is scoped to
+		Namespace      string `json:"namespace,omitempty"`
 		ArchivePackage bool   `json:"archivepackage"`
 	}
 
@@ -80,4 +100,31 @@ type (
 		ArchiveDownloadUrl string       `json:"archiveDownloadUrl"`
 		Checksum           fv1.Checksum `json:"checksum"`
 	}
+
+	// FunctionRefreshRequest asks the fetcher of a specialized pod to
+	// fetch the secrets and configmaps of the function again.
+	FunctionRefreshRequest struct {
+		Secrets    []fv1.SecretReference    `json:"secretList"`
+		ConfigMaps []fv1.ConfigMapReference `json:"configMapList"`
+		// Reload notifies the environment through its reload
+		// endpoint once the files are swapped.
+		Reload bool `json:"reload"`
+	}
+
+	// FunctionRefreshResponse is returned by the fetcher once the
+	// secrets and configmaps have been refreshed.
+	FunctionRefreshResponse struct {
+		// Reloaded is whether the environment reloaded them. It's
+		// false if the environment has no reload endpoint.
+		Reloaded bool `json:"reloaded"`
+	}
+)
+
+// Specialization phases timed by the fetcher.
+const (
+	PhaseDownload          = "download"
+	PhaseChecksum          = "checksum"
+	PhaseUnarchive         = "unarchive"
+	PhaseSecretsConfigMaps = "secretsConfigMaps"
+	PhaseSpecialize        = "specialize"
 )
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context/ctxhttp"
+
+	ferror "github.com/fission/fission/pkg/error"
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+)
+
+const (
+	unspecializeURL  = "http://127.0.0.1:8888/v2/unspecialize"
+	runtimeHealthURL = "http://127.0.0.1:8888/healthz"
+)
+
+// UnspecializeHandler resets a specialized pod so that it can be returned
+// to the pool. It responds with an error if the pod can't be reused safely,
+// in which case the executor deletes it.
+func (fetcher *Fetcher) UnspecializeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != "POST" {
+		http.Error(w, fmt.Sprintf("only POST is supported on this endpoint, %v received", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	logger := otelUtils.LoggerWithTraceID(ctx, fetcher.logger)
+
+	err := fetcher.UnspecializePod(ctx)
+	if err != nil {
+		logger.Error("error unspecializing pod", zap.Error(err))
+		code, msg := ferror.GetHTTPError(err)
+		http.Error(w, msg, code)
+		return
+	}
+
+	logger.Info("pod unspecialized")
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnspecializePod asks the environment to unload the function, then removes
+// the function package, secrets and configmaps from the shared volumes and
+// checks that the environment is still healthy.
+func (fetcher *Fetcher) UnspecializePod(ctx context.Context) error {
+	otelUtils.SpanTrackEvent(ctx, "unspecializeCall", otelUtils.MapToAttributes(map[string]string{
+		"url": unspecializeURL,
+	})...)
+	resp, err := ctxhttp.Post(ctx, fetcher.httpClient, unspecializeURL, "application/json", nil)
+	if err != nil {
+		return ferror.MakeError(ferror.ErrorInternal, fmt.Sprintf("error calling environment unspecialize endpoint: %v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return ferror.MakeError(ferror.ErrorNotImplemented, "environment does not support unspecialization")
+	}
+	if resp.StatusCode >= 300 {
+		return ferror.MakeErrorFromHTTP(resp)
+	}
+
+	for _, dir := range []string{fetcher.sharedVolumePath, fetcher.sharedSecretPath, fetcher.sharedConfigPath} {
+		err = clearDir(dir)
+		if err != nil {
+			return ferror.MakeError(ferror.ErrorInternal, fmt.Sprintf("error clearing %s: %v", dir, err))
+		}
+	}
+
+	health, err := ctxhttp.Get(ctx, fetcher.httpClient, runtimeHealthURL)
+	if err != nil {
+		return ferror.MakeError(ferror.ErrorInternal, fmt.Sprintf("error checking environment health: %v", err))
+	}
+	health.Body.Close()
+	if health.StatusCode != http.StatusOK {
+		return ferror.MakeError(ferror.ErrorInternal, fmt.Sprintf("environment is unhealthy after unspecialization: status %d", health.StatusCode))
+	}
+	return nil
+}
+
+// clearDir removes the content of a directory, keeping the directory itself
+// since it may be a volume mount point.
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		err = os.RemoveAll(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -41,7 +41,7 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{},
+		Optional: []flag.Flag{flag.Output},
 	})
 
 	deleteCmd := &cobra.Command{
@@ -23,7 +23,6 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
-	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
 )
 
 type DeleteSubCommand struct {
@@ -38,13 +37,11 @@ func (opts *DeleteSubCommand) do(input cli.Input) error {
 
 	archiveID := input.String(flagkey.ArchiveID)
 
-	storagesvcURL, err := util.GetStorageURL(input.Context(), opts.Client())
+	client, err := util.GetStorageClient(input.Context(), opts.Client())
 	if err != nil {
 		return err
 	}
 
-	client := storagesvcClient.MakeClient(storagesvcURL.String())
-
 	err = client.Delete(input.Context(), archiveID)
 	if err != nil {
 		return err
@@ -18,13 +18,12 @@ package archive
 
 import (
 	"fmt"
-	"strings"
+	"path"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
-	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
 )
 
 type DownloadSubCommand struct {
@@ -41,15 +40,14 @@ func (opts *DownloadSubCommand) do(input cli.Input) error {
 	archiveOutput := input.String(flagkey.ArchiveOutput)
 
 	if len(archiveOutput) == 0 {
-		archiveOutput = strings.TrimPrefix(archiveID, "/fission/fission-functions/")
+		archiveOutput = path.Base(archiveID)
 	}
 
-	storageAccessURL, err := util.GetStorageURL(input.Context(), opts.Client())
+	client, err := util.GetStorageClient(input.Context(), opts.Client())
 	if err != nil {
 		return err
 	}
 
-	client := storagesvcClient.MakeClient(storageAccessURL.String())
 	err = client.Download(input.Context(), archiveID, archiveOutput)
 	if err != nil {
 		return err
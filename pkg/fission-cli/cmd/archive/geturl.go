@@ -18,14 +18,11 @@ package archive
 
 import (
 	"fmt"
-	"net/http"
-	"net/url"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
-	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
 )
 
 type GetURLSubCommand struct {
@@ -40,40 +37,26 @@ func (opts *GetURLSubCommand) do(input cli.Input) error {
 
 	archiveID := input.String(flagkey.ArchiveID)
 
-	serverURL, err := util.GetStorageURL(input.Context(), opts.Client())
+	client, err := util.GetStorageClient(input.Context(), opts.Client())
 	if err != nil {
 		return err
 	}
 
-	relativeURL, _ := url.Parse(util.FISSION_STORAGE_URI)
-
-	queryString := relativeURL.Query()
-	queryString.Set("id", archiveID)
-	relativeURL.RawQuery = queryString.Encode()
-
-	storageAccessURL := serverURL.ResolveReference(relativeURL)
-
-	resp, err := http.Head(storageAccessURL.String())
+	header, err := client.Info(input.Context(), archiveID)
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error getting URL. Exited with Status:  %s", resp.Status)
+		return fmt.Errorf("error getting URL: %w", err)
 	}
 
-	storageType := resp.Header.Get("X-FISSION-STORAGETYPE")
+	storageType := header.Get("X-FISSION-STORAGETYPE")
 
 	if storageType == "local" {
-		storagesvcURL, err := util.GetStorageURL(input.Context(), opts.Client())
+		archiveURL, err := client.GetSignedUrl(input.Context(), archiveID)
 		if err != nil {
 			return err
 		}
-		client := storagesvcClient.MakeClient(storagesvcURL.String())
-		fmt.Printf("URL: %s", client.GetUrl(archiveID))
+		fmt.Printf("URL: %s", archiveURL)
 	} else if storageType == "s3" {
-		storageBucket := resp.Header.Get("X-FISSION-BUCKET")
+		storageBucket := header.Get("X-FISSION-BUCKET")
 		s3url := fmt.Sprintf("https://%s.s3.amazonaws.com/%s", storageBucket, archiveID)
 		fmt.Printf("URL: %s", s3url)
 	}
@@ -17,12 +17,13 @@ limitations under the License.
 package archive
 
 import (
-	"fmt"
+	"os"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 	"github.com/fission/fission/pkg/fission-cli/util"
-	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
 )
 
 type ListSubCommand struct {
@@ -34,23 +35,31 @@ func List(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) do(input cli.Input) error {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
-	storageAccessURL, err := util.GetStorageURL(input.Context(), opts.Client())
+	// archives of all namespaces are listed unless a namespace is given
+	namespace, _, err := opts.GetResourceNamespace(input, flagkey.Namespace)
 	if err != nil {
 		return err
 	}
 
-	client := storagesvcClient.MakeClient(storageAccessURL.String())
-	files, err := client.List(input.Context())
+	client, err := util.GetStorageClient(input.Context(), opts.Client())
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("ARCHIVES")
-	for _, file := range files {
-		fmt.Println(file)
+	files, err := client.List(input.Context(), namespace)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	table := &printer.Table{Columns: []printer.Column{{Header: "ARCHIVES"}}}
+	for _, file := range files {
+		table.AddRow(file)
+	}
 
+	return p.PrintData(os.Stdout, files, table)
 }
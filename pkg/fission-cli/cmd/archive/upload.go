@@ -23,7 +23,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
-	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
+	"github.com/fission/fission/pkg/storagesvc"
 )
 
 type UploadSubCommand struct {
@@ -38,13 +38,20 @@ func (opts *UploadSubCommand) do(input cli.Input) error {
 
 	archiveName := input.String(flagkey.ArchiveName)
 
-	storagesvcURL, err := util.GetStorageURL(input.Context(), opts.Client())
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.Namespace)
 	if err != nil {
 		return err
 	}
 
-	client := storagesvcClient.MakeClient(storagesvcURL.String())
-	archiveID, err := client.Upload(input.Context(), archiveName, nil)
+	client, err := util.GetStorageClient(input.Context(), opts.Client())
+	if err != nil {
+		return err
+	}
+
+	archiveID, err := client.Upload(input.Context(), archiveName, &map[string]string{
+		storagesvc.MetadataUploader:  "fission-cli",
+		storagesvc.MetadataNamespace: namespace,
+	})
 	if err != nil {
 		return err
 	}
@@ -43,7 +43,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(getCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.CanaryName},
-		Optional: []flag.Flag{flag.NamespaceCanary},
+		Optional: []flag.Flag{flag.NamespaceCanary, flag.Output},
 	})
 
 	updateCmd := &cobra.Command{
@@ -76,7 +76,7 @@ func Commands() *cobra.Command {
 		RunE:    wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceCanary, flag.AllNamespaces},
+		Optional: []flag.Flag{flag.NamespaceCanary, flag.AllNamespaces, flag.Output},
 	})
 
 	command := &cobra.Command{
@@ -19,13 +19,14 @@ package canaryconfig
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type GetSubCommand struct {
@@ -37,6 +38,10 @@ func Get(input cli.Input) error {
 }
 
 func (opts *GetSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceCanary)
 	if err != nil {
@@ -48,12 +53,19 @@ func (opts *GetSubCommand) run(input cli.Input) (err error) {
 		return fmt.Errorf("error getting canary config: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "TRIGGER", "FUNCTION-N", "FUNCTION-N-1", "WEIGHT-INCREMENT", "INTERVAL", "FAILURE-THRESHOLD", "FAILURE-TYPE", "STATUS")
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-		canaryCfg.ObjectMeta.Name, canaryCfg.Spec.Trigger, canaryCfg.Spec.NewFunction, canaryCfg.Spec.OldFunction, canaryCfg.Spec.WeightIncrement, canaryCfg.Spec.WeightIncrementDuration,
-		canaryCfg.Spec.FailureThreshold, canaryCfg.Spec.FailureType, canaryCfg.Status.Status)
+	return p.Print(os.Stdout, canaryCfg, canaryConfigTable([]fv1.CanaryConfig{*canaryCfg}))
+}
 
-	w.Flush()
-	return nil
+func canaryConfigTable(canaryCfgs []fv1.CanaryConfig) *printer.Table {
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "TRIGGER"}, {Header: "FUNCTION-N"}, {Header: "FUNCTION-N-1"}, {Header: "WEIGHT-INCREMENT"},
+		{Header: "INTERVAL"}, {Header: "FAILURE-THRESHOLD"}, {Header: "FAILURE-TYPE"}, {Header: "STATUS"},
+		{Header: "NAMESPACE", Wide: true},
+	}}
+	for _, canaryCfg := range canaryCfgs {
+		table.AddRow(canaryCfg.ObjectMeta.Name, canaryCfg.Spec.Trigger, canaryCfg.Spec.NewFunction, canaryCfg.Spec.OldFunction, canaryCfg.Spec.WeightIncrement,
+			canaryCfg.Spec.WeightIncrementDuration, canaryCfg.Spec.FailureThreshold, canaryCfg.Spec.FailureType, canaryCfg.Status.Status,
+			canaryCfg.ObjectMeta.Namespace)
+	}
+	return table
 }
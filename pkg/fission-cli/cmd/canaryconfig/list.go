@@ -19,13 +19,13 @@ package canaryconfig
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -54,6 +54,10 @@ func (opts *ListSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.namespace = metav1.NamespaceAll
@@ -64,14 +68,5 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 		return fmt.Errorf("error listing canary config: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "TRIGGER", "FUNCTION-N", "FUNCTION-N-1", "WEIGHT-INCREMENT", "INTERVAL", "FAILURE-THRESHOLD", "FAILURE-TYPE", "STATUS")
-	for _, canaryCfg := range canaryCfgs.Items {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			canaryCfg.ObjectMeta.Name, canaryCfg.Spec.Trigger, canaryCfg.Spec.NewFunction, canaryCfg.Spec.OldFunction, canaryCfg.Spec.WeightIncrement, canaryCfg.Spec.WeightIncrementDuration,
-			canaryCfg.Spec.FailureThreshold, canaryCfg.Spec.FailureType, canaryCfg.Status.Status)
-	}
-
-	w.Flush()
-	return nil
+	return p.Print(os.Stdout, canaryCfgs, canaryConfigTable(canaryCfgs.Items))
 }
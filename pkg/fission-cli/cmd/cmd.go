@@ -28,8 +28,22 @@ import (
 type (
 	CommandAction   func(input cli.Input) error
 	CommandActioner struct{}
+
+	// ExitError is returned by commands exiting with a status other than 1.
+	ExitError struct {
+		Code int
+		Err  error
+	}
 )
 
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 var (
 	once          = sync.Once{}
 	defaultClient Client
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dev
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+func Commands() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "dev",
+		Short: "Run the functions of the application specification locally",
+		Long: "Run the functions of the application specification on the workstation, with their environment runtimes " +
+			"as docker containers or local processes. HTTP triggers are served by a local router and time triggers run " +
+			"locally. Specs and code are reloaded when the project files change. Packages must have a deployment archive, " +
+			"builds aren't run.",
+		// no cluster is needed, so don't make the clients like the root command does
+		PersistentPreRunE: wrapper.Wrapper(func(input cli.Input) error {
+			console.Verbosity = input.Int(flagkey.Verbosity)
+			return nil
+		}),
+		RunE: wrapper.Wrapper(Dev),
+	}
+	wrapper.SetFlags(command, flag.FlagSet{
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecOverlay, flag.SpecValues,
+			flag.DevPort, flag.DevRuntime, flag.DevRuntimeCommand, flag.DevTest},
+	})
+
+	return command
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/router"
+	"github.com/fission/fission/pkg/timer"
+)
+
+type DevSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Dev runs the functions of the specs on the workstation. A local router
+// serves their HTTP triggers, their time triggers run locally, and the
+// specs are reloaded when the project files change.
+func Dev(input cli.Input) error {
+	return (&DevSubCommand{}).do(input)
+}
+
+func (opts *DevSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *DevSubCommand) run(input cli.Input) error {
+	ctx, stop := signal.NotifyContext(input.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	specDir := util.GetSpecDir(input)
+	port := input.Int(flagkey.DevPort)
+
+	workDir, err := os.MkdirTemp("", "fission-dev-")
+	if err != nil {
+		return fmt.Errorf("error creating work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	rt, err := makeRuntime(input.String(flagkey.DevRuntime), input.StringSlice(flagkey.DevRuntimeCommand), workDir)
+	if err != nil {
+		return err
+	}
+
+	logger, err := makeLogger()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync() //nolint: errcheck
+
+	executor := newLocalExecutor(logger, rt, workDir)
+	defer executor.stopAll()
+	localRouter := router.NewLocalRouter(logger, executor)
+	routerURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	localTimer := timer.MakeTimer(logger, routerURL)
+	defer localTimer.Sync(nil)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return fmt.Errorf("error listening on port %v: %w", port, err)
+	}
+	server := &http.Server{Handler: localRouter}
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			console.Error(fmt.Sprintf("error serving functions: %v", err))
+		}
+	}()
+	defer server.Close()
+
+	watcher, err := spec.NewSpecWatcher(specDir)
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for {
+		fr, err := opts.reload(ctx, input, executor, localRouter, localTimer)
+		if err != nil {
+			// wait for the files to be fixed
+			console.Error(err.Error())
+		} else {
+			printRoutes(routerURL, fr)
+			if input.Bool(flagkey.DevTest) {
+				opts.runTests(ctx, routerURL, fr)
+			}
+		}
+
+		fmt.Println("Watching files for changes...")
+		changed := make(chan error, 1)
+		go func() {
+			changed <- spec.WaitForSpecChange(watcher)
+		}()
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-changed:
+			if err != nil {
+				return fmt.Errorf("error watching files: %w", err)
+			}
+		}
+		fmt.Println("Noticed a file change, reloading specs...")
+	}
+}
+
+// reload reads the specs and runs their functions and triggers.
+func (opts *DevSubCommand) reload(ctx context.Context, input cli.Input, executor *localExecutor, localRouter *router.LocalRouter, localTimer *timer.Timer) (*spec.FissionResources, error) {
+	specDir := util.GetSpecDir(input)
+	fr, err := spec.ReadSpecsWithOverlay(specDir, util.GetSpecIgnore(input), false, spec.GetOverlay(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading specs: %w", err)
+	}
+	namespace := input.String(flagkey.Namespace)
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	fr.SetNamespace(namespace, false)
+
+	archives, err := fr.LocalArchives(ctx, specDir)
+	if err != nil {
+		return nil, fmt.Errorf("error creating archives: %w", err)
+	}
+	err = executor.update(ctx, fr, archives)
+	if err != nil {
+		return nil, err
+	}
+	err = localRouter.Update(fr.HttpTriggers, fr.Functions)
+	if err != nil {
+		return nil, fmt.Errorf("error updating router: %w", err)
+	}
+	localTimer.Sync(fr.TimeTriggers)
+	return fr, nil
+}
+
+// runTests runs the test suites of the specs against the local router.
+// Failures are printed, so that they can be fixed while dev mode runs.
+func (opts *DevSubCommand) runTests(ctx context.Context, routerURL string, fr *spec.FissionResources) {
+	if len(fr.TestSuites) == 0 {
+		return
+	}
+	u, err := url.Parse(routerURL)
+	if err != nil {
+		console.Error(err.Error())
+		return
+	}
+	err = fr.RunTests(ctx, u, os.Stdout, spec.TestOutputText)
+	if err != nil {
+		console.Error(err.Error())
+	}
+}
+
+// makeLogger returns a logger for the router and the executor, which only
+// logs errors unless the verbosity is raised.
+func makeLogger() (*zap.Logger, error) {
+	config := zap.NewDevelopmentConfig()
+	config.Level = zap.NewAtomicLevelAt(zapcore.ErrorLevel)
+	if console.Verbosity >= 2 {
+		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	}
+	return config.Build()
+}
+
+func printRoutes(routerURL string, fr *spec.FissionResources) {
+	fmt.Printf("Serving %v on %v\n", pluralize(len(fr.Functions), "function"), routerURL)
+	for _, ht := range fr.HttpTriggers {
+		path := ht.Spec.RelativeURL
+		if ht.Spec.Prefix != nil && *ht.Spec.Prefix != "" {
+			path = *ht.Spec.Prefix
+		}
+		fn := ht.Spec.FunctionReference.Name
+		if ht.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
+			fn = strings.Join(slices.Sorted(maps.Keys(ht.Spec.FunctionReference.FunctionWeights)), ", ")
+		}
+		fmt.Printf("  %v%v -> %v\n", routerURL, path, fn)
+	}
+	for _, tt := range fr.TimeTriggers {
+		fmt.Printf("  %v -> %v (time trigger %v)\n", tt.Spec.Cron, tt.Spec.FunctionReference.Name, tt.Name)
+	}
+}
+
+func pluralize(num int, word string) string {
+	if num == 1 {
+		return fmt.Sprintf("%v %v", num, word)
+	}
+	return fmt.Sprintf("%v %vs", num, word)
+}
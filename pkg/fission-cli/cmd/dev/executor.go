@@ -0,0 +1,435 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dev
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	eclient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/fetcher"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/utils"
+)
+
+const (
+	// specializeTimeout is how long a runtime has to start and load a function
+	specializeTimeout = time.Minute
+)
+
+type (
+	// localExecutor runs the functions of the specs on the workstation,
+	// starting the runtime of a function on its first request like the
+	// executor does with pods.
+	localExecutor struct {
+		logger  *zap.Logger
+		runtime runtime
+		workDir string
+
+		// starts dedupes concurrent starts of a function, so that e.mu
+		// isn't held while a runtime starts.
+		starts singleflight.Group
+
+		mu        sync.Mutex
+		functions map[string]*localFunction
+		instances map[string]*instance
+		// generation is bumped whenever the runtimes are stopped, the
+		// runtimes started for a previous generation are discarded.
+		generation int
+	}
+
+	// localFunction is a function with the environment it runs in, and
+	// the directory with its code.
+	localFunction struct {
+		fn      fv1.Function
+		env     fv1.Environment
+		codeDir string
+		// codeFile is the name of the code in codeDir, the one the
+		// fetcher gives it.
+		codeFile string
+	}
+)
+
+var _ eclient.ClientInterface = &localExecutor{}
+
+func newLocalExecutor(logger *zap.Logger, rt runtime, workDir string) *localExecutor {
+	return &localExecutor{
+		logger:    logger.Named("local_executor"),
+		runtime:   rt,
+		workDir:   workDir,
+		functions: make(map[string]*localFunction),
+		instances: make(map[string]*instance),
+	}
+}
+
+func functionKey(m *metav1.ObjectMeta) string {
+	return m.Namespace + "/" + m.Name
+}
+
+// GetServiceForFunction returns the address of the runtime of fn, starting
+// and specializing one if it isn't running.
+func (e *localExecutor) GetServiceForFunction(ctx context.Context, fn *fv1.Function) (string, error) {
+	key := functionKey(&fn.ObjectMeta)
+	addr, lf, generation, err := e.lookup(key)
+	if err != nil || addr != "" {
+		return addr, err
+	}
+
+	v, err, _ := e.starts.Do(fmt.Sprintf("%d/%s", generation, key), func() (interface{}, error) {
+		return e.start(ctx, key, lf, generation)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// lookup returns the address of the running runtime of a function, or the
+// function to start with the generation of the specs it belongs to.
+func (e *localExecutor) lookup(key string) (string, *localFunction, int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if inst, ok := e.instances[key]; ok {
+		if !inst.exited() {
+			return inst.addr, nil, 0, nil
+		}
+		e.logger.Info("runtime exited, restarting it", zap.String("function", key))
+		delete(e.instances, key)
+		e.stopInstance(key, inst)
+	}
+	lf, ok := e.functions[key]
+	if !ok {
+		return "", nil, 0, ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("function %v isn't in the specs", key))
+	}
+	return "", lf, e.generation, nil
+}
+
+// start starts and specializes a runtime of a function, and records it
+// unless the specs changed meanwhile.
+func (e *localExecutor) start(ctx context.Context, key string, lf *localFunction, generation int) (string, error) {
+	// a start which finished before this one was requested
+	addr, _, _, err := e.lookup(key)
+	if err != nil || addr != "" {
+		return addr, err
+	}
+
+	inst, err := e.runtime.start(ctx, lf)
+	if err != nil {
+		return "", fmt.Errorf("error starting runtime of function %v: %w", key, err)
+	}
+	err = specialize(ctx, inst, lf)
+	if err != nil {
+		e.stopInstance(key, inst)
+		return "", fmt.Errorf("error specializing function %v: %w", key, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.generation != generation {
+		e.stopInstance(key, inst)
+		return "", ferror.MakeError(ferror.ErrorNotFound, fmt.Sprintf("specs of function %v changed while it was starting", key))
+	}
+	e.logger.Info("started function", zap.String("function", key), zap.String("address", inst.addr))
+	e.instances[key] = inst
+	return inst.addr, nil
+}
+
+// TapService is a no-op, the runtimes run until the specs change.
+func (e *localExecutor) TapService(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL url.URL) {
+}
+
+// UnTapService is a no-op, the runtimes run until the specs change.
+func (e *localExecutor) UnTapService(ctx context.Context, fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL *url.URL) error {
+	return nil
+}
+
+// update stops the running functions and replaces them with the ones of fr,
+// whose code is extracted from the archives of the specs, by archive:// URL.
+func (e *localExecutor) update(ctx context.Context, fr *spec.FissionResources, archives map[string]fv1.Archive) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stopAllLocked()
+
+	codeDir := filepath.Join(e.workDir, "code")
+	err := os.RemoveAll(codeDir)
+	if err != nil {
+		return err
+	}
+	e.functions = make(map[string]*localFunction)
+
+	envs := make(map[string]fv1.Environment)
+	for _, env := range fr.Environments {
+		envs[functionKey(&env.ObjectMeta)] = env
+	}
+	pkgs := make(map[string]fv1.Package)
+	for _, pkg := range fr.Packages {
+		pkgs[functionKey(&pkg.ObjectMeta)] = pkg
+	}
+
+	// the secrets and configmaps of the specs, where the fetcher stores them
+	err = writeSecrets(filepath.Join(e.workDir, "secrets"), fr.Secrets)
+	if err != nil {
+		return err
+	}
+	err = writeConfigMaps(filepath.Join(e.workDir, "configs"), fr.ConfigMaps)
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range fr.Functions {
+		key := functionKey(&fn.ObjectMeta)
+		envKey := fn.Spec.Environment.Namespace + "/" + fn.Spec.Environment.Name
+		env, ok := envs[envKey]
+		if !ok {
+			return fmt.Errorf("environment %v of function %v isn't in the specs", envKey, key)
+		}
+		pkgKey := fn.Spec.Package.PackageRef.Namespace + "/" + fn.Spec.Package.PackageRef.Name
+		pkg, ok := pkgs[pkgKey]
+		if !ok {
+			return fmt.Errorf("package %v of function %v isn't in the specs", pkgKey, key)
+		}
+
+		lf := &localFunction{
+			fn:       fn,
+			env:      env,
+			codeDir:  filepath.Join(codeDir, fn.Namespace, fn.Name),
+			codeFile: "deployarchive",
+		}
+		if env.Spec.Version < 2 {
+			lf.codeFile = "user"
+		}
+		err = extractPackage(ctx, &pkg, archives, filepath.Join(lf.codeDir, lf.codeFile))
+		if err != nil {
+			return fmt.Errorf("error extracting package of function %v: %w", key, err)
+		}
+		e.functions[key] = lf
+	}
+	return nil
+}
+
+// stopAll stops the runtimes of all the functions.
+func (e *localExecutor) stopAll() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stopAllLocked()
+}
+
+// stopAllLocked stops the runtimes of all the functions, including the ones
+// still starting, e.mu must be held.
+func (e *localExecutor) stopAllLocked() {
+	e.generation++
+	for key, inst := range e.instances {
+		e.stopInstance(key, inst)
+		delete(e.instances, key)
+	}
+}
+
+// stopInstance stops the runtime of a function.
+func (e *localExecutor) stopInstance(key string, inst *instance) {
+	err := inst.stop()
+	if err != nil {
+		e.logger.Error("error stopping runtime", zap.String("function", key), zap.Error(err))
+	}
+}
+
+// extractPackage writes the deployment archive of pkg at target, as a
+// directory if it's a zip file, like the fetcher does.
+func extractPackage(ctx context.Context, pkg *fv1.Package, archives map[string]fv1.Archive, target string) error {
+	ar := pkg.Spec.Deployment
+	if len(ar.Literal) == 0 && ar.URL == "" {
+		if pkg.Spec.Source.Type != "" {
+			return fmt.Errorf("package %v has a source archive only, building packages isn't supported", pkg.Name)
+		}
+		return fmt.Errorf("package %v has no deployment archive", pkg.Name)
+	}
+	if ar.Type == fv1.ArchiveTypeGit || ar.Type == fv1.ArchiveTypeOCI {
+		return fmt.Errorf("package %v has a %v deployment archive, which isn't supported", pkg.Name, ar.Type)
+	}
+	if strings.HasPrefix(ar.URL, spec.ARCHIVE_URL_PREFIX) {
+		localAr, ok := archives[ar.URL]
+		if !ok {
+			return fmt.Errorf("unknown archive name %v", strings.TrimPrefix(ar.URL, spec.ARCHIVE_URL_PREFIX))
+		}
+		ar = localAr
+	}
+
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	var archivePath string
+	switch {
+	case len(ar.Literal) > 0:
+		archivePath = target + ".archive"
+		err = os.WriteFile(archivePath, ar.Literal, 0644)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(archivePath)
+	case strings.HasPrefix(ar.URL, "http://") || strings.HasPrefix(ar.URL, "https://"):
+		archivePath = target + ".archive"
+		err = utils.DownloadUrl(ctx, http.DefaultClient, ar.URL, archivePath)
+		if err != nil {
+			return fmt.Errorf("error downloading archive %v: %w", ar.URL, err)
+		}
+		defer os.Remove(archivePath)
+	default:
+		// a local archive of the specs
+		archivePath = ar.URL
+	}
+
+	if match, _ := utils.IsZip(ctx, archivePath); match {
+		return utils.Unarchive(ctx, archivePath, target)
+	}
+	return copyFile(archivePath, target)
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func writeSecrets(dir string, secrets []apiv1.Secret) error {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		for k, v := range secret.Data {
+			err = writeValue(filepath.Join(dir, secret.Namespace, secret.Name, k), v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+func writeConfigMaps(dir string, cms []apiv1.ConfigMap) error {
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	for _, cm := range cms {
+		for k, v := range cm.Data {
+			err = writeValue(filepath.Join(dir, cm.Namespace, cm.Name, k), []byte(v))
+			if err != nil {
+				return err
+			}
+		}
+		for k, v := range cm.BinaryData {
+			err = writeValue(filepath.Join(dir, cm.Namespace, cm.Name, k), v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+func writeValue(path string, value []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, value, 0644)
+}
+
+// specialize loads the function into its runtime, with the request the
+// fetcher makes to runtimes of the environment version.
+func specialize(ctx context.Context, inst *instance, lf *localFunction) error {
+	contentType := "text/plain"
+	specializeURL := "http://" + inst.addr + "/specialize"
+	var payload []byte
+	if lf.env.Spec.Version >= 2 {
+		loadReq := fetcher.FunctionLoadRequest{
+			FilePath:         filepath.Join(inst.codePath, lf.codeFile),
+			FunctionName:     lf.fn.Spec.Package.FunctionName,
+			FunctionMetadata: &lf.fn.ObjectMeta,
+			EnvVersion:       lf.env.Spec.Version,
+		}
+		var err error
+		payload, err = json.Marshal(loadReq)
+		if err != nil {
+			return fmt.Errorf("error encoding load request: %w", err)
+		}
+		contentType = "application/json"
+		specializeURL = "http://" + inst.addr + "/v2/specialize"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, specializeTimeout)
+	defer cancel()
+
+	// the runtime may not be listening yet, retry until it is
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, specializeURL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			defer resp.Body.Close()
+			if resp.StatusCode >= 300 {
+				return ferror.MakeErrorFromHTTP(resp)
+			}
+			return nil
+		}
+		if inst.exited() {
+			return fmt.Errorf("runtime exited: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("runtime isn't reachable: %w", err)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dev
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fetcher"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// fakeRuntime serves the specialize endpoints of a v2 environment, and
+// records the load requests it gets.
+type fakeRuntime struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests []fetcher.FunctionLoadRequest
+	starts   int
+	stops    int
+	// exited makes the started runtimes report they exited
+	exited bool
+}
+
+func (r *fakeRuntime) start(ctx context.Context, lf *localFunction) (*instance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts++
+	return &instance{
+		addr:     r.server.Listener.Addr().String(),
+		codePath: lf.codeDir,
+		stop: func() error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.stops++
+			return nil
+		},
+		exited: func() bool {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			return r.exited
+		},
+	}, nil
+}
+
+const testSpec = `apiVersion: fission.io/v1
+kind: Environment
+metadata:
+  name: nodejs
+spec:
+  version: 2
+  runtime:
+    image: ghcr.io/fission/node-env
+---
+kind: ArchiveUploadSpec
+name: hello-js
+include:
+- hello.js
+---
+apiVersion: fission.io/v1
+kind: Package
+metadata:
+  name: hello-pkg
+spec:
+  deployment:
+    type: url
+    url: archive://hello-js
+  environment:
+    name: nodejs
+---
+apiVersion: fission.io/v1
+kind: Function
+metadata:
+  name: hello
+spec:
+  environment:
+    name: nodejs
+  package:
+    functionName: hello.handler
+    packageref:
+      name: hello-pkg
+`
+
+func TestLocalExecutor(t *testing.T) {
+	projectDir := t.TempDir()
+	specDir := filepath.Join(projectDir, "specs")
+	require.NoError(t, os.Mkdir(specDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "hello.yaml"), []byte(testSpec), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "hello.js"), []byte("module.exports = 1"), 0644))
+
+	rt := &fakeRuntime{}
+	rt.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/specialize", r.URL.Path)
+		var req fetcher.FunctionLoadRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		rt.mu.Lock()
+		defer rt.mu.Unlock()
+		rt.requests = append(rt.requests, req)
+	}))
+	defer rt.server.Close()
+
+	load := func() *spec.FissionResources {
+		fr, err := spec.ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+		require.NoError(t, err)
+		fr.SetNamespace(metav1.NamespaceDefault, false)
+		return fr
+	}
+	fr := load()
+	archives, err := fr.LocalArchives(t.Context(), specDir)
+	require.NoError(t, err)
+
+	executor := newLocalExecutor(zap.NewNop(), rt, t.TempDir())
+	require.NoError(t, executor.update(t.Context(), fr, archives))
+
+	addr, err := executor.GetServiceForFunction(t.Context(), &fr.Functions[0])
+	require.NoError(t, err)
+	require.Equal(t, rt.server.Listener.Addr().String(), addr)
+	require.Len(t, rt.requests, 1)
+	require.Equal(t, "hello.handler", rt.requests[0].FunctionName)
+	require.Equal(t, 2, rt.requests[0].EnvVersion)
+	require.Equal(t, "hello", rt.requests[0].FunctionMetadata.Name)
+
+	// the code is where the load request says
+	content, err := os.ReadFile(rt.requests[0].FilePath)
+	require.NoError(t, err)
+	require.Equal(t, "module.exports = 1", string(content))
+
+	// the running runtime is reused
+	_, err = executor.GetServiceForFunction(t.Context(), &fr.Functions[0])
+	require.NoError(t, err)
+	require.Equal(t, 1, rt.starts)
+
+	// updates stop the runtimes, and load the changed code
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "hello.js"), []byte("module.exports = 2"), 0644))
+	fr = load()
+	archives, err = fr.LocalArchives(t.Context(), specDir)
+	require.NoError(t, err)
+	require.NoError(t, executor.update(t.Context(), fr, archives))
+	require.Equal(t, 1, rt.stops)
+
+	// concurrent requests start a single runtime
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = executor.GetServiceForFunction(t.Context(), &fr.Functions[0])
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, rt.starts)
+	content, err = os.ReadFile(rt.requests[1].FilePath)
+	require.NoError(t, err)
+	require.Equal(t, "module.exports = 2", string(content))
+
+	// runtimes which exited are restarted
+	rt.mu.Lock()
+	rt.exited = true
+	rt.mu.Unlock()
+	_, err = executor.GetServiceForFunction(t.Context(), &fr.Functions[0])
+	require.NoError(t, err)
+	require.Equal(t, 3, rt.starts)
+	require.Equal(t, 2, rt.stops)
+}
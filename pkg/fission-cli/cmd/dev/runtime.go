@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	runtimeDocker  = "docker"
+	runtimeProcess = "process"
+
+	// runtimePort is the port runtime containers listen on
+	runtimePort = 8888
+)
+
+type (
+	// runtime starts the environment runtimes of functions.
+	runtime interface {
+		start(ctx context.Context, lf *localFunction) (*instance, error)
+	}
+
+	// instance is a running environment runtime.
+	instance struct {
+		// addr is the host:port the runtime listens on
+		addr string
+		// codePath is the path of the code directory of the
+		// function in the runtime.
+		codePath string
+		stop     func() error
+		exited   func() bool
+	}
+
+	// dockerRuntime runs the runtime images of environments as containers,
+	// with the code, secrets and configmaps mounted where the fetcher
+	// stores them.
+	dockerRuntime struct {
+		workDir string
+	}
+
+	// processRuntime runs environment runtimes as local processes, with the
+	// commands given by environment name.
+	processRuntime struct {
+		commands map[string]string
+	}
+)
+
+func makeRuntime(kind string, commands []string, workDir string) (runtime, error) {
+	switch kind {
+	case runtimeDocker:
+		return &dockerRuntime{workDir: workDir}, nil
+	case runtimeProcess:
+		rt := &processRuntime{commands: make(map[string]string)}
+		for _, c := range commands {
+			env, command, ok := strings.Cut(c, "=")
+			if !ok || env == "" || command == "" {
+				return nil, fmt.Errorf("runtime command '%v' isn't in the ENVIRONMENT=COMMAND format", c)
+			}
+			rt.commands[env] = command
+		}
+		return rt, nil
+	default:
+		return nil, fmt.Errorf("unknown runtime '%v', expected '%v' or '%v'", kind, runtimeDocker, runtimeProcess)
+	}
+}
+
+func (r *dockerRuntime) start(ctx context.Context, lf *localFunction) (*instance, error) {
+	image := lf.env.Spec.Runtime.Image
+	if image == "" {
+		return nil, fmt.Errorf("environment %v has no runtime image", lf.env.Name)
+	}
+	args := []string{"run", "--detach", "--rm",
+		"--publish", fmt.Sprintf("127.0.0.1::%d", runtimePort),
+		"--volume", lf.codeDir + ":/userfunc",
+		"--volume", filepath.Join(r.workDir, "secrets") + ":/secrets",
+		"--volume", filepath.Join(r.workDir, "configs") + ":/configs",
+	}
+	if lf.env.Spec.Runtime.Container != nil {
+		for _, e := range lf.env.Spec.Runtime.Container.Env {
+			args = append(args, "--env", e.Name+"="+e.Value)
+		}
+	}
+	args = append(args, image)
+
+	out, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running container of image %v: %w", image, commandError(err))
+	}
+	id := strings.TrimSpace(string(out))
+	stop := func() error {
+		err := exec.Command("docker", "rm", "--force", id).Run()
+		if err != nil {
+			return fmt.Errorf("error removing container %v: %w", id, commandError(err))
+		}
+		return nil
+	}
+
+	// the address docker published the runtime port on, e.g. 127.0.0.1:32768
+	out, err = exec.CommandContext(ctx, "docker", "port", id, fmt.Sprintf("%d/tcp", runtimePort)).Output()
+	if err != nil {
+		stop() //nolint: errcheck
+		return nil, fmt.Errorf("error getting port of container %v: %w", id, commandError(err))
+	}
+	addr, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+
+	return &instance{
+		addr:     addr,
+		codePath: "/userfunc",
+		stop:     stop,
+		exited: func() bool {
+			out, err := exec.Command("docker", "inspect", "--format", "{{.State.Running}}", id).Output()
+			return err != nil || strings.TrimSpace(string(out)) != "true"
+		},
+	}, nil
+}
+
+func (r *processRuntime) start(ctx context.Context, lf *localFunction) (*instance, error) {
+	command, ok := r.commands[lf.env.Name]
+	if !ok {
+		return nil, fmt.Errorf("no runtime command for environment %v, set one with --runtime-command %v=COMMAND", lf.env.Name, lf.env.Name)
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+
+	// exec makes the runtime replace the shell, so that stopping the
+	// process stops the runtime
+	cmd := exec.Command("sh", "-c", "exec "+command)
+	cmd.Env = append(os.Environ(), "PORT="+strconv.Itoa(port), "USERFUNC="+lf.codeDir)
+	if lf.env.Spec.Runtime.Container != nil {
+		for _, e := range lf.env.Spec.Runtime.Container.Env {
+			cmd.Env = append(cmd.Env, e.Name+"="+e.Value)
+		}
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("error running '%v': %w", command, err)
+	}
+	done := make(chan struct{})
+	go func() {
+		cmd.Wait() //nolint: errcheck
+		close(done)
+	}()
+
+	return &instance{
+		addr:     fmt.Sprintf("127.0.0.1:%d", port),
+		codePath: lf.codeDir,
+		stop: func() error {
+			err := cmd.Process.Kill()
+			if err != nil && !errors.Is(err, os.ErrProcessDone) {
+				return err
+			}
+			<-done
+			return nil
+		},
+		exited: func() bool {
+			select {
+			case <-done:
+				return true
+			default:
+				return false
+			}
+		},
+	}, nil
+}
+
+// freePort returns a port nothing listens on.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// commandError adds the standard error output of a failed command to err.
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %v", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -34,7 +34,7 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{
 			flag.EnvPoolsize, flag.EnvBuilderImage, flag.EnvBuildCmd,
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
-			flag.EnvTerminationGracePeriod, flag.EnvVersion, flag.EnvImagePullSecret, flag.EnvKeepArchive,
+			flag.EnvTerminationGracePeriod, flag.EnvVersion, flag.EnvImagePullSecret, flag.EnvKeepArchive, flag.EnvRecyclePods, flag.EnvBuildTimeout, flag.EnvBuilderMode,
 			flag.NamespaceEnvironment, flag.EnvExternalNetwork, flag.Labels, flag.Annotation,
 			flag.SpecSave, flag.SpecDry, flag.EnvBuilder, flag.EnvRuntime},
 	})
@@ -46,7 +46,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(getCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.EnvName},
-		Optional: []flag.Flag{flag.NamespaceEnvironment},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.Output},
 	})
 
 	updateCmd := &cobra.Command{
@@ -59,7 +59,7 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{flag.EnvImage, flag.EnvPoolsize,
 			flag.EnvBuilderImage, flag.EnvBuildCmd, flag.EnvImagePullSecret,
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory, flag.RunTimeMaxMemory,
-			flag.EnvTerminationGracePeriod, flag.EnvKeepArchive, flag.EnvRuntime,
+			flag.EnvTerminationGracePeriod, flag.EnvKeepArchive, flag.EnvRecyclePods, flag.EnvBuildTimeout, flag.EnvBuilderMode, flag.EnvRuntime,
 			flag.NamespaceEnvironment, flag.EnvExternalNetwork,
 			flag.Labels, flag.Annotation},
 	})
@@ -81,7 +81,7 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.AllNamespaces},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.AllNamespaces, flag.Output},
 	})
 
 	listPodsCmd := &cobra.Command{
@@ -93,7 +93,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(listPodsCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.EnvName},
-		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvExecutorType},
+		Optional: []flag.Flag{flag.NamespaceEnvironment, flag.EnvExecutorType, flag.Output},
 	})
 
 	command := &cobra.Command{
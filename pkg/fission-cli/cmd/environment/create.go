@@ -126,6 +126,7 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 	envBuildCmd := input.String(flagkey.EnvBuildcommand)
 	envExternalNetwork := input.Bool(flagkey.EnvExternalNetwork)
 	keepArchive := input.Bool(flagkey.EnvKeeparchive)
+	recyclePods := input.Bool(flagkey.EnvRecyclePods)
 	envGracePeriod := input.Int64(flagkey.EnvGracePeriod)
 	pullSecret := input.String(flagkey.EnvImagePullSecret)
 
@@ -191,8 +192,10 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 				},
 			},
 			Builder: fv1.Builder{
-				Image:   envBuilderImg,
-				Command: envBuildCmd,
+				Image:        envBuilderImg,
+				Command:      envBuildCmd,
+				BuildTimeout: input.Int(flagkey.EnvBuildTimeout),
+				Mode:         fv1.BuilderMode(input.String(flagkey.EnvBuilderMode)),
 				Container: &apiv1.Container{
 					Name: fv1.BuilderContainerName,
 					Env:  builderEnvList,
@@ -211,6 +214,7 @@ func createEnvironmentFromCmd(input cli.Input) (*fv1.Environment, error) {
 			TerminationGracePeriod:       envGracePeriod,
 			KeepArchive:                  keepArchive,
 			ImagePullSecret:              pullSecret,
+			RecyclePods:                  recyclePods,
 		},
 	}
 
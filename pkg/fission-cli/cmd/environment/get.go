@@ -19,13 +19,13 @@ package environment
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type GetSubCommand struct {
@@ -37,6 +37,10 @@ func Get(input cli.Input) error {
 }
 
 func (opts *GetSubCommand) do(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	_, currentNS, err := opts.GetResourceNamespace(input, flagkey.NamespaceEnvironment)
 	if err != nil {
@@ -48,12 +52,11 @@ func (opts *GetSubCommand) do(input cli.Input) (err error) {
 		return fmt.Errorf("error getting environment: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-
-	fmt.Fprintf(w, "%v\t%v\n", "NAME", "IMAGE")
-	fmt.Fprintf(w, "%v\t%v\n",
-		env.ObjectMeta.Name, env.Spec.Runtime.Image)
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "IMAGE"},
+		{Header: "BUILDER_IMAGE", Wide: true}, {Header: "POOLSIZE", Wide: true}, {Header: "VERSION", Wide: true}, {Header: "NAMESPACE", Wide: true},
+	}}
+	table.AddRow(env.ObjectMeta.Name, env.Spec.Runtime.Image, env.Spec.Builder.Image, env.Spec.Poolsize, env.Spec.Version, env.Namespace)
 
-	w.Flush()
-	return nil
+	return p.Print(os.Stdout, env, table)
 }
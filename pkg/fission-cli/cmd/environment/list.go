@@ -19,13 +19,13 @@ package environment
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -37,6 +37,10 @@ func List(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) do(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	_, currentNS, err := opts.GetResourceNamespace(input, flagkey.NamespaceEnvironment)
 	if err != nil {
@@ -52,19 +56,21 @@ func (opts *ListSubCommand) do(input cli.Input) (err error) {
 		return fmt.Errorf("error listing environments: %w", err)
 	}
 
-	envs := response.Items
-
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "IMAGE", "BUILDER_IMAGE", "POOLSIZE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "EXTNET", "GRACETIME", "NAMESPACE")
-	for _, env := range envs {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "IMAGE"}, {Header: "BUILDER_IMAGE"}, {Header: "POOLSIZE"},
+		{Header: "MINCPU"}, {Header: "MAXCPU"}, {Header: "MINMEMORY"}, {Header: "MAXMEMORY"},
+		{Header: "EXTNET"}, {Header: "GRACETIME"}, {Header: "NAMESPACE"},
+		{Header: "VERSION", Wide: true}, {Header: "BUILDCMD", Wide: true},
+	}}
+	for _, env := range response.Items {
+		table.AddRow(
 			env.ObjectMeta.Name, env.Spec.Runtime.Image, env.Spec.Builder.Image, env.Spec.Poolsize,
 			env.Spec.Resources.Requests.Cpu(), env.Spec.Resources.Limits.Cpu(),
 			env.Spec.Resources.Requests.Memory(), env.Spec.Resources.Limits.Memory(),
 			env.Spec.AllowAccessToExternalNetwork, env.Spec.TerminationGracePeriod, env.Namespace,
+			env.Spec.Version, env.Spec.Builder.Command,
 		)
 	}
-	w.Flush()
 
-	return nil
+	return p.Print(os.Stdout, response, table)
 }
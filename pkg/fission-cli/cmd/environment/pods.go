@@ -19,8 +19,8 @@ package environment
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -28,6 +28,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 	"github.com/fission/fission/pkg/utils"
 )
 
@@ -40,6 +41,10 @@ func ListPods(input cli.Input) error {
 }
 
 func (opts *ListPodsSubCommand) do(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	_, currentNS, err := opts.GetResourceNamespace(input, flagkey.NamespaceEnvironment)
 	if err != nil {
@@ -67,20 +72,23 @@ func (opts *ListPodsSubCommand) do(input cli.Input) (err error) {
 		return fmt.Errorf("error listing environments: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n", "NAME", "NAMESPACE", "READY", "STATUS", "IP", "EXECUTORTYPE", "MANAGED")
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "NAMESPACE"}, {Header: "READY"}, {Header: "STATUS"}, {Header: "IP"},
+		{Header: "EXECUTORTYPE"}, {Header: "MANAGED"}, {Header: "NODE", Wide: true},
+	}}
+	running := &corev1.PodList{}
 	for _, pod := range podsList.Items {
 
 		// A deletion timestamp indicates that a pod is terminating. Do not count this pod.
 		if pod.ObjectMeta.DeletionTimestamp != nil {
 			continue
 		}
+		running.Items = append(running.Items, pod)
 
 		labelList := pod.GetLabels()
 		readyContainers, noOfContainers := utils.PodContainerReadyStatus(&pod)
-		fmt.Fprintf(w, "%v\t%v\t%v/%v\t%v\t%v\t%v\t%v\t\n", pod.ObjectMeta.Name, pod.ObjectMeta.Namespace, noOfContainers, readyContainers, pod.Status.Phase, pod.Status.PodIP, labelList[v1.EXECUTOR_TYPE], labelList[v1.MANAGED])
+		table.AddRow(pod.ObjectMeta.Name, pod.ObjectMeta.Namespace, fmt.Sprintf("%v/%v", noOfContainers, readyContainers), pod.Status.Phase, pod.Status.PodIP, labelList[v1.EXECUTOR_TYPE], labelList[v1.MANAGED], pod.Spec.NodeName)
 	}
-	w.Flush()
 
-	return nil
+	return p.Print(os.Stdout, running, table)
 }
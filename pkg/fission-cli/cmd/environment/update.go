@@ -119,6 +119,14 @@ func updateExistingEnvironmentWithCmd(env *fv1.Environment, input cli.Input) (*f
 		env.Spec.Builder.Command = input.String(flagkey.EnvBuildcommand)
 	}
 
+	if input.IsSet(flagkey.EnvBuildTimeout) {
+		env.Spec.Builder.BuildTimeout = input.Int(flagkey.EnvBuildTimeout)
+	}
+
+	if input.IsSet(flagkey.EnvBuilderMode) {
+		env.Spec.Builder.Mode = fv1.BuilderMode(input.String(flagkey.EnvBuilderMode))
+	}
+
 	if env.Spec.Version == 1 && (len(env.Spec.Builder.Image) > 0 || len(env.Spec.Builder.Command) > 0) {
 		e = multierror.Append(e, errors.New("version 1 Environments do not support builders. Must specify --version=2"))
 	}
@@ -141,6 +149,10 @@ func updateExistingEnvironmentWithCmd(env *fv1.Environment, input cli.Input) (*f
 		env.Spec.KeepArchive = input.Bool(flagkey.EnvKeeparchive)
 	}
 
+	if input.IsSet(flagkey.EnvRecyclePods) {
+		env.Spec.RecyclePods = input.Bool(flagkey.EnvRecyclePods)
+	}
+
 	if input.IsSet(flagkey.EnvImagePullSecret) {
 		env.Spec.ImagePullSecret = input.String(flagkey.EnvImagePullSecret)
 	}
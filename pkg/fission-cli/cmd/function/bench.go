@@ -0,0 +1,458 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	benchOutputText = "text"
+	benchOutputJSON = "json"
+
+	// benchPodInterval is how often the pods of the function are counted
+	benchPodInterval = time.Second
+)
+
+type (
+	BenchSubCommand struct {
+		cmd.CommandActioner
+	}
+
+	// benchOptions are the requests to send to the function, and how.
+	benchOptions struct {
+		url         string
+		method      string
+		headers     []string
+		body        string
+		rate        int
+		concurrency int
+		duration    time.Duration
+		warmup      time.Duration
+		timeout     time.Duration
+	}
+
+	// benchResult is the outcome of a request.
+	benchResult struct {
+		latency time.Duration
+		status  int
+		err     error
+	}
+
+	benchReport struct {
+		Function  string `json:"function"`
+		Namespace string `json:"namespace"`
+		// FirstRequest is the request sent before the others, which is a
+		// cold start if the function had no pods.
+		FirstRequest benchFirstRequest `json:"firstRequest"`
+		Requests     int               `json:"requests"`
+		DurationMs   float64           `json:"durationMs"`
+		RPS          float64           `json:"rps"`
+		Latency      benchLatency      `json:"latency"`
+		// Errors counts the failed requests by status code, or by "timeout"
+		// and "connection error" for requests that got no response.
+		Errors map[string]int `json:"errors"`
+		Pods   benchPods      `json:"pods"`
+	}
+
+	benchFirstRequest struct {
+		ColdStart bool    `json:"coldStart"`
+		LatencyMs float64 `json:"latencyMs"`
+		Status    string  `json:"status"`
+	}
+
+	benchLatency struct {
+		MeanMs float64 `json:"meanMs"`
+		P50Ms  float64 `json:"p50Ms"`
+		P90Ms  float64 `json:"p90Ms"`
+		P95Ms  float64 `json:"p95Ms"`
+		P99Ms  float64 `json:"p99Ms"`
+		MaxMs  float64 `json:"maxMs"`
+	}
+
+	benchPods struct {
+		Before int `json:"before"`
+		Max    int `json:"max"`
+		After  int `json:"after"`
+	}
+)
+
+// Bench measures the latency of a function under load, and how many pods
+// the executor scales it to.
+func Bench(input cli.Input) error {
+	return (&BenchSubCommand{}).do(input)
+}
+
+func (opts *BenchSubCommand) do(input cli.Input) error {
+	fnName := input.String(flagkey.FnName)
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return fmt.Errorf("error in benchmarking function : %w", err)
+	}
+
+	output := input.String(flagkey.FnBenchOutput)
+	if output != benchOutputText && output != benchOutputJSON {
+		return fmt.Errorf("unknown output '%v', expected %v or %v", output, benchOutputText, benchOutputJSON)
+	}
+
+	_, err = opts.Client().FissionClientSet.CoreV1().Functions(namespace).Get(input.Context(), fnName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("read function '%s': %w", fnName, err)
+	}
+	m := &metav1.ObjectMeta{
+		Name:      fnName,
+		Namespace: namespace,
+	}
+
+	routerURL, err := util.GetRouterURL(input.Context(), opts.Client())
+	if err != nil {
+		return fmt.Errorf("error getting router URL: %w", err)
+	}
+	method, err := functionTestMethod(input)
+	if err != nil {
+		return err
+	}
+
+	bo := &benchOptions{
+		url:         functionTestURL(input, routerURL, m).String(),
+		method:      method,
+		headers:     input.StringSlice(flagkey.FnTestHeader),
+		body:        input.String(flagkey.FnTestBody),
+		rate:        input.Int(flagkey.FnBenchRate),
+		concurrency: input.Int(flagkey.FnBenchConcurrency),
+		duration:    input.Duration(flagkey.FnBenchDuration),
+		warmup:      input.Duration(flagkey.FnBenchWarmup),
+		timeout:     input.Duration(flagkey.FnTestTimeout),
+	}
+	if bo.concurrency <= 0 {
+		return errors.New("concurrency must be greater than zero")
+	}
+	if bo.rate < 0 {
+		return errors.New("rate can't be negative")
+	}
+	if bo.duration <= 0 {
+		return errors.New("duration must be greater than zero")
+	}
+	// the test request is validated once, rather than by every request
+	_, err = newTestRequest(input.Context(), bo.url, bo.headers, bo.method, bo.body)
+	if err != nil {
+		return err
+	}
+
+	countPods := func(ctx context.Context) (int, error) {
+		return countFunctionPods(ctx, opts.Client(), m)
+	}
+	if output == benchOutputText {
+		console.Info(fmt.Sprintf("Benchmarking function %v for %v", fnName, bo.duration))
+	}
+	report, err := runBench(input.Context(), bo, countPods)
+	if err != nil {
+		return err
+	}
+	report.Function = fnName
+	report.Namespace = namespace
+
+	if output == benchOutputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printBenchReport(os.Stdout, report)
+	return nil
+}
+
+// countFunctionPods returns the number of pods of the function that aren't
+// terminating.
+func countFunctionPods(ctx context.Context, client cmd.Client, m *metav1.ObjectMeta) (int, error) {
+	selector := map[string]string{
+		v1.FUNCTION_NAME:      m.Name,
+		v1.FUNCTION_NAMESPACE: m.Namespace,
+	}
+	pods, err := client.KubernetesClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(selector).AsSelector().String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error listing function pods: %w", err)
+	}
+	count := 0
+	for _, pod := range pods.Items {
+		if pod.ObjectMeta.DeletionTimestamp == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// runBench sends a first request, then warms the function up, and measures
+// it under load for the duration of the options.
+func runBench(ctx context.Context, bo *benchOptions, countPods func(context.Context) (int, error)) (*benchReport, error) {
+	hc := &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			MaxIdleConnsPerHost: bo.concurrency,
+		},
+	}
+	report := &benchReport{Errors: make(map[string]int)}
+
+	podsBefore, err := countPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Pods.Before = podsBefore
+	report.Pods.Max = podsBefore
+
+	first := doBenchRequest(ctx, hc, bo)
+	report.FirstRequest = benchFirstRequest{
+		ColdStart: podsBefore == 0,
+		LatencyMs: milliseconds(first.latency),
+		Status:    first.statusText(),
+	}
+
+	if bo.warmup > 0 {
+		runLoad(ctx, hc, bo, bo.warmup)
+	}
+
+	// count the pods while the function is measured
+	podCtx, stopPods := context.WithCancel(ctx)
+	var podsWg sync.WaitGroup
+	podsWg.Add(1)
+	go func() {
+		defer podsWg.Done()
+		ticker := time.NewTicker(benchPodInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-podCtx.Done():
+				return
+			case <-ticker.C:
+				count, err := countPods(podCtx)
+				if err == nil && count > report.Pods.Max {
+					report.Pods.Max = count
+				}
+			}
+		}
+	}()
+
+	start := time.Now()
+	results := runLoad(ctx, hc, bo, bo.duration)
+	elapsed := time.Since(start)
+	stopPods()
+	podsWg.Wait()
+
+	podsAfter, err := countPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Pods.After = podsAfter
+	report.Pods.Max = max(report.Pods.Max, podsAfter)
+
+	report.Requests = len(results)
+	report.DurationMs = milliseconds(elapsed)
+	if elapsed > 0 {
+		report.RPS = float64(len(results)) / elapsed.Seconds()
+	}
+	latencies := make([]time.Duration, 0, len(results))
+	var total time.Duration
+	for _, r := range results {
+		if r.failed() {
+			report.Errors[r.statusText()]++
+		}
+		latencies = append(latencies, r.latency)
+		total += r.latency
+	}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		report.Latency = benchLatency{
+			MeanMs: milliseconds(total / time.Duration(len(latencies))),
+			P50Ms:  milliseconds(percentile(latencies, 50)),
+			P90Ms:  milliseconds(percentile(latencies, 90)),
+			P95Ms:  milliseconds(percentile(latencies, 95)),
+			P99Ms:  milliseconds(percentile(latencies, 99)),
+			MaxMs:  milliseconds(latencies[len(latencies)-1]),
+		}
+	}
+	return report, nil
+}
+
+// runLoad sends requests for the duration d, at the rate of the options if
+// set, and returns their results.
+func runLoad(ctx context.Context, hc *http.Client, bo *benchOptions, d time.Duration) []benchResult {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	// with a rate, requests are sent when the workers get a tick. Ticks
+	// are dropped while all workers are busy, so that the rate isn't made
+	// up later in bursts.
+	var ticks chan struct{}
+	if bo.rate > 0 {
+		ticks = make(chan struct{}, bo.concurrency)
+		go func() {
+			ticker := time.NewTicker(time.Second / time.Duration(bo.rate))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					select {
+					case ticks <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}()
+	}
+
+	var (
+		mu      sync.Mutex
+		results []benchResult
+		wg      sync.WaitGroup
+	)
+	for range bo.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if ticks != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticks:
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				// requests in flight at the end of the duration are
+				// finished, so that they aren't counted as timeouts
+				r := doBenchRequest(context.WithoutCancel(ctx), hc, bo)
+				mu.Lock()
+				results = append(results, r)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func doBenchRequest(ctx context.Context, hc *http.Client, bo *benchOptions) benchResult {
+	if bo.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bo.timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	req, err := newTestRequest(ctx, bo.url, bo.headers, bo.method, bo.body)
+	if err != nil {
+		return benchResult{err: err}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return benchResult{latency: time.Since(start), err: err}
+	}
+	// the latency includes reading the body, as clients of the function do
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return benchResult{latency: time.Since(start), status: resp.StatusCode, err: err}
+}
+
+func (r *benchResult) failed() bool {
+	return r.err != nil || r.status >= http.StatusBadRequest
+}
+
+// statusText is the status code of the response, or why there was none.
+func (r *benchResult) statusText() string {
+	switch {
+	case r.status != 0:
+		return strconv.Itoa(r.status)
+	case errors.Is(r.err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "connection error"
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies, by the
+// nearest rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+func printBenchReport(w io.Writer, report *benchReport) {
+	kind := "First request"
+	if report.FirstRequest.ColdStart {
+		kind = "Cold start"
+	}
+	fmt.Fprintf(w, "%v: %vms, status %v (%v pods before)\n", kind, report.FirstRequest.LatencyMs, report.FirstRequest.Status, report.Pods.Before)
+	errCount := 0
+	for _, count := range report.Errors {
+		errCount += count
+	}
+	fmt.Fprintf(w, "Requests: %v in %.1fs (%.1f req/s), %v failed\n", report.Requests, report.DurationMs/1000, report.RPS, errCount)
+	fmt.Fprintf(w, "Pods: %v before, %v max, %v after\n", report.Pods.Before, report.Pods.Max, report.Pods.After)
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n", "MEAN", "P50", "P90", "P95", "P99", "MAX")
+	l := report.Latency
+	fmt.Fprintf(tw, "%vms\t%vms\t%vms\t%vms\t%vms\t%vms\n", l.MeanMs, l.P50Ms, l.P90Ms, l.P95Ms, l.P99Ms, l.MaxMs)
+	tw.Flush()
+
+	if errCount == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "%v\t%v\n", "ERROR", "COUNT")
+	statuses := make([]string, 0, len(report.Errors))
+	for status := range report.Errors {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(tw, "%v\t%v\n", status, report.Errors[status])
+	}
+	tw.Flush()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBench(t *testing.T) {
+	// the handler runs outside of the test goroutine, the requests not
+	// made as expected are counted and checked once the bench is done
+	var requests, badRequests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-Test") != "value" {
+			badRequests.Add(1)
+		}
+		// every fourth request fails
+		if requests.Add(1)%4 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	// the function scales from zero to two pods
+	var pods atomic.Int64
+	countPods := func(ctx context.Context) (int, error) {
+		return int(pods.Swap(2)), nil
+	}
+
+	report, err := runBench(t.Context(), &benchOptions{
+		url:         server.URL,
+		method:      http.MethodPost,
+		headers:     []string{"X-Test:value"},
+		rate:        100,
+		concurrency: 2,
+		duration:    500 * time.Millisecond,
+		warmup:      100 * time.Millisecond,
+		timeout:     time.Second,
+	}, countPods)
+	require.NoError(t, err)
+	require.Zero(t, badRequests.Load(), "requests without the method or headers of the bench")
+
+	require.True(t, report.FirstRequest.ColdStart)
+	require.Equal(t, "200", report.FirstRequest.Status)
+	require.Equal(t, benchPods{Before: 0, Max: 2, After: 2}, report.Pods)
+	// the rate limits the requests, with some slack for slow machines
+	require.Greater(t, report.Requests, 10)
+	require.LessOrEqual(t, report.Requests, 55)
+	require.Greater(t, report.Errors["503"], 0)
+	require.Len(t, report.Errors, 1)
+	require.LessOrEqual(t, report.Latency.P50Ms, report.Latency.P99Ms)
+	require.LessOrEqual(t, report.Latency.P99Ms, report.Latency.MaxMs)
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	require.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	require.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	require.Equal(t, time.Millisecond, percentile(latencies[:1], 99))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/coldstart"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type ColdStartReportSubCommand struct {
+	cmd.CommandActioner
+}
+
+func ColdStartReport(input cli.Input) error {
+	return (&ColdStartReportSubCommand{}).do(input)
+}
+
+func (opts *ColdStartReportSubCommand) do(input cli.Input) error {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return fmt.Errorf("error in getting cold start report : %w", err)
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = metav1.NamespaceAll
+	}
+	fnName := input.String(flagkey.FnName)
+
+	executorURL, err := util.GetExecutorURL(input.Context(), opts.Client())
+	if err != nil {
+		return fmt.Errorf("error getting executor URL: %w", err)
+	}
+	query := url.Values{}
+	query.Set("namespace", namespace)
+	query.Set("function", fnName)
+	executorURL = executorURL.JoinPath("/v2/coldstarts")
+	executorURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(input.Context(), http.MethodGet, executorURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error getting cold starts from executor: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ferror.MakeErrorFromHTTP(resp)
+	}
+
+	var records []coldstart.Record
+	err = json.NewDecoder(resp.Body).Decode(&records)
+	if err != nil {
+		return fmt.Errorf("error decoding cold starts: %w", err)
+	}
+	summaries := coldstart.Summarize(records)
+	if !p.IsTable() {
+		return p.PrintData(os.Stdout, summaries, nil)
+	}
+	if len(records) == 0 {
+		fmt.Println("No recent cold starts found")
+		return nil
+	}
+
+	// the wide output has the average duration of each phase
+	phases := coldstart.Phases(records)
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "NAMESPACE"}, {Header: "EXECUTORTYPE"}, {Header: "COLDSTARTS"},
+		{Header: "ERRORS"}, {Header: "AVG"}, {Header: "MAX"}, {Header: "LAST"},
+	}}
+	for _, phase := range phases {
+		table.Columns = append(table.Columns, printer.Column{Header: strings.ToUpper(phase), Wide: true})
+	}
+	for _, s := range summaries {
+		row := []any{s.Function, s.Namespace, s.ExecutorType, s.Count, s.Errors,
+			s.Average.Round(time.Millisecond), s.Max.Round(time.Millisecond),
+			s.Last.Format(time.RFC3339)}
+		for _, phase := range phases {
+			row = append(row, s.Phases[phase].Round(time.Millisecond))
+		}
+		table.AddRow(row...)
+	}
+	err = p.PrintData(os.Stdout, summaries, table)
+	if err != nil {
+		return err
+	}
+
+	// break down the cold starts of a single function by phase
+	if len(fnName) == 0 || len(summaries) != 1 {
+		return nil
+	}
+	fmt.Println()
+	table = &printer.Table{Columns: []printer.Column{{Header: "PHASE"}, {Header: "AVG"}}}
+	for _, phase := range phases {
+		table.AddRow(phase, summaries[0].Phases[phase].Round(time.Millisecond))
+	}
+	return table.Print(os.Stdout, false)
+}
@@ -71,7 +71,7 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(getmetaCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.FnName},
-		Optional: []flag.Flag{flag.NamespaceFunction},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.Output},
 	})
 
 	updateCmd := &cobra.Command{
@@ -120,7 +120,7 @@ func Commands() *cobra.Command {
 		RunE:    wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.NamespaceFunction, flag.AllNamespaces},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.AllNamespaces, flag.Output},
 	})
 
 	logsCmd := &cobra.Command{
@@ -133,7 +133,8 @@ func Commands() *cobra.Command {
 		Required: []flag.Flag{flag.FnName},
 		Optional: []flag.Flag{
 			flag.FnLogFollow, flag.FnLogReverseQuery, flag.FnLogCount,
-			flag.FnLogDetail, flag.FnLogPod, flag.NamespaceFunction, flag.FnLogDBType, flag.NamespacePod, flag.FnLogAllPods},
+			flag.FnLogDetail, flag.FnLogPod, flag.NamespaceFunction, flag.FnLogDBType, flag.NamespacePod, flag.FnLogAllPods,
+			flag.FnLogGrep, flag.FnLogLevel},
 	})
 
 	testCmd := &cobra.Command{
@@ -211,16 +212,69 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(listPodsCmd, flag.FlagSet{
 		Required: []flag.Flag{flag.FnName},
-		Optional: []flag.Flag{flag.NamespaceFunction},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.Output},
+	})
+
+	coldStartReportCmd := &cobra.Command{
+		Use:   "coldstart-report",
+		Short: "Summarize recent cold starts of functions",
+		Long:  "Summarize the cold starts recently handled by the executor per function. With --name, cold start time is broken down by phase.",
+		RunE:  wrapper.Wrapper(ColdStartReport),
+	}
+	wrapper.SetFlags(coldStartReportCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.FnName, flag.NamespaceFunction, flag.AllNamespaces, flag.Output},
 	})
 
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure the latency of a function under load",
+		Long:  "Send requests to a function at a target rate or concurrency, and report the latency of the first request, which is a cold start if the function has no pods, the percentile latencies after the warm-up, the errors by status, and how many pods the executor scaled the function to.",
+		RunE:  wrapper.Wrapper(Bench),
+	}
+	wrapper.SetFlags(benchCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.HtMethod, flag.FnTestHeader, flag.FnTestBody,
+			flag.FnTestQuery, flag.FnTestTimeout, flag.FnSubPath, flag.NamespaceFunction,
+			flag.FnBenchRate, flag.FnBenchConcurrency, flag.FnBenchDuration, flag.FnBenchWarmup,
+			flag.FnBenchOutput,
+		},
+	})
+
+	jobGetCmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the status and result of a job invocation",
+		RunE:  wrapper.Wrapper(JobGet),
+	}
+	wrapper.SetFlags(jobGetCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnJobID},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.FnJobWait},
+	})
+
+	jobLogsCmd := &cobra.Command{
+		Use:     "logs",
+		Aliases: []string{"log"},
+		Short:   "Display the logs of a job invocation",
+		RunE:    wrapper.Wrapper(JobLogs),
+	}
+	wrapper.SetFlags(jobLogsCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName, flag.FnJobID},
+		Optional: []flag.Flag{flag.NamespaceFunction, flag.FnLogFollow},
+	})
+
+	jobCmd := &cobra.Command{
+		Use:   "job",
+		Short: "Inspect invocations of job functions",
+		Long:  "Inspect invocations of job functions. An invocation is submitted with a POST to the function, e.g. with 'fission fn test --method POST', which returns its ID.",
+	}
+	jobCmd.AddCommand(jobGetCmd, jobLogsCmd)
+
 	command := &cobra.Command{
 		Use:     "function",
 		Aliases: []string{"fn"},
 		Short:   "Create, update and manage functions",
 	}
 	command.AddCommand(createCmd, getCmd, getmetaCmd, updateCmd, deleteCmd, listCmd, logsCmd, testCmd,
-		runContainerCmd, updateContainerCmd, listPodsCmd)
+		runContainerCmd, updateContainerCmd, listPodsCmd, coldStartReportCmd, benchCmd, jobCmd)
 
 	return command
 }
@@ -489,8 +489,14 @@ func getExecutorType(input cli.Input) (executorType fv1.ExecutorType, err error)
 		executorType = fv1.ExecutorTypeNewdeploy
 	case string(fv1.ExecutorTypeContainer):
 		executorType = fv1.ExecutorTypeContainer
+	case string(fv1.ExecutorTypeJob):
+		executorType = fv1.ExecutorTypeJob
 	default:
-		err = fmt.Errorf("executor type must be one of '%v', '%v' or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer)
+		if t := fv1.ExecutorType(input.String(flagkey.FnExecutorType)); t.IsPlugin() {
+			executorType = t
+			break
+		}
+		err = fmt.Errorf("executor type must be one of '%v', '%v', '%v', '%v' or '%v<name>'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer, fv1.ExecutorTypeJob, fv1.ExecutorTypePluginPrefix)
 	}
 	return executorType, err
 }
@@ -572,8 +578,14 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 			fnExecutor = fv1.ExecutorTypeNewdeploy
 		case string(fv1.ExecutorTypeContainer):
 			fnExecutor = fv1.ExecutorTypeContainer
+		case string(fv1.ExecutorTypeJob):
+			fnExecutor = fv1.ExecutorTypeJob
 		default:
-			return nil, fmt.Errorf("executor type must be one of '%v', %v or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer)
+			if t := fv1.ExecutorType(input.String(flagkey.FnExecutorType)); t.IsPlugin() {
+				fnExecutor = t
+				break
+			}
+			return nil, fmt.Errorf("executor type must be one of '%v', '%v', '%v', '%v' or '%v<name>'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer, fv1.ExecutorTypeJob, fv1.ExecutorTypePluginPrefix)
 		}
 	}
 
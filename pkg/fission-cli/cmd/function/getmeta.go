@@ -18,12 +18,14 @@ package function
 
 import (
 	"fmt"
+	"os"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type GetMetaSubCommand struct {
@@ -35,6 +37,11 @@ func GetMeta(input cli.Input) error {
 }
 
 func (opts *GetMetaSubCommand) do(input cli.Input) error {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
 		return fmt.Errorf("error in getting meta function : %w", err)
@@ -45,6 +52,10 @@ func (opts *GetMetaSubCommand) do(input cli.Input) error {
 		return fmt.Errorf("error getting function: %w", err)
 	}
 
+	if !p.IsTable() {
+		return p.Print(os.Stdout, fn, nil)
+	}
+
 	fmt.Printf("Name: %v\n", fn.ObjectMeta.Name)
 	fmt.Printf("Environment: %v\n", fn.Spec.Environment.Name)
 	if len(fn.ObjectMeta.Labels) != 0 {
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/executortype/jobmgr"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// jobPollInterval is how often a job invocation is polled with --wait.
+const jobPollInterval = 2 * time.Second
+
+type JobSubCommand struct {
+	cmd.CommandActioner
+}
+
+// JobGet shows the status and result of an invocation of a job function.
+func JobGet(input cli.Input) error {
+	return (&JobSubCommand{}).get(input)
+}
+
+// JobLogs shows the logs of an invocation of a job function.
+func JobLogs(input cli.Input) error {
+	return (&JobSubCommand{}).logs(input)
+}
+
+func (opts *JobSubCommand) get(input cli.Input) error {
+	invocationURL, err := opts.invocationURL(input)
+	if err != nil {
+		return err
+	}
+
+	interval := time.Duration(0)
+	if input.Bool(flagkey.FnJobWait) {
+		interval = jobPollInterval
+	}
+	invocation, err := getJobInvocation(input.Context(), invocationURL, interval)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "ID", "STATUS", "EXITCODE", "CREATED", "COMPLETED")
+	exitCode, completed := "", ""
+	if invocation.ExitCode != nil {
+		exitCode = strconv.Itoa(int(*invocation.ExitCode))
+	}
+	if invocation.CompletionTime != nil {
+		completed = invocation.CompletionTime.Format(time.RFC3339)
+	}
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", invocation.ID, invocation.Status, exitCode,
+		invocation.CreationTime.Format(time.RFC3339), completed)
+	w.Flush()
+
+	if len(invocation.Result) > 0 {
+		fmt.Printf("\n%s\n", invocation.Result)
+	}
+	return nil
+}
+
+func (opts *JobSubCommand) logs(input cli.Input) error {
+	invocationURL, err := opts.invocationURL(input)
+	if err != nil {
+		return err
+	}
+	return getJobInvocationLogs(input.Context(), invocationURL, input.Bool(flagkey.FnLogFollow), os.Stdout)
+}
+
+// invocationURL returns the router URL of the job invocation of the flags.
+func (opts *JobSubCommand) invocationURL(input cli.Input) (*url.URL, error) {
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return nil, fmt.Errorf("error in getting job invocation : %w", err)
+	}
+	routerURL, err := util.GetRouterURL(input.Context(), opts.Client())
+	if err != nil {
+		return nil, fmt.Errorf("error getting router URL: %w", err)
+	}
+	return routerURL.JoinPath(util.UrlForFunction(input.String(flagkey.FnName), namespace), input.String(flagkey.FnJobID)), nil
+}
+
+// getJobInvocation returns the job invocation at the router URL. With a
+// positive interval, it's polled until the invocation is done.
+func getJobInvocation(ctx context.Context, invocationURL *url.URL, interval time.Duration) (*jobmgr.Invocation, error) {
+	for {
+		resp, err := jobRequest(ctx, invocationURL)
+		if err != nil {
+			return nil, err
+		}
+		invocation := &jobmgr.Invocation{}
+		err = json.NewDecoder(resp.Body).Decode(invocation)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding job invocation: %w", err)
+		}
+
+		done := invocation.Status == jobmgr.InvocationStatusSucceeded || invocation.Status == jobmgr.InvocationStatusFailed
+		if interval <= 0 || done {
+			return invocation, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// getJobInvocationLogs writes the logs of the job invocation at the router
+// URL to w.
+func getJobInvocationLogs(ctx context.Context, invocationURL *url.URL, follow bool, w io.Writer) error {
+	logsURL := invocationURL.JoinPath("logs")
+	if follow {
+		logsURL.RawQuery = url.Values{"follow": []string{"true"}}.Encode()
+	}
+	resp, err := jobRequest(ctx, logsURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading job invocation logs: %w", err)
+	}
+	return nil
+}
+
+func jobRequest(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting job invocation from router: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+	return resp, nil
+}
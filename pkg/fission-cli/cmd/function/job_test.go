@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fission/fission/pkg/executor/executortype/jobmgr"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+func TestJobInvocation(t *testing.T) {
+	fnPath := util.UrlForFunction("batch", "jobs")
+	// the router routes of a job function, the invocation succeeds on the
+	// third poll
+	var polls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+fnPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", fnPath+"/job-1")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"job-1","status":"queued"}`)
+	})
+	mux.HandleFunc("GET "+fnPath+"/job-1", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 3 {
+			fmt.Fprint(w, `{"id":"job-1","status":"running"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"job-1","status":"succeeded","result":"done","exitCode":0}`)
+	})
+	mux.HandleFunc("GET "+fnPath+"/job-1/logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "logs follow=%s", r.URL.Query().Get("follow"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	routerURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	resp, err := http.Post(routerURL.JoinPath(fnPath).String(), "text/plain", strings.NewReader("input"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	invocationURL, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	// without waiting, the current status is returned
+	invocation, err := getJobInvocation(t.Context(), invocationURL, 0)
+	require.NoError(t, err)
+	require.Equal(t, jobmgr.InvocationStatusRunning, invocation.Status)
+
+	invocation, err = getJobInvocation(t.Context(), invocationURL, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, jobmgr.InvocationStatusSucceeded, invocation.Status)
+	require.Equal(t, "done", invocation.Result)
+	require.EqualValues(t, 3, polls.Load())
+
+	var logs bytes.Buffer
+	require.NoError(t, getJobInvocationLogs(t.Context(), invocationURL, true, &logs))
+	require.Equal(t, "logs follow=true", logs.String())
+
+	// unknown invocations are reported
+	_, err = getJobInvocation(t.Context(), routerURL.JoinPath(fnPath, "job-2"), 0)
+	require.Error(t, err)
+}
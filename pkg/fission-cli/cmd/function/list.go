@@ -20,13 +20,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -38,6 +39,11 @@ func List(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) do(input cli.Input) error {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
 		return fmt.Errorf("error in listing function : %w", err)
@@ -52,10 +58,18 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 		return fmt.Errorf("error listing functions: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	return p.Print(os.Stdout, fns, functionTable(fns.Items))
+}
 
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "ENV", "EXECUTORTYPE", "MINSCALE", "MAXSCALE", "MINCPU", "MAXCPU", "MINMEMORY", "MAXMEMORY", "SECRETS", "CONFIGMAPS", "NAMESPACE")
-	for _, f := range fns.Items {
+// functionTable returns the table of the functions.
+func functionTable(fns []fv1.Function) *printer.Table {
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "ENV"}, {Header: "EXECUTORTYPE"}, {Header: "MINSCALE"}, {Header: "MAXSCALE"},
+		{Header: "MINCPU"}, {Header: "MAXCPU"}, {Header: "MINMEMORY"}, {Header: "MAXMEMORY"},
+		{Header: "SECRETS"}, {Header: "CONFIGMAPS"}, {Header: "NAMESPACE"},
+		{Header: "PACKAGE", Wide: true}, {Header: "ENTRYPOINT", Wide: true}, {Header: "FNTIMEOUT", Wide: true},
+	}}
+	for _, f := range fns {
 		secrets := f.Spec.Secrets
 		configMaps := f.Spec.ConfigMaps
 		var secretsList, configMapList []string
@@ -66,7 +80,7 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 			configMapList = append(configMapList, configMap.Name)
 		}
 
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+		table.AddRow(
 			f.ObjectMeta.Name, f.Spec.Environment.Name,
 			f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType,
 			f.Spec.InvokeStrategy.ExecutionStrategy.MinScale,
@@ -77,9 +91,10 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 			f.Spec.Resources.Limits.Memory().String(),
 			strings.Join(secretsList, ","),
 			strings.Join(configMapList, ","),
-			f.ObjectMeta.Namespace)
+			f.ObjectMeta.Namespace,
+			f.Spec.Package.PackageRef.Name,
+			f.Spec.Package.FunctionName,
+			f.Spec.FunctionTimeout)
 	}
-	w.Flush()
-
-	return nil
+	return table
 }
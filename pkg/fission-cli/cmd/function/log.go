@@ -49,6 +49,11 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 
 	dbType := input.String(flagkey.FnLogDBType)
 	fnPod := input.String(flagkey.FnLogPod)
+	grep := input.String(flagkey.FnLogGrep)
+	level := input.String(flagkey.FnLogLevel)
+	if level != "" && !logdb.SupportsLevel(dbType) {
+		return fmt.Errorf("log database %s doesn't support filtering by level", dbType)
+	}
 
 	logReverseQuery := !input.Bool(flagkey.FnLogFollow) && input.Bool(flagkey.FnLogReverseQuery)
 
@@ -73,6 +78,22 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 		return fmt.Errorf("failed to get log from %s: %w", dbType, err)
 	}
 
+	// stream the logs from the databases that can, rather than poll them
+	if follower, ok := logDB.(logdb.LogFollower); ok && input.Bool(flagkey.FnLogFollow) {
+		return follower.FollowLogs(input.Context(), logdb.LogFilter{
+			Pod:            fnPod,
+			PodNamespace:   input.String(flagkey.NamespacePod),
+			Function:       f.ObjectMeta.Name,
+			FuncUid:        string(f.ObjectMeta.UID),
+			RecordLimit:    recordLimit,
+			FunctionObject: f,
+			Details:        input.Bool(flagkey.FnLogDetail),
+			AllPods:        allPods,
+			Grep:           grep,
+			Level:          level,
+		}, os.Stdout)
+	}
+
 	requestChan := make(chan struct{})
 	responseChan := make(chan struct{})
 	ctx := input.Context()
@@ -96,6 +117,8 @@ func (opts *LogSubCommand) do(input cli.Input) error {
 					Details:        detail,
 					WarnUser:       warn,
 					AllPods:        allPods,
+					Grep:           grep,
+					Level:          level,
 				}
 
 				buf := new(bytes.Buffer)
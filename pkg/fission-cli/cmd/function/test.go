@@ -71,38 +71,9 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 	if err != nil {
 		return fmt.Errorf("error getting router URL: %w", err)
 	}
-	fnURI := util.UrlForFunction(m.Name, m.Namespace)
-	if input.IsSet(flagkey.FnSubPath) {
-		subPath := input.String(flagkey.FnSubPath)
-		if !strings.HasPrefix(subPath, "/") {
-			fnURI = fnURI + "/" + subPath
-		} else {
-			fnURI = fnURI + subPath
-		}
-	}
-	fnURL := routerURL.JoinPath(fnURI)
+	fnURL := functionTestURL(input, routerURL, m)
 	console.Verbose(2, "Function test url: %v", fnURL.String())
 
-	queryParams := input.StringSlice(flagkey.FnTestQuery)
-	if len(queryParams) > 0 {
-		query := url.Values{}
-		for _, q := range queryParams {
-			queryParts := strings.SplitN(q, "=", 2)
-			var key, value string
-			if len(queryParts) == 0 {
-				continue
-			}
-			if len(queryParts) > 0 {
-				key = queryParts[0]
-			}
-			if len(queryParts) > 1 {
-				value = queryParts[1]
-			}
-			query.Set(key, value)
-		}
-		fnURL.RawQuery = query.Encode()
-	}
-
 	var (
 		ctx        context.Context
 		reqTimeout time.Duration
@@ -126,13 +97,7 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 		defer closeCtx()
 	}
 
-	methods := input.StringSlice(flagkey.HtMethod)
-	if len(methods) == 0 {
-		return errors.New("HTTP method not mentioned")
-	} else if len(methods) > 1 {
-		return errors.New("more than one HTTP method not supported")
-	}
-	method, err := httptrigger.GetMethod(methods[0])
+	method, err := functionTestMethod(input)
 	if err != nil {
 		return err
 	}
@@ -167,6 +132,53 @@ func (opts *TestSubCommand) do(input cli.Input) error {
 	return errors.New("error getting function response")
 }
 
+// functionTestURL returns the router URL of the function, with the sub path
+// and query parameters of the function test flags.
+func functionTestURL(input cli.Input, routerURL *url.URL, m *metav1.ObjectMeta) *url.URL {
+	fnURI := util.UrlForFunction(m.Name, m.Namespace)
+	if input.IsSet(flagkey.FnSubPath) {
+		subPath := input.String(flagkey.FnSubPath)
+		if !strings.HasPrefix(subPath, "/") {
+			fnURI = fnURI + "/" + subPath
+		} else {
+			fnURI = fnURI + subPath
+		}
+	}
+	fnURL := routerURL.JoinPath(fnURI)
+
+	queryParams := input.StringSlice(flagkey.FnTestQuery)
+	if len(queryParams) > 0 {
+		query := url.Values{}
+		for _, q := range queryParams {
+			queryParts := strings.SplitN(q, "=", 2)
+			var key, value string
+			if len(queryParts) == 0 {
+				continue
+			}
+			if len(queryParts) > 0 {
+				key = queryParts[0]
+			}
+			if len(queryParts) > 1 {
+				value = queryParts[1]
+			}
+			query.Set(key, value)
+		}
+		fnURL.RawQuery = query.Encode()
+	}
+	return fnURL
+}
+
+// functionTestMethod returns the HTTP method of the function test flags.
+func functionTestMethod(input cli.Input) (string, error) {
+	methods := input.StringSlice(flagkey.HtMethod)
+	if len(methods) == 0 {
+		return "", errors.New("HTTP method not mentioned")
+	} else if len(methods) > 1 {
+		return "", errors.New("more than one HTTP method not supported")
+	}
+	return httptrigger.GetMethod(methods[0])
+}
+
 func doHTTPRequest(ctx context.Context, url string, headers []string, method, body string) (*http.Response, error) {
 	shutdown, err := otelUtils.InitProvider(ctx, nil, "fission-cli")
 	if err != nil {
@@ -185,22 +197,9 @@ func doHTTPRequest(ctx context.Context, url string, headers []string, method, bo
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	req, err := newTestRequest(ctx, url, headers, method, body)
 	if err != nil {
-		return nil, fmt.Errorf("error creating HTTP request: %w", err)
-	}
-
-	accesstoken, ok := os.LookupEnv(util.FISSION_AUTH_TOKEN)
-	if ok && len(accesstoken) != 0 {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accesstoken))
-	}
-
-	for _, header := range headers {
-		headerKeyValue := strings.SplitN(header, ":", 2)
-		if len(headerKeyValue) != 2 {
-			return nil, errors.New("failed to create request without appropriate headers")
-		}
-		req.Header.Set(headerKeyValue[0], headerKeyValue[1])
+		return nil, err
 	}
 
 	if console.Verbosity >= 2 {
@@ -212,7 +211,7 @@ func doHTTPRequest(ctx context.Context, url string, headers []string, method, bo
 	}
 
 	hc := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
-	resp, err := hc.Do(req.WithContext(ctx))
+	resp, err := hc.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error executing HTTP request: %w", err)
 	}
@@ -227,3 +226,26 @@ func doHTTPRequest(ctx context.Context, url string, headers []string, method, bo
 
 	return resp, nil
 }
+
+// newTestRequest creates a request to a function, with the auth token of the
+// environment and the headers in the "key:value" format.
+func newTestRequest(ctx context.Context, url string, headers []string, method, body string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+
+	accesstoken, ok := os.LookupEnv(util.FISSION_AUTH_TOKEN)
+	if ok && len(accesstoken) != 0 {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accesstoken))
+	}
+
+	for _, header := range headers {
+		headerKeyValue := strings.SplitN(header, ":", 2)
+		if len(headerKeyValue) != 2 {
+			return nil, errors.New("failed to create request without appropriate headers")
+		}
+		req.Header.Set(headerKeyValue[0], headerKeyValue[1])
+	}
+	return req, nil
+}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -28,6 +27,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type GetSubCommand struct {
@@ -43,6 +43,11 @@ func (opts *GetSubCommand) do(input cli.Input) error {
 }
 
 func (opts *GetSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
 	if err != nil {
 		return fmt.Errorf("error in deleting function : %w", err)
@@ -53,14 +58,15 @@ func (opts *GetSubCommand) run(input cli.Input) (err error) {
 		return fmt.Errorf("error getting http trigger: %w", err)
 	}
 
-	printHtSummary([]fv1.HTTPTrigger{*ht})
-
-	return nil
+	return p.Print(os.Stdout, ht, htTable([]fv1.HTTPTrigger{*ht}))
 }
 
-func printHtSummary(triggers []fv1.HTTPTrigger) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "METHOD", "URL", "FUNCTION(s)", "INGRESS", "HOST", "PATH", "TLS", "ANNOTATIONS", "NAMESPACE")
+func htTable(triggers []fv1.HTTPTrigger) *printer.Table {
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "METHOD"}, {Header: "URL"}, {Header: "FUNCTION(s)"}, {Header: "INGRESS"},
+		{Header: "HOST"}, {Header: "PATH"}, {Header: "TLS"}, {Header: "ANNOTATIONS"}, {Header: "NAMESPACE"},
+		{Header: "PREFIX", Wide: true}, {Header: "KEEPPREFIX", Wide: true},
+	}}
 	for _, trigger := range triggers {
 		function := ""
 		if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionName {
@@ -93,8 +99,12 @@ func printHtSummary(triggers []fv1.HTTPTrigger) {
 		if len(trigger.Spec.Methods) > 0 {
 			methods = trigger.Spec.Methods
 		}
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			trigger.Name, methods, trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann, trigger.ObjectMeta.Namespace)
+		prefix := ""
+		if trigger.Spec.Prefix != nil {
+			prefix = *trigger.Spec.Prefix
+		}
+		table.AddRow(trigger.Name, methods, trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann, trigger.ObjectMeta.Namespace,
+			prefix, trigger.Spec.KeepPrefix)
 	}
-	w.Flush()
+	return table
 }
@@ -18,6 +18,7 @@ package httptrigger
 
 import (
 	"fmt"
+	"os"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -40,6 +42,10 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
 	if err != nil {
@@ -57,16 +63,15 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 
 	filterFunctionName := input.String(flagkey.HtFnName)
 
-	var triggers []fv1.HTTPTrigger
+	triggers := &fv1.HTTPTriggerList{}
 	for _, ht := range hts.Items {
 		// TODO: list canary http triggers as well.
 		if len(filterFunctionName) == 0 ||
 			(len(filterFunctionName) > 0 && filterFunctionName == ht.Spec.FunctionReference.Name) {
 
-			triggers = append(triggers, ht)
+			triggers.Items = append(triggers.Items, ht)
 		}
 	}
 
-	printHtSummary(triggers)
-	return nil
+	return p.Print(os.Stdout, triggers, htTable(triggers.Items))
 }
@@ -19,7 +19,6 @@ package kubewatch
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -27,6 +26,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -55,6 +55,11 @@ func (opts *ListSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	var ws *v1.KubernetesWatchTriggerList
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.namespace = metav1.NamespaceAll
@@ -65,15 +70,13 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 		return fmt.Errorf("error listing kubewatchers: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-		"NAME", "NAMESPACE", "OBJTYPE", "LABELS", "FUNCTION_NAME")
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "NAMESPACE"}, {Header: "OBJTYPE"}, {Header: "LABELS"}, {Header: "FUNCTION_NAME"},
+		{Header: "TRIGGER_NAMESPACE", Wide: true},
+	}}
 	for _, wa := range ws.Items {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			wa.ObjectMeta.Name, wa.Spec.Namespace, wa.Spec.Type, wa.Spec.LabelSelector, wa.Spec.FunctionReference.Name)
+		table.AddRow(wa.ObjectMeta.Name, wa.Spec.Namespace, wa.Spec.Type, wa.Spec.LabelSelector, wa.Spec.FunctionReference.Name, wa.ObjectMeta.Namespace)
 	}
-	w.Flush()
 
-	return nil
+	return p.Print(os.Stdout, ws, table)
 }
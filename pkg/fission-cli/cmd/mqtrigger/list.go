@@ -19,13 +19,13 @@ package mqtrigger
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -54,6 +54,10 @@ func (opts *ListSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.namespace = metav1.NamespaceAll
@@ -64,15 +68,15 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 		return fmt.Errorf("error listing message queue triggers: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-		"NAME", "FUNCTION_NAME", "MESSAGE_QUEUE_TYPE", "TOPIC", "RESPONSE_TOPIC", "ERROR_TOPIC", "MAX_RETRIES", "PUB_MSG_CONTENT_TYPE", "NAMESPACE")
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "FUNCTION_NAME"}, {Header: "MESSAGE_QUEUE_TYPE"}, {Header: "TOPIC"}, {Header: "RESPONSE_TOPIC"},
+		{Header: "ERROR_TOPIC"}, {Header: "MAX_RETRIES"}, {Header: "PUB_MSG_CONTENT_TYPE"}, {Header: "NAMESPACE"},
+		{Header: "MQTKIND", Wide: true},
+	}}
 	for _, mqt := range mqts.Items {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			mqt.ObjectMeta.Name, mqt.Spec.FunctionReference.Name, mqt.Spec.MessageQueueType, mqt.Spec.Topic, mqt.Spec.ResponseTopic, mqt.Spec.ErrorTopic, mqt.Spec.MaxRetries, mqt.Spec.ContentType, mqt.ObjectMeta.Namespace)
+		table.AddRow(mqt.ObjectMeta.Name, mqt.Spec.FunctionReference.Name, mqt.Spec.MessageQueueType, mqt.Spec.Topic, mqt.Spec.ResponseTopic,
+			mqt.Spec.ErrorTopic, mqt.Spec.MaxRetries, mqt.Spec.ContentType, mqt.ObjectMeta.Namespace, mqt.Spec.MqtKind)
 	}
-	w.Flush()
 
-	return nil
+	return p.Print(os.Stdout, mqts, table)
 }
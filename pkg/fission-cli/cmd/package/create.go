@@ -81,8 +81,17 @@ func (opts *CreateSubCommand) run(input cli.Input) error {
 		noZip = true
 	}
 
-	if len(srcArchiveFiles) == 0 && len(deployArchiveFiles) == 0 {
-		return fmt.Errorf("need --%v or --%v or --%v argument", flagkey.PkgCode, flagkey.PkgSrcArchive, flagkey.PkgDeployArchive)
+	gitURL := input.String(flagkey.PkgGitURL)
+	if len(gitURL) > 0 && len(srcArchiveFiles) > 0 {
+		return fmt.Errorf("--%v and --%v are mutually exclusive", flagkey.PkgGitURL, flagkey.PkgSrcArchive)
+	}
+
+	if len(input.String(flagkey.PkgPush)) > 0 && len(srcArchiveFiles) > 0 && len(deployArchiveFiles) > 0 {
+		return fmt.Errorf("--%v pushes a single archive, not both a source and a deploy archive", flagkey.PkgPush)
+	}
+
+	if len(srcArchiveFiles) == 0 && len(deployArchiveFiles) == 0 && len(gitURL) == 0 {
+		return fmt.Errorf("need --%v or --%v or --%v or --%v argument", flagkey.PkgCode, flagkey.PkgSrcArchive, flagkey.PkgDeployArchive, flagkey.PkgGitURL)
 	}
 
 	var specDir, specFile string
@@ -152,7 +161,7 @@ func CreatePackage(input cli.Input, client cmd.Client, pkgName string, pkgNamesp
 		if len(specFile) > 0 { // we should do this in all cases, i think
 			pkgStatus = fv1.BuildStatusNone
 		}
-		deployment, err := CreateArchive(client, input, deployArchiveFiles, noZip, insecure, deployChecksum, specDir, specFile)
+		deployment, err := CreateArchive(client, input, pkgNamespace, deployArchiveFiles, noZip, insecure, deployChecksum, specDir, specFile)
 		if err != nil {
 			return nil, fmt.Errorf("error creating deploy archive: %w", err)
 		}
@@ -162,7 +171,7 @@ func CreatePackage(input cli.Input, client cmd.Client, pkgName string, pkgNamesp
 		}
 	}
 	if len(srcArchiveFiles) > 0 {
-		source, err := CreateArchive(client, input, srcArchiveFiles, false, insecure, srcChecksum, specDir, specFile)
+		source, err := CreateArchive(client, input, pkgNamespace, srcArchiveFiles, false, insecure, srcChecksum, specDir, specFile)
 		if err != nil {
 			return nil, fmt.Errorf("error creating source archive: %w", err)
 		}
@@ -173,9 +182,30 @@ func CreatePackage(input cli.Input, client cmd.Client, pkgName string, pkgNamesp
 		}
 	}
 
+	if gitURL := input.String(flagkey.PkgGitURL); len(gitURL) > 0 {
+		pkgSpec.Source = fv1.Archive{
+			Type: fv1.ArchiveTypeGit,
+			Git: &fv1.GitSource{
+				URL:     gitURL,
+				Ref:     input.String(flagkey.PkgGitRef),
+				SubPath: input.String(flagkey.PkgGitSubPath),
+				Secret:  input.String(flagkey.PkgGitSecret),
+			},
+		}
+		err := signGitSource(input, &pkgSpec.Source)
+		if err != nil {
+			return nil, err
+		}
+		pkgStatus = fv1.BuildStatusPending
+		if len(pkgName) == 0 {
+			pkgName = util.KubifyName(fmt.Sprintf("%v-%v", strings.TrimSuffix(path.Base(gitURL), ".git"), uniuri.NewLen(4)))
+		}
+	}
+
 	if len(buildcmd) > 0 {
 		pkgSpec.BuildCommand = buildcmd
 	}
+	pkgSpec.BuildTimeout = input.Int(flagkey.PkgBuildTimeout)
 
 	if len(pkgName) == 0 {
 		pkgName = strings.ToLower(uuid.NewString())
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -30,6 +31,9 @@ import (
 	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
+	"github.com/fission/fission/pkg/utils/uuid"
 )
 
 type GetSubCommand struct {
@@ -91,6 +95,25 @@ func (opts *GetSubCommand) run(input cli.Input) error {
 		}
 		defer readCloser.Close()
 		reader = readCloser
+	} else if archive.Type == fv1.ArchiveTypeOCI {
+		tmpDir, err := utils.GetTempDir()
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(tmpDir, uuid.NewString())
+		err = oci.PullFile(input.Context(), archive.URL, file, nil)
+		if err != nil {
+			return fmt.Errorf("error pulling archive %s: %w", archive.URL, err)
+		}
+		defer os.Remove(file)
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		reader = f
+	} else if archive.Type == fv1.ArchiveTypeGit {
+		return fmt.Errorf("source of package %s is the git repository %s, clone it instead", opts.name, archive.Git.URL)
 	}
 
 	if len(opts.output) > 0 {
@@ -17,22 +17,34 @@ limitations under the License.
 package _package
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"time"
 
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
+	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
 type InfoSubCommand struct {
 	cmd.CommandActioner
 	name      string
 	namespace string
+	printer   *printer.Printer
 }
 
 func Info(input cli.Input) error {
@@ -49,6 +61,13 @@ func (opts *InfoSubCommand) do(input cli.Input) error {
 
 func (opts *InfoSubCommand) complete(input cli.Input) (err error) {
 	opts.name = input.String(flagkey.PkgName)
+	opts.printer, err = printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+	if input.Bool(flagkey.PkgFollow) && !opts.printer.IsTable() {
+		return errors.New("--follow can't be used with the --output formats, which print the package once")
+	}
 
 	_, opts.namespace, err = opts.GetResourceNamespace(input, flagkey.NamespacePackage)
 	if err != nil {
@@ -58,11 +77,164 @@ func (opts *InfoSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *InfoSubCommand) run(input cli.Input) error {
-	pkg, err := opts.Client().FissionClientSet.CoreV1().Packages(opts.namespace).Get(input.Context(), opts.name, metav1.GetOptions{})
+	pkg, err := opts.getPackage(input.Context())
 	if err != nil {
-		return fmt.Errorf("error finding package %s: %w", opts.name, err)
+		return err
+	}
+	if !opts.printer.IsTable() {
+		return opts.printer.Print(os.Stdout, pkg, nil)
+	}
+
+	if !input.Bool(flagkey.PkgFollow) || !isBuilding(pkg) {
+		pkgutil.PrintPackageSummary(os.Stdout, pkg)
+		return nil
 	}
 
-	pkgutil.PrintPackageSummary(os.Stdout, pkg)
+	pkg, err = opts.followBuild(input.Context(), pkg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nStatus: %v\n", pkg.Status.BuildStatus)
+	if len(pkg.Status.BuildLogURL) > 0 {
+		fmt.Printf("Build Log URL: %v\n", pkg.Status.BuildLogURL)
+	}
 	return nil
 }
+
+func (opts *InfoSubCommand) getPackage(ctx context.Context) (*fv1.Package, error) {
+	pkg, err := opts.Client().FissionClientSet.CoreV1().Packages(opts.namespace).Get(ctx, opts.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error finding package %s: %w", opts.name, err)
+	}
+	return pkg, nil
+}
+
+func isBuilding(pkg *fv1.Package) bool {
+	return pkg.Status.BuildStatus == fv1.BuildStatusPending || pkg.Status.BuildStatus == fv1.BuildStatusRunning
+}
+
+// followBuild streams the logs of the running build of the package from the
+// environment builder, and returns the package once the build is done.
+func (opts *InfoSubCommand) followBuild(ctx context.Context, pkg *fv1.Package) (*fv1.Package, error) {
+	env, err := opts.Client().FissionClientSet.CoreV1().Environments(pkg.Spec.Environment.Namespace).Get(ctx, pkg.Spec.Environment.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error finding environment %s: %w", pkg.Spec.Environment.Name, err)
+	}
+	if env.Spec.Builder.Mode == fv1.BuilderModeJob {
+		return opts.followBuildJob(ctx, pkg)
+	}
+	builderURL, err := util.GetBuilderURL(ctx, opts.Client(), env)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the builder of environment %s: %w", env.Name, err)
+	}
+	logsURL := builderURL.JoinPath("/logs")
+	logsURL.RawQuery = url.Values{"package": []string{fmt.Sprintf("%s/%s", pkg.Namespace, pkg.Name)}}.Encode()
+
+	streamed := false
+	for isBuilding(pkg) {
+		// the build may not have reached the builder yet
+		if !streamed {
+			streamed, err = streamBuildLogs(ctx, logsURL.String())
+			if err != nil {
+				return nil, err
+			}
+		}
+		time.Sleep(time.Second)
+		pkg, err = opts.getPackage(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pkg, nil
+}
+
+// followBuildJob streams the logs of the builder of the job building the
+// package, and returns the package once the build is done.
+func (opts *InfoSubCommand) followBuildJob(ctx context.Context, pkg *fv1.Package) (*fv1.Package, error) {
+	streamed := false
+	var err error
+	for isBuilding(pkg) {
+		// the job may not have been created or started yet
+		if !streamed {
+			streamed, err = opts.streamBuildJobLogs(ctx, pkg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		time.Sleep(time.Second)
+		pkg, err = opts.getPackage(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pkg, nil
+}
+
+// streamBuildJobLogs copies the logs of the builder container of the build
+// job pod of the package to stdout until the container exits. It returns
+// false if the pod is not running yet.
+func (opts *InfoSubCommand) streamBuildJobLogs(ctx context.Context, pkg *fv1.Package) (bool, error) {
+	// build jobs live in the builder namespace
+	selector := labels.Set{
+		fv1.LABEL_PACKAGE_NAME:      pkg.Name,
+		fv1.LABEL_PACKAGE_NAMESPACE: pkg.Namespace,
+	}
+	pods, err := opts.Client().KubernetesClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error finding the build job of package %s: %w", pkg.Name, err)
+	}
+	var pod *apiv1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Status.Phase != apiv1.PodRunning {
+			continue
+		}
+		if pod == nil || pod.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			pod = &pods.Items[i]
+		}
+	}
+	if pod == nil {
+		return false, nil
+	}
+
+	stream, err := opts.Client().KubernetesClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &apiv1.PodLogOptions{
+		Container: fv1.BuilderContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting build logs: %w", err)
+	}
+	defer stream.Close()
+	_, err = io.Copy(os.Stdout, stream)
+	if err != nil {
+		return false, fmt.Errorf("error streaming build logs: %w", err)
+	}
+	return true, nil
+}
+
+// streamBuildLogs copies the build logs to stdout until the build is done.
+// It returns false if the builder has no logs for the package yet.
+func streamBuildLogs(ctx context.Context, logsURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logsURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error getting build logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, ferror.MakeErrorFromHTTP(resp)
+	}
+	_, err = io.Copy(os.Stdout, resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("error streaming build logs: %w", err)
+	}
+	return true, nil
+}
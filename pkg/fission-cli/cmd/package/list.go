@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"text/tabwriter"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +28,7 @@ import (
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -62,6 +62,10 @@ func (opts *ListSubCommand) complete(input cli.Input) (err error) {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
 
 	if input.Bool(flagkey.AllNamespaces) {
 		opts.pkgNamespace = v1.NamespaceAll
@@ -77,9 +81,11 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 		return pkgList.Items[i].Status.LastUpdateTimestamp.After(pkgList.Items[j].Status.LastUpdateTimestamp.Time)
 	})
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "NAME", "BUILD_STATUS", "ENV", "LASTUPDATEDAT", "NAMESPACE")
-
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "BUILD_STATUS"}, {Header: "ENV"}, {Header: "LASTUPDATEDAT"}, {Header: "NAMESPACE"},
+		{Header: "DEPLOY", Wide: true}, {Header: "SOURCE", Wide: true},
+	}}
+	pkgs := &fv1.PackageList{}
 	for _, pkg := range pkgList.Items {
 		show := true
 		// TODO improve list speed when --orphan
@@ -96,11 +102,11 @@ func (opts *ListSubCommand) run(input cli.Input) (err error) {
 			show = false
 		}
 		if show {
-			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", pkg.ObjectMeta.Name, pkg.Status.BuildStatus, pkg.Spec.Environment.Name, pkg.Status.LastUpdateTimestamp.Format(time.RFC822), pkg.ObjectMeta.Namespace)
+			pkgs.Items = append(pkgs.Items, pkg)
+			table.AddRow(pkg.ObjectMeta.Name, pkg.Status.BuildStatus, pkg.Spec.Environment.Name, pkg.Status.LastUpdateTimestamp.Format(time.RFC822), pkg.ObjectMeta.Namespace,
+				pkg.Spec.Deployment.Type, pkg.Spec.Source.Type)
 		}
 	}
 
-	w.Flush()
-
-	return nil
+	return p.Print(os.Stdout, pkgs, table)
 }
@@ -18,10 +18,12 @@ package _package
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"errors"
@@ -40,6 +42,8 @@ import (
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
 	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/oci"
+	"github.com/fission/fission/pkg/utils/signature"
 	"github.com/fission/fission/pkg/utils/uuid"
 )
 
@@ -47,7 +51,9 @@ import (
 // create an archive upload spec in the specs directory; otherwise
 // upload the archive using client.  noZip avoids zipping the
 // includeFiles, but is ignored if there's more than one includeFile.
-func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, noZip bool, insecure bool, checksum string, specDir string, specFile string) (*fv1.Archive, error) {
+// With --push, the archive is pushed to an OCI registry instead.
+// Uploaded archives are scoped to the namespace of the package.
+func CreateArchive(client cmd.Client, input cli.Input, namespace string, includeFiles []string, noZip bool, insecure bool, checksum string, specDir string, specFile string) (*fv1.Archive, error) {
 	// get root dir
 	var rootDir string
 	var err error
@@ -63,8 +69,8 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 
 	// check files existence
 	for _, path := range includeFiles {
-		// ignore http files
-		if utils.IsURL(path) {
+		// ignore http files and OCI references
+		if utils.IsURL(path) || oci.IsURL(path) {
 			if len(includeFiles) > 1 {
 				// It's intentional to disallow the user to provide file and URL at the same time.
 				return nil, errors.New("unable to create an archive that contains both file and URL")
@@ -101,12 +107,29 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 		return nil, errs.ErrorOrNil()
 	}
 
+	if oci.IsURL(fileURL) {
+		ref, err := oci.ParseURL(fileURL)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := ref.Digest(); err != nil {
+			console.Warn(fmt.Sprintf("%v is not pinned to a digest, the archive is not verified and may change", fileURL))
+		}
+		archive := &fv1.Archive{
+			Type:           fv1.ArchiveTypeOCI,
+			URL:            fileURL,
+			RegistrySecret: input.String(flagkey.PkgRegistrySecret),
+		}
+		return archive, signArchive(input, archive, "")
+	}
+
 	if len(fileURL) > 0 {
 		if insecure {
-			return &fv1.Archive{
+			archive := &fv1.Archive{
 				Type: fv1.ArchiveTypeUrl,
 				URL:  fileURL,
-			}, nil
+			}
+			return archive, signArchive(input, archive, "")
 		}
 
 		var csum *fv1.Checksum
@@ -137,14 +160,41 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 			}
 		}
 
-		return &fv1.Archive{
+		archive := &fv1.Archive{
 			Type:     fv1.ArchiveTypeUrl,
 			URL:      fileURL,
 			Checksum: *csum,
-		}, nil
+		}
+		return archive, signArchive(input, archive, csum.Sum)
+	}
+
+	if pushURL := input.String(flagkey.PkgPush); len(pushURL) > 0 {
+		archivePath, err := makeArchiveFile(input.Context(), "", includeFiles, noZip)
+		if err != nil {
+			return nil, err
+		}
+		csum, err := utils.GetFileChecksum(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		archiveURL, err := oci.PushFile(input.Context(), pushURL, archivePath, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error pushing archive to %v: %w", pushURL, err)
+		}
+		fmt.Printf("Archive pushed to %v\n", archiveURL)
+		archive := &fv1.Archive{
+			Type:           fv1.ArchiveTypeOCI,
+			URL:            archiveURL,
+			RegistrySecret: input.String(flagkey.PkgRegistrySecret),
+		}
+		return archive, signArchive(input, archive, csum.Sum)
 	}
 
 	if input.Bool(flagkey.SpecSave) || input.Bool(flagkey.SpecDry) {
+		if len(input.String(flagkey.PkgSignKey)) > 0 {
+			return nil, fmt.Errorf("--%v cannot sign archives uploaded when applying specs", flagkey.PkgSignKey)
+		}
+
 		// create an ArchiveUploadSpec and reference it from the archive
 		aus := &spectypes.ArchiveUploadSpec{
 			Name:         archiveName("", includeFiles),
@@ -191,7 +241,69 @@ func CreateArchive(client cmd.Client, input cli.Input, includeFiles []string, no
 		return nil, err
 	}
 
-	return pkgutil.UploadArchiveFile(input.Context(), client, archivePath)
+	archive, err := pkgutil.UploadArchiveFile(input.Context(), client, archivePath, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.String(flagkey.PkgSignKey)) > 0 {
+		csum, err := utils.GetFileChecksum(archivePath)
+		if err != nil {
+			return nil, err
+		}
+		err = signArchive(input, archive, csum.Sum)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// signArchive signs the archive with the private key of --sign-key, if
+// given. checksum is the SHA-256 of the archive file.
+func signArchive(input cli.Input, archive *fv1.Archive, checksum string) error {
+	keyFile := input.String(flagkey.PkgSignKey)
+	if len(keyFile) == 0 {
+		return nil
+	}
+	if len(checksum) == 0 {
+		return fmt.Errorf("--%v needs a local archive or the checksum of the archive to sign", flagkey.PkgSignKey)
+	}
+
+	digest, err := hex.DecodeString(checksum)
+	if err != nil {
+		return fmt.Errorf("invalid archive checksum %q: %w", checksum, err)
+	}
+	return signDigest(keyFile, archive, digest)
+}
+
+// gitCommitID matches full SHA-1 and SHA-256 git commit IDs.
+var gitCommitID = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// signGitSource signs the commit of a git source with the private key of
+// --sign-key, if any. Only a ref that is a full commit ID can be signed,
+// as branches and tags move.
+func signGitSource(input cli.Input, archive *fv1.Archive) error {
+	keyFile := input.String(flagkey.PkgSignKey)
+	if len(keyFile) == 0 {
+		return nil
+	}
+	if !gitCommitID.MatchString(archive.Git.Ref) {
+		return fmt.Errorf("--%v needs --%v to be a full commit ID to sign a git source", flagkey.PkgSignKey, flagkey.PkgGitRef)
+	}
+	return signDigest(keyFile, archive, signature.GitCommitDigest(archive.Git.Ref))
+}
+
+func signDigest(keyFile string, archive *fv1.Archive, digest []byte) error {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("error reading signing key: %w", err)
+	}
+	key, err := signature.ParsePrivateKey(data)
+	if err != nil {
+		return err
+	}
+	archive.Signature, err = signature.Sign(key, digest)
+	return err
 }
 
 // makeArchiveFile creates a zip file from the given list of input files,
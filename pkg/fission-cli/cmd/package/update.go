@@ -128,8 +128,13 @@ func UpdatePackage(input cli.Input, client cmd.Client, specFile string, pkg *fv1
 		needToUpdate = true
 	}
 
+	if input.IsSet(flagkey.PkgBuildTimeout) {
+		pkg.Spec.BuildTimeout = input.Int(flagkey.PkgBuildTimeout)
+		needToUpdate = true
+	}
+
 	if input.IsSet(flagkey.PkgSrcArchive) {
-		srcArchive, err := CreateArchive(client, input, srcArchiveFiles, noZip, insecure, srcChecksum, "", "")
+		srcArchive, err := CreateArchive(client, input, pkg.Namespace, srcArchiveFiles, noZip, insecure, srcChecksum, "", "")
 		if err != nil {
 			return nil, fmt.Errorf("error creating source archive: %w", err)
 		}
@@ -145,7 +150,7 @@ func UpdatePackage(input cli.Input, client cmd.Client, specFile string, pkg *fv1
 	}
 
 	if input.IsSet(flagkey.PkgDeployArchive) || input.IsSet(flagkey.PkgCode) {
-		deployArchive, err := CreateArchive(client, input, deployArchiveFiles, noZip, insecure, deployChecksum, "", "")
+		deployArchive, err := CreateArchive(client, input, pkg.Namespace, deployArchiveFiles, noZip, insecure, deployChecksum, "", "")
 		if err != nil {
 			return nil, fmt.Errorf("error creating deploy archive: %w", err)
 		}
@@ -29,12 +29,14 @@ import (
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/storagesvc"
 	storageSvcClient "github.com/fission/fission/pkg/storagesvc/client"
 	"github.com/fission/fission/pkg/utils"
 	"github.com/fission/fission/pkg/utils/uuid"
 )
 
-func UploadArchiveFile(ctx context.Context, client cmd.Client, fileName string) (*fv1.Archive, error) {
+// UploadArchiveFile uploads the file as an archive of a package of namespace.
+func UploadArchiveFile(ctx context.Context, client cmd.Client, fileName string, namespace string) (*fv1.Archive, error) {
 	var archive fv1.Archive
 
 	size, err := utils.FileSize(fileName)
@@ -49,19 +51,21 @@ func UploadArchiveFile(ctx context.Context, client cmd.Client, fileName string)
 			return nil, err
 		}
 	} else {
-		storagesvcURL, err := util.GetStorageURL(ctx, client)
+		storageClient, err := util.GetStorageClient(ctx, client)
 		if err != nil {
-			return nil, fmt.Errorf("error getting fission storage service URL: %w", err)
+			return nil, fmt.Errorf("error getting fission storage service client: %w", err)
 		}
 
-		storageClient := storageSvcClient.MakeClient(storagesvcURL.String())
 		// TODO add a progress bar
-		id, err := storageClient.Upload(ctx, fileName, nil)
+		id, err := storageClient.Upload(ctx, fileName, &map[string]string{
+			storagesvc.MetadataUploader:  "fission-cli",
+			storagesvc.MetadataNamespace: namespace,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("error uploading to fission storage service: %w", err)
 		}
 
-		archiveURL, err := getArchiveURL(ctx, client, id, storagesvcURL)
+		archiveURL, err := getArchiveURL(ctx, client, storageClient, id)
 		if err != nil {
 			return nil, fmt.Errorf("could not get URL of archive: %w", err)
 		}
@@ -80,26 +84,13 @@ func UploadArchiveFile(ctx context.Context, client cmd.Client, fileName string)
 	return &archive, nil
 }
 
-func getArchiveURL(ctx context.Context, client cmd.Client, archiveID string, serverURL *url.URL) (archiveURL string, err error) {
-	relativeURL, _ := url.Parse(util.FISSION_STORAGE_URI)
-
-	queryString := relativeURL.Query()
-	queryString.Set("id", archiveID)
-	relativeURL.RawQuery = queryString.Encode()
-
-	storageAccessURL := serverURL.ResolveReference(relativeURL)
-
-	resp, err := http.Head(storageAccessURL.String())
+func getArchiveURL(ctx context.Context, client cmd.Client, storageClient storageSvcClient.ClientInterface, archiveID string) (archiveURL string, err error) {
+	header, err := storageClient.Info(ctx, archiveID)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("error getting URL: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("error getting URL. Exited with Status:  %s", resp.Status)
-	}
-
-	storageType := resp.Header.Get("X-FISSION-STORAGETYPE")
+	storageType := header.Get("X-FISSION-STORAGETYPE")
 
 	if storageType == "local" {
 		storageSvc, err := util.GetSvcName(ctx, client.KubernetesClient, "fission-storage")
@@ -107,10 +98,12 @@ func getArchiveURL(ctx context.Context, client cmd.Client, archiveID string, ser
 			return "", err
 		}
 		storagesvcURL := "http://" + storageSvc
+		// the signed URL lets the fetchers of the package download
+		// the archive without credentials.
 		client := storageSvcClient.MakeClient(storagesvcURL)
-		return client.GetUrl(archiveID), nil
+		return storageSvcClient.SignUrl(client.GetUrl(archiveID), header.Get(storagesvc.SignatureHeader)), nil
 	} else if storageType == "s3" {
-		storageBucket := resp.Header.Get("X-FISSION-BUCKET")
+		storageBucket := header.Get("X-FISSION-BUCKET")
 		s3url := fmt.Sprintf("https://%s.s3.amazonaws.com/%s", storageBucket, archiveID)
 		return s3url, nil
 	}
@@ -171,18 +164,16 @@ func DownloadStrorageURL(ctx context.Context, client cmd.Client, fileUrl string)
 	}
 
 	if valid {
-		storagesvcURL, err := util.GetStorageURL(ctx, client)
+		url, err := url.Parse(fileUrl)
 		if err != nil {
 			return nil, err
 		}
+		id := url.Query().Get("id")
 
-		url, err := url.Parse(fileUrl)
+		client, err := util.GetStorageClient(ctx, client)
 		if err != nil {
 			return nil, err
 		}
-		id := url.Query().Get("id")
-
-		client := storageSvcClient.MakeClient(storagesvcURL.String())
 		resp, err = client.GetFile(ctx, id)
 		if err != nil {
 			return nil, err
@@ -231,6 +222,15 @@ func PrintPackageSummary(writer io.Writer, pkg *fv1.Package) {
 	fmt.Fprintf(w, "%v\t%v\n", "Name:", pkg.ObjectMeta.Name)
 	fmt.Fprintf(w, "%v\t%v\n", "Environment:", pkg.Spec.Environment.Name)
 	fmt.Fprintf(w, "%v\t%v\n", "Status:", pkg.Status.BuildStatus)
+	if git := pkg.Spec.Source.Git; git != nil {
+		fmt.Fprintf(w, "%v\t%v\n", "Source Repository:", git.URL)
+		if len(pkg.Status.SourceCommit) > 0 {
+			fmt.Fprintf(w, "%v\t%v\n", "Source Commit:", pkg.Status.SourceCommit)
+		}
+	}
+	if len(pkg.Status.BuildLogURL) > 0 {
+		fmt.Fprintf(w, "%v\t%v\n", "Build Log URL:", pkg.Status.BuildLogURL)
+	}
 	fmt.Fprintf(w, "%v\n%v", "Build Logs:", buildlog)
 	w.Flush()
 }
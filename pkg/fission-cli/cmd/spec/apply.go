@@ -17,8 +17,11 @@ limitations under the License.
 package spec
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -27,6 +30,8 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/go-git/go-git/v5"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
 
@@ -53,7 +58,8 @@ type ApplySubCommand struct {
 //
 // Apply is *not* transactional -- if the user hits Ctrl-C, or their laptop dies
 // etc, while doing an apply, they will get a partially applied deployment.  However,
-// they can retry their apply command once they're back online.
+// they can retry their apply command once they're back online.  With --atomic, the
+// changes of an apply that fails are rolled back.
 func Apply(input cli.Input) error {
 	return (&ApplySubCommand{}).do(input)
 }
@@ -67,70 +73,104 @@ func (opts *ApplySubCommand) do(input cli.Input) error {
 // we make sure that all component of a resource should be present in the same Namespace. i.e.
 // Function's env and package should be present in same namespace
 func (opts *ApplySubCommand) insertNamespace(input cli.Input, fr *FissionResources) error {
-
-	result := utils.MultiErrorWithFormat()
 	_, currentNS, err := opts.GetResourceNamespace(input, flagkey.NamespaceEnvironment)
 	if err != nil {
 		return fv1.AggregateValidationErrors("Environment", err)
 	}
+	fr.SetNamespace(currentNS, input.Bool(flagkey.ForceNamespace))
+	return nil
+}
 
+// SetNamespace sets the namespace of the resources that don't set one, or of
+// all of them if force is set.
+func (fr *FissionResources) SetNamespace(namespace string, force bool) {
 	for i := range fr.Functions {
-		if fr.Functions[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.Functions[i].Namespace = currentNS
-			fr.Functions[i].Spec.Package.PackageRef.Namespace = currentNS
-			fr.Functions[i].Spec.Environment.Namespace = currentNS
+		if fr.Functions[i].Namespace == "" || force {
+			fr.Functions[i].Namespace = namespace
+			fr.Functions[i].Spec.Package.PackageRef.Namespace = namespace
+			fr.Functions[i].Spec.Environment.Namespace = namespace
 			for j := range fr.Functions[i].Spec.ConfigMaps {
-				fr.Functions[i].Spec.ConfigMaps[j].Namespace = currentNS
+				fr.Functions[i].Spec.ConfigMaps[j].Namespace = namespace
 			}
 			for j := range fr.Functions[i].Spec.Secrets {
-				fr.Functions[i].Spec.Secrets[j].Namespace = currentNS
+				fr.Functions[i].Spec.Secrets[j].Namespace = namespace
 			}
 		}
 	}
 	for i := range fr.Environments {
-		if fr.Environments[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.Environments[i].Namespace = currentNS
+		if fr.Environments[i].Namespace == "" || force {
+			fr.Environments[i].Namespace = namespace
 		}
 	}
 	for i := range fr.Packages {
-		if fr.Packages[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.Packages[i].Namespace = currentNS
-			fr.Packages[i].Spec.Environment.Namespace = currentNS
-			fr.Packages[i].ObjectMeta.Namespace = currentNS
+		if fr.Packages[i].Namespace == "" || force {
+			fr.Packages[i].Namespace = namespace
+			fr.Packages[i].Spec.Environment.Namespace = namespace
+			fr.Packages[i].ObjectMeta.Namespace = namespace
 		}
 	}
 	for i := range fr.HttpTriggers {
-		if fr.HttpTriggers[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.HttpTriggers[i].Namespace = currentNS
+		if fr.HttpTriggers[i].Namespace == "" || force {
+			fr.HttpTriggers[i].Namespace = namespace
 		}
 	}
 	for i := range fr.MessageQueueTriggers {
-		if fr.MessageQueueTriggers[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.MessageQueueTriggers[i].Namespace = currentNS
+		if fr.MessageQueueTriggers[i].Namespace == "" || force {
+			fr.MessageQueueTriggers[i].Namespace = namespace
 		}
 	}
 	for i := range fr.TimeTriggers {
-		if fr.TimeTriggers[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.TimeTriggers[i].Namespace = currentNS
+		if fr.TimeTriggers[i].Namespace == "" || force {
+			fr.TimeTriggers[i].Namespace = namespace
 		}
 	}
 	for i := range fr.KubernetesWatchTriggers {
-		if fr.KubernetesWatchTriggers[i].Namespace == "" || input.Bool(flagkey.ForceNamespace) {
-			fr.KubernetesWatchTriggers[i].Namespace = currentNS
+		if fr.KubernetesWatchTriggers[i].Namespace == "" || force {
+			fr.KubernetesWatchTriggers[i].Namespace = namespace
+		}
+	}
+	for i := range fr.CanaryConfigs {
+		if fr.CanaryConfigs[i].Namespace == "" || force {
+			fr.CanaryConfigs[i].Namespace = namespace
+		}
+	}
+	for i := range fr.Secrets {
+		if fr.Secrets[i].Namespace == "" || force {
+			fr.Secrets[i].Namespace = namespace
+		}
+	}
+	for i := range fr.ConfigMaps {
+		if fr.ConfigMaps[i].Namespace == "" || force {
+			fr.ConfigMaps[i].Namespace = namespace
+		}
+	}
+	for i := range fr.TestSuites {
+		if fr.TestSuites[i].Namespace == "" || force {
+			fr.TestSuites[i].Namespace = namespace
 		}
 	}
-
-	return result.ErrorOrNil()
 }
 
 func (opts *ApplySubCommand) run(input cli.Input) error {
 	specDir := util.GetSpecDir(input)
-	specIgnore := util.GetSpecIgnore(input)
-	applyCommitLabel := input.Bool(flagkey.SpecApplyCommitLabel)
 	deleteResources := input.Bool(flagkey.SpecDelete)
 	watchResources := input.Bool(flagkey.SpecWatch)
 	waitForBuild := input.Bool(flagkey.SpecWait)
-	validateSpecs := util.GetValidationFlag(input)
+	dryRun := input.Bool(flagkey.SpecDryRun)
+	atomic := input.Bool(flagkey.SpecAtomic)
+	runTests := input.Bool(flagkey.SpecTest)
+
+	if dryRun && watchResources {
+		return errors.New("--dry-run can't be used with --watch")
+	}
+	if atomic && watchResources {
+		return errors.New("--atomic can't be used with --watch")
+	}
+	if runTests && watchResources {
+		return errors.New("--test can't be used with --watch")
+	}
+	// the tests need the functions built
+	waitForBuild = waitForBuild || runTests
 
 	var watcher *fsnotify.Watcher
 	var pbw *packageBuildWatcher
@@ -142,50 +182,26 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 
 	if watchResources {
 		var err error
-		watcher, err = fsnotify.NewWatcher()
+		watcher, err = NewSpecWatcher(specDir)
 		if err != nil {
-			return fmt.Errorf("error creating file watcher: %w", err)
-		}
-
-		// add watches
-		rootDir := filepath.Clean(specDir + "/..")
-		err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return fmt.Errorf("error scanning project files: %w", err)
-			}
-
-			if ignoreFile(path) {
-				return nil
-			}
-
-			err = watcher.Add(path)
-			if err != nil {
-				return fmt.Errorf("error watching path %v: %w", path, err)
-			}
-			return nil
-		})
-		if err != nil {
-			return fmt.Errorf("error scanning files to watch: %w", err)
+			return err
 		}
+		defer watcher.Close()
 	}
 
 	for {
-		// read all specs
-		fr, err := ReadSpecs(specDir, specIgnore, applyCommitLabel)
+		fr, err := opts.readSpecsForApply(input)
 		if err != nil {
-			return fmt.Errorf("error reading specs: %w", err)
+			return err
 		}
 
-		if validateSpecs {
-			err = validateForApply(input, fr)
+		if dryRun {
+			changes, err := planResources(input, opts.Client(), specDir, fr, deleteResources, input.Bool(flagkey.SpecAllowConflicts))
 			if err != nil {
-				return fmt.Errorf("abort applying resources: %w", err)
+				return fmt.Errorf("error computing changes: %w", err)
 			}
-		}
-
-		err = opts.insertNamespace(input, fr)
-		if err != nil {
-			return fmt.Errorf("error inserting namespace: %w", err)
+			printPlan(os.Stdout, changes)
+			return nil
 		}
 
 		err = warnIfDirtyWorkTree(filepath.Clean(specDir + "/.."))
@@ -193,9 +209,21 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 			console.Warn(err.Error())
 		}
 
+		// snapshot the resources the apply may change, to roll it back on failure
+		var snapshot *applySnapshot
+		if atomic {
+			snapshot, err = takeApplySnapshot(input.Context(), opts.Client(), fr)
+			if err != nil {
+				return fmt.Errorf("error taking snapshot of the resources: %w", err)
+			}
+		}
+
 		// make changes to the cluster based on the specs
 		pkgMetas, as, err := applyResources(input, opts.Client(), specDir, fr, deleteResources, input.Bool(flagkey.SpecAllowConflicts))
 		if err != nil {
+			if atomic {
+				return rollbackApply(input.Context(), snapshot, err)
+			}
 			return fmt.Errorf("error applying specs: %w", err)
 		}
 		printApplyStatus(as)
@@ -209,10 +237,29 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 
 		if watchResources {
 			// if we're watching for files, we don't need to wait for builds to complete
-			go pbw.watch(ctx)
+			go func() {
+				err := pbw.watch(ctx)
+				if err != nil {
+					console.Error(err.Error())
+					os.Exit(1)
+				}
+			}()
 		} else if waitForBuild {
 			// synchronously wait for build if --wait was specified
-			pbw.watch(ctx)
+			err = pbw.watch(ctx)
+			if err == nil && atomic {
+				err = waitForFunctions(ctx, opts.Client(), fr, input.Duration(flagkey.SpecWaitTimeout))
+			}
+			if err == nil && runTests {
+				err = opts.runApplyTests(ctx, input, fr)
+			}
+			if err != nil {
+				pkgWatchCancel()
+				if atomic {
+					return rollbackApply(input.Context(), snapshot, err)
+				}
+				return err
+			}
 		}
 
 		if !watchResources {
@@ -223,36 +270,52 @@ func (opts *ApplySubCommand) run(input cli.Input) error {
 		// listen for file watch events
 		fmt.Println("Watching files for changes...")
 
-	waitloop:
-		for {
-			select {
-			case e := <-watcher.Events:
-				if ignoreFile(e.Name) {
-					continue waitloop
-				}
-				fmt.Printf("Noticed a file change, reapplying specs...\n")
+		err = WaitForSpecChange(watcher)
 
-				// Builds that finish after this cancellation will be
-				// printed in the next watchPackageBuildStatus call.
-				pkgWatchCancel()
+		// Builds that finish after this cancellation will be
+		// printed in the next watchPackageBuildStatus call.
+		pkgWatchCancel()
 
-				err = waitForFileWatcherToSettleDown(watcher)
-				if err != nil {
-					return fmt.Errorf("error watching files: %w", err)
-				}
-				break waitloop
+		if err != nil {
+			return fmt.Errorf("error watching files: %w", err)
+		}
+		fmt.Printf("Noticed a file change, reapplying specs...\n")
+	}
 
-			case err := <-watcher.Errors:
-				pkgWatchCancel()
+	return nil
+}
 
-				if err != nil {
-					return fmt.Errorf("error watching files: %w", err)
-				}
-			}
+// runApplyTests runs the test suites of the applied specs against the router
+// of the cluster.
+func (opts *ApplySubCommand) runApplyTests(ctx context.Context, input cli.Input, fr *FissionResources) error {
+	routerURL, err := util.GetRouterURL(ctx, opts.Client())
+	if err != nil {
+		return fmt.Errorf("error getting router URL: %w", err)
+	}
+	fmt.Println("Running test suites...")
+	return fr.RunTests(ctx, routerURL, os.Stdout, input.String(flagkey.SpecTestOutput))
+}
+
+// readSpecsForApply reads and validates the specs, and inserts the namespace
+// of the resources that don't set one.
+func (opts *ApplySubCommand) readSpecsForApply(input cli.Input) (*FissionResources, error) {
+	fr, err := ReadSpecsWithOverlay(util.GetSpecDir(input), util.GetSpecIgnore(input), input.Bool(flagkey.SpecApplyCommitLabel), GetOverlay(input))
+	if err != nil {
+		return nil, fmt.Errorf("error reading specs: %w", err)
+	}
+
+	if util.GetValidationFlag(input) {
+		err = validateForApply(input, fr)
+		if err != nil {
+			return nil, fmt.Errorf("abort applying resources: %w", err)
 		}
 	}
 
-	return nil
+	err = opts.insertNamespace(input, fr)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting namespace: %w", err)
+	}
+	return fr, nil
 }
 
 func warnIfDirtyWorkTree(path string) error {
@@ -279,6 +342,57 @@ func warnIfDirtyWorkTree(path string) error {
 	return nil
 }
 
+// NewSpecWatcher returns a file watcher on the project directory of specDir,
+// the parent directory of the specs, whose files archives are made of.
+func NewSpecWatcher(specDir string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating file watcher: %w", err)
+	}
+
+	// add watches
+	rootDir := filepath.Clean(specDir + "/..")
+	err = filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("error scanning project files: %w", err)
+		}
+
+		if ignoreFile(path) {
+			return nil
+		}
+
+		err = watcher.Add(path)
+		if err != nil {
+			return fmt.Errorf("error watching path %v: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error scanning files to watch: %w", err)
+	}
+	return watcher, nil
+}
+
+// WaitForSpecChange blocks until a file watched by watcher changes, and the
+// changes settle down.
+func WaitForSpecChange(watcher *fsnotify.Watcher) error {
+	for {
+		select {
+		case e := <-watcher.Events:
+			if ignoreFile(e.Name) {
+				continue
+			}
+			return waitForFileWatcherToSettleDown(watcher)
+
+		case err := <-watcher.Errors:
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func ignoreFile(path string) bool {
 	return (strings.Contains(path, "/.#") || // editor autosave files
 		strings.HasSuffix(path, "~")) // editor backups, usually
@@ -347,26 +461,21 @@ func pluralize(num int, word string) string {
 }
 
 // applyArchives figures out the set of archives that need to be uploaded, and uploads them.
-func applyArchives(input cli.Input, fclient cmd.Client, specDir string, fr *FissionResources) error {
-
-	// archive:// URL -> archive map.
-	archiveFiles := make(map[string]fv1.Archive)
+// With dryRun, archives aren't uploaded and the packages keep referencing them by their
+// archive:// URL.
+func applyArchives(input cli.Input, fclient cmd.Client, specDir string, fr *FissionResources, dryRun bool) error {
 
-	// We'll first populate archiveFiles with references to local files, and then modify it to
-	// point at archive URLs.
-
-	// create archives locally and calculate checksums
-	for _, aus := range fr.ArchiveUploadSpecs {
-		ar, err := localArchiveFromSpec(input.Context(), specDir, &aus)
-		if err != nil {
-			return err
-		}
-		archiveUrl := fmt.Sprintf("%v%v", ARCHIVE_URL_PREFIX, aus.Name)
-		archiveFiles[archiveUrl] = *ar
+	// We'll first create archives of local files, and then make the
+	// packages point at archive URLs.
+	archiveFiles, err := fr.LocalArchives(input.Context(), specDir)
+	if err != nil {
+		return err
 	}
 
-	// get list of packages, make content-indexed map of available archives
-	availableArchives := make(map[string]string) // (sha256 -> url)
+	// get list of packages, make content-indexed map of available archives.
+	// archives are scoped to the namespace of their packages, so only the
+	// archives of packages in the same namespace are reused.
+	availableArchives := make(map[string]string) // (namespace/sha256 -> url)
 	pkgs, err := fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(input.Context(), metav1.ListOptions{})
 	if err != nil {
 		return err
@@ -374,63 +483,98 @@ func applyArchives(input cli.Input, fclient cmd.Client, specDir string, fr *Fiss
 	for _, pkg := range pkgs.Items {
 		for _, ar := range []fv1.Archive{pkg.Spec.Source, pkg.Spec.Deployment} {
 			if ar.Type == fv1.ArchiveTypeUrl && len(ar.URL) > 0 {
-				availableArchives[ar.Checksum.Sum] = ar.URL
+				availableArchives[pkg.Namespace+"/"+ar.Checksum.Sum] = ar.URL
 			}
 		}
 	}
 
-	// upload archives that we need to, updating the map
-	for name, ar := range archiveFiles {
-		if ar.Type == fv1.ArchiveTypeLiteral {
-			continue
-		}
-		// does the archive exist already?
-		if url, ok := availableArchives[ar.Checksum.Sum]; ok {
-			fmt.Printf("archive %v exists, not uploading\n", name)
-			ar.URL = url
-			archiveFiles[name] = ar
-		} else {
-			// doesn't exist, upload
-			fmt.Printf("uploading archive %v\n", name)
-			// ar.URL is actually a local filename at this stage
-			uploadedAr, err := pkgutil.UploadArchiveFile(input.Context(), fclient, ar.URL)
-			if err != nil {
-				return err
-			}
-			archiveFiles[name] = *uploadedAr
-		}
-	}
-
-	// resolve references to urls in packages to be applied
+	// upload archives that we need to in the namespaces of the packages
+	// referencing them, and resolve references to urls in packages to be applied
+	uploadedArchives := make(map[string]fv1.Archive) // (namespace/archive:// URL -> archive)
 	for i := range fr.Packages {
+		namespace := fr.Packages[i].Namespace
 		for _, ar := range []*fv1.Archive{&fr.Packages[i].Spec.Source, &fr.Packages[i].Spec.Deployment} {
-			if strings.HasPrefix(ar.URL, ARCHIVE_URL_PREFIX) {
-				availableAr, ok := archiveFiles[ar.URL]
-				if !ok {
-					return fmt.Errorf("unknown archive name %v", strings.TrimPrefix(ar.URL, ARCHIVE_URL_PREFIX))
+			if !strings.HasPrefix(ar.URL, ARCHIVE_URL_PREFIX) {
+				continue
+			}
+			localAr, ok := archiveFiles[ar.URL]
+			if !ok {
+				return fmt.Errorf("unknown archive name %v", strings.TrimPrefix(ar.URL, ARCHIVE_URL_PREFIX))
+			}
+			availableAr, ok := uploadedArchives[namespace+"/"+ar.URL]
+			if !ok && dryRun {
+				// nothing is uploaded, packages reference an archive with the
+				// same content, or the local archive by its archive:// URL
+				availableAr = localAr
+				if localAr.Type == fv1.ArchiveTypeUrl {
+					availableAr.URL = ar.URL
+					if url, exists := availableArchives[namespace+"/"+localAr.Checksum.Sum]; exists {
+						availableAr.URL = url
+					}
+				}
+			} else if !ok {
+				availableAr, err = uploadArchive(input, fclient, namespace, ar.URL, localAr, availableArchives)
+				if err != nil {
+					return err
 				}
-				ar.Type = availableAr.Type
-				ar.Literal = availableAr.Literal
-				ar.URL = availableAr.URL
-				ar.Checksum = availableAr.Checksum
+				uploadedArchives[namespace+"/"+ar.URL] = availableAr
 			}
+			ar.Type = availableAr.Type
+			ar.Literal = availableAr.Literal
+			ar.URL = availableAr.URL
+			ar.Checksum = availableAr.Checksum
 		}
 	}
 	return nil
 }
 
+// uploadArchive uploads a local archive for the packages of namespace, unless
+// a package of the namespace already references an archive with the same content.
+func uploadArchive(input cli.Input, fclient cmd.Client, namespace string, name string, ar fv1.Archive, availableArchives map[string]string) (fv1.Archive, error) {
+	if ar.Type == fv1.ArchiveTypeLiteral {
+		return ar, nil
+	}
+	// does the archive exist already?
+	if url, ok := availableArchives[namespace+"/"+ar.Checksum.Sum]; ok {
+		fmt.Printf("archive %v exists, not uploading\n", name)
+		ar.URL = url
+		return ar, nil
+	}
+	// doesn't exist, upload
+	fmt.Printf("uploading archive %v\n", name)
+	// ar.URL is actually a local filename at this stage
+	uploadedAr, err := pkgutil.UploadArchiveFile(input.Context(), fclient, ar.URL, namespace)
+	if err != nil {
+		return fv1.Archive{}, err
+	}
+	return *uploadedAr, nil
+}
+
 // applyResources applies the given set of fission resources.
 func applyResources(input cli.Input, fclient cmd.Client, specDir string, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, map[string]ResourceApplyStatus, error) {
 
 	applyStatus := make(map[string]ResourceApplyStatus)
 
 	// upload archives that need to be uploaded. Changes archive references in fr.Packages.
-	err := applyArchives(input, fclient, specDir, fr)
+	err := applyArchives(input, fclient, specDir, fr, false)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	_, ras, err := applyEnvironments(input.Context(), fclient, fr, delete, specAllowConflicts)
+	// secrets and configmaps come first, so that functions find them
+	_, ras, err := applySecrets(input.Context(), fclient, fr, delete, specAllowConflicts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("secret apply failed: %w", err)
+	}
+	applyStatus["Secret"] = *ras
+
+	_, ras, err = applyConfigMaps(input.Context(), fclient, fr, delete, specAllowConflicts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("configmap apply failed: %w", err)
+	}
+	applyStatus["ConfigMap"] = *ras
+
+	_, ras, err = applyEnvironments(input.Context(), fclient, fr, delete, specAllowConflicts)
 	if err != nil {
 		return nil, nil, fmt.Errorf("environment apply failed: %w", err)
 	}
@@ -477,6 +621,12 @@ func applyResources(input cli.Input, fclient cmd.Client, specDir string, fr *Fis
 	}
 	applyStatus["HTTPTrigger"] = *ras
 
+	_, ras, err = applyCanaryConfigs(input.Context(), fclient, fr, delete, specAllowConflicts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("canaryConfig apply failed: %w", err)
+	}
+	applyStatus["CanaryConfig"] = *ras
+
 	_, ras, err = applyKubernetesWatchTriggers(input.Context(), fclient, fr, delete, specAllowConflicts)
 	if err != nil {
 		return nil, nil, fmt.Errorf("kubernetesWatchTrigger apply failed: %w", err)
@@ -498,6 +648,20 @@ func applyResources(input cli.Input, fclient cmd.Client, specDir string, fr *Fis
 	return pkgMeta, applyStatus, nil
 }
 
+// LocalArchives creates the archives of the archive upload specs on the local
+// filesystem, and returns them by their archive:// URL.
+func (fr *FissionResources) LocalArchives(ctx context.Context, specDir string) (map[string]fv1.Archive, error) {
+	archives := make(map[string]fv1.Archive)
+	for _, aus := range fr.ArchiveUploadSpecs {
+		ar, err := localArchiveFromSpec(ctx, specDir, &aus)
+		if err != nil {
+			return nil, err
+		}
+		archives[ARCHIVE_URL_PREFIX+aus.Name] = *ar
+	}
+	return archives, nil
+}
+
 // localArchiveFromSpec creates an archive on the local filesystem from the given spec,
 // and returns its path and checksum.
 func localArchiveFromSpec(ctx context.Context, specDir string, aus *spectypes.ArchiveUploadSpec) (*fv1.Archive, error) {
@@ -683,18 +847,7 @@ func applyPackages(ctx context.Context, fclient cmd.Client, fr *FissionResources
 		existingObj, ok := existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
 		if ok {
 			// ok, a resource with the same name exists, is it the same?
-			keep := false
-			if reflect.DeepEqual(existingObj.Spec, o.Spec) {
-				keep = true
-			} else if reflect.DeepEqual(existingObj.Spec.Environment, o.Spec.Environment) &&
-				!reflect.DeepEqual(existingObj.Spec.Source, fv1.Archive{}) &&
-				reflect.DeepEqual(existingObj.Spec.Source, o.Spec.Source) &&
-				existingObj.Spec.BuildCommand == o.Spec.BuildCommand {
-
-				keep = true
-			}
-
-			if keep && isObjectMetaEqual(existingObj.ObjectMeta, o.ObjectMeta) && existingObj.Status.BuildStatus == fv1.BuildStatusSucceeded {
+			if isPackageUnchanged(&existingObj, &o) {
 				// nothing to do on the server
 				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = existingObj.ObjectMeta
 			} else {
@@ -971,6 +1124,8 @@ func applyHTTPTriggers(ctx context.Context, fclient cmd.Client, fr *FissionResou
 		// exists?
 		existingObj, ok := existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
 		if ok {
+			keepCanaryWeights(&o, &existingObj, fr)
+
 			// ok, a resource with the same name exists, is it the same?
 			if isObjectMetaEqual(existingObj.ObjectMeta, o.ObjectMeta) && reflect.DeepEqual(existingObj.Spec, o.Spec) {
 				// nothing to do on the server
@@ -1287,6 +1442,368 @@ func applyMessageQueueTriggers(ctx context.Context, fclient cmd.Client, fr *Fiss
 	return metadataMap, &ras, nil
 }
 
+func applyCanaryConfigs(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
+	// get list
+	allObjs, err := fclient.FissionClientSet.CoreV1().CanaryConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// filter
+	objs := make([]fv1.CanaryConfig, 0)
+	if specAllowConflicts {
+		objs = allObjs.Items
+	} else {
+		for _, o := range allObjs.Items {
+			if hasDeploymentConfig(&o.ObjectMeta, fr) {
+				objs = append(objs, o)
+			}
+		}
+	}
+
+	// index
+	existent := make(map[string]fv1.CanaryConfig)
+	for _, obj := range objs {
+		existent[k8sCache.MetaObjectToName(&obj.ObjectMeta).String()] = obj
+	}
+	metadataMap := make(map[string]metav1.ObjectMeta)
+
+	// desired set. used to compute the set to delete.
+	desired := make(map[string]bool)
+
+	var ras ResourceApplyStatus
+
+	// create or update desired state
+	for _, o := range fr.CanaryConfigs {
+		// apply deploymentConfig so we can find our objects on future apply invocations
+		applyDeploymentConfig(&o.ObjectMeta, fr)
+
+		// index desired state
+		desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = true
+
+		// exists?
+		existingObj, ok := existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+		if ok {
+			// ok, a resource with the same name exists, is it the same?
+			if isObjectMetaEqual(existingObj.ObjectMeta, o.ObjectMeta) && reflect.DeepEqual(existingObj.Spec, o.Spec) {
+				// nothing to do on the server
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = existingObj.ObjectMeta
+			} else {
+				// update
+				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
+				newmeta, err := fclient.FissionClientSet.CoreV1().CanaryConfigs(o.ObjectMeta.Namespace).Update(ctx, &o, metav1.UpdateOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Updated = append(ras.Updated, &newmeta.ObjectMeta)
+				// keep track of metadata in case we need to create a reference to it
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+			}
+		} else {
+			// create
+			newmeta, err := fclient.FissionClientSet.CoreV1().CanaryConfigs(o.ObjectMeta.Namespace).Create(ctx, &o, metav1.CreateOptions{})
+			if err != nil {
+				return nil, nil, err
+			}
+			ras.Created = append(ras.Created, &newmeta.ObjectMeta)
+			metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+		}
+	}
+
+	// deletes
+	if delete {
+		// objs is already filtered with our UID
+		for _, o := range objs {
+			_, wanted := desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+			if !wanted {
+				err := fclient.FissionClientSet.CoreV1().CanaryConfigs(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Deleted = append(ras.Deleted, &o.ObjectMeta)
+				fmt.Printf("Deleted %v %v\n", o.Kind, k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+			}
+		}
+	}
+
+	return metadataMap, &ras, nil
+}
+
+// keepCanaryWeights keeps the function weights of an HTTP trigger that a
+// canary config of the specs shifts traffic with, so that applying the specs
+// doesn't reset a rollout in progress.
+func keepCanaryWeights(o *fv1.HTTPTrigger, existingObj *fv1.HTTPTrigger, fr *FissionResources) {
+	if existingObj.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionWeights {
+		return
+	}
+	for _, c := range fr.CanaryConfigs {
+		if c.Spec.Trigger == o.ObjectMeta.Name && c.ObjectMeta.Namespace == o.ObjectMeta.Namespace {
+			o.Spec.FunctionReference = existingObj.Spec.FunctionReference
+			return
+		}
+	}
+}
+
+// applyDeploymentLabel labels a Kubernetes resource with the deployment UID,
+// so that the resources of the deployment are listed without listing all the
+// resources of the cluster.
+func applyDeploymentLabel(m *metav1.ObjectMeta, fr *FissionResources) {
+	labels := make(map[string]string, len(m.Labels)+1)
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+	labels[FISSION_DEPLOYMENT_UID_KEY] = fr.DeploymentConfig.UID
+	m.Labels = labels
+}
+
+func deploymentLabelSelector(fr *FissionResources) string {
+	return fmt.Sprintf("%v=%v", FISSION_DEPLOYMENT_UID_KEY, fr.DeploymentConfig.UID)
+}
+
+// listSecrets lists the secrets of the deployment. With specAllowConflicts, the
+// secrets of the specs created outside of them are listed too, to be taken over.
+func listSecrets(ctx context.Context, fclient cmd.Client, fr *FissionResources, specAllowConflicts bool) ([]apiv1.Secret, error) {
+	objs, err := fclient.KubernetesClient.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: deploymentLabelSelector(fr)})
+	if err != nil {
+		return nil, err
+	}
+	if !specAllowConflicts {
+		return objs.Items, nil
+	}
+
+	existent := make(map[string]bool)
+	for _, obj := range objs.Items {
+		existent[k8sCache.MetaObjectToName(&obj.ObjectMeta).String()] = true
+	}
+	for _, o := range fr.Secrets {
+		if existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] {
+			continue
+		}
+		obj, err := fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Get(ctx, o.ObjectMeta.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		objs.Items = append(objs.Items, *obj)
+	}
+	return objs.Items, nil
+}
+
+// applySecrets applies the secrets of the specs. Unlike Fission resources,
+// only the secrets labelled with the deployment UID are deleted, even if
+// conflicts are allowed.
+func applySecrets(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
+	objs, err := listSecrets(ctx, fclient, fr, specAllowConflicts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// index
+	existent := make(map[string]apiv1.Secret)
+	for _, obj := range objs {
+		existent[k8sCache.MetaObjectToName(&obj.ObjectMeta).String()] = obj
+	}
+	metadataMap := make(map[string]metav1.ObjectMeta)
+
+	// desired set. used to compute the set to delete.
+	desired := make(map[string]bool)
+
+	var ras ResourceApplyStatus
+
+	// create or update desired state
+	for _, o := range fr.Secrets {
+		// apply deploymentConfig so we can find our objects on future apply invocations
+		applyDeploymentConfig(&o.ObjectMeta, fr)
+		applyDeploymentLabel(&o.ObjectMeta, fr)
+
+		// index desired state
+		desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = true
+
+		// exists?
+		existingObj, ok := existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+		if ok {
+			// ok, a resource with the same name exists, is it the same?
+			if isSecretUnchanged(&existingObj, &o) {
+				// nothing to do on the server
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = existingObj.ObjectMeta
+			} else {
+				// update
+				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
+				newmeta, err := fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Update(ctx, &o, metav1.UpdateOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Updated = append(ras.Updated, &newmeta.ObjectMeta)
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+			}
+		} else {
+			// create
+			newmeta, err := fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Create(ctx, &o, metav1.CreateOptions{})
+			if err != nil {
+				return nil, nil, err
+			}
+			ras.Created = append(ras.Created, &newmeta.ObjectMeta)
+			metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+		}
+	}
+
+	// deletes
+	if delete {
+		for _, o := range objs {
+			_, wanted := desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+			if !wanted && o.ObjectMeta.Labels[FISSION_DEPLOYMENT_UID_KEY] == fr.DeploymentConfig.UID {
+				err := fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Deleted = append(ras.Deleted, &o.ObjectMeta)
+				fmt.Printf("Deleted Secret %v\n", k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+			}
+		}
+	}
+
+	return metadataMap, &ras, nil
+}
+
+// listConfigMaps lists the configmaps of the deployment. With specAllowConflicts, the
+// configmaps of the specs created outside of them are listed too, to be taken over.
+func listConfigMaps(ctx context.Context, fclient cmd.Client, fr *FissionResources, specAllowConflicts bool) ([]apiv1.ConfigMap, error) {
+	objs, err := fclient.KubernetesClient.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: deploymentLabelSelector(fr)})
+	if err != nil {
+		return nil, err
+	}
+	if !specAllowConflicts {
+		return objs.Items, nil
+	}
+
+	existent := make(map[string]bool)
+	for _, obj := range objs.Items {
+		existent[k8sCache.MetaObjectToName(&obj.ObjectMeta).String()] = true
+	}
+	for _, o := range fr.ConfigMaps {
+		if existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] {
+			continue
+		}
+		obj, err := fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Get(ctx, o.ObjectMeta.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		objs.Items = append(objs.Items, *obj)
+	}
+	return objs.Items, nil
+}
+
+// applyConfigMaps applies the configmaps of the specs. Unlike Fission resources,
+// only the configmaps labelled with the deployment UID are deleted, even if
+// conflicts are allowed.
+func applyConfigMaps(ctx context.Context, fclient cmd.Client, fr *FissionResources, delete bool, specAllowConflicts bool) (map[string]metav1.ObjectMeta, *ResourceApplyStatus, error) {
+	objs, err := listConfigMaps(ctx, fclient, fr, specAllowConflicts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// index
+	existent := make(map[string]apiv1.ConfigMap)
+	for _, obj := range objs {
+		existent[k8sCache.MetaObjectToName(&obj.ObjectMeta).String()] = obj
+	}
+	metadataMap := make(map[string]metav1.ObjectMeta)
+
+	// desired set. used to compute the set to delete.
+	desired := make(map[string]bool)
+
+	var ras ResourceApplyStatus
+
+	// create or update desired state
+	for _, o := range fr.ConfigMaps {
+		// apply deploymentConfig so we can find our objects on future apply invocations
+		applyDeploymentConfig(&o.ObjectMeta, fr)
+		applyDeploymentLabel(&o.ObjectMeta, fr)
+
+		// index desired state
+		desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = true
+
+		// exists?
+		existingObj, ok := existent[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+		if ok {
+			// ok, a resource with the same name exists, is it the same?
+			if isConfigMapUnchanged(&existingObj, &o) {
+				// nothing to do on the server
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = existingObj.ObjectMeta
+			} else {
+				// update
+				o.ObjectMeta.ResourceVersion = existingObj.ObjectMeta.ResourceVersion
+				newmeta, err := fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Update(ctx, &o, metav1.UpdateOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Updated = append(ras.Updated, &newmeta.ObjectMeta)
+				metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+			}
+		} else {
+			// create
+			newmeta, err := fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Create(ctx, &o, metav1.CreateOptions{})
+			if err != nil {
+				return nil, nil, err
+			}
+			ras.Created = append(ras.Created, &newmeta.ObjectMeta)
+			metadataMap[k8sCache.MetaObjectToName(&o.ObjectMeta).String()] = newmeta.ObjectMeta
+		}
+	}
+
+	// deletes
+	if delete {
+		for _, o := range objs {
+			_, wanted := desired[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]
+			if !wanted && o.ObjectMeta.Labels[FISSION_DEPLOYMENT_UID_KEY] == fr.DeploymentConfig.UID {
+				err := fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				ras.Deleted = append(ras.Deleted, &o.ObjectMeta)
+				fmt.Printf("Deleted ConfigMap %v\n", k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+			}
+		}
+	}
+
+	return metadataMap, &ras, nil
+}
+
+// isSecretUnchanged returns whether the existing secret has the labels,
+// annotations, type and data of the desired one.
+func isSecretUnchanged(existingObj, newObj *apiv1.Secret) bool {
+	return isObjectMetaEqual(existingObj.ObjectMeta, newObj.ObjectMeta) &&
+		existingObj.Type == newObj.Type &&
+		maps.EqualFunc(existingObj.Data, newObj.Data, bytes.Equal)
+}
+
+// isConfigMapUnchanged returns whether the existing configmap has the labels,
+// annotations and data of the desired one.
+func isConfigMapUnchanged(existingObj, newObj *apiv1.ConfigMap) bool {
+	return isObjectMetaEqual(existingObj.ObjectMeta, newObj.ObjectMeta) &&
+		maps.Equal(existingObj.Data, newObj.Data) &&
+		maps.EqualFunc(existingObj.BinaryData, newObj.BinaryData, bytes.Equal)
+}
+
+// isPackageUnchanged returns whether the existing package is kept as is. A package
+// built from the same source is kept, unless its build didn't succeed.
+func isPackageUnchanged(existingObj, newObj *fv1.Package) bool {
+	keep := false
+	if reflect.DeepEqual(existingObj.Spec, newObj.Spec) {
+		keep = true
+	} else if reflect.DeepEqual(existingObj.Spec.Environment, newObj.Spec.Environment) &&
+		!reflect.DeepEqual(existingObj.Spec.Source, fv1.Archive{}) &&
+		reflect.DeepEqual(existingObj.Spec.Source, newObj.Spec.Source) &&
+		existingObj.Spec.BuildCommand == newObj.Spec.BuildCommand {
+
+		keep = true
+	}
+	return keep && isObjectMetaEqual(existingObj.ObjectMeta, newObj.ObjectMeta) && existingObj.Status.BuildStatus == fv1.BuildStatusSucceeded
+}
+
 func isObjectMetaEqual(existingObj, newObj metav1.ObjectMeta) bool {
 	return reflect.DeepEqual(existingObj.Labels, newObj.Labels) && reflect.DeepEqual(existingObj.Annotations, newObj.Annotations)
 }
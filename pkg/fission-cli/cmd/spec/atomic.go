@@ -0,0 +1,430 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+)
+
+type (
+	// applySnapshot is the state of the resources an apply may change, taken
+	// before applying the specs so that a failed atomic apply can restore it.
+	applySnapshot struct {
+		// kinds are in the order applyResources applies them.
+		kinds []*kindSnapshot
+	}
+
+	// kindSnapshot is the state of the resources of a kind.
+	kindSnapshot struct {
+		kind string
+
+		// rollback restores the resources of the snapshot, and deletes the
+		// resources created since, recording what it did in ras.
+		rollback func(ctx context.Context, ras *ResourceApplyStatus) error
+	}
+
+	// objectClient is the part of the typed clients of a kind rollbacks use.
+	objectClient[T any] interface {
+		Create(ctx context.Context, obj *T, opts metav1.CreateOptions) (*T, error)
+		Update(ctx context.Context, obj *T, opts metav1.UpdateOptions) (*T, error)
+		Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	}
+)
+
+// takeApplySnapshot snapshots the resources of the deployment, and the
+// resources of the specs that exist outside of it, in the order
+// applyResources applies them.
+func takeApplySnapshot(ctx context.Context, fclient cmd.Client, fr *FissionResources) (*applySnapshot, error) {
+	fission := fclient.FissionClientSet.CoreV1()
+	kube := fclient.KubernetesClient.CoreV1()
+
+	var snapshot applySnapshot
+	add := func(ks *kindSnapshot, err error) error {
+		if err == nil {
+			snapshot.kinds = append(snapshot.kinds, ks)
+		}
+		return err
+	}
+
+	err := add(snapshotKind(ctx, "Secret", fr, fr.Secrets,
+		func(ctx context.Context) ([]apiv1.Secret, error) {
+			return listSecrets(ctx, fclient, fr, true)
+		},
+		func(ns string) objectClient[apiv1.Secret] { return kube.Secrets(ns) },
+		isSecretUnchanged))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "ConfigMap", fr, fr.ConfigMaps,
+		func(ctx context.Context) ([]apiv1.ConfigMap, error) {
+			return listConfigMaps(ctx, fclient, fr, true)
+		},
+		func(ns string) objectClient[apiv1.ConfigMap] { return kube.ConfigMaps(ns) },
+		isConfigMapUnchanged))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "environment", fr, fr.Environments,
+		func(ctx context.Context) ([]fv1.Environment, error) {
+			l, err := fission.Environments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.Environment] { return fission.Environments(ns) },
+		isResourceUnchanged[*fv1.Environment]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "package", fr, fr.Packages,
+		func(ctx context.Context) ([]fv1.Package, error) {
+			l, err := fission.Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.Package] { return fission.Packages(ns) },
+		isResourceUnchanged[*fv1.Package]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "function", fr, fr.Functions,
+		func(ctx context.Context) ([]fv1.Function, error) {
+			l, err := fission.Functions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.Function] { return fission.Functions(ns) },
+		isResourceUnchanged[*fv1.Function]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "HTTPTrigger", fr, fr.HttpTriggers,
+		func(ctx context.Context) ([]fv1.HTTPTrigger, error) {
+			l, err := fission.HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.HTTPTrigger] { return fission.HTTPTriggers(ns) },
+		isResourceUnchanged[*fv1.HTTPTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "CanaryConfig", fr, fr.CanaryConfigs,
+		func(ctx context.Context) ([]fv1.CanaryConfig, error) {
+			l, err := fission.CanaryConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.CanaryConfig] { return fission.CanaryConfigs(ns) },
+		isResourceUnchanged[*fv1.CanaryConfig]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "KubernetesWatchTrigger", fr, fr.KubernetesWatchTriggers,
+		func(ctx context.Context) ([]fv1.KubernetesWatchTrigger, error) {
+			l, err := fission.KubernetesWatchTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.KubernetesWatchTrigger] { return fission.KubernetesWatchTriggers(ns) },
+		isResourceUnchanged[*fv1.KubernetesWatchTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "TimeTrigger", fr, fr.TimeTriggers,
+		func(ctx context.Context) ([]fv1.TimeTrigger, error) {
+			l, err := fission.TimeTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.TimeTrigger] { return fission.TimeTriggers(ns) },
+		isResourceUnchanged[*fv1.TimeTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	err = add(snapshotKind(ctx, "MessageQueueTrigger", fr, fr.MessageQueueTriggers,
+		func(ctx context.Context) ([]fv1.MessageQueueTrigger, error) {
+			l, err := fission.MessageQueueTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return l.Items, nil
+		},
+		func(ns string) objectClient[fv1.MessageQueueTrigger] { return fission.MessageQueueTriggers(ns) },
+		isResourceUnchanged[*fv1.MessageQueueTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// snapshotKind snapshots the resources of a kind that belong to the
+// deployment or have the name of a resource of the specs.
+func snapshotKind[T any, PT interface {
+	*T
+	metav1.Object
+}](ctx context.Context, kind string, fr *FissionResources, desiredObjs []T,
+	list func(ctx context.Context) ([]T, error),
+	client func(namespace string) objectClient[T],
+	unchanged func(existingObj, newObj PT) bool) (*kindSnapshot, error) {
+
+	desired := make(map[string]bool)
+	for i := range desiredObjs {
+		obj := PT(&desiredObjs[i])
+		desired[k8sCache.NewObjectName(obj.GetNamespace(), obj.GetName()).String()] = true
+	}
+	index := func(objs []T) map[string]PT {
+		m := make(map[string]PT)
+		for i := range objs {
+			obj := PT(&objs[i])
+			name := k8sCache.NewObjectName(obj.GetNamespace(), obj.GetName()).String()
+			if desired[name] || obj.GetAnnotations()[FISSION_DEPLOYMENT_UID_KEY] == fr.DeploymentConfig.UID {
+				m[name] = obj
+			}
+		}
+		return m
+	}
+
+	objs, err := list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %v resources: %w", kind, err)
+	}
+	before := index(objs)
+
+	rollback := func(ctx context.Context, ras *ResourceApplyStatus) error {
+		objs, err := list(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing %v resources: %w", kind, err)
+		}
+		after := index(objs)
+
+		var result *multierror.Error
+		for name, obj := range after {
+			if _, ok := before[name]; ok {
+				continue
+			}
+			err := client(obj.GetNamespace()).Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("error deleting %v %v: %w", kind, name, err))
+				continue
+			}
+			ras.Deleted = append(ras.Deleted, &metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()})
+		}
+		for name, snapshotObj := range before {
+			// the snapshot is kept as is, for the rollback to be retried
+			o := *snapshotObj
+			obj := PT(&o)
+			current, ok := after[name]
+			if !ok {
+				// deleted, create it again
+				obj.SetResourceVersion("")
+				obj.SetUID("")
+				obj.SetCreationTimestamp(metav1.Time{})
+				obj.SetManagedFields(nil)
+				obj.SetGeneration(0)
+				_, err := client(obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+				if err != nil {
+					result = multierror.Append(result, fmt.Errorf("error recreating %v %v: %w", kind, name, err))
+					continue
+				}
+				ras.Created = append(ras.Created, &metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()})
+			} else if !unchanged(current, obj) {
+				obj.SetResourceVersion(current.GetResourceVersion())
+				obj.SetUID(current.GetUID())
+				_, err := client(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+				if err != nil {
+					result = multierror.Append(result, fmt.Errorf("error restoring %v %v: %w", kind, name, err))
+					continue
+				}
+				ras.Updated = append(ras.Updated, &metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()})
+			}
+		}
+		return result.ErrorOrNil()
+	}
+	return &kindSnapshot{kind: kind, rollback: rollback}, nil
+}
+
+// rollback restores the resources of the snapshot in the reverse order of
+// the apply, so that triggers stop referencing new functions first. It
+// carries on after errors, to restore as much as possible.
+func (s *applySnapshot) rollback(ctx context.Context) (map[string]ResourceApplyStatus, error) {
+	var result *multierror.Error
+	status := make(map[string]ResourceApplyStatus)
+	for _, ks := range slices.Backward(s.kinds) {
+		var ras ResourceApplyStatus
+		err := ks.rollback(ctx, &ras)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+		status[ks.kind] = ras
+	}
+	return status, result.ErrorOrNil()
+}
+
+// rollbackApply rolls back a failed atomic apply, and prints what was rolled
+// back. The rollback isn't cancelled along with the apply.
+func rollbackApply(ctx context.Context, snapshot *applySnapshot, applyErr error) error {
+	fmt.Printf("Applying the specs failed, rolling back: %v\n", applyErr)
+	status, err := snapshot.rollback(context.WithoutCancel(ctx))
+	printRollbackStatus(status)
+	if err != nil {
+		return fmt.Errorf("error applying specs: %w; rollback failed: %w", applyErr, err)
+	}
+	return fmt.Errorf("error applying specs, changes were rolled back: %w", applyErr)
+}
+
+// printRollbackStatus prints a summary of the resources a rollback deleted,
+// restored and recreated.
+func printRollbackStatus(status map[string]ResourceApplyStatus) {
+	changed := false
+	for typ, ras := range status {
+		n := len(ras.Deleted)
+		if n > 0 {
+			changed = true
+			fmt.Printf("%v created %v deleted: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Deleted), ", "))
+		}
+		n = len(ras.Updated)
+		if n > 0 {
+			changed = true
+			fmt.Printf("%v %v restored: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Updated), ", "))
+		}
+		n = len(ras.Created)
+		if n > 0 {
+			changed = true
+			fmt.Printf("%v deleted %v recreated: %v\n", n, pluralize(n, typ), strings.Join(metadataNames(ras.Created), ", "))
+		}
+	}
+
+	if !changed {
+		fmt.Println("Nothing to roll back.")
+	}
+}
+
+// waitForFunctions waits for the functions of the specs running in their
+// own deployment, with the newdeploy and container executors, to roll out.
+// Functions of the poolmgr executor and functions scaled to zero are only
+// specialized when invoked and aren't waited for.
+func waitForFunctions(ctx context.Context, fclient cmd.Client, fr *FissionResources, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, f := range fr.Functions {
+		strategy := f.Spec.InvokeStrategy.ExecutionStrategy
+		if strategy.ExecutorType != fv1.ExecutorTypeNewdeploy && strategy.ExecutorType != fv1.ExecutorTypeContainer {
+			continue
+		}
+		if strategy.MinScale <= 0 {
+			continue
+		}
+		name := k8sCache.MetaObjectToName(&f.ObjectMeta).String()
+		fmt.Printf("Waiting for function %v to be ready\n", name)
+		err := waitForFunction(ctx, fclient, &f)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("function %v isn't ready after %v", name, timeout)
+		} else if err != nil {
+			return fmt.Errorf("function %v isn't ready: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// waitForFunction waits for the deployment of the current version of the
+// function to roll out.
+func waitForFunction(ctx context.Context, fclient cmd.Client, f *fv1.Function) error {
+	for {
+		fn, err := fclient.FissionClientSet.CoreV1().Functions(f.ObjectMeta.Namespace).Get(ctx, f.ObjectMeta.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		deployments, err := fclient.KubernetesClient.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.Set{fv1.FUNCTION_UID: string(fn.ObjectMeta.UID)}.AsSelector().String(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, d := range deployments.Items {
+			if d.Annotations[fv1.FUNCTION_RESOURCE_VERSION] != fn.ObjectMeta.ResourceVersion {
+				continue
+			}
+			ready, err := isDeploymentRolledOut(&d)
+			if err != nil || ready {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// isDeploymentRolledOut returns whether all the replicas of the deployment
+// are updated and available, or an error if it won't roll out.
+func isDeploymentRolledOut(d *appsv1.Deployment) (bool, error) {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("deployment %v exceeded its progress deadline: %v", d.ObjectMeta.Name, c.Message)
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.ObjectMeta.Generation &&
+		d.Status.UpdatedReplicas >= replicas &&
+		d.Status.AvailableReplicas >= replicas, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	"github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+func TestApplySnapshotRollback(t *testing.T) {
+	deployed := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   metav1.NamespaceDefault,
+			Annotations: map[string]string{FISSION_DEPLOYMENT_UID_KEY: "uid"},
+		}
+	}
+	env := fv1.Environment{
+		ObjectMeta: deployed("nodejs"),
+		Spec:       fv1.EnvironmentSpec{Version: 2, Runtime: fv1.Runtime{Image: "node:1"}},
+	}
+	route := fv1.HTTPTrigger{
+		ObjectMeta: deployed("route"),
+		Spec:       fv1.HTTPTriggerSpec{RelativeURL: "/hello"},
+	}
+	other := fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: metav1.NamespaceDefault},
+		Spec:       fv1.EnvironmentSpec{Version: 2, Runtime: fv1.Runtime{Image: "other"}},
+	}
+	secret := apiv1.Secret{
+		ObjectMeta: deployed("db"),
+		Data:       map[string][]byte{"PASSWORD": []byte("old")},
+	}
+	secret.Labels = map[string]string{FISSION_DEPLOYMENT_UID_KEY: "uid"}
+
+	fissionClient := fake.NewSimpleClientset(&env, &route, &other)
+	kubeClient := kubefake.NewClientset(&secret)
+	fclient := cmd.Client{FissionClientSet: fissionClient, KubernetesClient: kubeClient}
+	fr := &FissionResources{
+		DeploymentConfig: types.DeploymentConfig{Name: "app", UID: "uid"},
+		Functions:        []fv1.Function{{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault}}},
+	}
+
+	snapshot, err := takeApplySnapshot(t.Context(), fclient, fr)
+	require.NoError(t, err)
+
+	// a partial apply: an environment updated, a function created, a
+	// trigger and a secret deleted
+	updated := env.DeepCopy()
+	updated.Spec.Runtime.Image = "node:2"
+	_, err = fissionClient.CoreV1().Environments(metav1.NamespaceDefault).Update(t.Context(), updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	fn := fv1.Function{ObjectMeta: deployed("hello")}
+	_, err = fissionClient.CoreV1().Functions(metav1.NamespaceDefault).Create(t.Context(), &fn, metav1.CreateOptions{})
+	require.NoError(t, err)
+	err = fissionClient.CoreV1().HTTPTriggers(metav1.NamespaceDefault).Delete(t.Context(), "route", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	err = kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Delete(t.Context(), "db", metav1.DeleteOptions{})
+	require.NoError(t, err)
+
+	status, err := snapshot.rollback(t.Context())
+	require.NoError(t, err)
+	require.Len(t, status["environment"].Updated, 1)
+	require.Len(t, status["function"].Deleted, 1)
+	require.Len(t, status["HTTPTrigger"].Created, 1)
+	require.Len(t, status["Secret"].Created, 1)
+
+	restored, err := fissionClient.CoreV1().Environments(metav1.NamespaceDefault).Get(t.Context(), "nodejs", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "node:1", restored.Spec.Runtime.Image)
+	_, err = fissionClient.CoreV1().Functions(metav1.NamespaceDefault).Get(t.Context(), "hello", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	recreated, err := fissionClient.CoreV1().HTTPTriggers(metav1.NamespaceDefault).Get(t.Context(), "route", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "/hello", recreated.Spec.RelativeURL)
+	recreatedSecret, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(t.Context(), "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("old"), recreatedSecret.Data["PASSWORD"])
+
+	// resources outside of the deployment and the specs are left alone
+	require.Empty(t, status["environment"].Deleted)
+	_, err = fissionClient.CoreV1().Environments(metav1.NamespaceDefault).Get(t.Context(), "other", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// a second rollback has nothing to do
+	status, err = snapshot.rollback(t.Context())
+	require.NoError(t, err)
+	for kind, ras := range status {
+		require.Empty(t, ras.Created, kind)
+		require.Empty(t, ras.Updated, kind)
+		require.Empty(t, ras.Deleted, kind)
+	}
+}
+
+func TestIsDeploymentRolledOut(t *testing.T) {
+	replicas := int32(2)
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+	}
+	ready, err := isDeploymentRolledOut(d)
+	require.NoError(t, err)
+	require.False(t, ready)
+
+	d.Status.AvailableReplicas = 2
+	ready, err = isDeploymentRolledOut(d)
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"}}
+	_, err = isDeploymentRolledOut(d)
+	require.ErrorContains(t, err, "exceeded its progress deadline")
+}
@@ -57,20 +57,24 @@ func (w *packageBuildWatcher) addPackages(pkgMeta map[string]metav1.ObjectMeta)
 	}
 }
 
-func (w *packageBuildWatcher) watch(ctx context.Context) {
+// watch prints the summaries of the builds of the packages as they finish, until
+// no build is running. It returns an error if a build fails.
+func (w *packageBuildWatcher) watch(ctx context.Context) error {
 	for {
 		// non-blocking check if we're cancelled
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		default:
 		}
 
 		// pull list of packages (TODO: convert to watch)
 		pkgs, err := w.fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
 		if err != nil {
-			fmt.Printf("Getting list of packages: %v", err)
-			os.Exit(1)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("error getting list of packages: %w", err)
 		}
 
 		// find packages that (a) are in the app spec and (b) have an interesting
@@ -106,13 +110,13 @@ func (w *packageBuildWatcher) watch(ctx context.Context) {
 				fmt.Printf("------\n")
 			}
 			if pkg.Status.BuildStatus == fv1.BuildStatusFailed {
-				os.Exit(1)
+				return fmt.Errorf("build of package %v failed", k8sCache.MetaObjectToName(&pkg.ObjectMeta).String())
 			}
 		}
 
 		// if there are no builds running, we can stop polling
 		if !keepWaiting {
-			return
+			return nil
 		}
 		time.Sleep(time.Second)
 	}
@@ -39,7 +39,7 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(Validate),
 	}
 	wrapper.SetFlags(validateCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecAllowConflicts},
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecAllowConflicts, flag.SpecOverlay, flag.SpecValues},
 	})
 
 	applyCmd := &cobra.Command{
@@ -49,7 +49,20 @@ func Commands() *cobra.Command {
 	}
 	wrapper.SetFlags(applyCmd, flag.FlagSet{
 		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecDelete, flag.SpecWait, flag.SpecWatch,
-			flag.SpecValidation, flag.SpecApplyCommitLabel, flag.SpecAllowConflicts, flag.ForceNamespace},
+			flag.SpecValidation, flag.SpecApplyCommitLabel, flag.SpecAllowConflicts, flag.ForceNamespace, flag.SpecDryRun,
+			flag.SpecOverlay, flag.SpecValues, flag.SpecAtomic, flag.SpecWaitTimeout, flag.SpecTest, flag.SpecTestOutput},
+	})
+
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes applying the application specification would make",
+		Long: "Show the resources applying the application specification would create, update or delete, " +
+			"with a diff of their fields. Exits with status 2 if the cluster differs from the specification.",
+		RunE: wrapper.Wrapper(Diff),
+	}
+	wrapper.SetFlags(diffCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecDelete, flag.SpecValidation,
+			flag.SpecApplyCommitLabel, flag.SpecAllowConflicts, flag.ForceNamespace, flag.SpecOverlay, flag.SpecValues},
 	})
 
 	destroyCmd := &cobra.Command{
@@ -58,7 +71,7 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(Destroy),
 	}
 	wrapper.SetFlags(destroyCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.ForceDelete},
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.ForceDelete, flag.SpecOverlay, flag.SpecValues},
 	})
 
 	listCmd := &cobra.Command{
@@ -67,7 +80,30 @@ func Commands() *cobra.Command {
 		RunE:  wrapper.Wrapper(List),
 	}
 	wrapper.SetFlags(listCmd, flag.FlagSet{
-		Optional: []flag.Flag{flag.SpecDeployID, flag.SpecDir, flag.SpecIgnore, flag.AllNamespaces},
+		Optional: []flag.Flag{flag.SpecDeployID, flag.SpecDir, flag.SpecIgnore, flag.AllNamespaces, flag.Output},
+	})
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export resources of the cluster to the application specification",
+		Long: "Write the specs of functions, environments, packages, triggers and canary configs of the cluster, " +
+			"and of the secrets and configmaps used by the functions, to the spec directory, " +
+			"downloading the package archives next to it, so that they can be managed with 'fission spec apply'. " +
+			"The data of secrets is written to env files of the spec directory, kept out of version control by its .gitignore.",
+		RunE: wrapper.Wrapper(Export),
+	}
+	wrapper.SetFlags(exportCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecExportName, flag.SpecLabels, flag.SpecAdopt, flag.AllNamespaces},
+	})
+
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run the test suites of the specs against the router",
+		RunE:  wrapper.Wrapper(Test),
+	}
+	wrapper.SetFlags(testCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.SpecDir, flag.SpecIgnore, flag.SpecOverlay, flag.SpecValues,
+			flag.SpecTestRouter, flag.SpecTestOutput, flag.ForceNamespace},
 	})
 
 	command := &cobra.Command{
@@ -76,7 +112,7 @@ func Commands() *cobra.Command {
 		Short:   "Manage a declarative application specification",
 	}
 
-	command.AddCommand(initCmd, validateCmd, applyCmd, listCmd, destroyCmd)
+	command.AddCommand(initCmd, validateCmd, applyCmd, diffCmd, exportCmd, listCmd, testCmd, destroyCmd)
 
 	return command
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
 
@@ -52,7 +53,7 @@ func (opts *DestroySubCommand) run(input cli.Input) error {
 	specIgnore := util.GetSpecIgnore(input)
 
 	// read everything
-	fr, err := ReadSpecs(specDir, specIgnore, false)
+	fr, err := ReadSpecsWithOverlay(specDir, specIgnore, false, GetOverlay(input))
 	if err != nil {
 		return fmt.Errorf("error reading specs: %w", err)
 	}
@@ -88,6 +89,11 @@ func forceDeleteResources(ctx context.Context, fclient cmd.Client, fr *FissionRe
 
 	var err error
 
+	_, _, err = applyCanaryConfigs(ctx, fclient, fr, true, false)
+	if err != nil {
+		return fmt.Errorf("canaryConfig delete failed: %w", err)
+	}
+
 	_, _, err = applyHTTPTriggers(ctx, fclient, fr, true, false)
 	if err != nil {
 		return fmt.Errorf("HTTPTrigger delete failed: %w", err)
@@ -123,6 +129,16 @@ func forceDeleteResources(ctx context.Context, fclient cmd.Client, fr *FissionRe
 		return fmt.Errorf("environment delete failed: %w", err)
 	}
 
+	_, _, err = applySecrets(ctx, fclient, fr, true, false)
+	if err != nil {
+		return fmt.Errorf("secret delete failed: %w", err)
+	}
+
+	_, _, err = applyConfigMaps(ctx, fclient, fr, true, false)
+	if err != nil {
+		return fmt.Errorf("configmap delete failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -172,6 +188,21 @@ func (opts *DestroySubCommand) insertNSToResource(input cli.Input, fr *FissionRe
 			fr.KubernetesWatchTriggers[i].Namespace = currentNS
 		}
 	}
+	for i := range fr.CanaryConfigs {
+		if fr.CanaryConfigs[i].Namespace == "" {
+			fr.CanaryConfigs[i].Namespace = currentNS
+		}
+	}
+	for i := range fr.Secrets {
+		if fr.Secrets[i].Namespace == "" {
+			fr.Secrets[i].Namespace = currentNS
+		}
+	}
+	for i := range fr.ConfigMaps {
+		if fr.ConfigMaps[i].Namespace == "" {
+			fr.ConfigMaps[i].Namespace = currentNS
+		}
+	}
 
 	return result.ErrorOrNil()
 }
@@ -180,6 +211,11 @@ func deleteResources(ctx context.Context, fclient cmd.Client, fr *FissionResourc
 
 	var err error
 
+	err = destroyCanaryConfigs(ctx, fclient, fr)
+	if err != nil {
+		return fmt.Errorf("canaryConfig delete failed: %w", err)
+	}
+
 	err = destroyHTTPTriggers(ctx, fclient, fr)
 	if err != nil {
 		return fmt.Errorf("HTTPTrigger delete failed: %w", err)
@@ -215,6 +251,32 @@ func deleteResources(ctx context.Context, fclient cmd.Client, fr *FissionResourc
 		return fmt.Errorf("environment delete failed: %w", err)
 	}
 
+	err = destroySecrets(ctx, fclient, fr)
+	if err != nil {
+		return fmt.Errorf("secret delete failed: %w", err)
+	}
+
+	err = destroyConfigMaps(ctx, fclient, fr)
+	if err != nil {
+		return fmt.Errorf("configmap delete failed: %w", err)
+	}
+
+	return nil
+}
+
+func destroyCanaryConfigs(ctx context.Context, fclient cmd.Client, fr *FissionResources) error {
+	for _, o := range fr.CanaryConfigs {
+		err := fclient.FissionClientSet.CoreV1().CanaryConfigs(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			console.Verbose(2, fmt.Sprintf("could not delete canary config: %s Namespace: %s", o.ObjectMeta.Name, o.ObjectMeta.Namespace))
+			err = nil
+			continue
+
+		} else if err != nil {
+			return err
+		}
+		fmt.Printf("Deleted %s %s\n", o.Kind, k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+	}
 	return nil
 }
 
@@ -341,3 +403,47 @@ func destroyEnvironments(ctx context.Context, fclient cmd.Client, fr *FissionRes
 
 	return nil
 }
+
+// destroySecrets deletes the secrets of the specs. Secrets of the same name
+// that weren't created by applying the specs are kept.
+func destroySecrets(ctx context.Context, fclient cmd.Client, fr *FissionResources) error {
+
+	for _, o := range fr.Secrets {
+		obj, err := fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Get(ctx, o.ObjectMeta.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) || (err == nil && obj.Labels[FISSION_DEPLOYMENT_UID_KEY] != fr.DeploymentConfig.UID) {
+			console.Verbose(2, fmt.Sprintf("could not delete Secret: %s Namespace: %s", o.ObjectMeta.Name, o.ObjectMeta.Namespace))
+			continue
+		} else if err != nil {
+			return err
+		}
+		err = fclient.KubernetesClient.CoreV1().Secrets(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Printf("Deleted Secret %s\n", k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+	}
+
+	return nil
+}
+
+// destroyConfigMaps deletes the configmaps of the specs. ConfigMaps of the same
+// name that weren't created by applying the specs are kept.
+func destroyConfigMaps(ctx context.Context, fclient cmd.Client, fr *FissionResources) error {
+
+	for _, o := range fr.ConfigMaps {
+		obj, err := fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Get(ctx, o.ObjectMeta.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) || (err == nil && obj.Labels[FISSION_DEPLOYMENT_UID_KEY] != fr.DeploymentConfig.UID) {
+			console.Verbose(2, fmt.Sprintf("could not delete ConfigMap: %s Namespace: %s", o.ObjectMeta.Name, o.ObjectMeta.Namespace))
+			continue
+		} else if err != nil {
+			return err
+		}
+		err = fclient.KubernetesClient.CoreV1().ConfigMaps(o.ObjectMeta.Namespace).Delete(ctx, o.ObjectMeta.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		fmt.Printf("Deleted ConfigMap %s\n", k8sCache.MetaObjectToName(&o.ObjectMeta).String())
+	}
+
+	return nil
+}
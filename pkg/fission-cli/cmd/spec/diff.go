@@ -0,0 +1,492 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+// DriftExitCode is the exit code of spec diff when applying the specs
+// would change the cluster.
+const DriftExitCode = 2
+
+type (
+	DiffSubCommand struct {
+		ApplySubCommand
+	}
+
+	// changeAction is what applying the specs does to a resource.
+	changeAction string
+
+	// resourceChange is the planned change of a resource, along with a
+	// unified diff from the cluster state to the spec.
+	resourceChange struct {
+		Kind   string
+		Name   string
+		Action changeAction
+		Diff   string
+	}
+)
+
+const (
+	actionCreate    changeAction = "create"
+	actionUpdate    changeAction = "update"
+	actionDelete    changeAction = "delete"
+	actionUnchanged changeAction = "unchanged"
+)
+
+// Diff prints the changes applying the specs would make on the cluster.
+// It exits with DriftExitCode if the cluster differs from the specs.
+func Diff(input cli.Input) error {
+	return (&DiffSubCommand{}).do(input)
+}
+
+func (opts *DiffSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *DiffSubCommand) run(input cli.Input) error {
+	fr, err := opts.readSpecsForApply(input)
+	if err != nil {
+		return err
+	}
+
+	changes, err := planResources(input, opts.Client(), util.GetSpecDir(input), fr,
+		input.Bool(flagkey.SpecDelete), input.Bool(flagkey.SpecAllowConflicts))
+	if err != nil {
+		return fmt.Errorf("error computing changes: %w", err)
+	}
+	if printPlan(os.Stdout, changes) {
+		return &cmd.ExitError{Code: DriftExitCode, Err: errors.New("the cluster differs from the specs")}
+	}
+	return nil
+}
+
+// planResources computes the changes applyResources would make on the
+// cluster, without changing it or uploading archives.
+func planResources(input cli.Input, fclient cmd.Client, specDir string, fr *FissionResources, delete bool, specAllowConflicts bool) ([]resourceChange, error) {
+	ctx := input.Context()
+
+	err := applyArchives(input, fclient, specDir, fr, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []resourceChange
+	add := func(c []resourceChange, err error) error {
+		changes = append(changes, c...)
+		return err
+	}
+
+	secrets, err := listSecrets(ctx, fclient, fr, specAllowConflicts)
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("Secret", secrets, labelledResources(fr.Secrets, fr), fr, delete, specAllowConflicts, isSecretUnchanged))
+	if err != nil {
+		return nil, err
+	}
+
+	cms, err := listConfigMaps(ctx, fclient, fr, specAllowConflicts)
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("ConfigMap", cms, labelledResources(fr.ConfigMaps, fr), fr, delete, specAllowConflicts, isConfigMapUnchanged))
+	if err != nil {
+		return nil, err
+	}
+
+	envs, err := fclient.FissionClientSet.CoreV1().Environments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("environment", envs.Items, fr.Environments, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.Environment]))
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := fclient.FissionClientSet.CoreV1().Packages(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pkgChanges, err := planKind("package", pkgs.Items, fr.Packages, fr, delete, specAllowConflicts, isPackageUnchanged)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, pkgChanges...)
+
+	// functions reference the resource version of the packages left
+	// unchanged, the others get a new one once applied.
+	pkgVersions := make(map[string]string)
+	for _, pkg := range pkgs.Items {
+		pkgVersions[k8sCache.MetaObjectToName(&pkg.ObjectMeta).String()] = pkg.ObjectMeta.ResourceVersion
+	}
+	pkgKnown := make(map[string]bool)
+	for _, c := range pkgChanges {
+		if c.Action == actionUnchanged {
+			pkgKnown[c.Name] = true
+		} else if c.Action != actionDelete {
+			pkgKnown[c.Name] = false
+		}
+	}
+	for i, f := range fr.Functions {
+		if f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeContainer {
+			continue
+		}
+		k := k8sCache.MetaObjectToName(&metav1.ObjectMeta{
+			Namespace: f.Spec.Package.PackageRef.Namespace,
+			Name:      f.Spec.Package.PackageRef.Name,
+		}).String()
+		unchanged, ok := pkgKnown[k]
+		if !ok {
+			return nil, fmt.Errorf("function %v/%v references package %v/%v, which doesn't exist in the specs",
+				f.ObjectMeta.Namespace, f.ObjectMeta.Name, f.Spec.Package.PackageRef.Namespace, f.Spec.Package.PackageRef.Name)
+		}
+		if unchanged {
+			fr.Functions[i].Spec.Package.PackageRef.ResourceVersion = pkgVersions[k]
+		}
+	}
+
+	fns, err := fclient.FissionClientSet.CoreV1().Functions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("function", fns.Items, fr.Functions, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.Function]))
+	if err != nil {
+		return nil, err
+	}
+
+	hts, err := fclient.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	existingHts := make(map[string]*fv1.HTTPTrigger)
+	for i := range hts.Items {
+		existingHts[k8sCache.MetaObjectToName(&hts.Items[i].ObjectMeta).String()] = &hts.Items[i]
+	}
+	desiredHts := make([]fv1.HTTPTrigger, len(fr.HttpTriggers))
+	for i, o := range fr.HttpTriggers {
+		if existingObj, ok := existingHts[k8sCache.MetaObjectToName(&o.ObjectMeta).String()]; ok {
+			keepCanaryWeights(&o, existingObj, fr)
+		}
+		desiredHts[i] = o
+	}
+	err = add(planKind("HTTPTrigger", hts.Items, desiredHts, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.HTTPTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	ccs, err := fclient.FissionClientSet.CoreV1().CanaryConfigs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("CanaryConfig", ccs.Items, fr.CanaryConfigs, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.CanaryConfig]))
+	if err != nil {
+		return nil, err
+	}
+
+	kws, err := fclient.FissionClientSet.CoreV1().KubernetesWatchTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("KubernetesWatchTrigger", kws.Items, fr.KubernetesWatchTriggers, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.KubernetesWatchTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	tts, err := fclient.FissionClientSet.CoreV1().TimeTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("TimeTrigger", tts.Items, fr.TimeTriggers, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.TimeTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	mqts, err := fclient.FissionClientSet.CoreV1().MessageQueueTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	err = add(planKind("MessageQueueTrigger", mqts.Items, fr.MessageQueueTriggers, fr, delete, specAllowConflicts, isResourceUnchanged[*fv1.MessageQueueTrigger]))
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// planKind computes the changes of the resources of a kind the same way the
+// apply functions do: the existing resources of the deployment, or all of them
+// if conflicts are allowed, are matched with the desired ones by name.
+func planKind[T any, PT interface {
+	*T
+	metav1.Object
+}](kind string, existingObjs []T, desiredObjs []T, fr *FissionResources, delete bool, specAllowConflicts bool,
+	unchanged func(existingObj, newObj PT) bool) ([]resourceChange, error) {
+
+	existent := make(map[string]PT)
+	for i := range existingObjs {
+		obj := PT(&existingObjs[i])
+		if specAllowConflicts || obj.GetAnnotations()[FISSION_DEPLOYMENT_UID_KEY] == fr.DeploymentConfig.UID {
+			existent[k8sCache.NewObjectName(obj.GetNamespace(), obj.GetName()).String()] = obj
+		}
+	}
+
+	var changes []resourceChange
+	desired := make(map[string]bool)
+	for i := range desiredObjs {
+		o := desiredObjs[i]
+		obj := PT(&o)
+		annotations := make(map[string]string, len(obj.GetAnnotations())+2)
+		for k, v := range obj.GetAnnotations() {
+			annotations[k] = v
+		}
+		annotations[FISSION_DEPLOYMENT_NAME_KEY] = fr.DeploymentConfig.Name
+		annotations[FISSION_DEPLOYMENT_UID_KEY] = fr.DeploymentConfig.UID
+		obj.SetAnnotations(annotations)
+
+		name := k8sCache.NewObjectName(obj.GetNamespace(), obj.GetName()).String()
+		desired[name] = true
+
+		existingObj, ok := existent[name]
+		var change resourceChange
+		var err error
+		switch {
+		case !ok:
+			change, err = makeResourceChange(kind, name, actionCreate, nil, obj)
+		case unchanged(existingObj, obj):
+			change, err = makeResourceChange(kind, name, actionUnchanged, existingObj, obj)
+		default:
+			change, err = makeResourceChange(kind, name, actionUpdate, existingObj, obj)
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if delete {
+		for name, obj := range existent {
+			if desired[name] {
+				continue
+			}
+			change, err := makeResourceChange(kind, name, actionDelete, obj, nil)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// labelledResources returns copies of the Kubernetes resources of the specs
+// labelled with the deployment UID, the way they're applied.
+func labelledResources[T any, PT interface {
+	*T
+	metav1.Object
+}](objs []T, fr *FissionResources) []T {
+	labelled := make([]T, len(objs))
+	for i := range objs {
+		labelled[i] = objs[i]
+		obj := PT(&labelled[i])
+		labels := make(map[string]string, len(obj.GetLabels())+1)
+		for k, v := range obj.GetLabels() {
+			labels[k] = v
+		}
+		labels[FISSION_DEPLOYMENT_UID_KEY] = fr.DeploymentConfig.UID
+		obj.SetLabels(labels)
+	}
+	return labelled
+}
+
+// isResourceUnchanged returns whether the existing resource is kept as is,
+// which is when its labels, annotations and spec match the desired ones.
+func isResourceUnchanged[PT metav1.Object](existingObj, newObj PT) bool {
+	return reflect.DeepEqual(existingObj.GetLabels(), newObj.GetLabels()) &&
+		reflect.DeepEqual(existingObj.GetAnnotations(), newObj.GetAnnotations()) &&
+		reflect.DeepEqual(specOf(existingObj), specOf(newObj))
+}
+
+// specOf returns the Spec field of a Fission resource.
+func specOf(obj interface{}) interface{} {
+	return reflect.Indirect(reflect.ValueOf(obj)).FieldByName("Spec").Interface()
+}
+
+// makeResourceChange renders the existing and desired resources, either of
+// which may be nil, and diffs them.
+func makeResourceChange(kind string, name string, action changeAction, existingObj, newObj metav1.Object) (resourceChange, error) {
+	from, err := renderResource(existingObj)
+	if err != nil {
+		return resourceChange{}, err
+	}
+	to, err := renderResource(newObj)
+	if err != nil {
+		return resourceChange{}, err
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "cluster/" + kind + "/" + name,
+		ToFile:   "spec/" + kind + "/" + name,
+		Context:  3,
+	})
+	if err != nil {
+		return resourceChange{}, err
+	}
+	return resourceChange{Kind: kind, Name: name, Action: action, Diff: diff}, nil
+}
+
+// renderResource renders the fields of a resource managed by the specs as
+// YAML, leaving out the fields set by the cluster.
+func renderResource(obj metav1.Object) (string, error) {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return "", nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]interface{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := map[string]interface{}{
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	}
+	if len(obj.GetLabels()) > 0 {
+		metadata["labels"] = obj.GetLabels()
+	}
+	if len(obj.GetAnnotations()) > 0 {
+		metadata["annotations"] = obj.GetAnnotations()
+	}
+	rendered := map[string]interface{}{
+		"metadata": metadata,
+	}
+	switch o := obj.(type) {
+	case *apiv1.Secret:
+		// secret values never show up in diffs, only whether they changed
+		rendered["type"] = o.Type
+		if len(o.Data) > 0 {
+			data := make(map[string]string, len(o.Data))
+			for k, v := range o.Data {
+				data[k] = redactSecretValue(v)
+			}
+			rendered["data"] = data
+		}
+	case *apiv1.ConfigMap:
+		if len(o.Data) > 0 {
+			rendered["data"] = o.Data
+		}
+		if len(o.BinaryData) > 0 {
+			rendered["binaryData"] = fields["binaryData"]
+		}
+	default:
+		rendered["spec"] = fields["spec"]
+	}
+	out, err := yaml.Marshal(rendered)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// redactSecretValue replaces a secret value with a digest keyed with a key
+// of the current run, so that diffs show which values changed without
+// revealing them.
+func redactSecretValue(v []byte) string {
+	mac := hmac.New(sha256.New, secretRedactionKey())
+	mac.Write(v)
+	return fmt.Sprintf("<redacted %x>", mac.Sum(nil)[:4])
+}
+
+var secretRedactionKey = sync.OnceValue(func() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+})
+
+// printPlan prints the changes, and returns whether applying them would
+// change the cluster.
+func printPlan(w io.Writer, changes []resourceChange) bool {
+	counts := make(map[changeAction]int)
+	for _, c := range changes {
+		counts[c.Action]++
+		switch c.Action {
+		case actionCreate:
+			fmt.Fprintf(w, "+ %v %v will be created\n", c.Kind, c.Name)
+		case actionUpdate:
+			fmt.Fprintf(w, "~ %v %v will be updated\n", c.Kind, c.Name)
+		case actionDelete:
+			fmt.Fprintf(w, "- %v %v will be deleted\n", c.Kind, c.Name)
+		default:
+			fmt.Fprintf(w, "= %v %v is unchanged\n", c.Kind, c.Name)
+			continue
+		}
+		if len(c.Diff) > 0 {
+			fmt.Fprint(w, indent(c.Diff, "    "))
+		} else {
+			// packages whose build didn't succeed are updated to be rebuilt
+			fmt.Fprintln(w, "    (no field changes)")
+		}
+	}
+
+	fmt.Fprintf(w, "\n%v to create, %v to update, %v to delete, %v unchanged.\n",
+		counts[actionCreate], counts[actionUpdate], counts[actionDelete], counts[actionUnchanged])
+	return counts[actionCreate]+counts[actionUpdate]+counts[actionDelete] > 0
+}
+
+func indent(s string, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	var sb strings.Builder
+	for _, line := range lines {
+		if len(line) > 0 {
+			sb.WriteString(prefix + line)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/dummy"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	"github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+)
+
+func TestPlanResources(t *testing.T) {
+	deployment := types.DeploymentConfig{Name: "app", UID: "uid"}
+	deployed := metav1.ObjectMeta{
+		Namespace: metav1.NamespaceDefault,
+		Annotations: map[string]string{
+			FISSION_DEPLOYMENT_NAME_KEY: deployment.Name,
+			FISSION_DEPLOYMENT_UID_KEY:  deployment.UID,
+		},
+		ResourceVersion: "1",
+	}
+	withName := func(meta metav1.ObjectMeta, name string) metav1.ObjectMeta {
+		meta.Name = name
+		return meta
+	}
+	env := fv1.Environment{
+		ObjectMeta: withName(deployed, "nodejs"),
+		Spec:       fv1.EnvironmentSpec{Version: 2, Runtime: fv1.Runtime{Image: "node"}},
+	}
+	pkg := fv1.Package{
+		ObjectMeta: withName(deployed, "hello-pkg"),
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+			Deployment:  fv1.Archive{Type: fv1.ArchiveTypeLiteral, Literal: []byte("code")},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusSucceeded},
+	}
+	function := func(name string, timeout int) fv1.Function {
+		return fv1.Function{
+			ObjectMeta: withName(deployed, name),
+			Spec: fv1.FunctionSpec{
+				Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+				Package: fv1.FunctionPackageRef{
+					PackageRef: fv1.PackageRef{Name: "hello-pkg", Namespace: metav1.NamespaceDefault, ResourceVersion: "1"},
+				},
+				FunctionTimeout: timeout,
+			},
+		}
+	}
+	hello := function("hello", 60)
+	stale := function("stale", 60)
+	fissionClient := fake.NewClientset(&env, &pkg, &hello, &stale)
+
+	desired := func() *FissionResources {
+		fr := &FissionResources{DeploymentConfig: deployment}
+		for _, o := range []fv1.Environment{env} {
+			o.ObjectMeta = metav1.ObjectMeta{Name: o.Name, Namespace: o.Namespace}
+			fr.Environments = append(fr.Environments, o)
+		}
+		for _, o := range []fv1.Package{pkg} {
+			o.ObjectMeta = metav1.ObjectMeta{Name: o.Name, Namespace: o.Namespace}
+			o.Status = fv1.PackageStatus{}
+			fr.Packages = append(fr.Packages, o)
+		}
+		for _, o := range []fv1.Function{function("hello", 120), function("new", 60)} {
+			o.ObjectMeta = metav1.ObjectMeta{Name: o.Name, Namespace: o.Namespace}
+			o.Spec.Package.PackageRef.ResourceVersion = ""
+			fr.Functions = append(fr.Functions, o)
+		}
+		return fr
+	}
+
+	input := dummy.TestFlagSet()
+	fclient := cmd.Client{FissionClientSet: fissionClient, KubernetesClient: kubefake.NewClientset()}
+
+	changes, err := planResources(input, fclient, t.TempDir(), desired(), false, false)
+	require.NoError(t, err)
+	actions := make(map[string]changeAction)
+	for _, c := range changes {
+		actions[c.Kind+" "+c.Name] = c.Action
+	}
+	require.Equal(t, map[string]changeAction{
+		"environment default/nodejs": actionUnchanged,
+		"package default/hello-pkg":  actionUnchanged,
+		"function default/hello":     actionUpdate,
+		"function default/new":       actionCreate,
+	}, actions)
+
+	out := &bytes.Buffer{}
+	require.True(t, printPlan(out, changes))
+	require.Contains(t, out.String(), "~ function default/hello will be updated")
+	require.Contains(t, out.String(), "-  functionTimeout: 60\n")
+	require.Contains(t, out.String(), "+  functionTimeout: 120\n")
+	require.Contains(t, out.String(), "1 to create, 1 to update, 0 to delete, 2 unchanged.")
+
+	// with --delete, resources of the deployment missing from the specs are deleted
+	changes, err = planResources(input, fclient, t.TempDir(), desired(), true, false)
+	require.NoError(t, err)
+	last := changes[len(changes)-1]
+	require.Equal(t, "default/stale", last.Name)
+	require.Equal(t, actionDelete, last.Action)
+	require.Contains(t, last.Diff, "-  functionTimeout: 60\n")
+
+	// nothing changes on the cluster
+	fns, err := fissionClient.CoreV1().Functions(metav1.NamespaceDefault).List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, fns.Items, 2)
+}
+
+func TestRenderSecretRedacted(t *testing.T) {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: metav1.NamespaceDefault},
+		Type:       apiv1.SecretTypeOpaque,
+		Data:       map[string][]byte{"PASSWORD": []byte("hunter2")},
+	}
+	out, err := renderResource(secret)
+	require.NoError(t, err)
+	require.NotContains(t, out, "hunter2")
+	require.Contains(t, out, "PASSWORD: <redacted ")
+
+	rotated := secret.DeepCopy()
+	rotated.Data["PASSWORD"] = []byte("rotated")
+	rotatedOut, err := renderResource(rotated)
+	require.NoError(t, err)
+	require.NotEqual(t, out, rotatedOut)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// loadSecretEnvFile adds the values of the env file of a Secret spec to its
+// data. The string data of the spec is moved to its data too, the way the
+// cluster stores it, so that secrets are compared with their data only.
+func loadSecretEnvFile(secret *apiv1.Secret, loc *Location) error {
+	values, err := readSpecEnvFile(&secret.ObjectMeta, "Secret", loc)
+	if err != nil {
+		return err
+	}
+	if len(secret.StringData)+len(values) > 0 && secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	for k, v := range secret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+	for k, v := range values {
+		secret.Data[k] = []byte(v)
+	}
+	if len(secret.Type) == 0 {
+		secret.Type = apiv1.SecretTypeOpaque
+	}
+	return nil
+}
+
+// loadConfigMapEnvFile adds the values of the env file of a ConfigMap spec
+// to its data.
+func loadConfigMapEnvFile(cm *apiv1.ConfigMap, loc *Location) error {
+	values, err := readSpecEnvFile(&cm.ObjectMeta, "ConfigMap", loc)
+	if err != nil {
+		return err
+	}
+	if len(values) > 0 && cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	for k, v := range values {
+		cm.Data[k] = v
+	}
+	return nil
+}
+
+// readSpecEnvFile reads the env file named by the ENV_FILE_ANNOTATION
+// annotation of the spec at loc.
+func readSpecEnvFile(m *metav1.ObjectMeta, kind string, loc *Location) (map[string]string, error) {
+	file, ok := m.Annotations[ENV_FILE_ANNOTATION]
+	if !ok {
+		return nil, nil
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(loc.Path), file)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error reading env file of %v '%v': %w", loc, kind, m.Name, err)
+	}
+	values, err := parseEnvFile(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("%v: error parsing env file %v of %v '%v': %w", loc, file, kind, m.Name, err)
+	}
+	return values, nil
+}
+
+// writeEnvFile writes values to a new env file of the spec directory, with
+// quoted values which parseEnvFile reads back as is. The .gitignore of the
+// spec directory is created if missing, to keep the env file out of version
+// control.
+func writeEnvFile(specDir string, envFile string, values map[string][]byte) error {
+	gitignore := filepath.Join(specDir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		err = os.WriteFile(gitignore, []byte(SPEC_GITIGNORE), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		fmt.Fprintf(&b, "%v=%v\n", k, strconv.Quote(string(values[k])))
+	}
+	f, err := os.OpenFile(filepath.Join(specDir, envFile), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(b.String())
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parseEnvFile parses the KEY=VALUE lines of an env file. Blank lines and
+// lines starting with # are skipped, and double quoted values are unquoted
+// like Go strings.
+func parseEnvFile(content string) (map[string]string, error) {
+	values := make(map[string]string)
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || len(key) == 0 {
+			return nil, fmt.Errorf("line %v: expected KEY=VALUE", i+1)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %v: invalid quoted value: %w", i+1, err)
+			}
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	values, err := parseEnvFile(`# credentials
+export USER=admin
+
+PASSWORD="p@ss\nword"
+TOKEN='a=b'
+EMPTY=
+`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"USER":     "admin",
+		"PASSWORD": "p@ss\nword",
+		"TOKEN":    "a=b",
+		"EMPTY":    "",
+	}, values)
+
+	_, err = parseEnvFile("USER=admin\nPASSWORD\n")
+	require.EqualError(t, err, "line 2: expected KEY=VALUE")
+}
+
+func TestApplySecretsAndConfigMaps(t *testing.T) {
+	specDir := t.TempDir()
+	writeSpecFile(t, filepath.Join(specDir, "fission-deployment-config.yaml"), `apiVersion: fission.io/v1
+kind: DeploymentConfig
+name: app
+uid: uid
+`)
+	writeSpecFile(t, filepath.Join(specDir, "secrets.yaml"), `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+  namespace: default
+  annotations:
+    fission.io/env-file: db.env
+stringData:
+  USER: admin
+  PASSWORD: inline
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: default
+data:
+  LOG_LEVEL: info
+`)
+	writeSpecFile(t, filepath.Join(specDir, "db.env"), "PASSWORD=secret\n")
+
+	fr, err := ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+	require.Len(t, fr.Secrets, 1)
+	require.Equal(t, map[string][]byte{"USER": []byte("admin"), "PASSWORD": []byte("secret")}, fr.Secrets[0].Data)
+	require.Empty(t, fr.Secrets[0].StringData)
+	require.Equal(t, apiv1.SecretTypeOpaque, fr.Secrets[0].Type)
+	require.Len(t, fr.ConfigMaps, 1)
+
+	unmanaged := &apiv1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: metav1.NamespaceDefault}}
+	kubeClient := kubefake.NewClientset(unmanaged)
+	fclient := cmd.Client{KubernetesClient: kubeClient}
+
+	_, ras, err := applySecrets(t.Context(), fclient, fr, true, false)
+	require.NoError(t, err)
+	require.Len(t, ras.Created, 1)
+	_, ras, err = applyConfigMaps(t.Context(), fclient, fr, true, false)
+	require.NoError(t, err)
+	require.Len(t, ras.Created, 1)
+
+	secret, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(t.Context(), "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "uid", secret.Labels[FISSION_DEPLOYMENT_UID_KEY])
+	require.Equal(t, []byte("secret"), secret.Data["PASSWORD"])
+
+	// applying again changes nothing
+	_, ras, err = applySecrets(t.Context(), fclient, fr, true, false)
+	require.NoError(t, err)
+	require.Empty(t, ras.Created)
+	require.Empty(t, ras.Updated)
+
+	// changed values are updated
+	writeSpecFile(t, filepath.Join(specDir, "db.env"), "PASSWORD=rotated\n")
+	fr, err = ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+	_, ras, err = applySecrets(t.Context(), fclient, fr, true, false)
+	require.NoError(t, err)
+	require.Len(t, ras.Updated, 1)
+
+	// removed specs delete the secrets of the deployment only
+	fr.Secrets = nil
+	_, ras, err = applySecrets(t.Context(), fclient, fr, true, false)
+	require.NoError(t, err)
+	require.Len(t, ras.Deleted, 1)
+	_, err = kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(t.Context(), "unmanaged", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, destroyConfigMaps(t.Context(), fclient, fr))
+	_, err = kubeClient.CoreV1().ConfigMaps(metav1.NamespaceDefault).Get(t.Context(), "settings", metav1.GetOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,663 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	pkgutil "github.com/fission/fission/pkg/fission-cli/cmd/package/util"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/utils"
+)
+
+// exportArchivesDir is the directory, relative to the parent directory of the
+// spec directory, the archives of the exported packages are written to.
+const exportArchivesDir = "archives"
+
+type ExportSubCommand struct {
+	cmd.CommandActioner
+}
+
+// Export writes the specs of resources of the cluster to the spec directory,
+// so that resources created with the other commands can be managed with
+// spec apply.
+func Export(input cli.Input) error {
+	return (&ExportSubCommand{}).do(input)
+}
+
+func (opts *ExportSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *ExportSubCommand) run(input cli.Input) error {
+	specDir := util.GetSpecDir(input)
+	fr, err := ReadSpecs(specDir, util.GetSpecIgnore(input), false)
+	if err != nil {
+		return fmt.Errorf("error reading specs: %w", err)
+	}
+	if len(fr.DeploymentConfig.UID) == 0 {
+		return fmt.Errorf("couldn't find the deployment config in %v, run `fission spec init` first", specDir)
+	}
+
+	_, namespace, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return fv1.AggregateValidationErrors("Function", err)
+	}
+	if input.Bool(flagkey.AllNamespaces) {
+		namespace = metav1.NamespaceAll
+	}
+
+	selected, err := selectExportResources(input.Context(), opts.Client(), namespace,
+		input.String(flagkey.SpecLabels), input.StringSlice(flagkey.SpecExportName))
+	if err != nil {
+		return err
+	}
+
+	exported, err := exportResources(input.Context(), opts.Client(), specDir, fr, selected)
+	if err != nil {
+		return err
+	}
+
+	console.Infof("Exported:\n * %v Functions\n * %v Environments\n * %v Packages \n * %v Http Triggers \n * %v MessageQueue Triggers\n * %v Time Triggers\n * %v Kube Watchers\n * %v Canary Configs\n * %v Secrets\n * %v ConfigMaps\n * %v ArchiveUploadSpec\n",
+		len(exported.Functions), len(exported.Environments), len(exported.Packages), len(exported.HttpTriggers), len(exported.MessageQueueTriggers), len(exported.TimeTriggers), len(exported.KubernetesWatchTriggers), len(exported.CanaryConfigs), len(exported.Secrets), len(exported.ConfigMaps), len(exported.ArchiveUploadSpecs))
+	if len(exported.Secrets) > 0 {
+		console.Warn("The data of the exported secrets is in the *.env files of the spec directory, keep them out of version control")
+	}
+
+	if input.Bool(flagkey.SpecAdopt) {
+		err = adoptResources(input.Context(), opts.Client(), fr, exported)
+		if err != nil {
+			return fmt.Errorf("error adopting exported resources: %w", err)
+		}
+		console.Infof("Adopted the exported resources into deployment %v", fr.DeploymentConfig.UID)
+	}
+	return nil
+}
+
+// selectExportResources returns the resources of the namespace matching the
+// label selector. With names, only the functions of these names are selected,
+// along with the triggers and canary configs invoking them. The packages,
+// environments, secrets and configmaps used by the selected resources are
+// always selected.
+func selectExportResources(ctx context.Context, c cmd.Client, namespace string, labelSelector string, names []string) (*FissionResources, error) {
+	core := c.FissionClientSet.CoreV1()
+	listOpts := metav1.ListOptions{LabelSelector: labelSelector}
+	selected := &FissionResources{}
+
+	fns, err := core.Functions(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing functions: %w", err)
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(fns.Items, func(fn fv1.Function) bool { return fn.Name == name }) {
+			return nil, fmt.Errorf("function %v not found", name)
+		}
+	}
+	for _, fn := range fns.Items {
+		if len(names) == 0 || slices.Contains(names, fn.Name) {
+			selected.Functions = append(selected.Functions, fn)
+		}
+	}
+
+	invokesSelected := func(namespace string, ref fv1.FunctionReference) bool {
+		if len(names) == 0 {
+			return true
+		}
+		return slices.ContainsFunc(selected.Functions, func(fn fv1.Function) bool {
+			_, weighted := ref.FunctionWeights[fn.Name]
+			return fn.Namespace == namespace && (ref.Name == fn.Name || weighted)
+		})
+	}
+	hts, err := core.HTTPTriggers(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing HTTP triggers: %w", err)
+	}
+	for _, ht := range hts.Items {
+		if invokesSelected(ht.Namespace, ht.Spec.FunctionReference) {
+			selected.HttpTriggers = append(selected.HttpTriggers, ht)
+		}
+	}
+	mqts, err := core.MessageQueueTriggers(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing message queue triggers: %w", err)
+	}
+	for _, mqt := range mqts.Items {
+		if invokesSelected(mqt.Namespace, mqt.Spec.FunctionReference) {
+			selected.MessageQueueTriggers = append(selected.MessageQueueTriggers, mqt)
+		}
+	}
+	tts, err := core.TimeTriggers(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing time triggers: %w", err)
+	}
+	for _, tt := range tts.Items {
+		if invokesSelected(tt.Namespace, tt.Spec.FunctionReference) {
+			selected.TimeTriggers = append(selected.TimeTriggers, tt)
+		}
+	}
+	kws, err := core.KubernetesWatchTriggers(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing kube watchers: %w", err)
+	}
+	for _, kw := range kws.Items {
+		if invokesSelected(kw.Namespace, kw.Spec.FunctionReference) {
+			selected.KubernetesWatchTriggers = append(selected.KubernetesWatchTriggers, kw)
+		}
+	}
+
+	ccs, err := core.CanaryConfigs(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error listing canary configs: %w", err)
+	}
+	for _, cc := range ccs.Items {
+		if len(names) == 0 || slices.ContainsFunc(selected.Functions, func(fn fv1.Function) bool {
+			return fn.Namespace == cc.Namespace && (fn.Name == cc.Spec.NewFunction || fn.Name == cc.Spec.OldFunction)
+		}) {
+			selected.CanaryConfigs = append(selected.CanaryConfigs, cc)
+		}
+	}
+
+	if len(names) == 0 {
+		envs, err := core.Environments(namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing environments: %w", err)
+		}
+		selected.Environments = envs.Items
+		pkgs, err := core.Packages(namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing packages: %w", err)
+		}
+		selected.Packages = pkgs.Items
+	}
+
+	for _, fn := range selected.Functions {
+		pkgNamespace := defaultNamespace(fn.Spec.Package.PackageRef.Namespace, fn.Namespace)
+		selected.Packages, err = addDependency(ctx, selected.Packages, "Package", pkgNamespace,
+			fn.Spec.Package.PackageRef.Name, core.Packages(pkgNamespace).Get)
+		if err != nil {
+			return nil, err
+		}
+	}
+	kubeCore := c.KubernetesClient.CoreV1()
+	for _, fn := range selected.Functions {
+		for _, ref := range fn.Spec.Secrets {
+			secretNamespace := defaultNamespace(ref.Namespace, fn.Namespace)
+			selected.Secrets, err = addDependency(ctx, selected.Secrets, "Secret", secretNamespace,
+				ref.Name, kubeCore.Secrets(secretNamespace).Get)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, ref := range fn.Spec.ConfigMaps {
+			cmNamespace := defaultNamespace(ref.Namespace, fn.Namespace)
+			selected.ConfigMaps, err = addDependency(ctx, selected.ConfigMaps, "ConfigMap", cmNamespace,
+				ref.Name, kubeCore.ConfigMaps(cmNamespace).Get)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	envRefs := make([]metav1.ObjectMeta, 0, len(selected.Functions)+len(selected.Packages))
+	for _, fn := range selected.Functions {
+		envRefs = append(envRefs, metav1.ObjectMeta{
+			Name:      fn.Spec.Environment.Name,
+			Namespace: defaultNamespace(fn.Spec.Environment.Namespace, fn.Namespace),
+		})
+	}
+	for _, pkg := range selected.Packages {
+		envRefs = append(envRefs, metav1.ObjectMeta{
+			Name:      pkg.Spec.Environment.Name,
+			Namespace: defaultNamespace(pkg.Spec.Environment.Namespace, pkg.Namespace),
+		})
+	}
+	for _, ref := range envRefs {
+		selected.Environments, err = addDependency(ctx, selected.Environments, "Environment", ref.Namespace,
+			ref.Name, core.Environments(ref.Namespace).Get)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return selected, nil
+}
+
+func defaultNamespace(namespace string, defaultNS string) string {
+	if len(namespace) == 0 {
+		return defaultNS
+	}
+	return namespace
+}
+
+// addDependency adds the resource used by a selected resource to objs, unless
+// it's already there.
+func addDependency[T any, PT interface {
+	*T
+	metav1.Object
+}](ctx context.Context, objs []T, kind string, namespace string, name string,
+	get func(context.Context, string, metav1.GetOptions) (PT, error)) ([]T, error) {
+
+	if len(name) == 0 {
+		return objs, nil
+	}
+	for i := range objs {
+		obj := PT(&objs[i])
+		if obj.GetName() == name && obj.GetNamespace() == namespace {
+			return objs, nil
+		}
+	}
+
+	obj, err := get(ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		console.Warn(fmt.Sprintf("%v %v/%v used by the exported resources doesn't exist", kind, namespace, name))
+		return objs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting %v %v/%v: %w", kind, namespace, name, err)
+	}
+	return append(objs, *obj), nil
+}
+
+// exportResources writes the specs of the selected resources to the spec
+// directory, and returns the resources it wrote. Resources already in the
+// specs are skipped.
+func exportResources(ctx context.Context, c cmd.Client, specDir string, fr *FissionResources, selected *FissionResources) (*FissionResources, error) {
+	exported := &FissionResources{}
+	var err error
+
+	exported.Environments, err = exportKind(selected.Environments, "Environment", specDir, fr,
+		func(env *fv1.Environment) (string, error) {
+			env.ObjectMeta = exportObjectMeta(env.ObjectMeta)
+			return fmt.Sprintf("env-%v.yaml", env.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.Packages, err = exportKind(selected.Packages, "Package", specDir, fr,
+		func(pkg *fv1.Package) (string, error) {
+			pkg.ObjectMeta = exportObjectMeta(pkg.ObjectMeta)
+			specFile := fmt.Sprintf("package-%v.yaml", pkg.Name)
+
+			// the deployment archive of packages with a source archive
+			// is built again.
+			archive, suffix := &pkg.Spec.Deployment, "deploy"
+			var status fv1.BuildStatus = fv1.BuildStatusNone
+			if len(pkg.Spec.Source.Type) > 0 {
+				pkg.Spec.Deployment = fv1.Archive{}
+				archive, suffix = &pkg.Spec.Source, "source"
+				status = fv1.BuildStatusPending
+			}
+			pkg.Status = fv1.PackageStatus{
+				BuildStatus:         status,
+				LastUpdateTimestamp: metav1.Time{Time: time.Now().UTC()},
+			}
+
+			aus, err := exportArchive(ctx, c, specDir, fr, archive, pkg.Name+"-"+suffix)
+			if err != nil {
+				return "", fmt.Errorf("error exporting archive of package %v/%v: %w", pkg.Namespace, pkg.Name, err)
+			}
+			if aus != nil {
+				// the archive upload spec is in the spec file of the package
+				_, _, data, err := crdToYaml(*aus)
+				if err != nil {
+					return "", err
+				}
+				err = save(data, specDir, specFile, false)
+				if err != nil {
+					return "", err
+				}
+				exported.ArchiveUploadSpecs = append(exported.ArchiveUploadSpecs, *aus)
+			}
+			return specFile, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.Functions, err = exportKind(selected.Functions, "Function", specDir, fr,
+		func(fn *fv1.Function) (string, error) {
+			fn.ObjectMeta = exportObjectMeta(fn.ObjectMeta)
+			fn.Spec.Package.PackageRef.ResourceVersion = ""
+			return fmt.Sprintf("function-%v.yaml", fn.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.HttpTriggers, err = exportKind(selected.HttpTriggers, "HTTPTrigger", specDir, fr,
+		func(ht *fv1.HTTPTrigger) (string, error) {
+			ht.ObjectMeta = exportObjectMeta(ht.ObjectMeta)
+			return fmt.Sprintf("route-%v.yaml", ht.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.MessageQueueTriggers, err = exportKind(selected.MessageQueueTriggers, "MessageQueueTrigger", specDir, fr,
+		func(mqt *fv1.MessageQueueTrigger) (string, error) {
+			mqt.ObjectMeta = exportObjectMeta(mqt.ObjectMeta)
+			return fmt.Sprintf("mqtrigger-%v.yaml", mqt.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.TimeTriggers, err = exportKind(selected.TimeTriggers, "TimeTrigger", specDir, fr,
+		func(tt *fv1.TimeTrigger) (string, error) {
+			tt.ObjectMeta = exportObjectMeta(tt.ObjectMeta)
+			return fmt.Sprintf("timetrigger-%v.yaml", tt.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.KubernetesWatchTriggers, err = exportKind(selected.KubernetesWatchTriggers, "KubernetesWatchTrigger", specDir, fr,
+		func(kw *fv1.KubernetesWatchTrigger) (string, error) {
+			kw.ObjectMeta = exportObjectMeta(kw.ObjectMeta)
+			return fmt.Sprintf("kubewatch-%v.yaml", kw.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.CanaryConfigs, err = exportKind(selected.CanaryConfigs, "CanaryConfig", specDir, fr,
+		func(cc *fv1.CanaryConfig) (string, error) {
+			cc.ObjectMeta = exportObjectMeta(cc.ObjectMeta)
+			cc.Status = fv1.CanaryConfigStatus{}
+			return fmt.Sprintf("canary-%v.yaml", cc.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// the data of secrets is written to env files, which the .gitignore of
+	// the spec directory keeps out of version control
+	exported.Secrets, err = exportKind(selected.Secrets, "Secret", specDir, fr,
+		func(secret *apiv1.Secret) (string, error) {
+			secret.ObjectMeta = exportObjectMeta(secret.ObjectMeta)
+			envFile := fmt.Sprintf("secret-%v.env", secret.Name)
+			err := writeEnvFile(specDir, envFile, secret.Data)
+			if err != nil {
+				return "", fmt.Errorf("error writing env file of secret %v/%v: %w", secret.Namespace, secret.Name, err)
+			}
+			if secret.Annotations == nil {
+				secret.Annotations = make(map[string]string)
+			}
+			secret.Annotations[ENV_FILE_ANNOTATION] = envFile
+			secret.Data = nil
+			secret.StringData = nil
+			return fmt.Sprintf("secret-%v.yaml", secret.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	exported.ConfigMaps, err = exportKind(selected.ConfigMaps, "ConfigMap", specDir, fr,
+		func(cm *apiv1.ConfigMap) (string, error) {
+			cm.ObjectMeta = exportObjectMeta(cm.ObjectMeta)
+			return fmt.Sprintf("configmap-%v.yaml", cm.Name), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return exported, nil
+}
+
+// exportKind writes the specs of the resources of a kind not in the specs yet.
+// prepare cleans a copy of the resource up for its spec, and returns the spec
+// file to write it to.
+func exportKind[T any, PT interface {
+	*T
+	metav1.Object
+}](objs []T, kind string, specDir string, fr *FissionResources, prepare func(PT) (string, error)) ([]T, error) {
+
+	var exported []T
+	for i := range objs {
+		o := objs[i]
+		obj := PT(&o)
+		exists, err := fr.ExistsInSpecs(o)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			console.Warn(fmt.Sprintf("%v %v/%v is already in the specs, skipping it", kind, obj.GetNamespace(), obj.GetName()))
+			continue
+		}
+
+		specFile, err := prepare(obj)
+		if err != nil {
+			return nil, err
+		}
+		_, _, data, err := crdToYaml(o)
+		if err != nil {
+			return nil, err
+		}
+		err = save(data, specDir, specFile, false)
+		if err != nil {
+			return nil, err
+		}
+		exported = append(exported, objs[i])
+	}
+	return exported, nil
+}
+
+// exportObjectMeta returns the metadata of a resource to write to its spec,
+// without the fields set by the cluster and the deployment annotations and
+// labels added by spec apply.
+func exportObjectMeta(m metav1.ObjectMeta) metav1.ObjectMeta {
+	annotations := maps.Clone(m.Annotations)
+	delete(annotations, FISSION_DEPLOYMENT_NAME_KEY)
+	delete(annotations, FISSION_DEPLOYMENT_UID_KEY)
+	delete(annotations, apiv1.LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	// secrets and configmaps are labelled with the deployment UID too
+	labels := maps.Clone(m.Labels)
+	delete(labels, FISSION_DEPLOYMENT_UID_KEY)
+	if len(labels) == 0 {
+		labels = nil
+	}
+	return metav1.ObjectMeta{
+		Name:        m.Name,
+		Namespace:   m.Namespace,
+		Labels:      labels,
+		Annotations: annotations,
+	}
+}
+
+// exportArchive downloads an archive stored by URL to a local directory, and
+// points the archive to the archive upload spec of that directory, so that
+// spec apply uploads it again. Literal archives stay in the spec, and OCI
+// and Git archives keep referencing their source.
+func exportArchive(ctx context.Context, c cmd.Client, specDir string, fr *FissionResources, archive *fv1.Archive, name string) (*types.ArchiveUploadSpec, error) {
+	if archive.Type != fv1.ArchiveTypeUrl || len(archive.URL) == 0 {
+		return nil, nil
+	}
+	if slices.ContainsFunc(fr.ArchiveUploadSpecs, func(aus types.ArchiveUploadSpec) bool { return aus.Name == name }) {
+		return nil, fmt.Errorf("archive %v is already in the specs", name)
+	}
+
+	relativeDir := filepath.Join(exportArchivesDir, name)
+	dir := filepath.Join(filepath.Clean(specDir+"/.."), relativeDir)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("archive directory %v already exists", dir)
+	}
+
+	tmpFile, err := os.CreateTemp("", "fission-export-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	reader, err := pkgutil.DownloadStrorageURL(ctx, c, archive.URL)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading archive %v: %w", archive.URL, err)
+	}
+	defer reader.Close()
+	_, err = io.Copy(tmpFile, reader)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading archive %v: %w", archive.URL, err)
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	aus := &types.ArchiveUploadSpec{Name: name}
+	if isZip, _ := utils.IsZip(ctx, tmpFile.Name()); isZip {
+		err = utils.Unarchive(ctx, tmpFile.Name(), dir)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting archive %v: %w", archive.URL, err)
+		}
+		aus.IncludeGlobs = []string{filepath.ToSlash(relativeDir) + "/*"}
+	} else {
+		// archives of a single file are uploaded as is
+		_, err = tmpFile.Seek(0, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+		err = pkgutil.WriteArchiveToFile(filepath.Join(dir, name), tmpFile)
+		if err != nil {
+			return nil, err
+		}
+		aus.IncludeGlobs = []string{filepath.ToSlash(filepath.Join(relativeDir, name))}
+	}
+
+	*archive = fv1.Archive{
+		Type: fv1.ArchiveTypeUrl,
+		URL:  ARCHIVE_URL_PREFIX + name,
+	}
+	return aus, nil
+}
+
+// adoptResources annotates the exported resources with the deployment of the
+// specs, as spec apply does for the resources it creates.
+func adoptResources(ctx context.Context, c cmd.Client, fr *FissionResources, exported *FissionResources) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				FISSION_DEPLOYMENT_NAME_KEY: fr.DeploymentConfig.Name,
+				FISSION_DEPLOYMENT_UID_KEY:  fr.DeploymentConfig.UID,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	core := c.FissionClientSet.CoreV1()
+	for _, o := range exported.Environments {
+		_, err = core.Environments(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting Environment %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.Packages {
+		_, err = core.Packages(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting Package %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.Functions {
+		_, err = core.Functions(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting Function %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.HttpTriggers {
+		_, err = core.HTTPTriggers(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting HTTPTrigger %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.MessageQueueTriggers {
+		_, err = core.MessageQueueTriggers(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting MessageQueueTrigger %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.TimeTriggers {
+		_, err = core.TimeTriggers(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting TimeTrigger %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.KubernetesWatchTriggers {
+		_, err = core.KubernetesWatchTriggers(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting KubernetesWatchTrigger %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.CanaryConfigs {
+		_, err = core.CanaryConfigs(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting CanaryConfig %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+
+	// spec apply finds the secrets and configmaps of the deployment by label
+	kubePatch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{
+				FISSION_DEPLOYMENT_UID_KEY: fr.DeploymentConfig.UID,
+			},
+			"annotations": map[string]string{
+				FISSION_DEPLOYMENT_NAME_KEY: fr.DeploymentConfig.Name,
+				FISSION_DEPLOYMENT_UID_KEY:  fr.DeploymentConfig.UID,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	kubeCore := c.KubernetesClient.CoreV1()
+	for _, o := range exported.Secrets {
+		_, err = kubeCore.Secrets(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, kubePatch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting Secret %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	for _, o := range exported.ConfigMaps {
+		_, err = kubeCore.ConfigMaps(o.Namespace).Patch(ctx, o.Name, k8stypes.MergePatchType, kubePatch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("error adopting ConfigMap %v/%v: %w", o.Namespace, o.Name, err)
+		}
+	}
+	return nil
+}
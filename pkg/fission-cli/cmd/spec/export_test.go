@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/util"
+	"github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils"
+)
+
+func TestExportResources(t *testing.T) {
+	srcDir := t.TempDir()
+	writeSpecFile(t, filepath.Join(srcDir, "hello.js"), "module.exports = () => 'hello'")
+	archiveFile := filepath.Join(t.TempDir(), "hello.zip")
+	_, err := utils.MakeZipArchiveWithGlobs(t.Context(), archiveFile, filepath.Join(srcDir, "*"))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archiveFile)
+	}))
+	t.Cleanup(server.Close)
+
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       metav1.NamespaceDefault,
+			ResourceVersion: "3",
+			UID:             "cluster-uid",
+			Labels:          map[string]string{"team": "a"},
+		}
+	}
+	env := fv1.Environment{
+		ObjectMeta: meta("nodejs"),
+		Spec:       fv1.EnvironmentSpec{Version: 2, Runtime: fv1.Runtime{Image: "node"}},
+	}
+	pkg := fv1.Package{
+		ObjectMeta: meta("hello-pkg"),
+		Spec: fv1.PackageSpec{
+			Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+			Deployment:  fv1.Archive{Type: fv1.ArchiveTypeUrl, URL: server.URL + "/hello.zip"},
+		},
+		Status: fv1.PackageStatus{BuildStatus: fv1.BuildStatusSucceeded},
+	}
+	function := func(name string) fv1.Function {
+		return fv1.Function{
+			ObjectMeta: meta(name),
+			Spec: fv1.FunctionSpec{
+				Environment: fv1.EnvironmentReference{Name: "nodejs", Namespace: metav1.NamespaceDefault},
+				Package: fv1.FunctionPackageRef{
+					PackageRef: fv1.PackageRef{Name: "hello-pkg", Namespace: metav1.NamespaceDefault, ResourceVersion: "3"},
+				},
+			},
+		}
+	}
+	trigger := func(name string, fn string) fv1.HTTPTrigger {
+		return fv1.HTTPTrigger{
+			ObjectMeta: meta(name),
+			Spec: fv1.HTTPTriggerSpec{
+				RelativeURL:       "/" + fn,
+				FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: fn},
+			},
+		}
+	}
+	hello, other := function("hello"), function("other")
+	hello.Spec.Secrets = []fv1.SecretReference{{Name: "hello-secret"}}
+	hello.Spec.ConfigMaps = []fv1.ConfigMapReference{{Name: "hello-config", Namespace: metav1.NamespaceDefault}}
+	helloRoute, otherRoute := trigger("hello-route", "hello"), trigger("other-route", "other")
+	canary := fv1.CanaryConfig{
+		ObjectMeta: meta("hello-canary"),
+		Spec: fv1.CanaryConfigSpec{
+			Trigger:     "hello-route",
+			NewFunction: "hello",
+			OldFunction: "other",
+		},
+		Status: fv1.CanaryConfigStatus{Status: fv1.CanaryConfigStatusPending},
+	}
+	secret := apiv1.Secret{
+		ObjectMeta: meta("hello-secret"),
+		Data:       map[string][]byte{"password": []byte("p4ss\"word\n"), "key.bin": {0xff, 0x00}},
+	}
+	configMap := apiv1.ConfigMap{
+		ObjectMeta: meta("hello-config"),
+		Data:       map[string]string{"greeting": "hello"},
+	}
+	fissionClient := fake.NewClientset(&env, &pkg, &hello, &other, &helloRoute, &otherRoute, &canary)
+	kubeClient := kubefake.NewClientset(&secret, &configMap)
+	fclient := cmd.Client{FissionClientSet: fissionClient, KubernetesClient: kubeClient}
+
+	specDir := filepath.Join(t.TempDir(), "specs")
+	writeSpecFile(t, filepath.Join(specDir, "fission-deployment-config.yaml"), `apiVersion: fission.io/v1
+kind: DeploymentConfig
+name: app
+uid: uid
+`)
+	fr, err := ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+
+	_, err = selectExportResources(t.Context(), fclient, metav1.NamespaceDefault, "", []string{"missing"})
+	require.ErrorContains(t, err, "function missing not found")
+
+	selected, err := selectExportResources(t.Context(), fclient, metav1.NamespaceDefault, "", []string{"hello"})
+	require.NoError(t, err)
+	require.Len(t, selected.Functions, 1)
+	require.Len(t, selected.HttpTriggers, 1)
+	require.Equal(t, "hello-route", selected.HttpTriggers[0].Name)
+	require.Len(t, selected.Packages, 1)
+	require.Len(t, selected.Environments, 1)
+	require.Len(t, selected.CanaryConfigs, 1)
+	require.Len(t, selected.Secrets, 1)
+	require.Len(t, selected.ConfigMaps, 1)
+
+	exported, err := exportResources(t.Context(), fclient, specDir, fr, selected)
+	require.NoError(t, err)
+	require.Len(t, exported.ArchiveUploadSpecs, 1)
+	require.NoError(t, adoptResources(t.Context(), fclient, fr, exported))
+
+	// the exported specs are re-appliable
+	exportedFr, err := ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+	require.Len(t, exportedFr.Functions, 1)
+	fn := exportedFr.Functions[0]
+	require.Empty(t, fn.ResourceVersion)
+	require.Empty(t, fn.UID)
+	require.Equal(t, map[string]string{"team": "a"}, fn.Labels)
+	require.Empty(t, fn.Spec.Package.PackageRef.ResourceVersion)
+	require.Len(t, exportedFr.Packages, 1)
+	require.Equal(t, ARCHIVE_URL_PREFIX+"hello-pkg-deploy", exportedFr.Packages[0].Spec.Deployment.URL)
+	require.Equal(t, fv1.BuildStatusNone, string(exportedFr.Packages[0].Status.BuildStatus))
+	require.Equal(t, []string{"archives/hello-pkg-deploy/*"}, exportedFr.ArchiveUploadSpecs[0].IncludeGlobs)
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(specDir), "archives", "hello-pkg-deploy", "hello.js"))
+	require.NoError(t, err)
+	require.Equal(t, "module.exports = () => 'hello'", string(content))
+	_, err = os.Stat(filepath.Join(specDir, "route-hello-route.yaml"))
+	require.NoError(t, err)
+	require.Len(t, exportedFr.CanaryConfigs, 1)
+	require.Empty(t, exportedFr.CanaryConfigs[0].Status.Status)
+	require.Len(t, exportedFr.ConfigMaps, 1)
+	require.Equal(t, "hello", exportedFr.ConfigMaps[0].Data["greeting"])
+
+	// the data of secrets is kept out of their spec, in an ignored env file
+	require.Len(t, exportedFr.Secrets, 1)
+	require.Equal(t, secret.Data, exportedFr.Secrets[0].Data)
+	content, err = os.ReadFile(filepath.Join(specDir, "secret-hello-secret.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "password")
+	gitignore, err := os.ReadFile(filepath.Join(specDir, ".gitignore"))
+	require.NoError(t, err)
+	require.Contains(t, string(gitignore), "*.env")
+
+	adopted, err := fissionClient.CoreV1().Functions(metav1.NamespaceDefault).Get(t.Context(), "hello", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "uid", adopted.Annotations[FISSION_DEPLOYMENT_UID_KEY])
+	notAdopted, err := fissionClient.CoreV1().Functions(metav1.NamespaceDefault).Get(t.Context(), "other", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, notAdopted.Annotations)
+	adoptedSecret, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Get(t.Context(), "hello-secret", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "uid", adoptedSecret.Labels[FISSION_DEPLOYMENT_UID_KEY])
+
+	// resources already in the specs are skipped
+	selected, err = selectExportResources(t.Context(), fclient, metav1.NamespaceDefault, "team=a", nil)
+	require.NoError(t, err)
+	require.Len(t, selected.Functions, 2)
+	exported, err = exportResources(t.Context(), fclient, specDir, exportedFr, selected)
+	require.NoError(t, err)
+	require.Len(t, exported.Functions, 1)
+	require.Equal(t, "other", exported.Functions[0].Name)
+	require.Len(t, exported.HttpTriggers, 1)
+	require.Empty(t, exported.Packages)
+	require.Empty(t, exported.Environments)
+}
@@ -109,6 +109,15 @@ func (opts *InitSubCommand) run(input cli.Input) error {
 		return err
 	}
 
+	// Keep the env files holding the values of secrets out of version control
+	gitignore := filepath.Join(specDir, ".gitignore")
+	if _, err := os.Stat(gitignore); os.IsNotExist(err) {
+		err = os.WriteFile(gitignore, []byte(SPEC_GITIGNORE), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = writeDeploymentConfig(config, opts.deployConfig)
 	if err != nil {
 		return fmt.Errorf("error writing deployment config: %w", err)
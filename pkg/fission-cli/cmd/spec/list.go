@@ -25,11 +25,13 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 	"github.com/fission/fission/pkg/fission-cli/util"
 )
 
@@ -48,6 +50,11 @@ func (opts *ListSubCommand) do(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) run(input cli.Input) error {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	deployID := input.String(flagkey.SpecDeployID)
 	if len(deployID) == 0 {
 		// get specdir, specignore and read the deployID
@@ -69,11 +76,15 @@ func (opts *ListSubCommand) run(input cli.Input) error {
 		currentNS = metav1.NamespaceAll
 	}
 
-	return opts.getResource(input, currentNS, deployID)
+	return opts.getResource(input, p, currentNS, deployID)
 
 }
 
-func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deployID string) (err error) {
+func (opts *ListSubCommand) getResource(input cli.Input, p *printer.Printer, namespace string, deployID string) (err error) {
+	// the machine readable output is a list of all the resources, like
+	// kubectl prints resources of many kinds
+	list := &metav1.List{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"}}
+
 	var allfn []fv1.Function
 	printNS := namespace
 	if printNS == metav1.NamespaceAll {
@@ -85,7 +96,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Functions from %s namespaces: %w", printNS, err)
 	}
 	specfns := getAppliedFunctions(allfn, deployID)
-	ShowFunctions(specfns)
+	if p.IsTable() {
+		ShowFunctions(specfns)
+	}
+	appendToList(list, specfns)
 
 	var allenvs []fv1.Environment
 	allenvs, err = getAllEnvironments(input.Context(), opts.Client(), namespace)
@@ -93,7 +107,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Environments from  %s namespaces: %w", printNS, err)
 	}
 	specenvs := getAppliedEnvironments(allenvs, deployID)
-	ShowEnvironments(specenvs)
+	if p.IsTable() {
+		ShowEnvironments(specenvs)
+	}
+	appendToList(list, specenvs)
 
 	var pkglists []fv1.Package
 	pkglists, err = getAllPackages(input.Context(), opts.Client(), namespace)
@@ -101,7 +118,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Packages from  %s namespaces: %w", printNS, err)
 	}
 	specPkgs := getAppliedPackages(pkglists, deployID)
-	ShowPackages(specPkgs)
+	if p.IsTable() {
+		ShowPackages(specPkgs)
+	}
+	appendToList(list, specPkgs)
 
 	var canaryCfgs []fv1.CanaryConfig
 	canaryCfgs, err = getAllCanaryConfigs(input.Context(), opts.Client(), namespace)
@@ -109,7 +129,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Canary Config from  %s namespaces: %w", printNS, err)
 	}
 	specCanaryCfgs := getAppliedCanaryConfigs(canaryCfgs, deployID)
-	ShowCanaryConfigs(specCanaryCfgs)
+	if p.IsTable() {
+		ShowCanaryConfigs(specCanaryCfgs)
+	}
+	appendToList(list, specCanaryCfgs)
 
 	var hts []fv1.HTTPTrigger
 	hts, err = getAllHTTPTriggers(input.Context(), opts.Client(), namespace)
@@ -117,7 +140,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting HTTP Triggers from  %s namespaces: %w", printNS, err)
 	}
 	specHTTPTriggers := getAppliedHTTPTriggers(hts, deployID)
-	ShowHTTPTriggers(specHTTPTriggers)
+	if p.IsTable() {
+		ShowHTTPTriggers(specHTTPTriggers)
+	}
+	appendToList(list, specHTTPTriggers)
 
 	var mqts []fv1.MessageQueueTrigger
 	mqts, err = getAllMessageQueueTriggers(input.Context(), opts.Client(), input.String(flagkey.MqtMQType), namespace)
@@ -125,7 +151,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting MessageQueue Triggers from  %s namespaces: %w", printNS, err)
 	}
 	specMessageQueueTriggers := getAppliedMessageQueueTriggers(mqts, deployID)
-	ShowMQTriggers(specMessageQueueTriggers)
+	if p.IsTable() {
+		ShowMQTriggers(specMessageQueueTriggers)
+	}
+	appendToList(list, specMessageQueueTriggers)
 
 	var tts []fv1.TimeTrigger
 	tts, err = getAllTimeTriggers(input.Context(), opts.Client(), namespace)
@@ -133,7 +162,10 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Time Triggers from  %s namespaces: %w", printNS, err)
 	}
 	specTimeTriggers := getAppliedTimeTriggers(tts, deployID)
-	ShowTimeTriggers(specTimeTriggers)
+	if p.IsTable() {
+		ShowTimeTriggers(specTimeTriggers)
+	}
+	appendToList(list, specTimeTriggers)
 
 	var kws []fv1.KubernetesWatchTrigger
 	kws, err = getAllKubeWatchTriggers(input.Context(), opts.Client(), namespace)
@@ -141,9 +173,25 @@ func (opts *ListSubCommand) getResource(input cli.Input, namespace string, deplo
 		return fmt.Errorf("error getting Kube Watchers from  %s namespaces: %w", printNS, err)
 	}
 	specKubeWatchers := getSpecKubeWatchers(kws, deployID)
-	ShowAppliedKubeWatchers(specKubeWatchers)
+	if p.IsTable() {
+		ShowAppliedKubeWatchers(specKubeWatchers)
+	}
+	appendToList(list, specKubeWatchers)
 
-	return err
+	if p.IsTable() {
+		return nil
+	}
+	return p.Print(os.Stdout, list, nil)
+}
+
+// appendToList appends the resources to the list.
+func appendToList[T any, PT interface {
+	*T
+	runtime.Object
+}](list *metav1.List, resources []T) {
+	for i := range resources {
+		list.Items = append(list.Items, runtime.RawExtension{Object: PT(&resources[i])})
+	}
 }
 
 func getAppliedFunctions(fns []fv1.Function, deployID string) []fv1.Function {
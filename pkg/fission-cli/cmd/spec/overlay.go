@@ -0,0 +1,346 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	ignore "github.com/sabhiram/go-gitignore"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/utils/gitrepo"
+)
+
+const (
+	// overlaysDir is the directory of the spec directory holding the overlays.
+	overlaysDir = "overlays"
+	// overlayValuesFile is the default values file of an overlay.
+	overlayValuesFile = "values.yaml"
+)
+
+// patchStructs are the types strategic merge patches of each kind are
+// applied with.
+var patchStructs = map[string]any{
+	"Package":                fv1.Package{},
+	"Function":               fv1.Function{},
+	"Environment":            fv1.Environment{},
+	"HTTPTrigger":            fv1.HTTPTrigger{},
+	"KubernetesWatchTrigger": fv1.KubernetesWatchTrigger{},
+	"TimeTrigger":            fv1.TimeTrigger{},
+	"MessageQueueTrigger":    fv1.MessageQueueTrigger{},
+	"CanaryConfig":           fv1.CanaryConfig{},
+	"Secret":                 apiv1.Secret{},
+	"ConfigMap":              apiv1.ConfigMap{},
+	"DeploymentConfig":       types.DeploymentConfig{},
+	"ArchiveUploadSpec":      types.ArchiveUploadSpec{},
+	"TestSuite":              types.TestSuite{},
+}
+
+// specVarRegexp matches ${VAR} variables, and $${VAR} escaping them.
+var specVarRegexp = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+type (
+	// Overlay selects the overlay patching the specs and the values of the
+	// ${VAR} variables in the specs. Variables are only substituted if an
+	// overlay or a values file is selected.
+	Overlay struct {
+		// Name is the name of the overlay directory under <specdir>/overlays.
+		Name string
+
+		// ValuesFile is a YAML file mapping variable names to their values,
+		// defaulting to values.yaml in the overlay directory. Variables
+		// missing from it are looked up in the environment.
+		ValuesFile string
+	}
+
+	// specDoc is a YAML document of a spec file.
+	specDoc struct {
+		data        []byte
+		loc         Location
+		commitLabel string
+
+		// patches are the locations of the overlay patches applied to data.
+		patches []Location
+	}
+
+	// specDocKey identifies the resource of a spec document.
+	specDocKey struct {
+		Kind     string `json:"kind"`
+		Name     string `json:"name,omitempty"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+	}
+)
+
+// GetOverlay returns the overlay selected by the --overlay and --values flags.
+func GetOverlay(input cli.Input) Overlay {
+	return Overlay{
+		Name:       input.String(flagkey.SpecOverlay),
+		ValuesFile: input.String(flagkey.SpecValues),
+	}
+}
+
+func (o Overlay) enabled() bool {
+	return len(o.Name) > 0 || len(o.ValuesFile) > 0
+}
+
+// overlaysPath returns the overlays directory of the spec directory, if the
+// specs use overlays, i.e. it has an overlay subdirectory. Otherwise it is
+// an ordinary directory of specs, and an empty path is returned. Spec files
+// directly in the overlays directory belong to no overlay, they are ignored
+// with a warning.
+func overlaysPath(specDir string) string {
+	dir := filepath.Join(specDir, overlaysDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var hasOverlays bool
+	var specFiles []string
+	for _, entry := range entries {
+		switch {
+		case entry.IsDir():
+			hasOverlays = true
+		case strings.HasSuffix(entry.Name(), ".yaml") || strings.HasSuffix(entry.Name(), ".yml"):
+			specFiles = append(specFiles, entry.Name())
+		}
+	}
+	if !hasOverlays {
+		return ""
+	}
+	if len(specFiles) > 0 {
+		console.Warn(fmt.Sprintf("Ignoring %v in %v, spec files of overlays must be in the directory of their overlay", strings.Join(specFiles, ", "), dir))
+	}
+	return dir
+}
+
+// apply substitutes the variables of the base documents and the overlay
+// patches, and patches the base documents. Patches of resources missing
+// from the base documents add them, and patches with the "$patch: delete"
+// directive remove them.
+func (o Overlay) apply(specDir string, docs []*specDoc, ignoreParser ignore.IgnoreParser, gr *gitrepo.GitRepo) ([]*specDoc, error) {
+	var overlayDir string
+	valuesFile := o.ValuesFile
+	if len(o.Name) > 0 {
+		if strings.ContainsAny(o.Name, `/\`) || o.Name == "." || o.Name == ".." {
+			return nil, fmt.Errorf("invalid overlay name %q", o.Name)
+		}
+		overlayDir = filepath.Join(specDir, overlaysDir, o.Name)
+		if _, err := os.Stat(overlayDir); os.IsNotExist(err) {
+			return nil, fmt.Errorf("overlay %v doesn't exist. Please check that the directory %v exists", o.Name, overlayDir)
+		}
+		if len(valuesFile) == 0 {
+			valuesFile = filepath.Join(overlayDir, overlayValuesFile)
+			if _, err := os.Stat(valuesFile); os.IsNotExist(err) {
+				valuesFile = ""
+			}
+		}
+	}
+
+	values, err := readValues(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var patches []*specDoc
+	if len(overlayDir) > 0 {
+		var result *multierror.Error
+		patches, result = readSpecDocs(overlayDir, filepath.Join(overlayDir, overlayValuesFile), ignoreParser, gr)
+		if err = result.ErrorOrNil(); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *multierror.Error
+	for _, doc := range slices.Concat(docs, patches) {
+		doc.data, err = substituteVars(doc.data, values)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%v: %w", doc.loc, err))
+		}
+	}
+	if err = result.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	for _, patch := range patches {
+		docs, err = applyPatch(docs, patch)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("%v: %w", patch.loc, err))
+		}
+	}
+	if err = result.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// readValues reads the variable values of a values file. Values must be
+// scalars.
+func readValues(valuesFile string) (map[string]string, error) {
+	values := make(map[string]string)
+	if len(valuesFile) == 0 {
+		return values, nil
+	}
+
+	b, err := os.ReadFile(valuesFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading values file: %w", err)
+	}
+	var raw map[string]json.RawMessage
+	err = yaml.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse values file %v: %w", valuesFile, err)
+	}
+	for name, v := range raw {
+		switch {
+		case len(v) == 0 || string(v) == "null":
+			values[name] = ""
+		case v[0] == '"':
+			var s string
+			err = json.Unmarshal(v, &s)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse value of %v in %v: %w", name, valuesFile, err)
+			}
+			values[name] = s
+		case v[0] == '{' || v[0] == '[':
+			return nil, fmt.Errorf("value of %v in %v must be a scalar", name, valuesFile)
+		default:
+			// numbers and booleans keep their YAML representation
+			values[name] = string(v)
+		}
+	}
+	return values, nil
+}
+
+// substituteVars replaces the ${VAR} variables of a document with their
+// values, or the environment variables of the same name. $${VAR} is replaced
+// with ${VAR}.
+func substituteVars(data []byte, values map[string]string) ([]byte, error) {
+	var undefined []string
+	data = specVarRegexp.ReplaceAllFunc(data, func(match []byte) []byte {
+		if match[1] == '$' {
+			return match[1:]
+		}
+		name := string(specVarRegexp.FindSubmatch(match)[1])
+		if v, ok := values[name]; ok {
+			return []byte(v)
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return []byte(v)
+		}
+		if !slices.Contains(undefined, name) {
+			undefined = append(undefined, name)
+		}
+		return match
+	})
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined variables %v", strings.Join(undefined, ", "))
+	}
+	return data, nil
+}
+
+// key returns the kind, namespace and name of the resource of the document.
+func (doc *specDoc) key() (specDocKey, error) {
+	var k specDocKey
+	err := yaml.Unmarshal(doc.data, &k)
+	if err != nil {
+		return k, fmt.Errorf("failed to decode yaml: %w", err)
+	}
+	if len(k.Metadata.Name) == 0 {
+		// DeploymentConfig and ArchiveUploadSpec aren't CRDs and
+		// have no metadata.
+		k.Metadata.Name = k.Name
+	}
+	k.Name = ""
+	if k.Kind == "DeploymentConfig" {
+		// there is a single deployment config, whatever its name
+		k.Metadata.Name = ""
+	}
+	return k, nil
+}
+
+func (doc *specDoc) patchLocations() string {
+	locs := make([]string, 0, len(doc.patches))
+	for _, loc := range doc.patches {
+		locs = append(locs, loc.String())
+	}
+	return strings.Join(locs, ", ")
+}
+
+// applyPatch applies the strategic merge patch of the overlay document to
+// the document of the same resource.
+func applyPatch(docs []*specDoc, patch *specDoc) ([]*specDoc, error) {
+	key, err := patch.key()
+	if err != nil {
+		return nil, err
+	}
+	if len(key.Kind) == 0 {
+		return docs, errors.New("overlay patch has no kind")
+	}
+
+	i := slices.IndexFunc(docs, func(doc *specDoc) bool {
+		k, err := doc.key()
+		return err == nil && k == key
+	})
+	if i < 0 {
+		// new resources are located in the overlay
+		return append(docs, patch), nil
+	}
+
+	dataStruct, ok := patchStructs[key.Kind]
+	if !ok {
+		return docs, fmt.Errorf("overlay patches of %v aren't supported", key.Kind)
+	}
+	original, err := yaml.YAMLToJSON(docs[i].data)
+	if err != nil {
+		return docs, fmt.Errorf("failed to decode yaml in %v: %w", docs[i].loc, err)
+	}
+	patchJSON, err := yaml.YAMLToJSON(patch.data)
+	if err != nil {
+		return docs, fmt.Errorf("failed to decode yaml: %w", err)
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patchJSON, dataStruct)
+	if err != nil {
+		return docs, fmt.Errorf("failed to patch %v %v: %w", key.Kind, key.Metadata.Name, err)
+	}
+	if string(merged) == "{}" {
+		// the patch deletes the resource
+		return slices.Delete(docs, i, i+1), nil
+	}
+
+	// the patched resource keeps the location of the base document
+	docs[i].data = merged
+	docs[i].patches = append(docs[i].patches, patch.loc)
+	return docs, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+func writeSpecFile(t *testing.T, path string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestReadSpecsWithOverlay(t *testing.T) {
+	specDir := t.TempDir()
+	writeSpecFile(t, filepath.Join(specDir, "fission-deployment-config.yaml"), `apiVersion: fission.io/v1
+kind: DeploymentConfig
+name: app
+uid: uid
+`)
+	writeSpecFile(t, filepath.Join(specDir, "function.yaml"), `apiVersion: fission.io/v1
+kind: Environment
+metadata:
+  name: nodejs
+  namespace: ${NAMESPACE}
+spec:
+  version: 2
+  runtime:
+    image: node
+  poolsize: 3
+---
+apiVersion: fission.io/v1
+kind: Function
+metadata:
+  name: hello
+  namespace: ${NAMESPACE}
+spec:
+  environment:
+    name: nodejs
+    namespace: ${NAMESPACE}
+  functionTimeout: 60
+  secrets:
+  - name: ${SECRET}
+    namespace: ${NAMESPACE}
+  InvokeStrategy:
+    ExecutionStrategy:
+      ExecutorType: poolmgr
+      MinScale: 1
+---
+apiVersion: fission.io/v1
+kind: HTTPTrigger
+metadata:
+  name: debug
+  namespace: ${NAMESPACE}
+spec:
+  functionref:
+    type: name
+    name: hello
+  relativeurl: /debug/$${PATH}
+`)
+	writeSpecFile(t, filepath.Join(specDir, overlaysDir, "prod", overlayValuesFile), `NAMESPACE: prod
+SECRET: prod-secret
+`)
+	writeSpecFile(t, filepath.Join(specDir, overlaysDir, "prod", "patches.yaml"), `kind: Function
+metadata:
+  name: hello
+  namespace: prod
+spec:
+  InvokeStrategy:
+    ExecutionStrategy:
+      MinScale: 3
+---
+kind: HTTPTrigger
+metadata:
+  name: debug
+  namespace: prod
+$patch: delete
+---
+apiVersion: fission.io/v1
+kind: TimeTrigger
+metadata:
+  name: nightly
+  namespace: prod
+spec:
+  cron: "@daily"
+  functionref:
+    type: name
+    name: hello
+`)
+
+	// without overlay, the overlays aren't read and variables aren't substituted
+	fr, err := ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+	require.Len(t, fr.Functions, 1)
+	require.Equal(t, "${NAMESPACE}", fr.Functions[0].Namespace)
+	require.Equal(t, 1, fr.Functions[0].Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+	require.Empty(t, fr.TimeTriggers)
+
+	fr, err = ReadSpecsWithOverlay(specDir, util.SPEC_IGNORE_FILE, false, Overlay{Name: "prod"})
+	require.NoError(t, err)
+	require.Len(t, fr.Functions, 1)
+	fn := fr.Functions[0]
+	require.Equal(t, "prod", fn.Namespace)
+	require.Equal(t, "prod-secret", fn.Spec.Secrets[0].Name)
+	require.Equal(t, "prod", fn.Spec.Environment.Namespace)
+	require.Equal(t, 3, fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
+	require.Equal(t, 60, fn.Spec.FunctionTimeout)
+	require.Len(t, fr.Environments, 1)
+	require.Equal(t, 3, fr.Environments[0].Spec.Poolsize)
+	require.Empty(t, fr.HttpTriggers)
+	require.Len(t, fr.TimeTriggers, 1)
+	require.Equal(t, "uid", fr.DeploymentConfig.UID)
+
+	// patched resources keep their base location
+	require.Equal(t, Location{Path: filepath.Join(specDir, "function.yaml"), Line: 11},
+		fr.SourceMap.Locations["Function"]["prod"]["hello"])
+	require.Equal(t, Location{Path: filepath.Join(specDir, overlaysDir, "prod", "patches.yaml"), Line: 15},
+		fr.SourceMap.Locations["TimeTrigger"]["prod"]["nightly"])
+
+	// values files take precedence over environment variables
+	t.Setenv("NAMESPACE", "staging")
+	t.Setenv("SECRET", "staging-secret")
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	writeSpecFile(t, valuesFile, "SECRET: other-secret\n")
+	fr, err = ReadSpecsWithOverlay(specDir, util.SPEC_IGNORE_FILE, false, Overlay{ValuesFile: valuesFile})
+	require.NoError(t, err)
+	require.Equal(t, "staging", fr.Functions[0].Namespace)
+	require.Equal(t, "other-secret", fr.Functions[0].Spec.Secrets[0].Name)
+	require.Equal(t, "/debug/${PATH}", fr.HttpTriggers[0].Spec.RelativeURL)
+
+	_, err = ReadSpecsWithOverlay(specDir, util.SPEC_IGNORE_FILE, false, Overlay{Name: "dev"})
+	require.ErrorContains(t, err, "overlay dev doesn't exist")
+}
+
+func TestOverlaysPath(t *testing.T) {
+	specDir := t.TempDir()
+	require.Empty(t, overlaysPath(specDir))
+
+	// without overlay subdirectories, overlays is a directory of specs
+	writeSpecFile(t, filepath.Join(specDir, overlaysDir, "function.yaml"), "kind: Function\n")
+	require.Empty(t, overlaysPath(specDir))
+
+	writeSpecFile(t, filepath.Join(specDir, overlaysDir, "prod", "patches.yaml"), "kind: Function\n")
+	require.Equal(t, filepath.Join(specDir, overlaysDir), overlaysPath(specDir))
+}
+
+func TestSubstituteVars(t *testing.T) {
+	t.Setenv("FROM_ENV", "env")
+	data, err := substituteVars([]byte("a: ${A}\nb: ${FROM_ENV}\nc: $${A}\nd: $HOME"), map[string]string{"A": "1"})
+	require.NoError(t, err)
+	require.Equal(t, "a: 1\nb: env\nc: ${A}\nd: $HOME", string(data))
+
+	_, err = substituteVars([]byte("a: ${MISSING}\nb: ${MISSING}"), nil)
+	require.EqualError(t, err, "undefined variables MISSING")
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	multierror "github.com/hashicorp/go-multierror"
+	apiv1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sCache "k8s.io/client-go/tools/cache"
@@ -43,6 +44,10 @@ const (
 	FISSION_DEPLOYMENT_NAME_KEY = "fission-name"
 	FISSION_DEPLOYMENT_UID_KEY  = "fission-uid"
 
+	// ENV_FILE_ANNOTATION names the env file holding the data of a Secret or
+	// ConfigMap spec, relative to the spec file.
+	ENV_FILE_ANNOTATION = "fission.io/env-file"
+
 	SPEC_API_VERSION          = "fission.io/v1"
 	ARCHIVE_URL_PREFIX string = "archive://"
 	SPEC_README               = `
@@ -87,6 +92,39 @@ All resources created by 'fission spec apply' are annotated with this UID.  Reso
 the cluster that are _not_ annotated with this UID are never modified or deleted by
 fission.
 
+Overlays
+--------
+
+Overlays adapt the specs to an environment, such as dev, staging or prod.  An overlay is
+a directory under 'overlays' holding strategic merge patches of the resources of this
+directory, matched by kind, namespace and name.  'fission spec apply --overlay prod'
+applies the specs patched by 'overlays/prod'.  Patches of resources that don't exist
+add them, and patches with '$patch: delete' remove them.
+
+With an overlay or the --values flag, ${VAR} variables in the specs are replaced with the
+values in the file given by --values, defaulting to 'values.yaml' of the overlay, or else
+the environment variables of the same name.  Use $${VAR} for a literal ${VAR}.
+
+Secrets and ConfigMaps
+----------------------
+
+Secret and ConfigMap specs are applied along with the other resources.  Keep sensitive
+values out of them: the 'fission.io/env-file' annotation names a file of KEY=VALUE lines,
+relative to the spec file, whose values are added to the data of the Secret or ConfigMap.
+The .gitignore of this directory keeps '*.env' files out of version control.
+
+Test suites
+-----------
+
+TestSuite specs list requests to the functions and the responses they must get: their
+status, headers, and body, matched exactly, by regular expression, or by JSON path.
+'fission spec test' runs them against the router, or the one of 'fission dev' with
+--router, and prints the results as text, JUnit or TAP.  'fission spec apply --test'
+runs them after applying the specs, and fails the apply if a test fails.
+
+`
+	SPEC_GITIGNORE = `# env files hold the values of secrets and must not be committed
+*.env
 `
 )
 
@@ -101,7 +139,11 @@ type (
 		KubernetesWatchTriggers []fv1.KubernetesWatchTrigger
 		TimeTriggers            []fv1.TimeTrigger
 		MessageQueueTriggers    []fv1.MessageQueueTrigger
+		CanaryConfigs           []fv1.CanaryConfig
+		Secrets                 []apiv1.Secret
+		ConfigMaps              []apiv1.ConfigMap
 		ArchiveUploadSpecs      []types.ArchiveUploadSpec
+		TestSuites              []types.TestSuite
 
 		SourceMap SourceMap
 	}
@@ -272,6 +314,24 @@ func crdToYaml(resource interface{}) (metav1.ObjectMeta, string, []byte, error)
 		meta = typedres.ObjectMeta
 		kind = typedres.Kind
 		data, err = yaml.Marshal(typedres)
+	case fv1.CanaryConfig:
+		typedres.APIVersion = fv1.CRD_VERSION
+		typedres.Kind = "CanaryConfig"
+		meta = typedres.ObjectMeta
+		kind = typedres.Kind
+		data, err = yaml.Marshal(typedres)
+	case apiv1.Secret:
+		typedres.APIVersion = "v1"
+		typedres.Kind = "Secret"
+		meta = typedres.ObjectMeta
+		kind = typedres.Kind
+		data, err = yaml.Marshal(typedres)
+	case apiv1.ConfigMap:
+		typedres.APIVersion = "v1"
+		typedres.Kind = "ConfigMap"
+		meta = typedres.ObjectMeta
+		kind = typedres.Kind
+		data, err = yaml.Marshal(typedres)
 	default:
 		err = fmt.Errorf("unknown object type '%v'", typedres)
 	}
@@ -317,6 +377,7 @@ func (fr *FissionResources) Validate(input cli.Input, client cmd.Client) ([]stri
 	//   functions -> environments + shared environments between functions [TODO]
 	//   functions -> secrets + configmaps (same ns) [TODO]
 	//   triggers -> functions
+	//   canary configs -> http triggers + functions
 
 	// index archives
 	archives := make(map[string]bool)
@@ -408,6 +469,9 @@ func (fr *FissionResources) Validate(input cli.Input, client cmd.Client) ([]stri
 		}
 
 		for _, cm := range f.Spec.ConfigMaps {
+			if _, ok := fr.SourceMap.Locations["ConfigMap"][cm.Namespace][cm.Name]; ok {
+				continue
+			}
 
 			err := util.ConfigMapExists(input.Context(), &metav1.ObjectMeta{Namespace: cm.Namespace, Name: cm.Name}, client.KubernetesClient)
 			if k8serrors.IsNotFound(err) {
@@ -416,6 +480,9 @@ func (fr *FissionResources) Validate(input cli.Input, client cmd.Client) ([]stri
 		}
 
 		for _, s := range f.Spec.Secrets {
+			if _, ok := fr.SourceMap.Locations["Secret"][s.Namespace][s.Name]; ok {
+				continue
+			}
 			err := util.SecretExists(input.Context(), &metav1.ObjectMeta{Namespace: s.Namespace, Name: s.Name}, client.KubernetesClient)
 
 			if k8serrors.IsNotFound(err) {
@@ -476,6 +543,44 @@ func (fr *FissionResources) Validate(input cli.Input, client cmd.Client) ([]stri
 		result = multierror.Append(result, t.Validate())
 	}
 
+	// check trigger and function refs from canary configs
+	httpTriggers := make(map[string]bool)
+	for _, t := range fr.HttpTriggers {
+		httpTriggers[k8sCache.MetaObjectToName(&t.ObjectMeta).String()] = true
+	}
+	for _, c := range fr.CanaryConfigs {
+		loc := fr.SourceMap.Locations["CanaryConfig"][c.ObjectMeta.Namespace][c.ObjectMeta.Name]
+		if !httpTriggers[k8sCache.NewObjectName(c.ObjectMeta.Namespace, c.Spec.Trigger).String()] {
+			result = multierror.Append(result, fmt.Errorf(
+				"%v: canary config '%v' references unknown HTTP trigger %v/%v",
+				loc, c.ObjectMeta.Name, c.ObjectMeta.Namespace, c.Spec.Trigger))
+		}
+		for _, fn := range []string{c.Spec.NewFunction, c.Spec.OldFunction} {
+			if _, ok := functions[k8sCache.NewObjectName(c.ObjectMeta.Namespace, fn).String()]; !ok {
+				result = multierror.Append(result, fmt.Errorf(
+					"%v: canary config '%v' references unknown function %v/%v",
+					loc, c.ObjectMeta.Name, c.ObjectMeta.Namespace, fn))
+			}
+		}
+	}
+
+	// check test cases and their function refs
+	for _, ts := range fr.TestSuites {
+		loc := fr.SourceMap.Locations["TestSuite"][""][ts.Name]
+		for _, tc := range ts.Cases {
+			err := validateTestCase(&tc)
+			if err == nil && tc.Function != "" {
+				if _, ok := functions[k8sCache.NewObjectName(ts.Namespace, tc.Function).String()]; !ok {
+					err = fmt.Errorf("references unknown function %v", tc.Function)
+				}
+			}
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf(
+					"%v: test case '%v' of test suite '%v' %w", loc, tc.Name, ts.Name, err))
+			}
+		}
+	}
+
 	// we do not error on unreferenced functions (you can call a function through workflows,
 	// `fission function test`, etc.)
 
@@ -622,6 +727,44 @@ func (fr *FissionResources) ParseYaml(b []byte, loc *Location, commitLabelVal st
 		m = &v.ObjectMeta
 		applyCommitLabel(commitLabelVal, m)
 		fr.MessageQueueTriggers = append(fr.MessageQueueTriggers, v)
+	case "CanaryConfig":
+		var v fv1.CanaryConfig
+		err = yaml.Unmarshal(b, &v)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v in %v: %w", tm.Kind, loc, err)
+		}
+		m = &v.ObjectMeta
+		applyCommitLabel(commitLabelVal, m)
+		fr.CanaryConfigs = append(fr.CanaryConfigs, v)
+
+	// The following are Kubernetes resources used by functions
+
+	case "Secret":
+		var v apiv1.Secret
+		err = yaml.Unmarshal(b, &v)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v in %v: %w", tm.Kind, loc, err)
+		}
+		m = &v.ObjectMeta
+		err = loadSecretEnvFile(&v, loc)
+		if err != nil {
+			return err
+		}
+		applyCommitLabel(commitLabelVal, m)
+		fr.Secrets = append(fr.Secrets, v)
+	case "ConfigMap":
+		var v apiv1.ConfigMap
+		err = yaml.Unmarshal(b, &v)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v in %v: %w", tm.Kind, loc, err)
+		}
+		m = &v.ObjectMeta
+		err = loadConfigMapEnvFile(&v, loc)
+		if err != nil {
+			return err
+		}
+		applyCommitLabel(commitLabelVal, m)
+		fr.ConfigMaps = append(fr.ConfigMaps, v)
 
 	// The following are not CRDs
 
@@ -646,6 +789,18 @@ func (fr *FissionResources) ParseYaml(b []byte, loc *Location, commitLabelVal st
 		}
 		applyCommitLabel(commitLabelVal, m)
 		fr.ArchiveUploadSpecs = append(fr.ArchiveUploadSpecs, v)
+	case "TestSuite":
+		var v types.TestSuite
+		err = yaml.Unmarshal(b, &v)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v in %v: %w", tm.Kind, loc, err)
+		}
+
+		m = &metav1.ObjectMeta{
+			Name:      v.Name,
+			Namespace: "",
+		}
+		fr.TestSuites = append(fr.TestSuites, v)
 	default:
 		// no need to error out just because there's some extra files around;
 		// also good for compatibility.
@@ -758,6 +913,27 @@ func (fr *FissionResources) ExistsInSpecs(resource interface{}) (bool, error) {
 				return true, nil
 			}
 		}
+	case fv1.CanaryConfig:
+		for _, obj := range fr.CanaryConfigs {
+			if obj.ObjectMeta.Name == typedres.ObjectMeta.Name &&
+				obj.ObjectMeta.Namespace == typedres.ObjectMeta.Namespace {
+				return true, nil
+			}
+		}
+	case apiv1.Secret:
+		for _, obj := range fr.Secrets {
+			if obj.ObjectMeta.Name == typedres.ObjectMeta.Name &&
+				obj.ObjectMeta.Namespace == typedres.ObjectMeta.Namespace {
+				return true, nil
+			}
+		}
+	case apiv1.ConfigMap:
+		for _, obj := range fr.ConfigMaps {
+			if obj.ObjectMeta.Name == typedres.ObjectMeta.Name &&
+				obj.ObjectMeta.Namespace == typedres.ObjectMeta.Namespace {
+				return true, nil
+			}
+		}
 	default:
 		return false, fmt.Errorf("unknown resource type %#v", typedres)
 	}
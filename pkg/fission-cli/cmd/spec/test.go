@@ -0,0 +1,418 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const (
+	TestOutputText  = "text"
+	TestOutputJUnit = "junit"
+	TestOutputTAP   = "tap"
+
+	// defaultTestTimeout is the timeout of test cases that don't set one
+	defaultTestTimeout = 60 * time.Second
+)
+
+type (
+	TestSubCommand struct {
+		cmd.CommandActioner
+	}
+
+	// testResult is the outcome of a test case.
+	testResult struct {
+		suite    string
+		name     string
+		duration time.Duration
+		// failure is why the test case failed, empty if it passed.
+		failure string
+	}
+)
+
+// Test runs the test suites of the specs against the router.
+func Test(input cli.Input) error {
+	return (&TestSubCommand{}).do(input)
+}
+
+func (opts *TestSubCommand) do(input cli.Input) error {
+	return opts.run(input)
+}
+
+func (opts *TestSubCommand) run(input cli.Input) error {
+	fr, err := ReadSpecsWithOverlay(util.GetSpecDir(input), util.GetSpecIgnore(input), false, GetOverlay(input))
+	if err != nil {
+		return fmt.Errorf("error reading specs: %w", err)
+	}
+	_, currentNS, err := opts.GetResourceNamespace(input, flagkey.NamespaceFunction)
+	if err != nil {
+		return err
+	}
+	fr.SetNamespace(currentNS, input.Bool(flagkey.ForceNamespace))
+
+	routerURL, err := testRouterURL(input, opts.Client())
+	if err != nil {
+		return err
+	}
+	return fr.RunTests(input.Context(), routerURL, os.Stdout, input.String(flagkey.SpecTestOutput))
+}
+
+// testRouterURL returns the URL of the router given with --router, or of
+// the router of the cluster.
+func testRouterURL(input cli.Input, client cmd.Client) (*url.URL, error) {
+	if input.IsSet(flagkey.SpecTestRouter) {
+		return url.Parse(input.String(flagkey.SpecTestRouter))
+	}
+	routerURL, err := util.GetRouterURL(input.Context(), client)
+	if err != nil {
+		return nil, fmt.Errorf("error getting router URL: %w", err)
+	}
+	return routerURL, nil
+}
+
+// RunTests runs the test cases of the test suites of the specs against the
+// router at routerURL, and writes their results to w in the output format.
+// It returns an error if a test case fails.
+func (fr *FissionResources) RunTests(ctx context.Context, routerURL *url.URL, w io.Writer, output string) error {
+	var write func(io.Writer, []testResult) error
+	switch output {
+	case "", TestOutputText:
+		write = writeTestText
+	case TestOutputJUnit:
+		write = writeTestJUnit
+	case TestOutputTAP:
+		write = writeTestTAP
+	default:
+		return fmt.Errorf("unknown test output '%v', expected %v, %v or %v", output, TestOutputText, TestOutputJUnit, TestOutputTAP)
+	}
+
+	var results []testResult
+	failed := 0
+	for _, ts := range fr.TestSuites {
+		for _, tc := range ts.Cases {
+			result := runTestCase(ctx, routerURL, &ts, &tc)
+			if result.failure != "" {
+				failed++
+			}
+			results = append(results, result)
+		}
+	}
+
+	err := write(w, results)
+	if err != nil {
+		return fmt.Errorf("error writing test results: %w", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v of %v test cases failed", failed, len(results))
+	}
+	return nil
+}
+
+// validateTestCase checks a test case can be run.
+func validateTestCase(tc *types.TestCase) error {
+	if tc.Name == "" {
+		return errors.New("has no name")
+	}
+	if tc.Function == "" && tc.Path == "" {
+		return errors.New("has no function or path to call")
+	}
+	matchers := make([]types.Matcher, 0, len(tc.Expect.Headers)+len(tc.Expect.Body))
+	for _, m := range tc.Expect.Headers {
+		if m.JSONPath != "" {
+			return errors.New("has a header matcher with a JSON path")
+		}
+		matchers = append(matchers, m)
+	}
+	matchers = append(matchers, tc.Expect.Body...)
+	for _, m := range matchers {
+		if m.Regex != "" {
+			_, err := regexp.Compile(m.Regex)
+			if err != nil {
+				return fmt.Errorf("has an invalid regex: %w", err)
+			}
+		}
+		if m.JSONPath != "" {
+			err := jsonpath.New("").Parse(m.JSONPath)
+			if err != nil {
+				return fmt.Errorf("has an invalid JSON path: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func runTestCase(ctx context.Context, routerURL *url.URL, ts *types.TestSuite, tc *types.TestCase) testResult {
+	start := time.Now()
+	err := doTestCase(ctx, routerURL, ts, tc)
+	result := testResult{
+		suite:    ts.Name,
+		name:     tc.Name,
+		duration: time.Since(start),
+	}
+	if err != nil {
+		result.failure = err.Error()
+	}
+	return result
+}
+
+func doTestCase(ctx context.Context, routerURL *url.URL, ts *types.TestSuite, tc *types.TestCase) error {
+	timeout := defaultTestTimeout
+	if tc.Timeout != nil {
+		timeout = tc.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	path := tc.Path
+	if tc.Function != "" {
+		path = util.UrlForFunction(tc.Function, ts.Namespace)
+		if tc.Path != "" {
+			path += "/" + strings.TrimPrefix(tc.Path, "/")
+		}
+	}
+	u := routerURL.JoinPath(path)
+	if len(tc.Query) > 0 {
+		query := url.Values{}
+		for k, v := range tc.Query {
+			query.Set(k, v)
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	method := tc.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), u.String(), strings.NewReader(tc.Body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	accessToken, ok := os.LookupEnv(util.FISSION_AUTH_TOKEN)
+	if ok && len(accessToken) != 0 {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+	}
+	for k, v := range tc.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling %v: %w", u.Path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	status := tc.Expect.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if resp.StatusCode != status {
+		return fmt.Errorf("expected status %v, got %v: %v", status, resp.StatusCode, truncate(string(body)))
+	}
+	for name, m := range tc.Expect.Headers {
+		values, ok := resp.Header[http.CanonicalHeaderKey(name)]
+		if !ok {
+			return fmt.Errorf("expected header %v", name)
+		}
+		err = matchValue(&m, strings.Join(values, ", "))
+		if err != nil {
+			return fmt.Errorf("header %v: %w", name, err)
+		}
+	}
+	for _, m := range tc.Expect.Body {
+		err = matchBody(&m, body)
+		if err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+	}
+	return nil
+}
+
+// matchBody matches the body, or the value of the JSON path of the matcher
+// in it.
+func matchBody(m *types.Matcher, body []byte) error {
+	if m.JSONPath == "" {
+		return matchValue(m, string(body))
+	}
+	var data any
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return fmt.Errorf("expected a JSON body for %v: %w", m.JSONPath, err)
+	}
+	jp := jsonpath.New("")
+	err = jp.Parse(m.JSONPath)
+	if err != nil {
+		return fmt.Errorf("invalid JSON path %v: %w", m.JSONPath, err)
+	}
+	var value strings.Builder
+	err = jp.Execute(&value, data)
+	if err != nil {
+		return fmt.Errorf("%v: %w", m.JSONPath, err)
+	}
+	err = matchValue(m, value.String())
+	if err != nil {
+		return fmt.Errorf("%v: %w", m.JSONPath, err)
+	}
+	return nil
+}
+
+func matchValue(m *types.Matcher, value string) error {
+	if m.Equals != nil && value != *m.Equals {
+		return fmt.Errorf("expected %q, got %q", *m.Equals, truncate(value))
+	}
+	if m.Regex != "" {
+		matched, err := regexp.MatchString(m.Regex, value)
+		if err != nil {
+			return fmt.Errorf("invalid regex %v: %w", m.Regex, err)
+		}
+		if !matched {
+			return fmt.Errorf("%q doesn't match %v", truncate(value), m.Regex)
+		}
+	}
+	return nil
+}
+
+// truncate shortens values quoted in failures.
+func truncate(s string) string {
+	const maxLen = 200
+	if len(s) > maxLen {
+		return s[:maxLen] + "..."
+	}
+	return s
+}
+
+func writeTestText(w io.Writer, results []testResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.failure != "" {
+			failed++
+			fmt.Fprintf(w, "FAIL %v/%v (%v): %v\n", r.suite, r.name, r.duration.Round(time.Millisecond), r.failure)
+		} else {
+			fmt.Fprintf(w, "PASS %v/%v (%v)\n", r.suite, r.name, r.duration.Round(time.Millisecond))
+		}
+	}
+	_, err := fmt.Fprintf(w, "%v passed, %v failed\n", len(results)-failed, failed)
+	return err
+}
+
+func writeTestTAP(w io.Writer, results []testResult) error {
+	fmt.Fprintf(w, "TAP version 13\n1..%v\n", len(results))
+	for i, r := range results {
+		if r.failure == "" {
+			fmt.Fprintf(w, "ok %v - %v/%v\n", i+1, r.suite, r.name)
+			continue
+		}
+		fmt.Fprintf(w, "not ok %v - %v/%v\n", i+1, r.suite, r.name)
+		fmt.Fprintf(w, "  ---\n  message: %q\n  ...\n", r.failure)
+	}
+	return nil
+}
+
+type (
+	junitTestSuites struct {
+		XMLName xml.Name         `xml:"testsuites"`
+		Suites  []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name     string          `xml:"name,attr"`
+		Tests    int             `xml:"tests,attr"`
+		Failures int             `xml:"failures,attr"`
+		Time     string          `xml:"time,attr"`
+		Cases    []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+	}
+)
+
+func writeTestJUnit(w io.Writer, results []testResult) error {
+	var doc junitTestSuites
+	suites := make(map[string]int)
+	durations := make(map[string]time.Duration)
+	for _, r := range results {
+		i, ok := suites[r.suite]
+		if !ok {
+			i = len(doc.Suites)
+			suites[r.suite] = i
+			doc.Suites = append(doc.Suites, junitTestSuite{Name: r.suite})
+		}
+		suite := &doc.Suites[i]
+		tc := junitTestCase{
+			Name:      r.name,
+			ClassName: r.suite,
+			Time:      fmt.Sprintf("%.3f", r.duration.Seconds()),
+		}
+		if r.failure != "" {
+			tc.Failure = &junitFailure{Message: r.failure}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+		durations[r.suite] += r.duration
+	}
+	for i := range doc.Suites {
+		doc.Suites[i].Time = fmt.Sprintf("%.3f", durations[doc.Suites[i].Name].Seconds())
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(doc)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+const testSuiteSpec = `apiVersion: fission.io/v1
+kind: TestSuite
+name: hello
+cases:
+- name: greets
+  function: hello
+  query:
+    name: world
+  expect:
+    headers:
+      Content-Type:
+        regex: ^application/json
+    body:
+    - jsonpath: "{.greeting}"
+      equals: hello world
+- name: posts
+  path: /hello
+  method: POST
+  body: ping
+  expect:
+    status: 201
+    body:
+    - equals: pong
+- name: fails
+  path: /missing
+`
+
+func TestRunTests(t *testing.T) {
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/fission-function/hello":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"greeting": "hello %v"}`, r.URL.Query().Get("name"))
+		case r.URL.Path == "/hello" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, "pong")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer router.Close()
+	routerURL, err := url.Parse(router.URL)
+	require.NoError(t, err)
+
+	specDir := t.TempDir()
+	writeSpecFile(t, filepath.Join(specDir, "test.yaml"), testSuiteSpec)
+	fr, err := ReadSpecs(specDir, util.SPEC_IGNORE_FILE, false)
+	require.NoError(t, err)
+	require.Len(t, fr.TestSuites, 1)
+	require.Len(t, fr.TestSuites[0].Cases, 3)
+	fr.SetNamespace(metav1.NamespaceDefault, false)
+	for _, tc := range fr.TestSuites[0].Cases {
+		require.NoError(t, validateTestCase(&tc))
+	}
+
+	var out bytes.Buffer
+	err = fr.RunTests(t.Context(), routerURL, &out, TestOutputText)
+	require.EqualError(t, err, "1 of 3 test cases failed")
+	require.Contains(t, out.String(), "PASS hello/greets")
+	require.Contains(t, out.String(), "PASS hello/posts")
+	require.Contains(t, out.String(), "FAIL hello/fails")
+	require.Contains(t, out.String(), "expected status 200, got 404")
+
+	out.Reset()
+	err = fr.RunTests(t.Context(), routerURL, &out, TestOutputTAP)
+	require.Error(t, err)
+	require.Contains(t, out.String(), "1..3\nok 1 - hello/greets\nok 2 - hello/posts\nnot ok 3 - hello/fails\n")
+
+	out.Reset()
+	err = fr.RunTests(t.Context(), routerURL, &out, TestOutputJUnit)
+	require.Error(t, err)
+	require.Contains(t, out.String(), `<testsuite name="hello" tests="3" failures="1"`)
+
+	// body matchers that don't match fail the test case
+	fr.TestSuites[0].Cases = fr.TestSuites[0].Cases[:1]
+	fr.TestSuites[0].Cases[0].Query["name"] = "there"
+	out.Reset()
+	err = fr.RunTests(t.Context(), routerURL, &out, TestOutputText)
+	require.Error(t, err)
+	require.Contains(t, out.String(), `{.greeting}: expected "hello world", got "hello there"`)
+}
+
+func TestValidateTestCase(t *testing.T) {
+	for name, tc := range map[string]types.TestCase{
+		"has no function or path to call": {Name: "t"},
+		"has an invalid regex":            {Name: "t", Path: "/", Expect: types.TestExpectation{Body: []types.Matcher{{Regex: "("}}}},
+		"has an invalid JSON path":        {Name: "t", Path: "/", Expect: types.TestExpectation{Body: []types.Matcher{{JSONPath: "{.a"}}}},
+	} {
+		err := validateTestCase(&tc)
+		require.ErrorContains(t, err, name)
+	}
+}
@@ -16,6 +16,10 @@ limitations under the License.
 
 package types
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // CLI spec types
 type (
 	// DeploymentConfig is the global configuration for a set of Fission specs.
@@ -62,6 +66,86 @@ type (
 		ExcludeGlobs []string `json:"exclude,omitempty"`
 	}
 
+	// TestSuite is a set of requests to the functions of the specs, with
+	// the responses they're expected to get. 'fission spec test' runs them.
+	TestSuite struct {
+		// TypeMeta describes the type of this object. It is inlined. The Kind
+		// field should always be "TestSuite".
+		TypeMeta `json:",inline"`
+
+		// Name identifies the test suite in the test results.
+		Name string `json:"name"`
+
+		// Namespace of the functions the cases call. It defaults to the
+		// namespace the specs are applied to.
+		Namespace string `json:"namespace,omitempty"`
+
+		// Cases are the requests of the test suite.
+		Cases []TestCase `json:"cases"`
+	}
+
+	// TestCase is a request to a function and its expected response.
+	TestCase struct {
+		// Name identifies the test case in the test results.
+		Name string `json:"name"`
+
+		// Function is the name of the function to call. The request goes
+		// to the internal route of the function unless it's empty, in
+		// which case Path is a route of the router, such as the URL of an
+		// HTTP trigger.
+		Function string `json:"function,omitempty"`
+
+		// Path is appended to the route of the function, or is the route of
+		// the router to call if Function is empty.
+		Path string `json:"path,omitempty"`
+
+		// Method of the request, GET by default.
+		Method string `json:"method,omitempty"`
+
+		// Headers of the request. A Host header sets the host the request
+		// is made for, for HTTP triggers with a host.
+		Headers map[string]string `json:"headers,omitempty"`
+
+		// Query parameters of the request.
+		Query map[string]string `json:"query,omitempty"`
+
+		// Body of the request.
+		Body string `json:"body,omitempty"`
+
+		// Timeout of the request, 60s by default.
+		Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+		// Expect is the response the request must get.
+		Expect TestExpectation `json:"expect,omitempty"`
+	}
+
+	// TestExpectation is the expected response of a test case.
+	TestExpectation struct {
+		// Status code of the response, 200 by default.
+		Status int `json:"status,omitempty"`
+
+		// Headers the response must have, matching the matchers.
+		Headers map[string]Matcher `json:"headers,omitempty"`
+
+		// Body matchers, which all must match the body of the response.
+		Body []Matcher `json:"body,omitempty"`
+	}
+
+	// Matcher matches a value of a response. A matcher without Equals or
+	// Regex only checks the value exists.
+	Matcher struct {
+		// Equals is the exact value expected.
+		Equals *string `json:"equals,omitempty"`
+
+		// Regex is a regular expression the value must match.
+		Regex string `json:"regex,omitempty"`
+
+		// JSONPath selects the value to match in a JSON body, with the
+		// kubectl syntax, for example '{.items[0].name}'. Only used for
+		// body matchers.
+		JSONPath string `json:"jsonpath,omitempty"`
+	}
+
 	// TypeMeta is the same as Kubernetes' TypeMeta, and allows us to version and
 	// unmarshal local-only objects (like ArchiveUploadSpec) the same way that
 	// Kubernetes does.
@@ -25,12 +25,15 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	ignore "github.com/sabhiram/go-gitignore"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/cmd/spec/types"
 	"github.com/fission/fission/pkg/fission-cli/console"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/fission-cli/util"
@@ -68,15 +71,16 @@ func (opts *ValidateSubCommand) run(input cli.Input, fr *FissionResources) (err
 
 	// If the call for validate is from apply spec we already have a parsed fission resource
 	if fr == nil {
-		fr, err = ReadSpecs(specDir, specIgnore, false)
+		fr, err = ReadSpecsWithOverlay(specDir, specIgnore, false, GetOverlay(input))
 		if err != nil {
 			return fmt.Errorf("error reading specs: %w", err)
 		}
 	}
 
 	console.Infof("DeployUID: %v", fr.DeploymentConfig.UID)
-	console.Infof("Resources:\n * %v Functions\n * %v Environments\n * %v Packages \n * %v Http Triggers \n * %v MessageQueue Triggers\n * %v Time Triggers\n * %v Kube Watchers\n * %v ArchiveUploadSpec\n",
-		len(fr.Functions), len(fr.Environments), len(fr.Packages), len(fr.HttpTriggers), len(fr.MessageQueueTriggers), len(fr.TimeTriggers), len(fr.KubernetesWatchTriggers), len(fr.ArchiveUploadSpecs))
+	console.Infof("Resources:\n * %v Functions\n * %v Environments\n * %v Packages \n * %v Http Triggers \n * %v MessageQueue Triggers\n * %v Time Triggers\n * %v Kube Watchers\n * %v Canary Configs\n * %v Secrets\n * %v ConfigMaps\n * %v ArchiveUploadSpec\n * %v Test Suites\n",
+		len(fr.Functions), len(fr.Environments), len(fr.Packages), len(fr.HttpTriggers), len(fr.MessageQueueTriggers), len(fr.TimeTriggers), len(fr.KubernetesWatchTriggers),
+		len(fr.CanaryConfigs), len(fr.Secrets), len(fr.ConfigMaps), len(fr.ArchiveUploadSpecs), len(fr.TestSuites))
 
 	var warnings []string
 	// this does the rest of the checks, like dangling refs
@@ -222,6 +226,13 @@ func isResourceConflicts(deployUID string, specObj objectWithKind, clusterObj ob
 // ReadSpecs reads all specs in the specified directory and returns a parsed set of
 // fission resources.
 func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResources, error) {
+	return ReadSpecsWithOverlay(specDir, specIgnore, applyCommitLabel, Overlay{})
+}
+
+// ReadSpecsWithOverlay reads all specs in the specified directory, patches them
+// with the overlay and returns a parsed set of fission resources. The source map
+// of patched resources points to their location in the base specs.
+func ReadSpecsWithOverlay(specDir, specIgnore string, applyCommitLabel bool, overlay Overlay) (*FissionResources, error) {
 
 	// make sure spec directory exists before continue
 	if _, err := os.Stat(specDir); os.IsNotExist(err) {
@@ -242,6 +253,10 @@ func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResou
 		KubernetesWatchTriggers: make([]fv1.KubernetesWatchTrigger, 0),
 		TimeTriggers:            make([]fv1.TimeTrigger, 0),
 		MessageQueueTriggers:    make([]fv1.MessageQueueTrigger, 0),
+		CanaryConfigs:           make([]fv1.CanaryConfig, 0),
+		Secrets:                 make([]apiv1.Secret, 0),
+		ConfigMaps:              make([]apiv1.ConfigMap, 0),
+		TestSuites:              make([]types.TestSuite, 0),
 
 		SourceMap: SourceMap{
 			Locations: make(map[string](map[string](map[string]Location))),
@@ -263,14 +278,57 @@ func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResou
 		gr = gitrepo.NewGitRepo(specDir)
 	}
 
+	// overlays are read separately, only when selected
+	docs, result := readSpecDocs(specDir, overlaysPath(specDir), ignoreParser, gr)
+	if err = result.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	if overlay.enabled() {
+		docs, err = overlay.apply(specDir, docs, ignoreParser, gr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, doc := range docs {
+		// parse this document and add whatever is in it to fr
+		err = fr.ParseYaml(doc.data, &doc.loc, doc.commitLabel)
+		if err != nil {
+			if len(doc.patches) > 0 {
+				err = fmt.Errorf("%w (patched by %v)", err, doc.patchLocations())
+			}
+			// collect all errors so user can fix them all
+			result = multierror.Append(result, err)
+		}
+	}
+
+	if err = result.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &fr, nil
+}
+
+// readSpecDocs reads the YAML documents of the spec files in dir, skipping
+// the file or directory at skipPath.
+func readSpecDocs(dir string, skipPath string, ignoreParser ignore.IgnoreParser, gr *gitrepo.GitRepo) ([]*specDoc, *multierror.Error) {
 	var result *multierror.Error
+	var specDocs []*specDoc
 
 	// Users can organize the specdir into subdirs if they want to.
-	err = filepath.Walk(specDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		if path == skipPath {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// For now just read YAML files. We'll add jsonnet at some point. Skip
 		// unsupported files.
 		if !(strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
@@ -284,7 +342,7 @@ func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResou
 
 		var fileCommitLabelVal string
 		// check if applyCommitLabel is true and specdir is tracked by git repo
-		if applyCommitLabel {
+		if gr != nil {
 			fileCommitLabelVal, _ = gr.GetFileCommitLabel(path)
 		}
 
@@ -302,15 +360,14 @@ func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResou
 		for _, doc := range docs {
 			d := []byte(strings.TrimSpace(string(doc)))
 			if len(d) != 0 {
-				// parse this document and add whatever is in it to fr
-				err = fr.ParseYaml(d, &Location{
-					Path: path,
-					Line: lines,
-				}, fileCommitLabelVal)
-				if err != nil {
-					// collect all errors so user can fix them all
-					result = multierror.Append(result, err)
-				}
+				specDocs = append(specDocs, &specDoc{
+					data: d,
+					loc: Location{
+						Path: path,
+						Line: lines,
+					},
+					commitLabel: fileCommitLabelVal,
+				})
 			}
 			// the separator occupies one line, hence the +1
 			lines += strings.Count(string(doc), "\n") + 1
@@ -319,11 +376,7 @@ func ReadSpecs(specDir, specIgnore string, applyCommitLabel bool) (*FissionResou
 	})
 
 	if err != nil {
-		return nil, err
-	}
-	if err = result.ErrorOrNil(); err != nil {
-		return nil, err
+		result = multierror.Append(result, err)
 	}
-
-	return &fr, nil
+	return specDocs, result
 }
@@ -19,13 +19,13 @@ package timetrigger
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
 	"github.com/fission/fission/pkg/fission-cli/cmd"
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/printer"
 )
 
 type ListSubCommand struct {
@@ -37,6 +37,11 @@ func List(input cli.Input) error {
 }
 
 func (opts *ListSubCommand) do(input cli.Input) (err error) {
+	p, err := printer.New(input.String(flagkey.Output))
+	if err != nil {
+		return err
+	}
+
 	_, ttNs, err := opts.GetResourceNamespace(input, flagkey.NamespaceTrigger)
 	if err != nil {
 		return fmt.Errorf("error in deleting function : %w", err)
@@ -51,14 +56,13 @@ func (opts *ListSubCommand) do(input cli.Input) (err error) {
 		return fmt.Errorf("list Time triggers: %w", err)
 	}
 
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
-
-	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", "NAME", "CRON", "FUNCTION_NAME", "METHOD", "SUBPATH")
+	table := &printer.Table{Columns: []printer.Column{
+		{Header: "NAME"}, {Header: "CRON"}, {Header: "FUNCTION_NAME"}, {Header: "METHOD"}, {Header: "SUBPATH"},
+		{Header: "NAMESPACE", Wide: true},
+	}}
 	for _, tt := range tts.Items {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			tt.ObjectMeta.Name, tt.Spec.Cron, tt.Spec.Name, tt.Spec.Method, tt.Spec.Subpath)
+		table.AddRow(tt.ObjectMeta.Name, tt.Spec.Cron, tt.Spec.Name, tt.Spec.Method, tt.Spec.Subpath, tt.ObjectMeta.Namespace)
 	}
-	w.Flush()
 
-	return nil
+	return p.Print(os.Stdout, tts, table)
 }
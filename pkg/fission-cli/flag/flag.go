@@ -90,6 +90,7 @@ var (
 	ForceNamespace       = Flag{Type: Bool, Name: flagkey.ForceNamespace, Aliases: []string{"force"}, Usage: "If true, resources will be created in namespace provided by (--namespace flag ) even if spec file contains some other namespace", DefaultValue: false}
 	ForceDelete          = Flag{Type: Bool, Name: flagkey.ForceDelete, Aliases: []string{"force"}, Usage: "Delete all resources across all namespaces present in spec"}
 	AllNamespaces        = Flag{Type: Bool, Name: flagkey.AllNamespaces, Short: "A", Usage: "Fetch resources from all namespaces"}
+	Output               = Flag{Type: String, Name: flagkey.Output, Short: "o", Usage: "Output format: json, yaml, wide, jsonpath=TEMPLATE or go-template=TEMPLATE. Defaults to a table"}
 	RunTimeMinCPU        = Flag{Type: Int, Name: flagkey.RuntimeMincpu, Usage: "Minimum CPU to be assigned to pod (In millicore, minimum 1)"}
 	RunTimeMaxCPU        = Flag{Type: Int, Name: flagkey.RuntimeMaxcpu, Usage: "Maximum CPU to be assigned to pod (In millicore, minimum 1)"}
 	RunTimeTargetCPU     = Flag{Type: Int, Name: flagkey.RuntimeTargetcpu, Usage: "Target average CPU usage percentage across pods for scaling", DefaultValue: 80}
@@ -112,12 +113,12 @@ var (
 	FnBuildCmd              = Flag{Type: String, Name: flagkey.FnBuildCmd, Usage: "Package build command for builder to run with"}
 	FnSecret                = Flag{Type: StringSlice, Name: flagkey.FnSecret, Usage: "Function access to secret, should be present in the same namespace as the function. You can provide multiple secrets using multiple --secrets flags. In the case of fn update the secrets will be replaced by the provided list of secrets."}
 	FnCfgMap                = Flag{Type: StringSlice, Name: flagkey.FnCfgMap, Usage: "Function access to configmap, should be present in the same namespace as the function. You can provide multiple configmaps using multiple --configmap flags. In case of fn update the configmaps will be replaced by the provided list of configmaps."}
-	FnExecutorType          = Flag{Type: String, Name: flagkey.FnExecutorType, Usage: "Executor type for execution; one of 'poolmgr', 'newdeploy'", DefaultValue: string(fv1.ExecutorTypePoolmgr)}
+	FnExecutorType          = Flag{Type: String, Name: flagkey.FnExecutorType, Usage: "Executor type for execution; one of 'poolmgr', 'newdeploy', 'job' or 'plugin-<name>' for a registered executor plugin", DefaultValue: string(fv1.ExecutorTypePoolmgr)}
 	FnExecutionTimeout      = Flag{Type: Int, Name: flagkey.FnExecutionTimeout, Aliases: []string{"ft"}, Usage: "Maximum time for a request to wait for the response from the function", DefaultValue: 60}
 	FnLogPod                = Flag{Type: String, Name: flagkey.FnLogPod, Usage: "Function pod name (use the latest pod name if unspecified)"}
 	FnLogFollow             = Flag{Type: Bool, Name: flagkey.FnLogFollow, Short: "f", Usage: "Specify if the logs should be streamed"}
 	FnLogDetail             = Flag{Type: Bool, Name: flagkey.FnLogDetail, Short: "d", Usage: "Display detailed information"}
-	FnLogDBType             = Flag{Type: String, Name: flagkey.FnLogDBType, Usage: "Log database type, e.g. influxdb (currently influxdb and kubernetes logs are supported)", DefaultValue: "kubernetes"}
+	FnLogDBType             = Flag{Type: String, Name: flagkey.FnLogDBType, Usage: "Log database type: kubernetes, influxdb, loki or elasticsearch. loki and elasticsearch are found with the LOKI_URL and ELASTICSEARCH_URL environment variables", DefaultValue: "kubernetes"}
 	FnLogReverseQuery       = Flag{Type: Bool, Name: flagkey.FnLogReverseQuery, Short: "r", Usage: "Specify the log reverse query base on time, it will be invalid if the 'follow' flag is specified. valid for dbtype as influxdb"}
 	FnLogCount              = Flag{Type: Int, Name: flagkey.FnLogCount, Usage: "Get N most recent log records", DefaultValue: 20}
 	NamespacePod            = Flag{Type: String, Name: flagkey.NamespacePod, Usage: "Namespace in which function's pod are created. If not specified, function's namespace is used. Note: version <1.18 used fission-function as pod's default ns."}
@@ -130,8 +131,17 @@ var (
 	FnRequestsPerPod        = Flag{Type: Int, Name: flagkey.FnRequestsPerPod, Aliases: []string{"rpp"}, Usage: "Maximum number of concurrent requests that can be served by a specialized pod (Only valid for executortype; `poolmgr`)", DefaultValue: 1}
 	FnOnceOnly              = Flag{Type: Bool, Name: flagkey.FnOnceOnly, Aliases: []string{"yolo"}, Usage: "Specifies if specialized pod will serve exactly one request in its lifetime (Only valid for executortype; `poolmgr`)"}
 	FnSubPath               = Flag{Type: String, Name: flagkey.FnSubPath, Usage: "Sub Path to check if function internally supports routing"}
+	FnLogGrep               = Flag{Type: String, Name: flagkey.FnLogGrep, Usage: "Only show the log lines containing the text"}
+	FnLogLevel              = Flag{Type: String, Name: flagkey.FnLogLevel, Usage: "Only show the log lines of the level, e.g. error. Valid for dbtype as loki and elasticsearch"}
 	FnLogAllPods            = Flag{Type: Bool, Name: flagkey.FnLogAllPods, Usage: "Get all pod's logs in the function."}
 	FnRetainPods            = Flag{Type: Int, Name: flagkey.FnRetainPods, Usage: "Number of pods to retain after pods specialization.", DefaultValue: 0}
+	FnBenchRate             = Flag{Type: Int, Name: flagkey.FnBenchRate, Usage: "Target number of requests per second. If zero, requests are sent as fast as --concurrency allows"}
+	FnBenchConcurrency      = Flag{Type: Int, Name: flagkey.FnBenchConcurrency, Usage: "Maximum number of requests in flight", DefaultValue: 10}
+	FnBenchDuration         = Flag{Type: Duration, Name: flagkey.FnBenchDuration, Usage: "Length of time to measure the function for", DefaultValue: 30 * time.Second}
+	FnBenchWarmup           = Flag{Type: Duration, Name: flagkey.FnBenchWarmup, Usage: "Length of time to send requests for before measuring, to let the executor scale the function"}
+	FnBenchOutput           = Flag{Type: String, Name: flagkey.FnBenchOutput, Short: "o", Usage: "Format of the report: text or json", DefaultValue: "text"}
+	FnJobID                 = Flag{Type: String, Name: flagkey.FnJobID, Usage: "ID of the job invocation, the last segment of the Location returned when submitting it"}
+	FnJobWait               = Flag{Type: Bool, Name: flagkey.FnJobWait, Short: "w", Usage: "Wait for the job invocation to succeed or fail"}
 	// Termination Grace Period configurable at function creation/update only for container functions
 	FnTerminationGracePeriod = Flag{Type: Int64, Name: flagkey.FnGracePeriod, Usage: "Grace time (in seconds) for pod to perform connection draining before termination (only non-negative values considered)", DefaultValue: 360}
 
@@ -180,6 +190,9 @@ var (
 	EnvImage                  = Flag{Type: String, Name: flagkey.EnvImage, Usage: "Environment image URL"}
 	EnvBuilderImage           = Flag{Type: String, Name: flagkey.EnvBuilderImage, Usage: "Environment builder image URL"}
 	EnvBuildCmd               = Flag{Type: String, Name: flagkey.EnvBuildcommand, Usage: "Build command for environment builder to build source package"}
+	EnvBuildTimeout           = Flag{Type: Int, Name: flagkey.EnvBuildTimeout, Usage: "Maximum time (in seconds) a build may run before it is killed, 0 means no limit"}
+	EnvBuilderMode            = Flag{Type: String, Name: flagkey.EnvBuilderMode, Usage: "How packages are built; one of 'deployment' (shared builder deployment), 'job' (one job per build)"}
+	EnvRecyclePods            = Flag{Type: Bool, Name: flagkey.EnvRecyclePods, Usage: "Return idle specialized pods to the pool instead of deleting them (poolmgr only, the runtime must support unspecialization)"}
 	EnvKeepArchive            = Flag{Type: Bool, Name: flagkey.EnvKeeparchive, Usage: "Keep the archive instead of extracting it into a directory (mainly for the JVM environment because .jar is one kind of zip archive)"}
 	EnvExternalNetwork        = Flag{Type: Bool, Name: flagkey.EnvExternalNetwork, Usage: "Allow pod to access external network (only works when istio feature is enabled)"}
 	EnvTerminationGracePeriod = Flag{Type: Int64, Name: flagkey.EnvGracePeriod, Aliases: []string{"period"}, Usage: "Grace time (in seconds) for pod to perform connection draining before termination (only non-negative values considered)", DefaultValue: 360}
@@ -200,6 +213,8 @@ var (
 	PkgForce          = Flag{Type: Bool, Name: flagkey.PkgForce, Short: "f", Usage: "Force update a package even if it is used by one or more functions"}
 	PkgEnvironment    = Flag{Type: String, Name: flagkey.PkgEnvironment, Usage: "Environment name"}
 	PkgBuildCmd       = Flag{Type: String, Name: flagkey.PkgBuildCmd, Usage: "Build command for builder to run with"}
+	PkgBuildTimeout   = Flag{Type: Int, Name: flagkey.PkgBuildTimeout, Usage: "Maximum time (in seconds) the build may run before it is killed, overrides the build timeout of the environment"}
+	PkgFollow         = Flag{Type: Bool, Name: flagkey.PkgFollow, Short: "f", Usage: "Follow the logs of a running build"}
 	PkgOutput         = Flag{Type: String, Name: flagkey.PkgOutput, Short: "o", Usage: "Output filename to save archive content"}
 	PkgStatus         = Flag{Type: String, Name: flagkey.PkgStatus, Usage: `Filter packages by status`}
 	PkgOrphan         = Flag{Type: Bool, Name: flagkey.PkgOrphan, Usage: "Orphan packages that are not referenced by any function"}
@@ -209,6 +224,13 @@ var (
 	PkgSrcArchive     = Flag{Type: StringSlice, Name: flagkey.PkgSrcArchive, Aliases: []string{"source", "src"}, Usage: "URL or local paths for source archive"}
 	PkgSrcChecksum    = Flag{Type: String, Name: flagkey.PkgSrcChecksum, Usage: "SHA256 checksum of source archive when providing URL"}
 	PkgInsecure       = Flag{Type: Bool, Name: flagkey.PkgInsecure, Usage: "Skip generating SHA256 checksum for file integrity validation"}
+	PkgGitURL         = Flag{Type: String, Name: flagkey.PkgGitURL, Usage: "URL of the Git repository to build the package from, instead of a source archive"}
+	PkgGitRef         = Flag{Type: String, Name: flagkey.PkgGitRef, Usage: "Branch, tag or commit SHA of the Git repository, defaults to the default branch"}
+	PkgGitSubPath     = Flag{Type: String, Name: flagkey.PkgGitSubPath, Usage: "Directory of the Git repository holding the package source"}
+	PkgPush           = Flag{Type: String, Name: flagkey.PkgPush, Usage: "Push the archive as an OCI artifact to the given reference, e.g. oci://registry/repo:tag, instead of uploading it to the storage service"}
+	PkgSignKey        = Flag{Type: String, Name: flagkey.PkgSignKey, Usage: "PEM encoded ed25519, ECDSA or RSA private key to sign the archives with"}
+	PkgGitSecret      = Flag{Type: String, Name: flagkey.PkgGitSecret, Usage: "Name of the secret holding the Git repository credentials (username/password or ssh-privatekey/known_hosts)"}
+	PkgRegistrySecret = Flag{Type: String, Name: flagkey.PkgRegistrySecret, Usage: "Name of the image pull secret holding the credentials of the registry of an OCI archive, defaults to the image pull secret of the environment"}
 
 	SpecSave             = Flag{Type: Bool, Name: flagkey.SpecSave, Usage: "Save to the spec directory instead of creating on cluster"}
 	SpecDir              = Flag{Type: String, Name: flagkey.SpecDir, Usage: "Directory to store specs, defaults to ./specs"}
@@ -218,10 +240,26 @@ var (
 	SpecWatch            = Flag{Type: Bool, Name: flagkey.SpecWatch, Usage: "Watch local files for change, and re-apply specs as necessary"}
 	SpecDelete           = Flag{Type: Bool, Name: flagkey.SpecDelete, Usage: "Allow apply to delete resources that no longer exist in the specification"}
 	SpecDry              = Flag{Type: Bool, Name: flagkey.SpecDry, Usage: "View the generated specs"}
+	SpecDryRun           = Flag{Type: Bool, Name: flagkey.SpecDryRun, Usage: "Print the changes that would be made to the cluster without making them"}
 	SpecValidation       = Flag{Type: String, Name: flagkey.SpecValidate, Usage: "Turns server side validations of Fission objects on/off"}
 	SpecIgnore           = Flag{Type: String, Name: flagkey.SpecIgnore, Usage: fmt.Sprintf("File containing specs to be ignored inside --specdir, defaults to %v", util.SPEC_IGNORE_FILE)}
 	SpecApplyCommitLabel = Flag{Type: Bool, Name: flagkey.SpecApplyCommitLabel, Usage: "Apply commit label to the resources"}
 	SpecAllowConflicts   = Flag{Type: Bool, Name: flagkey.SpecAllowConflicts, Usage: "If true, spec apply will be forced even if conflicting resources exist", DefaultValue: false}
+	SpecOverlay          = Flag{Type: String, Name: flagkey.SpecOverlay, Usage: "Name of the overlay in --specdir/overlays to patch the specs with"}
+	SpecValues           = Flag{Type: String, Name: flagkey.SpecValues, Usage: "YAML file with the values of the ${VAR} variables in the specs, defaults to values.yaml of the overlay"}
+	SpecExportName       = Flag{Type: StringSlice, Name: flagkey.SpecExportName, Usage: "Name of a function to export, along with its package, environment and triggers. Use multiple --name flags to export several functions"}
+	SpecLabels           = Flag{Type: String, Name: flagkey.SpecLabels, Usage: "Label selector of the resources to export, of the form a=b,c=d"}
+	SpecAdopt            = Flag{Type: Bool, Name: flagkey.SpecAdopt, Usage: "Annotate the exported resources with the deployment UID of the specs, so that spec apply manages them"}
+	SpecAtomic           = Flag{Type: Bool, Name: flagkey.SpecAtomic, Usage: "Roll back the changes to the cluster if applying the specs fails. With --wait, failed package builds and functions that don't become ready fail the apply too"}
+	SpecWaitTimeout      = Flag{Type: Duration, Name: flagkey.SpecWaitTimeout, Usage: "Length of time to wait for functions to become ready with --atomic and --wait", DefaultValue: 5 * time.Minute}
+	SpecTest             = Flag{Type: Bool, Name: flagkey.SpecTest, Usage: "Run the test suites of the specs after applying them, implies --wait. With --atomic, failed tests roll back the changes"}
+	SpecTestRouter       = Flag{Type: String, Name: flagkey.SpecTestRouter, Usage: "URL of the router to run the tests against, e.g. the one of fission dev. Defaults to the router of the cluster"}
+	SpecTestOutput       = Flag{Type: String, Name: flagkey.SpecTestOutput, Short: "o", Usage: "Format of the test results: text, junit or tap", DefaultValue: "text"}
+
+	DevPort           = Flag{Type: Int, Name: flagkey.DevPort, Usage: "Port to serve the HTTP triggers of the functions on", DefaultValue: 8888}
+	DevRuntime        = Flag{Type: String, Name: flagkey.DevRuntime, Usage: "How to run environment runtimes: docker runs their images, process runs the commands given with --runtime-command", DefaultValue: "docker"}
+	DevRuntimeCommand = Flag{Type: StringSlice, Name: flagkey.DevRuntimeCommand, Usage: "Command running the runtime of an environment with --runtime process: --runtime-command nodejs=\"node server.js\". The command gets the PORT to listen on and the USERFUNC directory with the code as environment variables"}
+	DevTest           = Flag{Type: Bool, Name: flagkey.DevTest, Usage: "Run the test suites of the specs after each reload"}
 
 	SupportOutput = Flag{Type: String, Name: flagkey.SupportOutput, Short: "o", Usage: "Output directory to save dump archive/files", DefaultValue: flagkey.DefaultSpecOutputDir}
 	SupportNoZip  = Flag{Type: Bool, Name: flagkey.SupportNoZip, Usage: "Save dump information into multiple files instead of single zip file"}
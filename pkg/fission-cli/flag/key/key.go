@@ -86,7 +86,16 @@ const (
 	FnSubPath               = "subpath"
 	FnGracePeriod           = "graceperiod"
 	FnLogAllPods            = "all-pods"
+	FnLogGrep               = "grep"
+	FnLogLevel              = "level"
 	FnRetainPods            = "retainpods"
+	FnBenchRate             = "rate"
+	FnBenchConcurrency      = FnConcurrency
+	FnBenchDuration         = "duration"
+	FnBenchWarmup           = "warmup"
+	FnBenchOutput           = Output
+	FnJobID                 = "id"
+	FnJobWait               = "wait"
 
 	HtName              = resourceName
 	HtMethod            = "method"
@@ -133,7 +142,10 @@ const (
 	EnvImage           = "image"
 	EnvBuilderImage    = "builder"
 	EnvBuildcommand    = "buildcmd"
+	EnvBuildTimeout    = "buildtimeout"
+	EnvBuilderMode     = "buildermode"
 	EnvKeeparchive     = "keeparchive"
+	EnvRecyclePods     = "recycle-pods"
 	EnvExternalNetwork = "externalnetwork"
 	EnvGracePeriod     = "graceperiod"
 	EnvVersion         = "version"
@@ -158,7 +170,16 @@ const (
 	PkgSrcChecksum    = "srcchecksum"
 	PkgDeployChecksum = "deploychecksum"
 	PkgInsecure       = "insecure"
+	PkgGitURL         = "git-url"
+	PkgGitRef         = "git-ref"
+	PkgGitSubPath     = "git-subpath"
+	PkgGitSecret      = "git-secret"
+	PkgPush           = "push"
+	PkgRegistrySecret = "registry-secret"
+	PkgSignKey        = "sign-key"
 	PkgBuildCmd       = "buildcmd"
+	PkgBuildTimeout   = "buildtimeout"
+	PkgFollow         = "follow"
 	PkgOutput         = Output
 	PkgStatus         = "status"
 	PkgOrphan         = "orphan"
@@ -171,10 +192,26 @@ const (
 	SpecWatch            = "watch"
 	SpecDelete           = "delete"
 	SpecDry              = "dry"
+	SpecDryRun           = "dry-run"
 	SpecValidate         = "validation"
 	SpecIgnore           = "specignore"
 	SpecApplyCommitLabel = "commitlabel"
 	SpecAllowConflicts   = "allowconflicts"
+	SpecOverlay          = "overlay"
+	SpecValues           = "values"
+	SpecExportName       = resourceName
+	SpecLabels           = "labels"
+	SpecAdopt            = "adopt"
+	SpecAtomic           = "atomic"
+	SpecWaitTimeout      = "wait-timeout"
+	SpecTest             = "test"
+	SpecTestRouter       = "router"
+	SpecTestOutput       = Output
+
+	DevPort           = "port"
+	DevRuntime        = "runtime"
+	DevRuntimeCommand = "runtime-command"
+	DevTest           = "test"
 
 	SupportOutput = Output
 	SupportNoZip  = "nozip"
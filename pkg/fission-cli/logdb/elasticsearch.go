@@ -0,0 +1,300 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	ferror "github.com/fission/fission/pkg/error"
+)
+
+const (
+	ELASTICSEARCH_URL                = "ELASTICSEARCH_URL"
+	ELASTICSEARCH_USERNAME           = "ELASTICSEARCH_USERNAME"
+	ELASTICSEARCH_PASSWORD           = "ELASTICSEARCH_PASSWORD"
+	ELASTICSEARCH_INDEX              = "ELASTICSEARCH_INDEX"
+	ELASTICSEARCH_FUNCTION_UID_FIELD = "ELASTICSEARCH_FUNCTION_UID_FIELD"
+
+	// fields of the OpenTelemetry mapping of the elasticsearch exporter of
+	// the OpenTelemetry Collector, with the pod metadata added by its
+	// k8sattributes processor
+	esTimestampField = "@timestamp"
+	esMessageField   = "body.text"
+	esLevelField     = "severity_text"
+	esPodField       = "resource.attributes.k8s.pod.name"
+	esNamespaceField = "resource.attributes.k8s.namespace.name"
+	esContainerField = "resource.attributes.k8s.container.name"
+	esFunctionField  = "resource.attributes.k8s.pod.labels.functionName"
+
+	// esFollowInterval is how often new logs are searched when following
+	esFollowInterval = time.Second
+	// esFollowBatch is the most logs searched at once when following
+	esFollowBatch = 1000
+	// esFollowLag is how late logs may be indexed and still be followed
+	esFollowLag = 30 * time.Second
+)
+
+// Elasticsearch searches the OpenTelemetry logs of functions in
+// Elasticsearch or OpenSearch, as the OpenTelemetry Collector stores them.
+type Elasticsearch struct {
+	endpoint string
+	username string
+	password string
+	index    string
+	uidField string
+}
+
+type (
+	esHit struct {
+		ID     string         `json:"_id"`
+		Source map[string]any `json:"_source"`
+	}
+
+	esSearchResponse struct {
+		Hits struct {
+			Hits []esHit `json:"hits"`
+		} `json:"hits"`
+	}
+)
+
+func NewElasticsearch(logDBOptions LogDBOptions) (Elasticsearch, error) {
+	endpoint := os.Getenv(ELASTICSEARCH_URL)
+	if endpoint == "" {
+		return Elasticsearch{}, fmt.Errorf("set the URL of Elasticsearch with the %s environment variable", ELASTICSEARCH_URL)
+	}
+	index := os.Getenv(ELASTICSEARCH_INDEX)
+	if index == "" {
+		index = "logs-*"
+	}
+	uidField := os.Getenv(ELASTICSEARCH_FUNCTION_UID_FIELD)
+	if uidField == "" {
+		uidField = "resource.attributes.k8s.pod.labels.functionUid"
+	}
+	return Elasticsearch{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		username: os.Getenv(ELASTICSEARCH_USERNAME),
+		password: os.Getenv(ELASTICSEARCH_PASSWORD),
+		index:    index,
+		uidField: uidField,
+	}, nil
+}
+
+func (es Elasticsearch) GetLogs(ctx context.Context, filter LogFilter, output *bytes.Buffer) error {
+	hits, err := es.searchLatest(ctx, filter)
+	if err != nil {
+		return err
+	}
+	entries, err := es.entries(hits)
+	if err != nil {
+		return err
+	}
+	sort.Sort(ByTimestamp(entries, filter.Reverse))
+	return writeLogEntries(output, entries, filter.Details)
+}
+
+// FollowLogs searches the new logs every esFollowInterval. Logs are indexed
+// some time after they are written, so the logs of the last esFollowLag are
+// searched again, and written if they weren't yet. Logs indexed later than
+// that are skipped.
+func (es Elasticsearch) FollowLogs(ctx context.Context, filter LogFilter, output io.Writer) error {
+	hits, err := es.searchLatest(ctx, filter)
+	if err != nil {
+		return err
+	}
+	slices.Reverse(hits)
+
+	// seen holds the timestamps of the logs written, by document ID, until
+	// they're out of the searched window.
+	last := filter.Since
+	seen := make(map[string]time.Time)
+	// write writes the logs not written yet, and returns the time of the
+	// last hit.
+	write := func(hits []esHit) (time.Time, error) {
+		entries, err := es.entries(hits)
+		if err != nil {
+			return time.Time{}, err
+		}
+		var hitsLast time.Time
+		for i, entry := range entries {
+			hitsLast = entry.Timestamp
+			if _, ok := seen[hits[i].ID]; ok {
+				continue
+			}
+			seen[hits[i].ID] = entry.Timestamp
+			if entry.Timestamp.After(last) {
+				last = entry.Timestamp
+			}
+			err = writeLogEntries(output, entries[i:i+1], filter.Details)
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+		return hitsLast, nil
+	}
+	_, err = write(hits)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(esFollowInterval):
+		}
+
+		from := last.Add(-esFollowLag)
+		if from.Before(filter.Since) {
+			from = filter.Since
+		}
+		maps.DeleteFunc(seen, func(_ string, t time.Time) bool { return t.Before(from) })
+		for {
+			hits, err = es.query(ctx, es.searchBody(filter, "gte", from, esFollowBatch, "asc"))
+			if err != nil {
+				return err
+			}
+			hitsLast, err := write(hits)
+			if err != nil {
+				return err
+			}
+			if len(hits) < esFollowBatch || !hitsLast.After(from) {
+				break
+			}
+			// a full batch, search the next one
+			from = hitsLast
+		}
+	}
+}
+
+// searchLatest returns the latest logs after the time of the filter, the
+// latest first.
+func (es Elasticsearch) searchLatest(ctx context.Context, filter LogFilter) ([]esHit, error) {
+	return es.query(ctx, es.searchBody(filter, "gt", filter.Since, filter.RecordLimit, "desc"))
+}
+
+func (es Elasticsearch) searchBody(filter LogFilter, op string, since time.Time, size int, order string) map[string]any {
+	filters := []any{
+		map[string]any{"term": map[string]any{es.uidField: filter.FuncUid}},
+	}
+	if since.Unix() > 0 {
+		filters = append(filters, map[string]any{"range": map[string]any{
+			esTimestampField: map[string]any{op: since.UTC().Format(time.RFC3339Nano)},
+		}})
+	}
+	if filter.Pod != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{esPodField: filter.Pod}})
+	}
+	if filter.Level != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{
+			esLevelField: map[string]any{"value": filter.Level, "case_insensitive": true},
+		}})
+	}
+	if filter.Grep != "" {
+		filters = append(filters, map[string]any{"match_phrase": map[string]any{esMessageField: filter.Grep}})
+	}
+	return map[string]any{
+		"size":  size,
+		"sort":  []any{map[string]any{esTimestampField: map[string]any{"order": order}}},
+		"query": map[string]any{"bool": map[string]any{"filter": filters}},
+	}
+}
+
+func (es Elasticsearch) query(ctx context.Context, body map[string]any) ([]esHit, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, es.endpoint+"/"+es.index+"/_search", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for Elasticsearch: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if es.username != "" || es.password != "" {
+		req.SetBasicAuth(es.username, es.password)
+	}
+
+	httpClient := http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	var response esSearchResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Elasticsearch response: %w", err)
+	}
+	return response.Hits.Hits, nil
+}
+
+// entries returns the log entries of the hits, in the same order.
+func (es Elasticsearch) entries(hits []esHit) ([]LogEntry, error) {
+	entries := make([]LogEntry, 0, len(hits))
+	for _, hit := range hits {
+		ts := sourceString(hit.Source, esTimestampField)
+		t, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Elasticsearch log timestamp %s: %w", ts, err)
+		}
+		entries = append(entries, LogEntry{
+			Timestamp: t,
+			Message:   strings.TrimSuffix(sourceString(hit.Source, esMessageField), "\n"),
+			Container: sourceString(hit.Source, esContainerField),
+			Namespace: sourceString(hit.Source, esNamespaceField),
+			FuncName:  sourceString(hit.Source, esFunctionField),
+			FuncUid:   sourceString(hit.Source, es.uidField),
+			Pod:       sourceString(hit.Source, esPodField),
+		})
+	}
+	return entries, nil
+}
+
+// sourceString returns the string value of the field of a document. The
+// path of the field may be stored as nested objects, dotted names like the
+// OpenTelemetry attributes, or both.
+func sourceString(source map[string]any, path string) string {
+	if v, ok := source[path]; ok {
+		s, _ := v.(string)
+		return s
+	}
+	for i := range len(path) {
+		if path[i] != '.' {
+			continue
+		}
+		if sub, ok := source[path[:i]].(map[string]any); ok {
+			if s := sourceString(sub, path[i+1:]); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
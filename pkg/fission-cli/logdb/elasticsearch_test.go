@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func esDoc(id string, ts string, message string) map[string]any {
+	return map[string]any{
+		"_id": id,
+		"_source": map[string]any{
+			"@timestamp": ts,
+			"body":       map[string]any{"text": message},
+			// the OpenTelemetry attributes are stored with dotted names
+			"resource": map[string]any{"attributes": map[string]any{
+				"k8s.pod.name":                "hello-pod",
+				"k8s.pod.labels.functionUid":  "uid",
+				"k8s.pod.labels.functionName": "hello",
+				"k8s.namespace.name":          "default",
+				"k8s.container.name":          "nodejs",
+			}},
+		},
+	}
+}
+
+func TestElasticsearch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		searches []map[string]any
+		// docs are the documents returned by the searches, in order
+		docs = [][]any{
+			{esDoc("2", "2025-01-01T00:00:02Z", "GET /b"), esDoc("1", "2025-01-01T00:00:01Z", "GET /a")},
+			{esDoc("2", "2025-01-01T00:00:02Z", "GET /b"), esDoc("3", "2025-01-01T00:00:02Z", "GET /c")},
+		}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/logs-fission/_search", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		searches = append(searches, body)
+		var hits []any
+		if len(docs) > 0 {
+			hits, docs = docs[0], docs[1:]
+		}
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"hits": map[string]any{"hits": hits}}) //nolint: errcheck
+	}))
+	defer server.Close()
+
+	t.Setenv(ELASTICSEARCH_URL, server.URL)
+	t.Setenv(ELASTICSEARCH_INDEX, "logs-fission")
+	logDB, err := GetLogDB(ELASTICSEARCH, t.Context(), LogDBOptions{})
+	require.NoError(t, err)
+
+	filter := LogFilter{
+		FuncUid:     "uid",
+		RecordLimit: 2,
+		Grep:        "GET",
+		Level:       "info",
+		Details:     true,
+	}
+	var out bytes.Buffer
+	require.NoError(t, logDB.GetLogs(t.Context(), filter, &out))
+	require.Contains(t, out.String(), "Function Name: hello\nFunction ID: uid\nPod: hello-pod\nContainer: nodejs")
+	require.Less(t, strings.Index(out.String(), "GET /a"), strings.Index(out.String(), "GET /b"))
+	filters := searches[0]["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]any)
+	require.Equal(t, []any{
+		map[string]any{"term": map[string]any{"resource.attributes.k8s.pod.labels.functionUid": "uid"}},
+		map[string]any{"term": map[string]any{"severity_text": map[string]any{"value": "info", "case_insensitive": true}}},
+		map[string]any{"match_phrase": map[string]any{"body.text": "GET"}},
+	}, filters)
+
+	// following searches again the logs shortly before the last one, to get
+	// the ones indexed late, and skips the logs written
+	mu.Lock()
+	searches = nil
+	docs = [][]any{
+		{esDoc("2", "2025-01-01T00:00:02Z", "GET /b"), esDoc("1", "2025-01-01T00:00:01Z", "GET /a")},
+		{esDoc("1", "2025-01-01T00:00:01Z", "GET /a"), esDoc("4", "2025-01-01T00:00:01.5Z", "GET /late"),
+			esDoc("2", "2025-01-01T00:00:02Z", "GET /b"), esDoc("3", "2025-01-01T00:00:02Z", "GET /c")},
+	}
+	mu.Unlock()
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	filter.Details = false
+	var followed syncBuffer
+	done := make(chan error)
+	go func() {
+		done <- logDB.(LogFollower).FollowLogs(ctx, filter, &followed)
+	}()
+	require.Eventually(t, func() bool {
+		return strings.Contains(followed.String(), "GET /c")
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	require.Equal(t, 1, strings.Count(followed.String(), "GET /a"))
+	require.Equal(t, 1, strings.Count(followed.String(), "GET /b"))
+	require.Contains(t, followed.String(), "GET /late")
+
+	mu.Lock()
+	defer mu.Unlock()
+	follow := searches[1]["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]any)
+	require.Contains(t, follow, map[string]any{"range": map[string]any{"@timestamp": map[string]any{"gte": "2024-12-31T23:59:32Z"}}})
+}
+
+func TestSourceString(t *testing.T) {
+	source := map[string]any{
+		"a":   map[string]any{"b.c": "dotted", "d": map[string]any{"e": "nested"}},
+		"f.g": "flat",
+	}
+	require.Equal(t, "dotted", sourceString(source, "a.b.c"))
+	require.Equal(t, "nested", sourceString(source, "a.d.e"))
+	require.Equal(t, "flat", sourceString(source, "f.g"))
+	require.Equal(t, "", sourceString(source, "a.x"))
+}
@@ -140,6 +140,9 @@ func (influx InfluxDB) GetLogs(ctx context.Context, filter LogFilter, output *by
 					Stream:    getEntryValue(row, stream, -1),
 					Sequence:  seqNum, // sequence tag
 				}
+				if filter.Grep != "" && !strings.Contains(entry.Message, filter.Grep) {
+					continue
+				}
 				logEntries = append(logEntries, entry)
 			}
 		}
@@ -147,22 +150,7 @@ func (influx InfluxDB) GetLogs(ctx context.Context, filter LogFilter, output *by
 
 	sort.Sort(ByTimestamp(logEntries, filter.Reverse))
 
-	for _, logEntry := range logEntries {
-		if filter.Details {
-			msg := fmt.Sprintf("Timestamp: %s\nNamespace: %s\nFunction Name: %s\nFunction ID: %s\nPod: %s\nContainer: %s\nStream: %s\nLog: %s\n---\n",
-				logEntry.Timestamp, logEntry.Namespace, logEntry.FuncName, logEntry.FuncUid, logEntry.Pod, logEntry.Container, logEntry.Stream, logEntry.Message)
-			if _, err := output.WriteString(msg); err != nil {
-				return fmt.Errorf("error copying pod log: %w", err)
-			}
-		} else {
-			msg := fmt.Sprintf("[%s] %s\n", logEntry.Timestamp, logEntry.Message)
-			if _, err := output.WriteString(msg); err != nil {
-				return fmt.Errorf("error copying pod log: %w", err)
-			}
-		}
-	}
-
-	return nil
+	return writeLogEntries(output, logEntries, filter.Details)
 }
 
 func (influx InfluxDB) query(ctx context.Context, query influxdbClient.Query) (*influxdbClient.Response, error) {
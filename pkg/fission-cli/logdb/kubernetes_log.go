@@ -17,12 +17,14 @@ limitations under the License.
 package logdb
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -143,7 +145,7 @@ func streamContainerLog(ctx context.Context, kubernetesClient kubernetes.Interfa
 			}
 		}
 
-		_, err = io.Copy(output, podLogs)
+		err = copyLogLines(output, podLogs, logFilter.Grep)
 		if err != nil {
 			return fmt.Errorf("error copying pod log: %w", err)
 		}
@@ -153,3 +155,26 @@ func streamContainerLog(ctx context.Context, kubernetesClient kubernetes.Interfa
 
 	return nil
 }
+
+// copyLogLines copies the log lines containing grep.
+func copyLogLines(output io.Writer, logs io.Reader, grep string) error {
+	if grep == "" {
+		_, err := io.Copy(output, logs)
+		return err
+	}
+	reader := bufio.NewReader(logs)
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.Contains(line, grep) {
+			if _, werr := io.WriteString(output, line); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -20,20 +20,32 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	v1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
 const (
-	INFLUXDB   = "influxdb"
-	KUBERNETES = "kubernetes"
+	INFLUXDB      = "influxdb"
+	KUBERNETES    = "kubernetes"
+	LOKI          = "loki"
+	ELASTICSEARCH = "elasticsearch"
 )
 
 type LogDatabase interface {
 	GetLogs(context.Context, LogFilter, *bytes.Buffer) error
 }
 
+// LogFollower is implemented by the log databases that can stream the logs
+// of a function as they come, instead of being polled for them.
+type LogFollower interface {
+	// FollowLogs writes the logs since the time of the filter, and then
+	// the new logs until the context is done.
+	FollowLogs(context.Context, LogFilter, io.Writer) error
+}
+
 type LogFilter struct {
 	Pod            string
 	PodNamespace   string
@@ -46,6 +58,11 @@ type LogFilter struct {
 	Details        bool
 	WarnUser       bool
 	AllPods        bool
+	// Grep selects the log lines containing it.
+	Grep string
+	// Level selects the log lines of the severity level, for the
+	// databases that record it.
+	Level string
 }
 
 type LogEntry struct {
@@ -85,6 +102,32 @@ func GetLogDB(dbType string, ctx context.Context, logDBOptions LogDBOptions) (Lo
 		return NewInfluxDB(ctx, logDBOptions)
 	case KUBERNETES:
 		return NewKubernetesEndpoint(logDBOptions)
+	case LOKI:
+		return NewLoki(logDBOptions)
+	case ELASTICSEARCH:
+		return NewElasticsearch(logDBOptions)
+	}
+	return nil, fmt.Errorf("log database type is incorrect, now only support %s", strings.Join([]string{INFLUXDB, KUBERNETES, LOKI, ELASTICSEARCH}, ", "))
+}
+
+// SupportsLevel returns whether the log database can filter logs by level.
+func SupportsLevel(dbType string) bool {
+	return dbType == LOKI || dbType == ELASTICSEARCH
+}
+
+// writeLogEntries writes the log entries, with their details if asked.
+func writeLogEntries(output io.Writer, entries []LogEntry, details bool) error {
+	for _, logEntry := range entries {
+		var msg string
+		if details {
+			msg = fmt.Sprintf("Timestamp: %s\nNamespace: %s\nFunction Name: %s\nFunction ID: %s\nPod: %s\nContainer: %s\nStream: %s\nLog: %s\n---\n",
+				logEntry.Timestamp, logEntry.Namespace, logEntry.FuncName, logEntry.FuncUid, logEntry.Pod, logEntry.Container, logEntry.Stream, logEntry.Message)
+		} else {
+			msg = fmt.Sprintf("[%s] %s\n", logEntry.Timestamp, logEntry.Message)
+		}
+		if _, err := io.WriteString(output, msg); err != nil {
+			return fmt.Errorf("error copying pod log: %w", err)
+		}
 	}
-	return nil, fmt.Errorf("log database type is incorrect, now only support %s", INFLUXDB)
+	return nil
 }
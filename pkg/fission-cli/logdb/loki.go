@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/fission-cli/console"
+)
+
+const (
+	LOKI_URL                = "LOKI_URL"
+	LOKI_USERNAME           = "LOKI_USERNAME"
+	LOKI_PASSWORD           = "LOKI_PASSWORD"
+	LOKI_TENANT             = "LOKI_TENANT"
+	LOKI_FUNCTION_UID_LABEL = "LOKI_FUNCTION_UID_LABEL"
+
+	// lokiLookback is how far back logs are queried when no time is given
+	lokiLookback = 24 * time.Hour
+)
+
+// Loki queries the logs of functions in Grafana Loki. The log streams of
+// function pods are selected by the label of the function UID, which log
+// collectors like Promtail and Alloy set from the pod labels.
+type Loki struct {
+	endpoint string
+	username string
+	password string
+	tenant   string
+	uidLabel string
+}
+
+type (
+	lokiStream struct {
+		Stream map[string]string `json:"stream"`
+		// Values are the timestamps in nanoseconds and log lines
+		Values [][2]string `json:"values"`
+	}
+
+	lokiQueryResponse struct {
+		Data struct {
+			Result []lokiStream `json:"result"`
+		} `json:"data"`
+	}
+
+	lokiTailResponse struct {
+		Streams        []lokiStream `json:"streams"`
+		DroppedEntries []struct {
+			Timestamp string `json:"timestamp"`
+		} `json:"dropped_entries"`
+	}
+)
+
+func NewLoki(logDBOptions LogDBOptions) (Loki, error) {
+	endpoint := os.Getenv(LOKI_URL)
+	if endpoint == "" {
+		return Loki{}, fmt.Errorf("set the URL of Loki with the %s environment variable", LOKI_URL)
+	}
+	uidLabel := os.Getenv(LOKI_FUNCTION_UID_LABEL)
+	if uidLabel == "" {
+		uidLabel = "functionUid"
+	}
+	return Loki{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		username: os.Getenv(LOKI_USERNAME),
+		password: os.Getenv(LOKI_PASSWORD),
+		tenant:   os.Getenv(LOKI_TENANT),
+		uidLabel: uidLabel,
+	}, nil
+}
+
+func (loki Loki) GetLogs(ctx context.Context, filter LogFilter, output *bytes.Buffer) error {
+	entries, err := loki.queryRange(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return writeLogEntries(output, entries, filter.Details)
+}
+
+func (loki Loki) FollowLogs(ctx context.Context, filter LogFilter, output io.Writer) error {
+	// the latest logs are queried first, as the tail of Loki returns the
+	// oldest logs since its start
+	entries, err := loki.queryRange(ctx, filter)
+	if err != nil {
+		return err
+	}
+	err = writeLogEntries(output, entries, filter.Details)
+	if err != nil {
+		return err
+	}
+	start := time.Now().Add(-time.Minute)
+	if len(entries) > 0 {
+		start = entries[len(entries)-1].Timestamp.Add(time.Nanosecond)
+	}
+
+	u, err := url.Parse(loki.endpoint + "/loki/api/v1/tail")
+	if err != nil {
+		return fmt.Errorf("error parsing Loki URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	params := url.Values{}
+	params.Set("query", loki.logQL(filter))
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	u.RawQuery = params.Encode()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, u.String(), loki.header())
+	if err != nil {
+		if resp != nil {
+			return ferror.MakeErrorFromHTTP(resp)
+		}
+		return fmt.Errorf("error tailing logs from Loki: %w", err)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		var tail lokiTailResponse
+		err := conn.ReadJSON(&tail)
+		if err != nil {
+			if ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return fmt.Errorf("error tailing logs from Loki: %w", err)
+		}
+		if len(tail.DroppedEntries) > 0 {
+			console.Warn(fmt.Sprintf("Loki dropped %d log entries while tailing", len(tail.DroppedEntries)))
+		}
+		entries, err := loki.entries(tail.Streams)
+		if err != nil {
+			return err
+		}
+		sort.Sort(ByTimestamp(entries, false))
+		err = writeLogEntries(output, entries, filter.Details)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// queryRange returns the latest logs matching the filter, in the order of
+// the filter.
+func (loki Loki) queryRange(ctx context.Context, filter LogFilter) ([]LogEntry, error) {
+	start := filter.Since
+	if start.Unix() <= 0 {
+		start = time.Now().Add(-lokiLookback)
+	}
+	params := url.Values{}
+	params.Set("query", loki.logQL(filter))
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	params.Set("limit", strconv.Itoa(filter.RecordLimit))
+	// backward returns the latest logs within the limit
+	params.Set("direction", "backward")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loki.endpoint+"/loki/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for Loki: %w", err)
+	}
+	req.Header = loki.header()
+
+	httpClient := http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	var response lokiQueryResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Loki response: %w", err)
+	}
+	entries, err := loki.entries(response.Data.Result)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(ByTimestamp(entries, filter.Reverse))
+	return entries, nil
+}
+
+// logQL returns the LogQL query of the filter.
+func (loki Loki) logQL(filter LogFilter) string {
+	selectors := []string{fmt.Sprintf("%s=%s", loki.uidLabel, strconv.Quote(filter.FuncUid))}
+	if filter.Pod != "" {
+		selectors = append(selectors, fmt.Sprintf("pod=%s", strconv.Quote(filter.Pod)))
+	}
+	query := "{" + strings.Join(selectors, ", ") + "}"
+	if filter.Grep != "" {
+		query += " |= " + strconv.Quote(filter.Grep)
+	}
+	if filter.Level != "" {
+		// Loki detects the level of log lines in structured metadata
+		query += " | detected_level=~" + strconv.Quote("(?i)"+regexp.QuoteMeta(filter.Level))
+	}
+	return query
+}
+
+func (loki Loki) header() http.Header {
+	header := http.Header{}
+	if loki.username != "" || loki.password != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(loki.username + ":" + loki.password))
+		header.Set("Authorization", "Basic "+auth)
+	}
+	if loki.tenant != "" {
+		header.Set("X-Scope-OrgID", loki.tenant)
+	}
+	return header
+}
+
+// entries returns the log entries of the streams, with the labels the
+// Kubernetes service discovery of log collectors sets.
+func (loki Loki) entries(streams []lokiStream) ([]LogEntry, error) {
+	var entries []LogEntry
+	for _, stream := range streams {
+		for _, value := range stream.Values {
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, errors.New("failed to parse Loki log timestamp " + value[0])
+			}
+			entries = append(entries, LogEntry{
+				Timestamp: time.Unix(0, ns).UTC(),
+				Message:   strings.TrimSuffix(value[1], "\n"),
+				Stream:    stream.Stream["stream"],
+				Container: stream.Stream["container"],
+				Namespace: stream.Stream["namespace"],
+				FuncName:  stream.Stream["functionName"],
+				FuncUid:   stream.Stream[loki.uidLabel],
+				Pod:       stream.Stream["pod"],
+			})
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a buffer the logs can be followed into while a test reads
+// them.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLoki(t *testing.T) {
+	stream := map[string]string{"functionUid": "uid", "pod": "hello-pod", "namespace": "default"}
+	var tailStart string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "tenant", r.Header.Get("X-Scope-OrgID"))
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/loki/api/v1/query_range":
+			require.Equal(t, `{functionUid="uid", pod="hello-pod"} |= "GET /" | detected_level=~"(?i)info"`, query.Get("query"))
+			require.Equal(t, "2", query.Get("limit"))
+			require.Equal(t, "backward", query.Get("direction"))
+			// the latest logs first
+			json.NewEncoder(w).Encode(map[string]any{ //nolint: errcheck
+				"status": "success",
+				"data": map[string]any{
+					"resultType": "streams",
+					"result": []any{map[string]any{
+						"stream": stream,
+						"values": [][2]string{{"2000000000", "GET /b\n"}, {"1000000000", "GET /a\n"}},
+					}},
+				},
+			})
+		case "/loki/api/v1/tail":
+			tailStart = query.Get("start")
+			conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer conn.Close()
+			require.NoError(t, conn.WriteJSON(map[string]any{
+				"streams": []any{map[string]any{
+					"stream": stream,
+					"values": [][2]string{{"3000000000", "GET /c"}},
+				}},
+			}))
+			// wait for the client to go
+			conn.ReadMessage() //nolint: errcheck
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(LOKI_URL, server.URL)
+	t.Setenv(LOKI_TENANT, "tenant")
+	logDB, err := GetLogDB(LOKI, t.Context(), LogDBOptions{})
+	require.NoError(t, err)
+
+	filter := LogFilter{
+		FuncUid:     "uid",
+		Pod:         "hello-pod",
+		RecordLimit: 2,
+		Grep:        "GET /",
+		Level:       "info",
+	}
+	var out bytes.Buffer
+	require.NoError(t, logDB.GetLogs(t.Context(), filter, &out))
+	require.Equal(t, "["+time.Unix(1, 0).UTC().String()+"] GET /a\n["+time.Unix(2, 0).UTC().String()+"] GET /b\n", out.String())
+
+	// following writes the latest logs, then tails from the last one
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var followed syncBuffer
+	done := make(chan error)
+	go func() {
+		done <- logDB.(LogFollower).FollowLogs(ctx, filter, &followed)
+	}()
+	require.Eventually(t, func() bool {
+		return strings.Contains(followed.String(), "GET /c")
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "2000000001", tailStart)
+	require.Equal(t, 3, strings.Count(followed.String(), "GET /"))
+	cancel()
+	require.NoError(t, <-done)
+}
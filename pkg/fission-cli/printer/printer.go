@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package printer prints the resources of get and list commands as tables,
+// or in the machine readable formats of the --output flag.
+package printer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	fissionscheme "github.com/fission/fission/pkg/generated/clientset/versioned/scheme"
+)
+
+const (
+	FormatTable      = ""
+	FormatWide       = "wide"
+	FormatJSON       = "json"
+	FormatYAML       = "yaml"
+	FormatJSONPath   = "jsonpath"
+	FormatGoTemplate = "go-template"
+)
+
+type (
+	// Printer prints resources in an output format.
+	Printer struct {
+		format   string
+		template string
+	}
+
+	// Table is the table output of resources. The cells of rows are in the
+	// order of the columns, wide ones included.
+	Table struct {
+		Columns []Column
+		Rows    [][]any
+	}
+
+	Column struct {
+		Header string
+		// Wide columns are only printed with the wide output format.
+		Wide bool
+	}
+)
+
+// scheme knows the kinds of the Fission and Kubernetes resources printed.
+var scheme = runtime.NewScheme()
+
+func init() {
+	err := fissionscheme.AddToScheme(scheme)
+	if err != nil {
+		panic(err)
+	}
+	err = clientgoscheme.AddToScheme(scheme)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// New returns a printer of the output format: json, yaml, wide,
+// jsonpath=TEMPLATE or go-template=TEMPLATE. Tables are printed if the
+// format is empty.
+func New(output string) (*Printer, error) {
+	format, tmpl, hasTemplate := strings.Cut(output, "=")
+	switch format {
+	case FormatTable, FormatWide, FormatJSON, FormatYAML:
+		if hasTemplate {
+			return nil, fmt.Errorf("output format %v doesn't take a template", format)
+		}
+	case FormatJSONPath:
+		if tmpl == "" {
+			return nil, errors.New("jsonpath output format needs a template: -o jsonpath={.metadata.name}")
+		}
+		// like kubectl, the braces of simple templates may be omitted
+		if !strings.Contains(tmpl, "{") {
+			tmpl = "{" + tmpl + "}"
+		}
+		err := jsonpath.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jsonpath template: %w", err)
+		}
+	case FormatGoTemplate:
+		if tmpl == "" {
+			return nil, errors.New("go-template output format needs a template: -o go-template={{.metadata.name}}")
+		}
+		_, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing go-template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format '%v', expected json, yaml, wide, jsonpath=TEMPLATE or go-template=TEMPLATE", output)
+	}
+	return &Printer{format: format, template: tmpl}, nil
+}
+
+// IsTable returns whether resources are printed as tables.
+func (p *Printer) IsTable() bool {
+	return p.format == FormatTable || p.format == FormatWide
+}
+
+// Print prints the object, which may be a list, or its table.
+func (p *Printer) Print(w io.Writer, obj runtime.Object, table *Table) error {
+	if p.IsTable() {
+		if table == nil {
+			return errors.New("no table output for the resource")
+		}
+		return table.Print(w, p.format == FormatWide)
+	}
+
+	obj, err := prepare(obj)
+	if err != nil {
+		return err
+	}
+	return p.encode(w, obj)
+}
+
+// PrintData prints data which isn't a resource, like the reports of Fission
+// services, or its table.
+func (p *Printer) PrintData(w io.Writer, data any, table *Table) error {
+	if p.IsTable() {
+		if table == nil {
+			return errors.New("no table output for the data")
+		}
+		return table.Print(w, p.format == FormatWide)
+	}
+	return p.encode(w, data)
+}
+
+// encode prints the object in the machine readable output format.
+func (p *Printer) encode(w io.Writer, obj any) error {
+	if p.format == FormatYAML {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	data, err := json.MarshalIndent(obj, "", "    ")
+	if err != nil {
+		return err
+	}
+	if p.format == FormatJSON {
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	// the templates are executed on the JSON of the object, so that they
+	// use the field names of the resource definitions
+	var value any
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if p.format == FormatJSONPath {
+		jp := jsonpath.New("output").AllowMissingKeys(true)
+		err = jp.Parse(p.template)
+		if err != nil {
+			return err
+		}
+		return jp.Execute(w, value)
+	}
+	tmpl, err := template.New("output").Parse(p.template)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, value)
+}
+
+// prepare sets the kinds of the object and its items, which clients leave
+// empty, and hides their managed fields like kubectl does. The items of lists
+// of resources of many kinds are encoded, as only their encoding is printed.
+func prepare(obj runtime.Object) (runtime.Object, error) {
+	obj = obj.DeepCopyObject()
+	items := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		listItems, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, listItems...)
+	}
+	for _, item := range items {
+		if item.GetObjectKind().GroupVersionKind().Empty() {
+			gvks, _, err := scheme.ObjectKinds(item)
+			if err == nil && len(gvks) > 0 {
+				item.GetObjectKind().SetGroupVersionKind(gvks[0])
+			}
+		}
+		if accessor, err := meta.Accessor(item); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+	}
+	if list, ok := obj.(*metav1.List); ok {
+		for i, item := range list.Items {
+			if item.Object == nil {
+				continue
+			}
+			data, err := json.Marshal(item.Object)
+			if err != nil {
+				return nil, err
+			}
+			list.Items[i].Raw = data
+		}
+	}
+	return obj, nil
+}
+
+// AddRow adds a row with the cells of the columns.
+func (t *Table) AddRow(cells ...any) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Print prints the table, with its wide columns if wide is set.
+func (t *Table) Print(w io.Writer, wide bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	var headers []string
+	for _, c := range t.Columns {
+		if wide || !c.Wide {
+			headers = append(headers, c.Header)
+		}
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range t.Rows {
+		var cells []string
+		for i, c := range t.Columns {
+			if i < len(row) && (wide || !c.Wide) {
+				cells = append(cells, fmt.Sprint(row[i]))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func testFunctions() (*fv1.FunctionList, *Table) {
+	fns := &fv1.FunctionList{Items: []fv1.Function{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "hello",
+				Namespace:     "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "fission"}},
+			},
+			Spec: fv1.FunctionSpec{Environment: fv1.EnvironmentReference{Name: "nodejs"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "world", Namespace: "default"},
+			Spec:       fv1.FunctionSpec{Environment: fv1.EnvironmentReference{Name: "python"}},
+		},
+	}}
+	table := &Table{Columns: []Column{{Header: "NAME"}, {Header: "ENV"}, {Header: "NAMESPACE", Wide: true}}}
+	for _, fn := range fns.Items {
+		table.AddRow(fn.Name, fn.Spec.Environment.Name, fn.Namespace)
+	}
+	return fns, table
+}
+
+func printOutput(t *testing.T, output string, obj runtime.Object, table *Table) string {
+	t.Helper()
+	p, err := New(output)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, p.Print(&out, obj, table))
+	return out.String()
+}
+
+func TestPrint(t *testing.T) {
+	fns, table := testFunctions()
+
+	require.Equal(t, "NAME  ENV\nhello nodejs\nworld python\n", printOutput(t, "", fns, table))
+	require.Equal(t, "NAME  ENV    NAMESPACE\nhello nodejs default\nworld python default\n", printOutput(t, "wide", fns, table))
+
+	var list map[string]any
+	require.NoError(t, json.Unmarshal([]byte(printOutput(t, "json", fns, table)), &list))
+	require.Equal(t, "FunctionList", list["kind"])
+	item := list["items"].([]any)[0].(map[string]any)
+	require.Equal(t, "Function", item["kind"])
+	require.Equal(t, "fission.io/v1", item["apiVersion"])
+	require.NotContains(t, item["metadata"], "managedFields")
+	// the printed object is left as it was
+	require.NotEmpty(t, fns.Items[0].ManagedFields)
+
+	yaml := printOutput(t, "yaml", &fns.Items[0], nil)
+	require.Contains(t, yaml, "kind: Function\n")
+	require.Contains(t, yaml, "  name: hello\n")
+
+	require.Equal(t, "hello world", printOutput(t, "jsonpath={.items[*].metadata.name}", fns, nil))
+	require.Equal(t, "hello", printOutput(t, "jsonpath=.metadata.name", &fns.Items[0], nil))
+	require.Equal(t, "hello:nodejs,world:python,",
+		printOutput(t, "go-template={{range .items}}{{.metadata.name}}:{{.spec.environment.name}},{{end}}", fns, nil))
+}
+
+func TestPrintList(t *testing.T) {
+	fns, _ := testFunctions()
+	env := &fv1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: "default"}}
+	list := &metav1.List{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+		Items:    []runtime.RawExtension{{Object: &fns.Items[0]}, {Object: env}},
+	}
+	require.Equal(t, "Function/hello Environment/nodejs ", printOutput(t, `jsonpath={range .items[*]}{.kind}/{.metadata.name}{" "}{end}`, list, nil))
+}
+
+func TestPrintData(t *testing.T) {
+	type report struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	data := []report{{Name: "hello", Count: 2}, {Name: "world", Count: 1}}
+	table := &Table{Columns: []Column{{Header: "NAME"}, {Header: "COUNT", Wide: true}}}
+	for _, r := range data {
+		table.AddRow(r.Name, r.Count)
+	}
+
+	print := func(output string) string {
+		p, err := New(output)
+		require.NoError(t, err)
+		var out bytes.Buffer
+		require.NoError(t, p.PrintData(&out, data, table))
+		return out.String()
+	}
+	require.Equal(t, "NAME\nhello\nworld\n", print(""))
+	require.Equal(t, "NAME  COUNT\nhello 2\nworld 1\n", print("wide"))
+	require.JSONEq(t, `[{"name":"hello","count":2},{"name":"world","count":1}]`, print("json"))
+	require.Equal(t, "- count: 2\n  name: hello\n- count: 1\n  name: world\n", print("yaml"))
+	require.Equal(t, "hello world", print("jsonpath={[*].name}"))
+}
+
+func TestNew(t *testing.T) {
+	for _, output := range []string{"", "wide", "json", "yaml", "jsonpath={.metadata.name}", "go-template={{.metadata.name}}"} {
+		_, err := New(output)
+		require.NoError(t, err, output)
+	}
+	for _, output := range []string{"xml", "json=x", "jsonpath", "jsonpath={.metadata", "go-template=", "go-template={{.metadata"} {
+		_, err := New(output)
+		require.Error(t, err, output)
+	}
+
+	p, err := New("json")
+	require.NoError(t, err)
+	require.False(t, p.IsTable())
+	require.Error(t, (&Printer{}).Print(&bytes.Buffer{}, &fv1.Function{}, nil))
+}
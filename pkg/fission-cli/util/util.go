@@ -49,6 +49,7 @@ import (
 	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
 	"github.com/fission/fission/pkg/info"
 	"github.com/fission/fission/pkg/plugin"
+	storagesvcClient "github.com/fission/fission/pkg/storagesvc/client"
 	"github.com/fission/fission/pkg/utils"
 )
 
@@ -233,6 +234,36 @@ func GetRouterURL(ctx context.Context, cmdClient cmd.Client) (serverURL *url.URL
 	return serverURL, err
 }
 
+// GetExecutorURL returns the URL of the executor, port-forwarding to it
+// unless FISSION_EXECUTOR_URL is set.
+func GetExecutorURL(ctx context.Context, client cmd.Client) (*url.URL, error) {
+	executorURL := os.Getenv("FISSION_EXECUTOR_URL")
+	if len(executorURL) > 0 {
+		return url.Parse(executorURL)
+	}
+	executorLocalPort, err := SetupPortForward(ctx, client, GetFissionNamespace(), "application=fission-executor")
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("%s%s", localhostURL, executorLocalPort))
+}
+
+// GetBuilderURL returns the URL of the builder of the environment,
+// port-forwarding to the builder pod.
+func GetBuilderURL(ctx context.Context, client cmd.Client, env *fv1.Environment) (*url.URL, error) {
+	// builder pods may live in a different namespace than the environment,
+	// the resource version identifies the builder of the environment
+	selector := labels.Set{
+		fv1.LABEL_ENV_NAME:            env.Name,
+		fv1.LABEL_ENV_RESOURCEVERSION: env.ResourceVersion,
+	}
+	builderLocalPort, err := SetupPortForward(ctx, client, metav1.NamespaceAll, selector.String())
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("%s%s", localhostURL, builderLocalPort))
+}
+
 func GetResourceReqs(input cli.Input, resReqs *v1.ResourceRequirements) (*v1.ResourceRequirements, error) {
 	r := &v1.ResourceRequirements{}
 
@@ -467,6 +498,16 @@ func GetStorageURL(ctx context.Context, client cmd.Client) (*url.URL, error) {
 	return serverURL, nil
 }
 
+// GetStorageClient returns a client of the storage service, authenticated
+// with the credentials of the Kubernetes client.
+func GetStorageClient(ctx context.Context, client cmd.Client) (storagesvcClient.ClientInterface, error) {
+	storagesvcURL, err := GetStorageURL(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return storagesvcClient.MakeAuthenticatedClient(storagesvcURL.String(), client.RestConfig)
+}
+
 // CheckHTTPTriggerDuplicates checks whether the tuple (Method, Host, URL) is duplicate or not.
 func CheckHTTPTriggerDuplicates(ctx context.Context, client cmd.Client, t *fv1.HTTPTrigger) error {
 	triggers, err := client.FissionClientSet.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
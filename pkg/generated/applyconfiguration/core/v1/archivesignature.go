@@ -0,0 +1,48 @@
+/*
+Copyright The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ArchiveSignatureApplyConfiguration represents a declarative configuration of the ArchiveSignature type for use
+// with apply.
+type ArchiveSignatureApplyConfiguration struct {
+	Signature *string `json:"signature,omitempty"`
+	KeyID     *string `json:"keyID,omitempty"`
+}
+
+// ArchiveSignatureApplyConfiguration constructs a declarative configuration of the ArchiveSignature type for use with
+// apply.
+func ArchiveSignature() *ArchiveSignatureApplyConfiguration {
+	return &ArchiveSignatureApplyConfiguration{}
+}
+
+// WithSignature sets the Signature field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Signature field is set to the value of the last call.
+func (b *ArchiveSignatureApplyConfiguration) WithSignature(value string) *ArchiveSignatureApplyConfiguration {
+	b.Signature = &value
+	return b
+}
+
+// WithKeyID sets the KeyID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyID field is set to the value of the last call.
+func (b *ArchiveSignatureApplyConfiguration) WithKeyID(value string) *ArchiveSignatureApplyConfiguration {
+	b.KeyID = &value
+	return b
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// BuildCacheApplyConfiguration represents a declarative configuration of the BuildCache type for use
+// with apply.
+type BuildCacheApplyConfiguration struct {
+	Paths                 []string           `json:"paths,omitempty"`
+	KeyFiles              []string           `json:"keyFiles,omitempty"`
+	MaxSize               *resource.Quantity `json:"maxSize,omitempty"`
+	PersistentVolumeClaim *string            `json:"persistentVolumeClaim,omitempty"`
+}
+
+// BuildCacheApplyConfiguration constructs a declarative configuration of the BuildCache type for use with
+// apply.
+func BuildCache() *BuildCacheApplyConfiguration {
+	return &BuildCacheApplyConfiguration{}
+}
+
+// WithPaths adds the given value to the Paths field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Paths field.
+func (b *BuildCacheApplyConfiguration) WithPaths(values ...string) *BuildCacheApplyConfiguration {
+	for i := range values {
+		b.Paths = append(b.Paths, values[i])
+	}
+	return b
+}
+
+// WithKeyFiles adds the given value to the KeyFiles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the KeyFiles field.
+func (b *BuildCacheApplyConfiguration) WithKeyFiles(values ...string) *BuildCacheApplyConfiguration {
+	for i := range values {
+		b.KeyFiles = append(b.KeyFiles, values[i])
+	}
+	return b
+}
+
+// WithMaxSize sets the MaxSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSize field is set to the value of the last call.
+func (b *BuildCacheApplyConfiguration) WithMaxSize(value resource.Quantity) *BuildCacheApplyConfiguration {
+	b.MaxSize = &value
+	return b
+}
+
+// WithPersistentVolumeClaim sets the PersistentVolumeClaim field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PersistentVolumeClaim field is set to the value of the last call.
+func (b *BuildCacheApplyConfiguration) WithPersistentVolumeClaim(value string) *BuildCacheApplyConfiguration {
+	b.PersistentVolumeClaim = &value
+	return b
+}
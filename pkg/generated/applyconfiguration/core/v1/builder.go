@@ -19,16 +19,20 @@ limitations under the License.
 package v1
 
 import (
-	corev1 "k8s.io/api/core/v1"
+	corev1 "github.com/fission/fission/pkg/apis/core/v1"
+	apicorev1 "k8s.io/api/core/v1"
 )
 
 // BuilderApplyConfiguration represents a declarative configuration of the Builder type for use
 // with apply.
 type BuilderApplyConfiguration struct {
-	Image     *string           `json:"image,omitempty"`
-	Command   *string           `json:"command,omitempty"`
-	Container *corev1.Container `json:"container,omitempty"`
-	PodSpec   *corev1.PodSpec   `json:"podspec,omitempty"`
+	Image        *string                       `json:"image,omitempty"`
+	Command      *string                       `json:"command,omitempty"`
+	Mode         *corev1.BuilderMode           `json:"mode,omitempty"`
+	BuildTimeout *int                          `json:"buildTimeout,omitempty"`
+	Cache        *BuildCacheApplyConfiguration `json:"cache,omitempty"`
+	Container    *apicorev1.Container          `json:"container,omitempty"`
+	PodSpec      *apicorev1.PodSpec            `json:"podspec,omitempty"`
 }
 
 // BuilderApplyConfiguration constructs a declarative configuration of the Builder type for use with
@@ -53,10 +57,34 @@ func (b *BuilderApplyConfiguration) WithCommand(value string) *BuilderApplyConfi
 	return b
 }
 
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithMode(value corev1.BuilderMode) *BuilderApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithBuildTimeout sets the BuildTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildTimeout field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithBuildTimeout(value int) *BuilderApplyConfiguration {
+	b.BuildTimeout = &value
+	return b
+}
+
+// WithCache sets the Cache field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cache field is set to the value of the last call.
+func (b *BuilderApplyConfiguration) WithCache(value *BuildCacheApplyConfiguration) *BuilderApplyConfiguration {
+	b.Cache = value
+	return b
+}
+
 // WithContainer sets the Container field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Container field is set to the value of the last call.
-func (b *BuilderApplyConfiguration) WithContainer(value corev1.Container) *BuilderApplyConfiguration {
+func (b *BuilderApplyConfiguration) WithContainer(value apicorev1.Container) *BuilderApplyConfiguration {
 	b.Container = &value
 	return b
 }
@@ -64,7 +92,7 @@ func (b *BuilderApplyConfiguration) WithContainer(value corev1.Container) *Build
 // WithPodSpec sets the PodSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PodSpec field is set to the value of the last call.
-func (b *BuilderApplyConfiguration) WithPodSpec(value corev1.PodSpec) *BuilderApplyConfiguration {
+func (b *BuilderApplyConfiguration) WithPodSpec(value apicorev1.PodSpec) *BuilderApplyConfiguration {
 	b.PodSpec = &value
 	return b
 }
@@ -26,16 +26,18 @@ import (
 // EnvironmentSpecApplyConfiguration represents a declarative configuration of the EnvironmentSpec type for use
 // with apply.
 type EnvironmentSpecApplyConfiguration struct {
-	Version                      *int                                 `json:"version,omitempty"`
-	Runtime                      *RuntimeApplyConfiguration           `json:"runtime,omitempty"`
-	Builder                      *BuilderApplyConfiguration           `json:"builder,omitempty"`
-	AllowedFunctionsPerContainer *corev1.AllowedFunctionsPerContainer `json:"allowedFunctionsPerContainer,omitempty"`
-	AllowAccessToExternalNetwork *bool                                `json:"allowAccessToExternalNetwork,omitempty"`
-	Resources                    *apicorev1.ResourceRequirements      `json:"resources,omitempty"`
-	Poolsize                     *int                                 `json:"poolsize,omitempty"`
-	TerminationGracePeriod       *int64                               `json:"terminationGracePeriod,omitempty"`
-	KeepArchive                  *bool                                `json:"keeparchive,omitempty"`
-	ImagePullSecret              *string                              `json:"imagepullsecret,omitempty"`
+	Version                      *int                                   `json:"version,omitempty"`
+	Runtime                      *RuntimeApplyConfiguration             `json:"runtime,omitempty"`
+	Builder                      *BuilderApplyConfiguration             `json:"builder,omitempty"`
+	AllowedFunctionsPerContainer *corev1.AllowedFunctionsPerContainer   `json:"allowedFunctionsPerContainer,omitempty"`
+	AllowAccessToExternalNetwork *bool                                  `json:"allowAccessToExternalNetwork,omitempty"`
+	Resources                    *apicorev1.ResourceRequirements        `json:"resources,omitempty"`
+	Poolsize                     *int                                   `json:"poolsize,omitempty"`
+	TerminationGracePeriod       *int64                                 `json:"terminationGracePeriod,omitempty"`
+	KeepArchive                  *bool                                  `json:"keeparchive,omitempty"`
+	ImagePullSecret              *string                                `json:"imagepullsecret,omitempty"`
+	RecyclePods                  *bool                                  `json:"recyclePods,omitempty"`
+	PackageVerification          *PackageVerificationApplyConfiguration `json:"packageVerification,omitempty"`
 }
 
 // EnvironmentSpecApplyConfiguration constructs a declarative configuration of the EnvironmentSpec type for use with
@@ -123,3 +125,19 @@ func (b *EnvironmentSpecApplyConfiguration) WithImagePullSecret(value string) *E
 	b.ImagePullSecret = &value
 	return b
 }
+
+// WithRecyclePods sets the RecyclePods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecyclePods field is set to the value of the last call.
+func (b *EnvironmentSpecApplyConfiguration) WithRecyclePods(value bool) *EnvironmentSpecApplyConfiguration {
+	b.RecyclePods = &value
+	return b
+}
+
+// WithPackageVerification sets the PackageVerification field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PackageVerification field is set to the value of the last call.
+func (b *EnvironmentSpecApplyConfiguration) WithPackageVerification(value *PackageVerificationApplyConfiguration) *EnvironmentSpecApplyConfiguration {
+	b.PackageVerification = value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// GitSourceApplyConfiguration represents a declarative configuration of the GitSource type for use
+// with apply.
+type GitSourceApplyConfiguration struct {
+	URL     *string `json:"url,omitempty"`
+	Ref     *string `json:"ref,omitempty"`
+	SubPath *string `json:"subPath,omitempty"`
+	Secret  *string `json:"secret,omitempty"`
+}
+
+// GitSourceApplyConfiguration constructs a declarative configuration of the GitSource type for use with
+// apply.
+func GitSource() *GitSourceApplyConfiguration {
+	return &GitSourceApplyConfiguration{}
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *GitSourceApplyConfiguration) WithURL(value string) *GitSourceApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithRef sets the Ref field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ref field is set to the value of the last call.
+func (b *GitSourceApplyConfiguration) WithRef(value string) *GitSourceApplyConfiguration {
+	b.Ref = &value
+	return b
+}
+
+// WithSubPath sets the SubPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubPath field is set to the value of the last call.
+func (b *GitSourceApplyConfiguration) WithSubPath(value string) *GitSourceApplyConfiguration {
+	b.SubPath = &value
+	return b
+}
+
+// WithSecret sets the Secret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Secret field is set to the value of the last call.
+func (b *GitSourceApplyConfiguration) WithSecret(value string) *GitSourceApplyConfiguration {
+	b.Secret = &value
+	return b
+}
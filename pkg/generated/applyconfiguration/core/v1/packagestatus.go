@@ -28,6 +28,8 @@ import (
 type PackageStatusApplyConfiguration struct {
 	BuildStatus         *corev1.BuildStatus `json:"buildstatus,omitempty"`
 	BuildLog            *string             `json:"buildlog,omitempty"`
+	BuildLogURL         *string             `json:"buildLogUrl,omitempty"`
+	SourceCommit        *string             `json:"sourceCommit,omitempty"`
 	LastUpdateTimestamp *metav1.Time        `json:"lastUpdateTimestamp,omitempty"`
 }
 
@@ -53,6 +55,22 @@ func (b *PackageStatusApplyConfiguration) WithBuildLog(value string) *PackageSta
 	return b
 }
 
+// WithBuildLogURL sets the BuildLogURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BuildLogURL field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithBuildLogURL(value string) *PackageStatusApplyConfiguration {
+	b.BuildLogURL = &value
+	return b
+}
+
+// WithSourceCommit sets the SourceCommit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SourceCommit field is set to the value of the last call.
+func (b *PackageStatusApplyConfiguration) WithSourceCommit(value string) *PackageStatusApplyConfiguration {
+	b.SourceCommit = &value
+	return b
+}
+
 // WithLastUpdateTimestamp sets the LastUpdateTimestamp field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LastUpdateTimestamp field is set to the value of the last call.
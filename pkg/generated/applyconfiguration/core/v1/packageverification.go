@@ -0,0 +1,52 @@
+/*
+Copyright The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	corev1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// PackageVerificationApplyConfiguration represents a declarative configuration of the PackageVerification type for use
+// with apply.
+type PackageVerificationApplyConfiguration struct {
+	Policy      *corev1.VerificationPolicy `json:"policy,omitempty"`
+	TrustedKeys *string                    `json:"trustedKeys,omitempty"`
+}
+
+// PackageVerificationApplyConfiguration constructs a declarative configuration of the PackageVerification type for use with
+// apply.
+func PackageVerification() *PackageVerificationApplyConfiguration {
+	return &PackageVerificationApplyConfiguration{}
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *PackageVerificationApplyConfiguration) WithPolicy(value corev1.VerificationPolicy) *PackageVerificationApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithTrustedKeys sets the TrustedKeys field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrustedKeys field is set to the value of the last call.
+func (b *PackageVerificationApplyConfiguration) WithTrustedKeys(value string) *PackageVerificationApplyConfiguration {
+	b.TrustedKeys = &value
+	return b
+}
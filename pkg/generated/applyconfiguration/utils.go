@@ -34,6 +34,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=fission.io, Version=v1
 	case v1.SchemeGroupVersion.WithKind("Archive"):
 		return &corev1.ArchiveApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ArchiveSignature"):
+		return &corev1.ArchiveSignatureApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BuildCache"):
+		return &corev1.BuildCacheApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Builder"):
 		return &corev1.BuilderApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CanaryConfig"):
@@ -62,6 +66,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.FunctionReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FunctionSpec"):
 		return &corev1.FunctionSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GitSource"):
+		return &corev1.GitSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HTTPTrigger"):
 		return &corev1.HTTPTriggerApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HTTPTriggerSpec"):
@@ -86,6 +92,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &corev1.PackageSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PackageStatus"):
 		return &corev1.PackageStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PackageVerification"):
+		return &corev1.PackageVerificationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Runtime"):
 		return &corev1.RuntimeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SecretReference"):
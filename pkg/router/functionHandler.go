@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"path"
 	"strings"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -186,6 +188,8 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 	var retryCounter int
 	var err error
 	var fnMeta = &roundTripper.funcHandler.function.ObjectMeta
+	var requestPath = req.URL.Path
+	var isJob = roundTripper.funcHandler.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeJob
 
 	logger := otelUtils.LoggerWithTraceID(ctx, roundTripper.logger).With(zap.String("function", fnMeta.Name), zap.String("namespace", fnMeta.Namespace))
 
@@ -288,6 +292,20 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 				req.URL.Path = "/"
 			}
 
+			// Job functions don't serve HTTP, requests are sent to the
+			// job API of the executor instead.
+			if isJob {
+				subPath := "/"
+				if prefixTrim != "" {
+					subPath = strings.TrimPrefix(requestPath, prefixTrim)
+				}
+				jobPath, status := jobInvocationPath(fnMeta, req.Method, subPath)
+				if status != http.StatusOK {
+					return jobErrorResponse(req, status), nil
+				}
+				req.URL.Path = jobPath
+			}
+
 			logger.Debug("function invoke url",
 				zap.String("prefixTrim", prefixTrim),
 				zap.Bool("keepPrefix", keepPrefix),
@@ -325,6 +343,12 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 			dumpRespFunc(resp)
 		}
 		if err == nil {
+			if isJob && resp.StatusCode == http.StatusAccepted {
+				// point the client to the invocation through the router
+				if location := resp.Header.Get("Location"); location != "" {
+					resp.Header.Set("Location", jobLocation(requestPath, location))
+				}
+			}
 			// return response back to user
 			return resp, nil
 		}
@@ -388,6 +412,51 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 	return nil, e
 }
 
+// jobInvocationPath returns the executor job API path of a request to a job
+// function, given the path of the request relative to the function. A POST
+// to the function submits an invocation, a GET of /<id> returns the
+// invocation and a GET of /<id>/logs streams its logs. Otherwise, it returns
+// the HTTP status to reject the request with.
+func jobInvocationPath(fnMeta *metav1.ObjectMeta, method, subPath string) (string, int) {
+	jobURL := utils.UrlForJobInvocation(fnMeta.Name, fnMeta.Namespace)
+	parts := strings.Split(strings.Trim(subPath, "/"), "/")
+	switch {
+	case parts[0] == "":
+		if method != http.MethodPost {
+			return "", http.StatusMethodNotAllowed
+		}
+		return jobURL, http.StatusOK
+	case len(parts) == 1, len(parts) == 2 && parts[1] == "logs":
+		if method != http.MethodGet {
+			return "", http.StatusMethodNotAllowed
+		}
+		return jobURL + "/" + strings.Join(parts, "/"), http.StatusOK
+	default:
+		return "", http.StatusNotFound
+	}
+}
+
+// jobLocation returns the router path of a submitted job invocation. The
+// executor responds with the location of the invocation in its job API,
+// which isn't reachable by clients of the router.
+func jobLocation(requestPath, executorLocation string) string {
+	return strings.TrimSuffix(requestPath, "/") + "/" + path.Base(executorLocation)
+}
+
+func jobErrorResponse(req *http.Request, status int) *http.Response {
+	msg := fmt.Sprintf("job functions accept POST to submit an invocation and GET of <function url>/<id>[/logs]: %s", http.StatusText(status))
+	return &http.Response{
+		StatusCode:    status,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Body:          io.NopCloser(bytes.NewBufferString(msg)),
+		ContentLength: int64(len(msg)),
+		Request:       req,
+		Header:        make(http.Header),
+	}
+}
+
 // getDefaultTransport returns a pointer to new copy of http.Transport object to prevent
 // the value of http.DefaultTransport from being changed by goroutines.
 func (roundTripper RetryingRoundTripper) getDefaultTransport() *http.Transport {
@@ -19,17 +19,24 @@ package router
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
 )
 
 func TestProxyErrorHandler(t *testing.T) {
@@ -67,3 +74,77 @@ func TestProxyErrorHandler(t *testing.T) {
 	errHandler(respRecorder, req, errors.New("dummy"))
 	assert.Equal(t, http.StatusInternalServerError, respRecorder.Code)
 }
+
+func TestJobFunctionRouting(t *testing.T) {
+	// executor job API, the invocation succeeds on the second poll
+	var polls atomic.Int32
+	executorRouter := mux.NewRouter()
+	executorRouter.HandleFunc("/v2/jobs/{namespace}/{function}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", r.URL.Path+"/job-1")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"id":"job-1","status":"queued"}`)
+	}).Methods(http.MethodPost)
+	executorRouter.HandleFunc("/v2/jobs/{namespace}/{function}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if polls.Add(1) < 2 {
+			fmt.Fprintf(w, `{"id":"%s","status":"running"}`, mux.Vars(r)["id"])
+			return
+		}
+		fmt.Fprintf(w, `{"id":"%s","status":"succeeded","result":"done"}`, mux.Vars(r)["id"])
+	}).Methods(http.MethodGet)
+	executorRouter.HandleFunc("/v2/jobs/{namespace}/{function}/{id}/logs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "logs of %s", mux.Vars(r)["id"])
+	}).Methods(http.MethodGet)
+	executorServer := httptest.NewServer(executorRouter)
+	defer executorServer.Close()
+
+	lr := NewLocalRouter(loggerfactory.GetLogger(), &staticExecutor{addr: executorServer.Listener.Addr().String()})
+	fn := fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "jobs"},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeJob},
+			},
+		},
+	}
+	require.NoError(t, lr.Update(nil, []fv1.Function{fn}))
+
+	serve := func(method string, path string) *http.Response {
+		w := httptest.NewRecorder()
+		lr.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader("input")))
+		return w.Result()
+	}
+	readBody := func(resp *http.Response) string {
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	resp := serve(http.MethodPost, "/fission-function/jobs/batch")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	require.Equal(t, "/fission-function/jobs/batch/job-1", location)
+
+	// the invocation is polled through the router until it's done
+	var body string
+	require.Eventually(t, func() bool {
+		resp = serve(http.MethodGet, location)
+		if resp.StatusCode != http.StatusOK {
+			return false
+		}
+		body = readBody(resp)
+		return strings.Contains(body, `"status":"succeeded"`)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Contains(t, body, `"result":"done"`)
+
+	resp = serve(http.MethodGet, location+"/logs")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "logs of job-1", readBody(resp))
+
+	// a GET of the function doesn't submit an invocation
+	resp = serve(http.MethodGet, "/fission-function/jobs/batch")
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp = serve(http.MethodPost, location)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp = serve(http.MethodGet, location+"/logs/more")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"time"
+
+	"github.com/bep/debounce"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	eclient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/throttler"
+)
+
+// LocalRouter routes requests to functions the same way the router does,
+// but from the triggers and functions it's given instead of from the
+// cluster. It's used to serve functions running on a workstation.
+type LocalRouter struct {
+	*mutableRouter
+
+	logger   *zap.Logger
+	executor eclient.ClientInterface
+}
+
+// NewLocalRouter returns a LocalRouter that gets function addresses from
+// executor. It serves no triggers until Update is called.
+func NewLocalRouter(logger *zap.Logger, executor eclient.ClientInterface) *LocalRouter {
+	return &LocalRouter{
+		mutableRouter: newMutableRouter(logger, mux.NewRouter()),
+		logger:        logger.Named("local_router"),
+		executor:      executor,
+	}
+}
+
+// Update replaces the routes of the router with the ones of triggers and
+// functions.
+func (lr *LocalRouter) Update(triggers []fv1.HTTPTrigger, functions []fv1.Function) error {
+	funcInformer := make(map[string]k8sCache.SharedIndexInformer)
+	functionTimeout := make(map[types.UID]int)
+	fns := make([]fv1.Function, 0, len(functions))
+	for _, f := range functions {
+		fn := *f.DeepCopy()
+		// functions read from specs have no UID, which function
+		// timeouts are looked up by
+		if fn.UID == "" {
+			fn.UID = types.UID(fn.Namespace + "/" + fn.Name)
+		}
+		informer, ok := funcInformer[fn.Namespace]
+		if !ok {
+			informer = k8sCache.NewSharedIndexInformer(&k8sCache.ListWatch{}, &fv1.Function{}, 0, k8sCache.Indexers{})
+			funcInformer[fn.Namespace] = informer
+		}
+		err := informer.GetStore().Add(&fn)
+		if err != nil {
+			return err
+		}
+		functionTimeout[fn.UID] = fn.Spec.FunctionTimeout
+		fns = append(fns, fn)
+	}
+
+	ts := &HTTPTriggerSet{
+		logger:             lr.logger,
+		functionServiceMap: makeFunctionServiceMap(lr.logger, time.Minute),
+		mutableRouter:      lr.mutableRouter,
+		executor:           lr.executor,
+		resolver:           makeFunctionReferenceResolver(lr.logger, funcInformer),
+		triggers:           triggers,
+		functions:          fns,
+		funcInformer:       funcInformer,
+		// the defaults of the helm chart
+		tsRoundTripperParams: &tsRoundTripperParams{
+			timeout:           50 * time.Millisecond,
+			timeoutExponent:   2,
+			keepAliveTime:     30 * time.Second,
+			maxRetries:        10,
+			svcAddrRetryCount: 5,
+		},
+		svcAddrUpdateThrottler: throttler.MakeThrottler(30 * time.Second),
+		unTapServiceTimeout:    time.Hour,
+		syncDebouncer:          debounce.New(time.Millisecond * 20),
+	}
+	router, err := ts.getRouter(functionTimeout)
+	if err != nil {
+		return err
+	}
+	lr.updateRouter(router)
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// staticExecutor returns the same address for all functions.
+type staticExecutor struct {
+	addr string
+}
+
+func (e *staticExecutor) GetServiceForFunction(ctx context.Context, fn *fv1.Function) (string, error) {
+	return e.addr, nil
+}
+
+func (e *staticExecutor) TapService(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL url.URL) {
+}
+
+func (e *staticExecutor) UnTapService(ctx context.Context, fnMeta metav1.ObjectMeta, executorType fv1.ExecutorType, serviceURL *url.URL) error {
+	return nil
+}
+
+func TestLocalRouter(t *testing.T) {
+	fnServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%v %v", r.Method, r.URL.Path)
+	}))
+	defer fnServer.Close()
+
+	lr := NewLocalRouter(loggerfactory.GetLogger(), &staticExecutor{addr: fnServer.Listener.Addr().String()})
+	functions := []fv1.Function{{ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault}}}
+	triggers := []fv1.HTTPTrigger{{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL: "/hello",
+			Methods:     []string{http.MethodPost},
+			FunctionReference: fv1.FunctionReference{
+				Type: fv1.FunctionReferenceTypeFunctionName,
+				Name: "hello",
+			},
+		},
+	}}
+	require.NoError(t, lr.Update(triggers, functions))
+
+	serve := func(method string, path string) (int, string) {
+		w := httptest.NewRecorder()
+		lr.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		body, err := io.ReadAll(w.Result().Body)
+		require.NoError(t, err)
+		return w.Code, string(body)
+	}
+
+	code, body := serve(http.MethodPost, "/hello")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "POST /", body)
+
+	// the method of the trigger is matched
+	code, _ = serve(http.MethodGet, "/hello")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+
+	// functions are served by name, for the other triggers
+	code, body = serve(http.MethodGet, "/fission-function/hello/sub")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "GET /sub", body)
+
+	// removed triggers aren't routed anymore
+	require.NoError(t, lr.Update(nil, functions))
+	code, _ = serve(http.MethodPost, "/hello")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -19,26 +19,33 @@ package storagesvc
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
 
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
-	"github.com/fission/fission/pkg/generated/clientset/versioned"
 	"github.com/fission/fission/pkg/utils"
 	"github.com/fission/fission/pkg/utils/manager"
 )
 
 type ArchivePruner struct {
 	logger        *zap.Logger
-	crdClient     versioned.Interface
+	pkgInformer   map[string]k8sCache.SharedIndexInformer
 	archiveChan   chan string
 	stowClient    *StowClient
 	pruneInterval time.Duration
 }
 
-const defaultPruneInterval int = 60 // in minutes
+const (
+	defaultPruneInterval int = 60 // in minutes
+
+	// archiveGracePeriod is the time an unreferenced archive is kept after
+	// its upload, to let the uploader create the package referencing it.
+	archiveGracePeriod = time.Minute
+)
 
 func MakeArchivePruner(logger *zap.Logger, clientGen crd.ClientGeneratorInterface, stowClient *StowClient, pruneInterval time.Duration) (*ArchivePruner, error) {
 	fissionClient, err := clientGen.GetFissionClient()
@@ -48,7 +55,7 @@ func MakeArchivePruner(logger *zap.Logger, clientGen crd.ClientGeneratorInterfac
 
 	return &ArchivePruner{
 		logger:        logger.Named("archive_pruner"),
-		crdClient:     fissionClient,
+		pkgInformer:   utils.GetInformersForNamespaces(fissionClient, time.Minute*30, fv1.PackagesResource),
 		archiveChan:   make(chan string),
 		stowClient:    stowClient,
 		pruneInterval: pruneInterval,
@@ -83,74 +90,219 @@ func (pruner *ArchivePruner) insertArchive(archiveID string) {
 	pruner.archiveChan <- archiveID
 }
 
-// A user may have deleted pkgs with kubectl or fission cli. That only deletes crd.Package objects from kubernetes
-// and not the archives that are referenced by them, leaving the archives as orphans.
-// getOrphanArchives reaps the orphaned archives.
-func (pruner *ArchivePruner) getOrphanArchives(ctx context.Context) {
-	pruner.logger.Debug("getting orphan archives")
-	archivesRefByPkgs := make([]string, 0)
-	var archiveID string
+func packageRef(pkg *fv1.Package) string {
+	return pkg.Namespace + "/" + pkg.Name
+}
 
-	// get all pkgs from kubernetes
-	for _, namespace := range utils.DefaultNSResolver().FissionResourceNS {
-		pkgList, err := pruner.crdClient.CoreV1().Packages(namespace).List(ctx, metav1.ListOptions{})
+// getPackageArchiveIDs returns the IDs of the archives referenced by a package.
+func (pruner *ArchivePruner) getPackageArchiveIDs(pkg *fv1.Package) []string {
+	var archiveIDs []string
+	for _, archiveURL := range []string{pkg.Spec.Deployment.URL, pkg.Spec.Source.URL, pkg.Status.BuildLogURL} {
+		if archiveURL == "" {
+			continue
+		}
+		archiveID, err := getQueryParamValue(archiveURL, "id")
 		if err != nil {
-			pruner.logger.Error("error getting package list from kubernetes", zap.Error(err))
-			return
+			pruner.logger.Error("error extracting value of archiveID from package url",
+				zap.Error(err),
+				zap.String("package", packageRef(pkg)),
+				zap.String("url", archiveURL))
+			continue
+		}
+		if archiveID != "" && !slices.Contains(archiveIDs, archiveID) {
+			archiveIDs = append(archiveIDs, archiveID)
+		}
+	}
+	return archiveIDs
+}
+
+// addPackageRefs records the package as a reference of the archives it uses.
+func (pruner *ArchivePruner) addPackageRefs(pkg *fv1.Package, archiveIDs []string) {
+	for _, archiveID := range archiveIDs {
+		if !isContentAddressed(archiveID) {
+			continue
+		}
+		err := pruner.stowClient.addPackageRef(archiveID, packageRef(pkg))
+		if err != nil && err != ErrNotFound {
+			pruner.logger.Error("error adding package reference to archive",
+				zap.Error(err),
+				zap.String("package", packageRef(pkg)),
+				zap.String("archive_id", archiveID))
 		}
+	}
+}
+
+// removePackageRefs drops the package from the references of the archives.
+func (pruner *ArchivePruner) removePackageRefs(pkg *fv1.Package, archiveIDs []string) {
+	ref := packageRef(pkg)
+	for _, archiveID := range archiveIDs {
+		if !isContentAddressed(archiveID) {
+			continue
+		}
+		err := pruner.stowClient.removePackageRefs(archiveID, func(pkgRef string) bool {
+			return pkgRef == ref
+		})
+		if err != nil && err != ErrNotFound {
+			pruner.logger.Error("error removing package reference from archive",
+				zap.Error(err),
+				zap.String("package", ref),
+				zap.String("archive_id", archiveID))
+		}
+	}
+}
 
-		// extract archives referenced by these pkgs
-		for _, pkg := range pkgList.Items {
-			if pkg.Spec.Deployment.URL != "" {
-				archiveID, err = getQueryParamValue(pkg.Spec.Deployment.URL, "id")
-				if err != nil {
-					pruner.logger.Error("error extracting value of archiveID from deployment url",
-						zap.Error(err),
-						zap.String("url", pkg.Spec.Deployment.URL))
-					return
+// packageInformerHandler keeps the package references of the archives in
+// sync with the packages.
+func (pruner *ArchivePruner) packageInformerHandler() k8sCache.ResourceEventHandlerFuncs {
+	return k8sCache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pkg := obj.(*fv1.Package)
+			pruner.addPackageRefs(pkg, pruner.getPackageArchiveIDs(pkg))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPkg := oldObj.(*fv1.Package)
+			pkg := newObj.(*fv1.Package)
+			archiveIDs := pruner.getPackageArchiveIDs(pkg)
+			pruner.addPackageRefs(pkg, archiveIDs)
+
+			var unused []string
+			for _, archiveID := range pruner.getPackageArchiveIDs(oldPkg) {
+				if !slices.Contains(archiveIDs, archiveID) {
+					unused = append(unused, archiveID)
 				}
-				archivesRefByPkgs = append(archivesRefByPkgs, archiveID)
 			}
-			if pkg.Spec.Source.URL != "" {
-				archiveID, err = getQueryParamValue(pkg.Spec.Source.URL, "id")
-				if err != nil {
-					pruner.logger.Error("error extracting value of archiveID from source url",
-						zap.Error(err),
-						zap.String("url", pkg.Spec.Source.URL))
-					return
-				}
-				archivesRefByPkgs = append(archivesRefByPkgs, archiveID)
+			pruner.removePackageRefs(pkg, unused)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
 			}
+			pkg, ok := obj.(*fv1.Package)
+			if !ok {
+				return
+			}
+			pruner.removePackageRefs(pkg, pruner.getPackageArchiveIDs(pkg))
+		},
+	}
+}
+
+// getCachedPackage returns the package with the given reference from the informer cache.
+func (pruner *ArchivePruner) getCachedPackage(pkgRef string) *fv1.Package {
+	for _, informer := range pruner.pkgInformer {
+		obj, exists, err := informer.GetStore().GetByKey(pkgRef)
+		if err == nil && exists {
+			return obj.(*fv1.Package)
+		}
+	}
+	return nil
+}
+
+// isStaleRef returns whether the package no longer references the archive.
+// Packages may have been deleted or updated while storagesvc was down.
+func (pruner *ArchivePruner) isStaleRef(archiveID string, pkgRef string) bool {
+	pkg := pruner.getCachedPackage(pkgRef)
+	return pkg == nil || !slices.Contains(pruner.getPackageArchiveIDs(pkg), archiveID)
+}
+
+// getCachedArchiveIDs returns the IDs of the archives referenced by the packages of the informer cache.
+func (pruner *ArchivePruner) getCachedArchiveIDs() []string {
+	archivesRefByPkgs := make([]string, 0)
+	for _, informer := range pruner.pkgInformer {
+		for _, obj := range informer.GetStore().List() {
+			archivesRefByPkgs = append(archivesRefByPkgs, pruner.getPackageArchiveIDs(obj.(*fv1.Package))...)
 		}
 	}
+	return archivesRefByPkgs
+}
 
-	pruner.logger.Debug("archives referenced by packagese", zap.Strings("archives", archivesRefByPkgs))
+// A user may have deleted pkgs with kubectl or fission cli. That only deletes crd.Package objects from kubernetes
+// and not the archives that are referenced by them, leaving the archives as orphans.
+// getOrphanArchives returns the archives no package references. Content-addressed archives are
+// reference counted in their metadata; archives without metadata are looked up in the packages.
+func (pruner *ArchivePruner) getOrphanArchives(now time.Time) []string {
+	pruner.logger.Debug("getting orphan archives")
 
 	// get all archives on storage
 	// out of them, there may be some just created but not referenced by packages yet.
 	// need to filter them out.
-	archivesInStorage, err := pruner.stowClient.getItemIDsWithFilter(pruner.stowClient.filterItemCreatedAMinuteAgo, time.Now())
+	archivesInStorage, err := pruner.stowClient.getItemIDsWithFilter(pruner.stowClient.filterItemCreatedAMinuteAgo, now)
 	if err != nil {
 		pruner.logger.Error("error getting items from storage", zap.Error(err))
-		return
+		return nil
 	}
 	pruner.logger.Debug("archives in storage", zap.Strings("archives", archivesInStorage))
 
-	// difference of the two lists gives us the list of orphan archives. This is just a brute force approach.
-	// need to do something more optimal at scale.
-	orphanedArchives := getDifferenceOfLists(archivesInStorage, archivesRefByPkgs)
-	pruner.logger.Debug("orphan archives", zap.Strings("archives", orphanedArchives))
+	orphanedArchives := make([]string, 0)
+	legacyArchives := make([]string, 0)
+	for _, archiveID := range archivesInStorage {
+		if !isContentAddressed(archiveID) {
+			legacyArchives = append(legacyArchives, archiveID)
+			continue
+		}
+		meta, err := pruner.stowClient.getMetadataByID(archiveID)
+		if err == ErrNotFound {
+			legacyArchives = append(legacyArchives, archiveID)
+			continue
+		}
+		if err != nil {
+			pruner.logger.Error("error getting archive metadata", zap.Error(err), zap.String("archive_id", archiveID))
+			continue
+		}
+
+		refs := slices.DeleteFunc(slices.Clone(meta.PackageRefs), func(pkgRef string) bool {
+			return pruner.isStaleRef(archiveID, pkgRef)
+		})
+		if len(refs) != len(meta.PackageRefs) {
+			pruner.logger.Info("removing stale package references of archive",
+				zap.String("archive_id", archiveID),
+				zap.Strings("package_refs", meta.PackageRefs))
+			err = pruner.stowClient.removePackageRefs(archiveID, func(pkgRef string) bool {
+				return pruner.isStaleRef(archiveID, pkgRef)
+			})
+			if err != nil {
+				pruner.logger.Error("error removing stale package references of archive", zap.Error(err), zap.String("archive_id", archiveID))
+				continue
+			}
+		}
+		if len(refs) == 0 && now.Sub(meta.LastUploaded) >= archiveGracePeriod {
+			orphanedArchives = append(orphanedArchives, archiveID)
+		}
+	}
 
-	// send each orphan archive away for deletion
-	for _, archiveID = range orphanedArchives {
-		pruner.insertArchive(archiveID)
+	if len(legacyArchives) > 0 {
+		archivesRefByPkgs := pruner.getCachedArchiveIDs()
+		pruner.logger.Debug("archives referenced by packages", zap.Strings("archives", archivesRefByPkgs))
+		orphanedArchives = append(orphanedArchives, getDifferenceOfLists(legacyArchives, archivesRefByPkgs)...)
 	}
+
+	pruner.logger.Debug("orphan archives", zap.Strings("archives", orphanedArchives))
+	return orphanedArchives
 }
 
 // Start starts a go routine that listens to a channel for archive IDs that need to deleted.
 // Also wakes up at regular intervals to make a list of archive IDs that need to be reaped
 // and sends them over to the channel for deletion
 func (pruner *ArchivePruner) Start(ctx context.Context, mgr manager.Interface) {
+	for _, informer := range pruner.pkgInformer {
+		_, err := informer.AddEventHandler(pruner.packageInformerHandler())
+		if err != nil {
+			pruner.logger.Error("error adding package informer handler", zap.Error(err))
+			return
+		}
+	}
+	mgr.AddInformers(ctx, pruner.pkgInformer)
+
+	hasSynced := make([]k8sCache.InformerSynced, 0, len(pruner.pkgInformer))
+	for _, informer := range pruner.pkgInformer {
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+	// the references of the archives are only complete once the
+	// informers have seen all the packages.
+	if !k8sCache.WaitForCacheSync(ctx.Done(), hasSynced...) {
+		pruner.logger.Error("package informer cache failed to sync")
+		return
+	}
+
 	ticker := time.NewTicker(pruner.pruneInterval * time.Minute)
 	mgr.Add(ctx, func(ctx context.Context) {
 		pruner.pruneArchives(ctx)
@@ -160,7 +312,9 @@ func (pruner *ArchivePruner) Start(ctx context.Context, mgr manager.Interface) {
 		case <-ticker.C:
 			// This method fetches unused archive IDs and sends them to archiveChannel for deletion
 			// silencing the errors, hoping they go away in next iteration.
-			pruner.getOrphanArchives(ctx)
+			for _, archiveID := range pruner.getOrphanArchives(time.Now()) {
+				pruner.insertArchive(archiveID)
+			}
 		case <-ctx.Done():
 			ticker.Stop()
 			return
@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/kubernetes"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// When authentication is enabled, requests carry a Kubernetes bearer token
+// in the "Authorization" header. The token is validated with a TokenReview,
+// and the access to the archives of a namespace is checked with a
+// SubjectAccessReview on the "archive" subresource of packages:
+//
+//   - create: upload archives, and look up archives by SHA-256.
+//   - get: download archives, and get their signed URL.
+//   - list: list archives.
+//   - delete: delete archives.
+//
+// Archives that aren't scoped to a namespace, stored before namespaces were
+// recorded, require the permission in all namespaces.
+//
+// A signed URL carries an HMAC of the archive ID in the "signature" query
+// parameter, and downloads the archive without a bearer token. The URLs of
+// package archives are signed, so that anyone allowed to read the package
+// can fetch its archive.
+
+const (
+	// SignatureParam is the query parameter holding the signature of an archive URL
+	SignatureParam = "signature"
+	// SignatureHeader holds the signature of an archive in the response of an info request
+	SignatureHeader = "X-Fission-Signature"
+
+	archiveSubresource = "archive"
+
+	verbCreate = "create"
+	verbGet    = "get"
+	verbList   = "list"
+	verbDelete = "delete"
+
+	// authCacheTTL is the time token and access reviews are cached for
+	authCacheTTL  = time.Minute
+	authCacheSize = 1024
+)
+
+var (
+	errUnauthenticated = errors.New("unauthenticated")
+	errForbidden       = errors.New("forbidden")
+)
+
+type authorizer struct {
+	logger      *zap.Logger
+	kubeClient  kubernetes.Interface
+	signingKey  []byte
+	tokenCache  *cache.LRUExpireCache
+	accessCache *cache.LRUExpireCache
+}
+
+// makeAuthorizer creates an authorizer. A random signing key is generated
+// if signingKey is empty, the URLs signed with it don't outlive the process.
+func makeAuthorizer(logger *zap.Logger, kubeClient kubernetes.Interface, signingKey string) (*authorizer, error) {
+	key := []byte(signingKey)
+	if len(key) == 0 {
+		logger.Warn("no signing key configured, signed archive URLs won't survive a restart of the storage service")
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("error generating signing key: %w", err)
+		}
+	}
+	return &authorizer{
+		logger:      logger.Named("authorizer"),
+		kubeClient:  kubeClient,
+		signingKey:  key,
+		tokenCache:  cache.NewLRUExpireCache(authCacheSize),
+		accessCache: cache.NewLRUExpireCache(authCacheSize),
+	}, nil
+}
+
+// getBearerToken returns the bearer token of the request.
+func getBearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// authenticate returns the user of the bearer token of the request.
+func (a *authorizer) authenticate(ctx context.Context, r *http.Request) (*authenticationv1.UserInfo, error) {
+	token := getBearerToken(r)
+	if len(token) == 0 {
+		return nil, errUnauthenticated
+	}
+	// the cache is keyed by the hash of the token, to not keep tokens in memory
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	if user, ok := a.tokenCache.Get(key); ok {
+		if user == nil {
+			return nil, errUnauthenticated
+		}
+		return user.(*authenticationv1.UserInfo), nil
+	}
+
+	review, err := a.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		a.logger.Debug("token not authenticated", zap.String("error", review.Status.Error))
+		a.tokenCache.Add(key, nil, authCacheTTL)
+		return nil, errUnauthenticated
+	}
+	user := &review.Status.User
+	a.tokenCache.Add(key, user, authCacheTTL)
+	return user, nil
+}
+
+// authorize checks that the user can perform the verb on the archives of the namespace.
+func (a *authorizer) authorize(ctx context.Context, user *authenticationv1.UserInfo, namespace string, verb string) error {
+	key := strings.Join([]string{user.UID, user.Username, namespace, verb}, "/")
+	if allowed, ok := a.accessCache.Get(key); ok {
+		if !allowed.(bool) {
+			return errForbidden
+		}
+		return nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review, err := a.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        verb,
+				Group:       fv1.SchemeGroupVersion.Group,
+				Resource:    "packages",
+				Subresource: archiveSubresource,
+			},
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error reviewing access: %w", err)
+	}
+	a.accessCache.Add(key, review.Status.Allowed, authCacheTTL)
+	if !review.Status.Allowed {
+		a.logger.Debug("access denied",
+			zap.String("user", user.Username),
+			zap.String("namespace", namespace),
+			zap.String("verb", verb),
+			zap.String("reason", review.Status.Reason))
+		return errForbidden
+	}
+	return nil
+}
+
+// sign returns the signature of the archive ID.
+func (a *authorizer) sign(id string) string {
+	mac := hmac.New(sha256.New, a.signingKey)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validSignature returns whether signature is the signature of the archive ID.
+func (a *authorizer) validSignature(id string, signature string) bool {
+	sum, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(a.sign(id))
+	return hmac.Equal(sum, expected)
+}
+
+// authenticate returns the user of the request, or responds with 401.
+// The user is nil if authentication is disabled.
+func (ss *StorageService) authenticate(w http.ResponseWriter, r *http.Request) (*authenticationv1.UserInfo, bool) {
+	if ss.auth == nil {
+		return nil, true
+	}
+	user, err := ss.auth.authenticate(r.Context(), r)
+	if err != nil {
+		ss.writeAuthError(w, r, err)
+		return nil, false
+	}
+	return user, true
+}
+
+// authorize checks the access of the user to the archives of the
+// namespace, or responds with 403.
+func (ss *StorageService) authorize(w http.ResponseWriter, r *http.Request, user *authenticationv1.UserInfo, namespace string, verb string) bool {
+	if ss.auth == nil {
+		return true
+	}
+	err := ss.auth.authorize(r.Context(), user, namespace, verb)
+	if err != nil {
+		ss.writeAuthError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// checkAccess authenticates the request and authorizes the access to the
+// archives of the namespace.
+func (ss *StorageService) checkAccess(w http.ResponseWriter, r *http.Request, namespace string, verb string) bool {
+	user, ok := ss.authenticate(w, r)
+	return ok && ss.authorize(w, r, user, namespace, verb)
+}
+
+// checkArchiveAccess authenticates the request and authorizes the access to
+// the namespace of the archive.
+func (ss *StorageService) checkArchiveAccess(w http.ResponseWriter, r *http.Request, id string, verb string) bool {
+	user, ok := ss.authenticate(w, r)
+	if !ok {
+		return false
+	}
+	if ss.auth == nil {
+		return true
+	}
+	namespace, err := ss.storageClient.getArchiveNamespace(id)
+	if err == ErrNotFound {
+		http.Error(w, "Error retrieving item: not found", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		http.Error(w, "Error retrieving item", http.StatusInternalServerError)
+		return false
+	}
+	return ss.authorize(w, r, user, namespace, verb)
+}
+
+func (ss *StorageService) writeAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case errUnauthenticated:
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errForbidden:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		ss.logger.Error("error checking access", zap.Error(err), zap.String("path", r.URL.Path))
+		http.Error(w, "Error checking access", http.StatusInternalServerError)
+	}
+}
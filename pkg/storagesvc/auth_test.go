@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// makeTestKubeClient returns a client authenticating the tokens "alice" and
+// "bob", allowed to access the archives of the "team-a" and "team-b"
+// namespaces respectively.
+func makeTestKubeClient() *fake.Clientset {
+	allowed := map[string]string{"alice": "team-a", "bob": "team-b"}
+	kubeClient := fake.NewClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if _, ok := allowed[review.Spec.Token]; ok {
+			review.Status.Authenticated = true
+			review.Status.User.Username = review.Spec.Token
+		}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Resource == "packages" && attrs.Subresource == archiveSubresource &&
+			allowed[review.Spec.User] == attrs.Namespace
+		return true, review, nil
+	})
+	return kubeClient
+}
+
+func TestStorageServiceAuth(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	ss := MakeStorageService(logger, makeTestStowClient(t), 0)
+	auth, err := makeAuthorizer(logger, makeTestKubeClient(), "signing-key")
+	require.NoError(t, err)
+	ss.auth = auth
+	server := httptest.NewServer(ss.Handler())
+	t.Cleanup(server.Close)
+
+	content := "archive"
+	do := func(method, path string, token string, body *bytes.Buffer, header map[string]string) *http.Response {
+		if body == nil {
+			body = &bytes.Buffer{}
+		}
+		req, err := http.NewRequestWithContext(t.Context(), method, server.URL+path, body)
+		require.NoError(t, err)
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	upload := func(token string, namespace string) *http.Response {
+		buf := &bytes.Buffer{}
+		bodyWriter := multipart.NewWriter(buf)
+		require.NoError(t, bodyWriter.WriteField(MetadataNamespace, namespace))
+		fileWriter, err := bodyWriter.CreateFormFile("uploadfile", "archive.zip")
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, bodyWriter.Close())
+		return do(http.MethodPost, "/v1/archive", token, buf, map[string]string{
+			"Content-Type": bodyWriter.FormDataContentType(),
+			"X-File-Size":  fmt.Sprint(len(content)),
+		})
+	}
+
+	require.Equal(t, http.StatusUnauthorized, upload("", "team-a").StatusCode)
+	require.Equal(t, http.StatusUnauthorized, upload("mallory", "team-a").StatusCode)
+	require.Equal(t, http.StatusForbidden, upload("bob", "team-a").StatusCode)
+	// unscoped archives require access to all namespaces
+	require.Equal(t, http.StatusForbidden, upload("alice", "").StatusCode)
+
+	resp := upload("alice", "team-a")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var uploaded UploadResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploaded))
+	archivePath := "/v1/archive?id=" + url.QueryEscape(uploaded.ID)
+
+	// the same content is stored once per namespace
+	resp = upload("bob", "team-b")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var otherUploaded UploadResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&otherUploaded))
+	require.NotEqual(t, uploaded.ID, otherUploaded.ID)
+
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, archivePath, "", nil, nil).StatusCode)
+	require.Equal(t, http.StatusForbidden, do(http.MethodGet, archivePath, "bob", nil, nil).StatusCode)
+	require.Equal(t, http.StatusOK, do(http.MethodGet, archivePath, "alice", nil, nil).StatusCode)
+	require.Equal(t, http.StatusForbidden, do(http.MethodDelete, archivePath, "bob", nil, nil).StatusCode)
+
+	// a signed URL downloads the archive without a token
+	resp = do(http.MethodHead, archivePath, "alice", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	signature := resp.Header.Get(SignatureHeader)
+	require.NotEmpty(t, signature)
+	require.Equal(t, http.StatusOK, do(http.MethodGet, archivePath+"&"+SignatureParam+"="+signature, "", nil, nil).StatusCode)
+	otherPath := "/v1/archive?id=" + url.QueryEscape(otherUploaded.ID)
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, otherPath+"&"+SignatureParam+"="+signature, "", nil, nil).StatusCode)
+
+	resp = do(http.MethodGet, "/v1/archive?namespace=team-a", "alice", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var ids []string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&ids))
+	require.Equal(t, []string{uploaded.ID}, ids)
+	require.Equal(t, http.StatusForbidden, do(http.MethodGet, "/v1/archive", "alice", nil, nil).StatusCode)
+
+	// upload sessions are bound to their namespace
+	resp = do(http.MethodPost, "/v1/uploads?namespace=team-a", "alice", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var session UploadSession
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&session))
+	require.Equal(t, http.StatusForbidden, do(http.MethodHead, "/v1/uploads/"+session.ID, "bob", nil, nil).StatusCode)
+	require.Equal(t, http.StatusOK, do(http.MethodHead, "/v1/uploads/"+session.ID, "alice", nil, nil).StatusCode)
+
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, archivePath, "alice", nil, nil).StatusCode)
+	require.Equal(t, http.StatusNotFound, do(http.MethodGet, archivePath, "alice", nil, nil).StatusCode)
+}
+
+// readCounter counts the bytes read from a reader.
+type readCounter struct {
+	io.Reader
+	n int
+}
+
+func (r *readCounter) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += n
+	return n, err
+}
+
+func TestUploadAuthenticatesBeforeReading(t *testing.T) {
+	logger := loggerfactory.GetLogger()
+	ss := MakeStorageService(logger, makeTestStowClient(t), 0)
+	auth, err := makeAuthorizer(logger, makeTestKubeClient(), "signing-key")
+	require.NoError(t, err)
+	ss.auth = auth
+
+	for _, token := range []string{"", "mallory"} {
+		body := &readCounter{Reader: strings.NewReader(strings.Repeat("x", 1024))}
+		req := httptest.NewRequest(http.MethodPost, "/v1/archive", body)
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		ss.Handler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.Zero(t, body.n, "body of an unauthenticated upload is read")
+	}
+}
+
+func TestItemIDValidation(t *testing.T) {
+	client := makeTestStowClient(t)
+	id, _, err := client.putFile(strings.NewReader("archive"), int64(len("archive")), "", "team-a", "test")
+	require.NoError(t, err)
+	require.Contains(t, id, "/team-a/"+archiveNamePrefix)
+
+	namespace, err := client.getArchiveNamespace(id)
+	require.NoError(t, err)
+	require.Equal(t, "team-a", namespace)
+
+	_, _, err = client.putFile(strings.NewReader("archive"), int64(len("archive")), "", "../team-b", "test")
+	require.ErrorIs(t, err, ErrInvalidNamespace)
+
+	for _, itemID := range []string{
+		"",
+		"/etc/passwd",
+		client.container.ID() + "/../" + archiveNamePrefix,
+		"team-a/../../" + archiveNamePrefix,
+	} {
+		_, err := client.getItem(itemID)
+		require.ErrorIs(t, err, ErrNotFound, itemID)
+	}
+}
@@ -32,15 +32,19 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/net/context/ctxhttp"
+	"k8s.io/client-go/rest"
 
 	"github.com/fission/fission/pkg/storagesvc"
+	"github.com/fission/fission/pkg/utils"
 )
 
 type (
 	ClientInterface interface {
 		Upload(ctx context.Context, filePath string, metadata *map[string]string) (string, error)
 		GetUrl(id string) string
-		List(ctx context.Context) ([]string, error)
+		GetSignedUrl(ctx context.Context, id string) (string, error)
+		Info(ctx context.Context, id string) (http.Header, error)
+		List(ctx context.Context, namespace string) ([]string, error)
 		Download(ctx context.Context, id string, filePath string) error
 		GetFile(ctx context.Context, id string) (*http.Response, error)
 		Delete(ctx context.Context, id string) error
@@ -60,9 +64,28 @@ func MakeClient(url string) ClientInterface {
 	}
 }
 
+// MakeAuthenticatedClient creates a storage service client sending the
+// credentials of the Kubernetes client config, for storage services
+// requiring authentication. Without config, the client is unauthenticated.
+func MakeAuthenticatedClient(url string, config *rest.Config) (ClientInterface, error) {
+	if config == nil {
+		return MakeClient(url), nil
+	}
+	transport, err := rest.HTTPWrappersForConfig(config, otelhttp.NewTransport(http.DefaultTransport))
+	if err != nil {
+		return nil, fmt.Errorf("error creating authenticated transport: %w", err)
+	}
+	return &client{
+		url:        strings.TrimSuffix(url, "/") + "/v1",
+		httpClient: &http.Client{Transport: transport},
+	}, nil
+}
+
 // Upload sends the local file pointed to by filePath to the storage
 // service, along with the metadata.  It returns a file ID that can be
-// used to retrieve the file.
+// used to retrieve the file. The file isn't sent if the storage service
+// already stores a file with the same content. The archive is scoped to
+// the namespace of the storagesvc.MetadataNamespace metadata.
 func (c *client) Upload(ctx context.Context, filePath string, metadata *map[string]string) (string, error) {
 	fi, err := os.Stat(filePath)
 	if err != nil {
@@ -70,8 +93,41 @@ func (c *client) Upload(ctx context.Context, filePath string, metadata *map[stri
 	}
 	fileSize := fi.Size()
 
+	sum, err := utils.GetFileChecksum(filePath)
+	if err != nil {
+		return "", err
+	}
+	var namespace string
+	if metadata != nil {
+		namespace = (*metadata)[storagesvc.MetadataNamespace]
+	}
+	id, err := c.lookup(ctx, namespace, sum.Sum)
+	if err != nil {
+		return "", err
+	}
+	if len(id) > 0 {
+		return id, nil
+	}
+
+	// large files are sent in parts, so that a failure doesn't
+	// require sending the whole file again.
+	if fileSize > chunkedUploadThreshold {
+		id, err := c.uploadChunked(ctx, filePath, fileSize, sum.Sum, metadata)
+		if err != errResumableUnsupported {
+			return id, err
+		}
+	}
+
 	buf := &bytes.Buffer{}
 	bodyWriter := multipart.NewWriter(buf)
+	if metadata != nil {
+		for k, v := range *metadata {
+			err = bodyWriter.WriteField(k, v)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
 	fileWriter, err := bodyWriter.CreateFormFile("uploadfile", filePath)
 	if err != nil {
 		return "", err
@@ -81,6 +137,7 @@ func (c *client) Upload(ctx context.Context, filePath string, metadata *map[stri
 	if err != nil {
 		return "", err
 	}
+	defer f.Close()
 
 	_, err = io.Copy(fileWriter, f)
 	if err != nil {
@@ -95,6 +152,7 @@ func (c *client) Upload(ctx context.Context, filePath string, metadata *map[stri
 		return "", err
 	}
 	req.Header["X-File-Size"] = []string{fmt.Sprintf("%v", fileSize)}
+	req.Header["X-File-Sha256"] = []string{sum.Sum}
 	req.Header["Content-Type"] = []string{contentType}
 
 	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
@@ -120,13 +178,93 @@ func (c *client) Upload(ctx context.Context, filePath string, metadata *map[stri
 	return ur.ID, nil
 }
 
+// lookup returns the ID of the file of namespace with the given SHA-256,
+// or an empty ID if the storage service doesn't store it.
+func (c *client) lookup(ctx context.Context, namespace string, checksum string) (string, error) {
+	query := url.Values{}
+	if len(namespace) > 0 {
+		query.Set(storagesvc.MetadataNamespace, namespace)
+	}
+	req, err := http.NewRequest(http.MethodGet, c.url+"/archive/sha256/"+checksum+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		// storage services predating content-addressed
+		// archives don't serve lookups.
+		return "", nil
+	default:
+		return "", fmt.Errorf("Lookup error %v", resp.Status)
+	}
+
+	var ur storagesvc.UploadResponse
+	err = json.Unmarshal(body, &ur)
+	if err != nil {
+		return "", err
+	}
+	return ur.ID, nil
+}
+
 // GetUrl returns an HTTP URL that can be used to download the file pointed to by ID
 func (c *client) GetUrl(id string) string {
 	return fmt.Sprintf("%v/archive?id=%v", c.url, url.PathEscape(id))
 }
 
-func (c *client) List(ctx context.Context) ([]string, error) {
-	req, err := http.NewRequest(http.MethodGet, c.url+"/archive", nil)
+// GetSignedUrl returns an HTTP URL that can be used to download the file
+// pointed to by ID without credentials. It's the URL returned by GetUrl if
+// the storage service doesn't sign URLs.
+func (c *client) GetSignedUrl(ctx context.Context, id string) (string, error) {
+	header, err := c.Info(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return SignUrl(c.GetUrl(id), header.Get(storagesvc.SignatureHeader)), nil
+}
+
+// SignUrl adds the signature returned by Info to a URL returned by GetUrl.
+func SignUrl(fileURL string, signature string) string {
+	if len(signature) == 0 {
+		return fileURL
+	}
+	return fileURL + "&" + storagesvc.SignatureParam + "=" + url.QueryEscape(signature)
+}
+
+// Info returns the headers describing the file pointed to by ID.
+func (c *client) Info(ctx context.Context, id string) (http.Header, error) {
+	req, err := http.NewRequest(http.MethodHead, c.GetUrl(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Info error %v", resp.Status)
+	}
+	return resp.Header, nil
+}
+
+// List returns the IDs of the files of namespace, or of all namespaces if
+// namespace is empty.
+func (c *client) List(ctx context.Context, namespace string) ([]string, error) {
+	query := url.Values{}
+	if len(namespace) > 0 {
+		query.Set(storagesvc.MetadataNamespace, namespace)
+	}
+	req, err := http.NewRequest(http.MethodGet, c.url+"/archive?"+query.Encode(), nil)
 	if err != nil {
 		return []string{}, err
 	}
@@ -153,7 +291,8 @@ func (c *client) List(ctx context.Context) ([]string, error) {
 }
 
 // Download fetches the file identified by ID to the local file path.
-// filePath must not exist.
+// filePath must not exist. An interrupted download is resumed from the
+// bytes already received.
 func (c *client) Download(ctx context.Context, id string, filePath string) error {
 	// url for id
 	url := c.GetUrl(id)
@@ -171,26 +310,18 @@ func (c *client) Download(ctx context.Context, id string, filePath string) error
 	}
 	defer f.Close()
 
-	// make request
-	resp, err := ctxhttp.Get(ctx, c.httpClient, url)
-	if err != nil {
-		fmt.Println(err)
-		os.Remove(filePath)
-		return err
+	var offset int64
+	for attempt := 1; ; attempt++ {
+		offset, err = c.downloadFrom(ctx, url, f, offset)
+		var retryErr retryableError
+		if err == nil || !errors.As(err, &retryErr) || attempt > maxRetries || waitRetry(ctx, attempt) != nil {
+			break
+		}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		msg := fmt.Sprintf("HTTP error %v", resp.StatusCode)
-		os.Remove(filePath)
-		return errors.New(msg)
-	}
-
-	// download and write data
-	_, err = io.Copy(f, resp.Body)
 	if err != nil {
+		os.Remove(filePath)
 		return err
 	}
-
 	return nil
 }
 
@@ -224,7 +355,8 @@ func (c *client) Delete(ctx context.Context, id string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP error %v", resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP error %v: %s", resp.StatusCode, strings.TrimSpace(string(body)))
 	}
 
 	return nil
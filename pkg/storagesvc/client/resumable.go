@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/fission/fission/pkg/storagesvc"
+)
+
+var (
+	// chunkedUploadThreshold is the size above which files are uploaded in parts
+	chunkedUploadThreshold int64 = 32 << 20
+	// uploadPartSize is the size of the parts of a chunked upload
+	uploadPartSize int64 = 8 << 20
+	// maxRetries is the number of times a failed part or download is retried
+	maxRetries = 5
+	// retryInterval is multiplied by the attempt number to wait before a retry
+	retryInterval = 500 * time.Millisecond
+
+	// errResumableUnsupported is returned by storage services predating resumable uploads
+	errResumableUnsupported = errors.New("resumable uploads not supported")
+)
+
+func waitRetry(ctx context.Context, attempt int) error {
+	select {
+	case <-time.After(time.Duration(attempt) * retryInterval):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *client) uploadURL(id string) string {
+	return c.url + "/uploads/" + url.PathEscape(id)
+}
+
+// uploadChunked sends the file in parts of uploadPartSize. A failed part is
+// resumed from the offset the storage service received.
+func (c *client) uploadChunked(ctx context.Context, filePath string, fileSize int64, checksum string, metadata *map[string]string) (string, error) {
+	query := url.Values{}
+	if metadata != nil {
+		query.Set(storagesvc.MetadataUploader, (*metadata)[storagesvc.MetadataUploader])
+		query.Set(storagesvc.MetadataNamespace, (*metadata)[storagesvc.MetadataNamespace])
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url+"/uploads?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-File-Size", strconv.FormatInt(fileSize, 10))
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return "", errResumableUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Upload error %v", resp.Status)
+	}
+	var session storagesvc.UploadSession
+	err = json.NewDecoder(resp.Body).Decode(&session)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := c.uploadParts(ctx, session.ID, filePath, fileSize, checksum)
+	if err != nil {
+		c.abortUpload(session.ID)
+		return "", err
+	}
+	return id, nil
+}
+
+func (c *client) uploadParts(ctx context.Context, uploadID string, filePath string, fileSize int64, checksum string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var offset int64
+	attempt := 0
+	for offset < fileSize {
+		newOffset, err := c.uploadPart(ctx, uploadID, io.NewSectionReader(f, offset, min(uploadPartSize, fileSize-offset)), offset)
+		if err == nil {
+			offset = newOffset
+			attempt = 0
+			continue
+		}
+
+		attempt++
+		if attempt > maxRetries || ctx.Err() != nil {
+			return "", err
+		}
+		if err := waitRetry(ctx, attempt); err != nil {
+			return "", err
+		}
+		// resume from what the storage service received
+		if resumeOffset, err := c.getUploadOffset(ctx, uploadID); err == nil {
+			offset = resumeOffset
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.uploadURL(uploadID)+"/complete", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-File-Sha256", checksum)
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Upload error %v", resp.Status)
+	}
+	var ur storagesvc.UploadResponse
+	err = json.NewDecoder(resp.Body).Decode(&ur)
+	if err != nil {
+		return "", err
+	}
+	return ur.ID, nil
+}
+
+// uploadPart sends a part at offset and returns the offset of the next part.
+func (c *client) uploadPart(ctx context.Context, uploadID string, part *io.SectionReader, offset int64) (int64, error) {
+	req, err := http.NewRequest(http.MethodPatch, c.uploadURL(uploadID), part)
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = part.Size()
+	req.Header.Set(storagesvc.UploadOffsetHeader, strconv.FormatInt(offset, 10))
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Upload error %v", resp.Status)
+	}
+	return strconv.ParseInt(resp.Header.Get(storagesvc.UploadOffsetHeader), 10, 64)
+}
+
+func (c *client) getUploadOffset(ctx context.Context, uploadID string) (int64, error) {
+	req, err := http.NewRequest(http.MethodHead, c.uploadURL(uploadID), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Upload error %v", resp.Status)
+	}
+	return strconv.ParseInt(resp.Header.Get(storagesvc.UploadOffsetHeader), 10, 64)
+}
+
+// abortUpload drops the upload on a best effort basis, the storage service
+// expires abandoned uploads anyway.
+func (c *client) abortUpload(uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodDelete, c.uploadURL(uploadID), nil)
+	if err != nil {
+		return
+	}
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// retryableError is a download error worth retrying.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e retryableError) Unwrap() error {
+	return e.err
+}
+
+// downloadFrom writes the file at downloadURL into f from offset, and
+// returns the offset written up to. Storage services ignoring the range
+// send the whole file, which is written from the start.
+func (c *client) downloadFrom(ctx context.Context, downloadURL string, f *os.File, offset int64) (int64, error) {
+	req, err := http.NewRequest(http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return offset, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		return offset, retryableError{err}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		offset = 0
+		err = f.Truncate(0)
+		if err != nil {
+			return offset, err
+		}
+	case http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the previous attempt failed after receiving the whole file
+		if resp.Header.Get("Content-Range") == fmt.Sprintf("bytes */%d", offset) {
+			return offset, nil
+		}
+		return offset, fmt.Errorf("HTTP error %v", resp.StatusCode)
+	default:
+		err = fmt.Errorf("HTTP error %v", resp.StatusCode)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return offset, retryableError{err}
+		}
+		return offset, err
+	}
+
+	_, err = f.Seek(offset, io.SeekStart)
+	if err != nil {
+		return offset, err
+	}
+	n, err := io.Copy(f, resp.Body)
+	offset += n
+	if err == nil && resp.ContentLength >= 0 && n != resp.ContentLength {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return offset, retryableError{err}
+	}
+	return offset, nil
+}
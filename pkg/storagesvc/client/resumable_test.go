@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fission/fission/pkg/storagesvc"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+// flakyHandler fails the first upload part after receiving a few bytes of
+// it, and cuts the first full download short.
+type flakyHandler struct {
+	handler       http.Handler
+	lock          sync.Mutex
+	failedPart    bool
+	failedGet     bool
+	rangeRequests []string
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.lock.Lock()
+	failPart := r.Method == http.MethodPatch && !h.failedPart
+	h.failedPart = h.failedPart || failPart
+	failGet := r.Method == http.MethodGet && r.URL.Path == "/v1/archive" && r.URL.Query().Has("id") && !h.failedGet
+	h.failedGet = h.failedGet || failGet
+	if rangeHeader := r.Header.Get("Range"); len(rangeHeader) > 0 {
+		h.rangeRequests = append(h.rangeRequests, rangeHeader)
+	}
+	h.lock.Unlock()
+
+	switch {
+	case failPart:
+		r.Body = io.NopCloser(io.LimitReader(r.Body, 3))
+		h.handler.ServeHTTP(httptest.NewRecorder(), r)
+		http.Error(w, "connection reset", http.StatusBadGateway)
+	case failGet:
+		rec := httptest.NewRecorder()
+		h.handler.ServeHTTP(rec, r)
+		w.Header().Set("Content-Length", rec.Header().Get("Content-Length"))
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes()[:rec.Body.Len()/2])
+	default:
+		h.handler.ServeHTTP(w, r)
+	}
+}
+
+func TestResumableUploadDownload(t *testing.T) {
+	threshold, partSize, interval := chunkedUploadThreshold, uploadPartSize, retryInterval
+	chunkedUploadThreshold, uploadPartSize, retryInterval = 16, 10, time.Millisecond
+	t.Cleanup(func() {
+		chunkedUploadThreshold, uploadPartSize, retryInterval = threshold, partSize, interval
+	})
+
+	logger := loggerfactory.GetLogger()
+	stowClient, err := storagesvc.MakeStowClient(logger, storagesvc.NewLocalStorage(t.TempDir()))
+	require.NoError(t, err)
+	t.Setenv("STORAGE_UPLOAD_DIR", t.TempDir())
+	flaky := &flakyHandler{handler: storagesvc.MakeStorageService(logger, stowClient, 0).Handler()}
+	server := httptest.NewServer(flaky)
+	t.Cleanup(server.Close)
+
+	content := strings.Repeat("0123456789", 5)
+	filePath := filepath.Join(t.TempDir(), "archive.zip")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+
+	client := MakeClient(server.URL)
+	id, err := client.Upload(t.Context(), filePath, &map[string]string{
+		storagesvc.MetadataUploader: "fission-cli",
+	})
+	require.NoError(t, err)
+	require.True(t, flaky.failedPart)
+
+	// uploading the same content again is a lookup
+	sameID, err := client.Upload(t.Context(), filePath, nil)
+	require.NoError(t, err)
+	require.Equal(t, id, sameID)
+
+	downloadPath := filepath.Join(t.TempDir(), "download.zip")
+	require.NoError(t, client.Download(t.Context(), id, downloadPath))
+	require.True(t, flaky.failedGet)
+	require.Equal(t, []string{"bytes=" + strconv.Itoa(len(content)/2) + "-"}, flaky.rangeRequests)
+
+	downloaded, err := os.ReadFile(downloadPath)
+	require.NoError(t, err)
+	require.Equal(t, content, string(downloaded))
+}
@@ -240,7 +240,7 @@ func TestLocalStorageService(t *testing.T) {
 	fileID, err := client.Upload(ctx, tmpfile.Name(), &metadata)
 	failTest(t, err)
 
-	ids, err := client.List(ctx)
+	ids, err := client.List(ctx, "")
 	if err != nil {
 		t.Fatalf("Could not list files: %s", err)
 	}
@@ -2,11 +2,10 @@ package storagesvc
 
 import (
 	"os"
+	"path"
 
 	"github.com/graymeta/stow"
 	_ "github.com/graymeta/stow/local"
-
-	"github.com/fission/fission/pkg/utils/uuid"
 )
 
 type localStorage struct {
@@ -33,10 +32,9 @@ func (ls localStorage) getStorageType() StorageType {
 	return ls.storageType
 }
 
-func (ls localStorage) getUploadFileName() (string, error) {
+func (ls localStorage) getUploadFileName(namespace string, checksum string) (string, error) {
 	// This is not the item ID (that's returned by Put)
-	// should we just use handler.Filename? what are the constraints here?
-	return uuid.NewString(), nil
+	return path.Join(namespace, archiveNamePrefix+checksum), nil
 }
 
 func (ls localStorage) getSubDir() string {
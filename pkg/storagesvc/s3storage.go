@@ -6,8 +6,6 @@ import (
 
 	"github.com/graymeta/stow"
 	"github.com/graymeta/stow/s3"
-
-	"github.com/fission/fission/pkg/utils/uuid"
 )
 
 type (
@@ -54,9 +52,8 @@ func (ss s3Storage) getSubDir() string {
 	return ss.subDir
 }
 
-func (ss s3Storage) getUploadFileName() (string, error) {
-	id := uuid.NewString()
-	return path.Join(ss.subDir, id), nil
+func (ss s3Storage) getUploadFileName(namespace string, checksum string) (string, error) {
+	return path.Join(ss.subDir, namespace, archiveNamePrefix+checksum), nil
 }
 
 func (ss s3Storage) dial() (stow.Location, error) {
@@ -20,8 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -45,14 +47,17 @@ type (
 		dial() (stow.Location, error)
 		getSubDir() string
 		getContainerName() string
-		getUploadFileName() (string, error)
+		getUploadFileName(namespace string, checksum string) (string, error)
 	}
 
 	// StorageService is a struct to hold all things for storage service
 	StorageService struct {
 		logger        *zap.Logger
 		storageClient *StowClient
-		port          int
+		uploads       *uploadManager
+		// auth authenticates and authorizes requests, nil if disabled
+		auth *authorizer
+		port int
 	}
 
 	UploadResponse struct {
@@ -60,6 +65,17 @@ type (
 	}
 )
 
+const (
+	// MetadataUploader is the upload metadata key of the uploader name
+	MetadataUploader = "uploader"
+	// MetadataNamespace is the upload metadata key of the namespace the archive is scoped to
+	MetadataNamespace = "namespace"
+
+	// multipartOverhead bounds the size the multipart encoding of an
+	// upload adds to the size of the archive
+	multipartOverhead = 1 << 20
+)
+
 // Functions handling storage interface
 func getStorageType(storage Storage) string {
 	return string(storage.getStorageType())
@@ -71,12 +87,26 @@ func getStorageLocation(config *storageConfig) (stow.Location, error) {
 
 func (ss *StorageService) listItems(w http.ResponseWriter, r *http.Request) {
 	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	// archives of all namespaces are listed if no namespace is given
+	namespace := r.URL.Query().Get(MetadataNamespace)
+	if !ss.checkAccess(w, r, namespace, verbList) {
+		return
+	}
+
 	// get all archives on storage
 	// out of them, there may be some just created but not referenced by packages yet.
 	// need to filter them out.
-	archivesInStorage, err := ss.storageClient.getItemIDsWithFilter(ss.storageClient.filterAllItems, false)
+	var archivesInStorage []string
+	var err error
+	if len(namespace) > 0 {
+		archivesInStorage, err = ss.storageClient.getItemIDsWithFilter(ss.storageClient.filterOtherNamespaces, namespace)
+	} else {
+		archivesInStorage, err = ss.storageClient.getItemIDsWithFilter(ss.storageClient.filterAllItems, false)
+	}
 	if err != nil {
 		logger.Error("error getting items from storage", zap.Error(err))
+		http.Error(w, "Error getting items from storage", http.StatusInternalServerError)
 		return
 	}
 	logger.Debug("archives in storage", zap.Strings("archives", archivesInStorage))
@@ -100,10 +130,25 @@ func (ss *StorageService) listItems(w http.ResponseWriter, r *http.Request) {
 func (ss *StorageService) uploadHandler(w http.ResponseWriter, r *http.Request) {
 	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
 
+	// the namespace is part of the form, authenticate before reading the
+	// body and authorize once it's parsed
+	user, ok := ss.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, ss.uploads.maxSize+multipartOverhead)
+
 	// handle upload
 	err := r.ParseMultipartForm(0)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("upload exceeds the maximum size of %d bytes", ss.uploads.maxSize), http.StatusRequestEntityTooLarge)
+		return
+	}
 	if err != nil {
 		http.Error(w, "failed to parse request", http.StatusBadRequest)
+		return
 	}
 	file, handler, err := r.FormFile("uploadfile")
 	if err != nil {
@@ -134,12 +179,37 @@ func (ss *StorageService) uploadHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "missing or bad X-File-Size header", http.StatusBadRequest)
 		return
 	}
+	if int64(fileSize) > ss.uploads.maxSize {
+		http.Error(w, fmt.Sprintf("upload exceeds the maximum size of %d bytes", ss.uploads.maxSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// the optional "X-File-Sha256" header is verified against
+	// the content of the uploaded file.
+	checksum := r.Header.Get("X-File-Sha256")
+	uploader := r.FormValue(MetadataUploader)
+	namespace := r.FormValue(MetadataNamespace)
+	if !ss.authorize(w, r, user, namespace, verbCreate) {
+		return
+	}
 
-	// TODO: allow headers to add more metadata (e.g. environment and function metadata)
 	logger.Debug("handling upload",
-		zap.String("filename", handler.Filename))
+		zap.String("filename", handler.Filename),
+		zap.String("namespace", namespace),
+		zap.String("uploader", uploader))
 
-	id, err := ss.storageClient.putFile(file, int64(fileSize))
+	id, written, err := ss.storageClient.putFile(file, int64(fileSize), checksum, namespace, uploader)
+	if err == ErrInvalidNamespace {
+		http.Error(w, "invalid namespace", http.StatusBadRequest)
+		return
+	}
+	if err == ErrChecksumMismatch {
+		logger.Error("uploaded file doesn't match the 'X-File-Sha256' header",
+			zap.String("checksum", checksum),
+			zap.String("filename", handler.Filename))
+		http.Error(w, "uploaded file doesn't match X-File-Sha256 header", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		logger.Error("error saving uploaded file",
 			zap.Error(err),
@@ -148,8 +218,6 @@ func (ss *StorageService) uploadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	totalMemoryUsage.WithLabelValues().Add(float64(fileSize))
-
 	// respond with an ID that can be used to retrieve the file
 	ur := &UploadResponse{
 		ID: id,
@@ -171,7 +239,45 @@ func (ss *StorageService) uploadHandler(w http.ResponseWriter, r *http.Request)
 		)
 	}
 
-	totalArchives.WithLabelValues().Inc()
+	if written {
+		totalArchives.WithLabelValues().Inc()
+		totalMemoryUsage.WithLabelValues().Add(float64(fileSize))
+	}
+}
+
+// lookupHandler returns the ID of the archive with the SHA-256 of the request,
+// so that clients can skip uploading an archive that is already stored.
+func (ss *StorageService) lookupHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	namespace := r.URL.Query().Get(MetadataNamespace)
+	if !ss.checkAccess(w, r, namespace, verbCreate) {
+		return
+	}
+	checksum := mux.Vars(r)["checksum"]
+	id, err := ss.storageClient.getItemBySHA256(namespace, checksum)
+	if err == ErrNotFound {
+		http.Error(w, "Error retrieving item: not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.Error("error looking up archive", zap.Error(err), zap.String("checksum", checksum))
+		http.Error(w, "Error retrieving item", http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := json.Marshal(&UploadResponse{ID: id})
+	if err != nil {
+		http.Error(w, "Error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		logger.Error(
+			"error writing HTTP response",
+			zap.Error(err),
+		)
+	}
 }
 
 func (ss *StorageService) getIdFromRequest(r *http.Request) (string, error) {
@@ -190,13 +296,23 @@ func (ss *StorageService) deleteHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if !ss.checkArchiveAccess(w, r, fileId, verbDelete) {
+		return
+	}
 
 	filesize, err := ss.storageClient.getFileSize(fileId)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	err = ss.storageClient.removeFileByID(fileId)
+	// archives of packages are deleted by the archive pruner
+	// once the packages are gone
+	err = ss.storageClient.removeUnreferencedFile(fileId)
+	if errors.Is(err, ErrArchiveInUse) {
+		http.Error(w, fmt.Sprintf("Error deleting item: %v", err), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		msg := fmt.Sprintf("Error deleting item: %v", err)
 		http.Error(w, msg, http.StatusInternalServerError)
@@ -217,10 +333,17 @@ func (ss *StorageService) downloadHandler(w http.ResponseWriter, r *http.Request
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	// a signed URL downloads the archive without a bearer token
+	signature := r.URL.Query().Get(SignatureParam)
+	if ss.auth == nil || len(signature) == 0 || !ss.auth.validSignature(fileId, signature) {
+		if !ss.checkArchiveAccess(w, r, fileId, verbGet) {
+			return
+		}
+	}
 
 	// Get the file (called "item" in stow's jargon), open it,
 	// stream it to response
-	err = ss.storageClient.copyFileToStream(fileId, w)
+	f, size, err := ss.storageClient.openFile(fileId)
 	if err != nil {
 		logger.Error("error getting file from storage client", zap.Error(err), zap.String("file_id", fileId))
 		if err == ErrNotFound {
@@ -229,11 +352,42 @@ func (ss *StorageService) downloadHandler(w http.ResponseWriter, r *http.Request
 			http.Error(w, "Error retrieving item", http.StatusBadRequest)
 		} else if err == ErrOpeningItem {
 			http.Error(w, "Error opening item", http.StatusBadRequest)
-		} else if err == ErrWritingFileIntoResponse {
-			http.Error(w, "Error writing response", http.StatusInternalServerError)
 		}
 		return
 	}
+	defer f.Close()
+
+	// a single byte range lets clients resume interrupted downloads,
+	// other ranges get the whole file.
+	start, length, err := parseRange(r.Header.Get("Range"), size)
+	if err != nil {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	if length != size {
+		if seeker, ok := f.(io.Seeker); ok {
+			_, err = seeker.Seek(start, io.SeekStart)
+		} else {
+			_, err = io.CopyN(io.Discard, f, start)
+		}
+		if err != nil {
+			logger.Error("error seeking file", zap.Error(err), zap.String("file_id", fileId))
+			http.Error(w, "Error opening item", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+		w.WriteHeader(http.StatusPartialContent)
+	}
+
+	_, err = io.CopyN(w, f, length)
+	if err != nil {
+		logger.Error("error writing file into http response", zap.Error(err), zap.String("file_id", fileId))
+		return
+	}
+	logger.Debug("successfully wrote file into httpresponse", zap.String("file", fileId))
 }
 
 func (ss *StorageService) infoHandler(w http.ResponseWriter, r *http.Request) {
@@ -243,12 +397,18 @@ func (ss *StorageService) infoHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if !ss.checkArchiveAccess(w, r, fileID, verbGet) {
+		return
+	}
 
-	_, err = ss.storageClient.container.Item(fileID)
+	_, err = ss.storageClient.getItem(fileID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	if ss.auth != nil {
+		w.Header().Set(SignatureHeader, ss.auth.sign(fileID))
+	}
 
 	storageType := ss.storageClient.config.storage.getStorageType()
 	if storageType == StorageTypeS3 {
@@ -262,24 +422,46 @@ func (ss *StorageService) healthHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func MakeStorageService(logger *zap.Logger, storageClient *StowClient, port int) *StorageService {
+	// resumable uploads are staged on the local disk until completed
+	uploadDir := os.Getenv("STORAGE_UPLOAD_DIR")
+	if len(uploadDir) == 0 {
+		uploadDir = filepath.Join(os.TempDir(), "fission-uploads")
+	}
+	ssLogger := logger.Named("storage_service")
+	maxUploadSize, err := strconv.ParseInt(os.Getenv("STORAGE_MAX_UPLOAD_SIZE"), 10, 64)
+	if err != nil {
+		maxUploadSize = DefaultMaxUploadSize
+	}
 	return &StorageService{
-		logger:        logger.Named("storage_service"),
+		logger:        ssLogger,
 		storageClient: storageClient,
+		uploads:       makeUploadManager(ssLogger, uploadDir, maxUploadSize),
 		port:          port,
 	}
 }
 
-func (ss *StorageService) Start(ctx context.Context, mgr manager.Interface, port int) {
+// Handler returns the HTTP handler of the storage service API.
+func (ss *StorageService) Handler() http.Handler {
 	r := mux.NewRouter()
 	r.Use(metrics.HTTPMetricMiddleware)
 	r.HandleFunc("/v1/archive", ss.uploadHandler).Methods("POST")
+	r.HandleFunc("/v1/archive/sha256/{checksum}", ss.lookupHandler).Methods("GET")
 	r.HandleFunc("/v1/archive", ss.downloadHandler).Queries("id", "{id}").Methods("GET")
 	r.HandleFunc("/v1/archive", ss.listItems).Methods("GET")
 	r.HandleFunc("/v1/archive", ss.deleteHandler).Methods("DELETE")
 	r.HandleFunc("/v1/archive", ss.infoHandler).Methods("HEAD")
+	r.HandleFunc("/v1/uploads", ss.createUploadHandler).Methods("POST")
+	r.HandleFunc("/v1/uploads/{id}", ss.uploadPartHandler).Methods("PATCH")
+	r.HandleFunc("/v1/uploads/{id}", ss.uploadOffsetHandler).Methods("HEAD")
+	r.HandleFunc("/v1/uploads/{id}", ss.abortUploadHandler).Methods("DELETE")
+	r.HandleFunc("/v1/uploads/{id}/complete", ss.completeUploadHandler).Methods("POST")
 	r.HandleFunc("/healthz", ss.healthHandler).Methods("GET")
+	return r
+}
 
-	handler := otelUtils.GetHandlerWithOTEL(r, "fission-storagesvc", otelUtils.UrlsToIgnore("/healthz"))
+func (ss *StorageService) Start(ctx context.Context, mgr manager.Interface, port int) {
+	mgr.Add(ctx, ss.uploads.Start)
+	handler := otelUtils.GetHandlerWithOTEL(ss.Handler(), "fission-storagesvc", otelUtils.UrlsToIgnore("/healthz"))
 	httpserver.StartServer(ctx, ss.logger, mgr, "storagesvc", fmt.Sprintf("%d", port), handler)
 }
 
@@ -298,6 +480,17 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 
 	// create http handlers
 	storageService := MakeStorageService(logger, storageClient, port)
+	enableAuth, _ := strconv.ParseBool(os.Getenv("STORAGE_AUTH_ENABLED"))
+	if enableAuth {
+		kubeClient, err := clientGen.GetKubernetesClient()
+		if err != nil {
+			return fmt.Errorf("error making the kube client: %w", err)
+		}
+		storageService.auth, err = makeAuthorizer(storageService.logger, kubeClient, os.Getenv("STORAGE_SIGNING_KEY"))
+		if err != nil {
+			return fmt.Errorf("error creating authorizer: %w", err)
+		}
+	}
 	mgr.Add(ctx, func(ctx context.Context) {
 		metrics.ServeMetrics(ctx, "storagesvc", logger, mgr)
 	})
@@ -17,17 +17,25 @@ limitations under the License.
 package storagesvc
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"errors"
 
 	"github.com/graymeta/stow"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type (
@@ -44,6 +52,22 @@ type (
 		config    *storageConfig
 		location  stow.Location
 		container stow.Container
+		// metadataLock serializes the read-modify-write of archive metadata
+		metadataLock sync.Mutex
+	}
+
+	// ArchiveMetadata is stored alongside a content-addressed archive.
+	ArchiveMetadata struct {
+		ID           string    `json:"id"`
+		SHA256       string    `json:"sha256"`
+		Size         int64     `json:"size"`
+		Namespace    string    `json:"namespace,omitempty"`
+		Uploader     string    `json:"uploader,omitempty"`
+		Created      time.Time `json:"created"`
+		LastUploaded time.Time `json:"lastUploaded"`
+		// PackageRefs holds the "namespace/name" of the packages
+		// referencing the archive.
+		PackageRefs []string `json:"packageRefs,omitempty"`
 	}
 )
 
@@ -54,6 +78,11 @@ const (
 	StorageTypeS3 StorageType = "s3"
 	// PaginationSize is a constant to hold no of pages
 	PaginationSize = 10
+
+	// archiveNamePrefix prefixes the SHA-256 of content-addressed archive names
+	archiveNamePrefix = "sha256-"
+	// metadataSuffix is appended to the archive name to get its metadata name
+	metadataSuffix = ".meta.json"
 )
 
 var (
@@ -62,6 +91,9 @@ var (
 	ErrOpeningItem             = errors.New("unable to open item")
 	ErrWritingFile             = errors.New("unable to write file")
 	ErrWritingFileIntoResponse = errors.New("unable to copy item into http response")
+	ErrChecksumMismatch        = errors.New("checksum mismatch")
+	ErrInvalidNamespace        = errors.New("invalid namespace")
+	ErrArchiveInUse            = errors.New("archive is referenced by packages")
 )
 
 func getContainer(loc stow.Location, containerName string, cursor string) (stow.Container, error) {
@@ -132,65 +164,318 @@ func MakeStowClient(logger *zap.Logger, storage Storage) (*StowClient, error) {
 	return stowClient, nil
 }
 
-// putFile writes the file on the storage
-func (client *StowClient) putFile(file multipart.File, fileSize int64) (string, error) {
-	uploadName, err := client.config.storage.getUploadFileName()
+// putFile writes the file on the storage under its SHA-256. If an archive
+// with the same content is already stored, the file isn't written again and
+// the ID of the existing archive is returned. A non-empty checksum must match
+// the SHA-256 of the file. Archives are scoped to namespace, an archive
+// uploaded to several namespaces is stored once per namespace. The returned
+// bool is true if the file was written.
+func (client *StowClient) putFile(file io.ReadSeeker, fileSize int64, checksum string, namespace string, uploader string) (string, bool, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", false, fmt.Errorf("error hashing file: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", false, fmt.Errorf("error rewinding file: %w", err)
+	}
+	sum := hex.EncodeToString(hasher.Sum(nil))
+	if len(checksum) > 0 && !strings.EqualFold(checksum, sum) {
+		return "", false, ErrChecksumMismatch
+	}
+	if !isValidNamespace(namespace) {
+		return "", false, ErrInvalidNamespace
+	}
+
+	uploadName, err := client.config.storage.getUploadFileName(namespace, sum)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
-	// save the file to the storage backend
-	item, err := client.container.Put(uploadName, file, fileSize, nil)
+	item, err := client.container.Item(uploadName)
+	written := err != nil
+	if !written {
+		client.logger.Debug("archive already exists on storage", zap.String("file", uploadName))
+	} else {
+		// save the file to the storage backend
+		item, err = client.container.Put(uploadName, file, fileSize, nil)
+		if err != nil {
+			client.logger.Error("error writing file on storage",
+				zap.Error(err),
+				zap.String("file", uploadName))
+			return "", false, ErrWritingFile
+		}
+		client.logger.Debug("successfully wrote file on storage", zap.String("file", uploadName))
+	}
+
+	now := time.Now().UTC()
+	_, err = client.updateMetadata(item, func(meta *ArchiveMetadata) bool {
+		if meta.Created.IsZero() {
+			meta.SHA256 = sum
+			meta.Size = fileSize
+			meta.Namespace = namespace
+			meta.Uploader = uploader
+			meta.Created = now
+		}
+		meta.LastUploaded = now
+		return true
+	})
 	if err != nil {
-		client.logger.Error("error writing file on storage",
+		client.logger.Error("error writing archive metadata on storage",
 			zap.Error(err),
 			zap.String("file", uploadName))
-		return "", ErrWritingFile
+		return "", false, ErrWritingFile
 	}
 
-	client.logger.Debug("successfully wrote file on storage", zap.String("file", uploadName))
+	return item.ID(), written, nil
+}
+
+// getItemBySHA256 returns the ID of the archive of namespace with the given SHA-256.
+func (client *StowClient) getItemBySHA256(namespace string, checksum string) (string, error) {
+	if sum, err := hex.DecodeString(checksum); err != nil || len(sum) != sha256.Size || !isValidNamespace(namespace) {
+		return "", ErrNotFound
+	}
+	uploadName, err := client.config.storage.getUploadFileName(namespace, strings.ToLower(checksum))
+	if err != nil {
+		return "", err
+	}
+	item, err := client.container.Item(uploadName)
+	if err != nil {
+		if err == stow.ErrNotFound {
+			return "", ErrNotFound
+		}
+		return "", ErrRetrievingItem
+	}
 	return item.ID(), nil
 }
 
-// copyFileToStream gets the file contents into a stream
-func (client *StowClient) copyFileToStream(fileId string, w io.Writer) error {
-	item, err := client.container.Item(fileId)
+// isValidNamespace returns whether archives can be scoped to the namespace.
+// Archives with an empty namespace aren't scoped to a namespace.
+func isValidNamespace(namespace string) bool {
+	return len(namespace) == 0 || len(validation.IsDNS1123Label(namespace)) == 0
+}
+
+// getItem returns the item with the given ID. IDs outside of the container
+// are not found.
+func (client *StowClient) getItem(itemID string) (stow.Item, error) {
+	if !client.isValidItemID(itemID) {
+		return nil, ErrNotFound
+	}
+	item, err := client.container.Item(itemID)
 	if err != nil {
 		if err == stow.ErrNotFound {
-			return ErrNotFound
-		} else {
-			return ErrRetrievingItem
+			return nil, ErrNotFound
 		}
+		return nil, ErrRetrievingItem
 	}
+	return item, nil
+}
 
-	f, err := item.Open()
+// isValidItemID returns whether the item ID refers to an item of the container.
+// The IDs of local storage items are absolute paths.
+func (client *StowClient) isValidItemID(itemID string) bool {
+	if len(itemID) == 0 || slices.Contains(strings.Split(filepath.ToSlash(itemID), "/"), "..") {
+		return false
+	}
+	if filepath.IsAbs(itemID) && client.config.storage.getStorageType() == StorageTypeLocal {
+		return strings.HasPrefix(itemID, client.container.ID()+string(filepath.Separator))
+	}
+	return true
+}
+
+// getArchiveNamespace returns the namespace the archive is scoped to. Archives
+// without metadata aren't scoped to a namespace.
+func (client *StowClient) getArchiveNamespace(itemID string) (string, error) {
+	meta, err := client.getMetadataByID(itemID)
+	if err == ErrNotFound {
+		if _, err := client.getItem(itemID); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return meta.Namespace, nil
+}
+
+// isContentAddressed returns whether the archive is stored under its SHA-256.
+// Archives uploaded by older versions are stored under a random ID and have no
+// metadata.
+func isContentAddressed(itemID string) bool {
+	return strings.HasPrefix(path.Base(itemID), archiveNamePrefix) && !isMetadata(itemID)
+}
+
+func isMetadata(itemID string) bool {
+	return strings.HasSuffix(itemID, metadataSuffix)
+}
+
+// getMetadata returns the metadata of a content-addressed archive.
+func (client *StowClient) getMetadata(item stow.Item) (*ArchiveMetadata, error) {
+	metaItem, err := client.container.Item(item.Name() + metadataSuffix)
+	if err != nil {
+		if err == stow.ErrNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, ErrRetrievingItem
+	}
+	f, err := metaItem.Open()
 	if err != nil {
-		return ErrOpeningItem
+		return nil, ErrOpeningItem
 	}
 	defer f.Close()
 
-	_, err = io.Copy(w, f)
+	meta := &ArchiveMetadata{}
+	err = json.NewDecoder(f).Decode(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding metadata of %s: %w", item.ID(), err)
+	}
+	return meta, nil
+}
+
+// getMetadataByID returns the metadata of the archive with the given ID.
+func (client *StowClient) getMetadataByID(itemID string) (*ArchiveMetadata, error) {
+	item, err := client.getItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	return client.getMetadata(item)
+}
+
+// updateMetadata applies update to the metadata of an archive and writes it
+// back if update returns true. Missing metadata is created.
+func (client *StowClient) updateMetadata(item stow.Item, update func(meta *ArchiveMetadata) bool) (*ArchiveMetadata, error) {
+	client.metadataLock.Lock()
+	defer client.metadataLock.Unlock()
+
+	meta, err := client.getMetadata(item)
+	if err == ErrNotFound {
+		meta = &ArchiveMetadata{}
+		if size, err := item.Size(); err == nil {
+			meta.Size = size
+		}
+		if lastMod, err := item.LastMod(); err == nil {
+			meta.LastUploaded = lastMod
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	meta.ID = item.ID()
+	if len(meta.SHA256) == 0 {
+		meta.SHA256 = strings.TrimPrefix(path.Base(item.Name()), archiveNamePrefix)
+	}
+
+	if !update(meta) {
+		return meta, nil
+	}
+
+	data, err := json.Marshal(meta)
 	if err != nil {
-		return ErrWritingFileIntoResponse
+		return nil, err
 	}
+	_, err = client.container.Put(item.Name()+metadataSuffix, bytes.NewReader(data), int64(len(data)), nil)
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
 
-	client.logger.Debug("successfully wrote file into httpresponse", zap.String("file", fileId))
-	return nil
+// addPackageRef records that a package references the archive.
+func (client *StowClient) addPackageRef(itemID string, pkgRef string) error {
+	item, err := client.getItem(itemID)
+	if err != nil {
+		return err
+	}
+	_, err = client.updateMetadata(item, func(meta *ArchiveMetadata) bool {
+		if slices.Contains(meta.PackageRefs, pkgRef) {
+			return false
+		}
+		meta.PackageRefs = append(meta.PackageRefs, pkgRef)
+		slices.Sort(meta.PackageRefs)
+		return true
+	})
+	return err
+}
+
+// removePackageRefs drops the package references of the archive for which
+// remove returns true.
+func (client *StowClient) removePackageRefs(itemID string, remove func(pkgRef string) bool) error {
+	item, err := client.getItem(itemID)
+	if err != nil {
+		return err
+	}
+	_, err = client.updateMetadata(item, func(meta *ArchiveMetadata) bool {
+		refs := slices.DeleteFunc(slices.Clone(meta.PackageRefs), remove)
+		if len(refs) == len(meta.PackageRefs) {
+			return false
+		}
+		meta.PackageRefs = refs
+		return true
+	})
+	return err
 }
 
-// removeFileByID deletes the file from storage
+// openFile opens the file for reading and returns its size
+func (client *StowClient) openFile(fileId string) (io.ReadCloser, int64, error) {
+	item, err := client.getItem(fileId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size, err := item.Size()
+	if err != nil {
+		return nil, 0, ErrRetrievingItem
+	}
+	f, err := item.Open()
+	if err != nil {
+		return nil, 0, ErrOpeningItem
+	}
+	return f, size, nil
+}
+
+// removeFileByID deletes the file and its metadata from storage
 func (client *StowClient) removeFileByID(itemID string) error {
+	item, err := client.getItem(itemID)
+	if err != nil {
+		return err
+	}
+	if isContentAddressed(itemID) {
+		metaItem, err := client.container.Item(item.Name() + metadataSuffix)
+		if err == nil {
+			if err := client.container.RemoveItem(metaItem.ID()); err != nil {
+				return err
+			}
+		}
+	}
 	return client.container.RemoveItem(itemID)
 }
 
-func (client *StowClient) getFileSize(itemID string) (int64, error) {
-	item, err := client.container.Item(itemID)
+// removeUnreferencedFile deletes the file and its metadata from storage,
+// unless packages still reference it.
+func (client *StowClient) removeUnreferencedFile(itemID string) error {
+	// hold the metadata lock so that no reference is added meanwhile
+	client.metadataLock.Lock()
+	defer client.metadataLock.Unlock()
+
+	item, err := client.getItem(itemID)
 	if err != nil {
-		if err == stow.ErrNotFound {
-			return 0, ErrNotFound
-		} else {
-			return 0, ErrRetrievingItem
+		return err
+	}
+	if isContentAddressed(itemID) {
+		meta, err := client.getMetadata(item)
+		if err != nil && err != ErrNotFound {
+			return err
 		}
+		if err == nil && len(meta.PackageRefs) > 0 {
+			return fmt.Errorf("%w: %s", ErrArchiveInUse, strings.Join(meta.PackageRefs, ", "))
+		}
+	}
+	return client.removeFileByID(itemID)
+}
+
+func (client *StowClient) getFileSize(itemID string) (int64, error) {
+	item, err := client.getItem(itemID)
+	if err != nil {
+		return 0, err
 	}
 	return item.Size()
 }
@@ -213,6 +498,9 @@ func (client *StowClient) getItemIDsWithFilter(filterFunc filter, filterFuncPara
 		}
 
 		for _, item := range items {
+			if isMetadata(item.ID()) {
+				continue
+			}
 			isItemFilterable := filterFunc(item, filterFuncParam)
 			if isItemFilterable {
 				continue
@@ -230,7 +518,7 @@ func (client *StowClient) getItemIDsWithFilter(filterFunc filter, filterFuncPara
 
 // filterItemCreatedAMinuteAgo is one type of filter function that filters out items created less than a minute ago.
 // More filter functions can be written if needed, as long as they are of type filter
-func (client StowClient) filterItemCreatedAMinuteAgo(item stow.Item, currentTime interface{}) bool {
+func (client *StowClient) filterItemCreatedAMinuteAgo(item stow.Item, currentTime interface{}) bool {
 	itemLastModTime, _ := item.LastMod()
 	if currentTime.(time.Time).Sub(itemLastModTime) < 1*time.Minute {
 
@@ -242,7 +530,7 @@ func (client StowClient) filterItemCreatedAMinuteAgo(item stow.Item, currentTime
 	return false
 }
 
-func (client StowClient) filterAllItems(item stow.Item, _ interface{}) bool {
+func (client *StowClient) filterAllItems(item stow.Item, _ interface{}) bool {
 	itemLastModTime, _ := item.LastMod()
 	client.logger.Debug("item info",
 		zap.String("item", item.ID()),
@@ -250,3 +538,12 @@ func (client StowClient) filterAllItems(item stow.Item, _ interface{}) bool {
 	return false
 
 }
+
+// filterOtherNamespaces filters out items that aren't scoped to the namespace.
+func (client *StowClient) filterOtherNamespaces(item stow.Item, namespace interface{}) bool {
+	meta, err := client.getMetadata(item)
+	if err != nil {
+		return len(namespace.(string)) > 0
+	}
+	return meta.Namespace != namespace.(string)
+}
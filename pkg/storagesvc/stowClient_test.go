@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	fissionfake "github.com/fission/fission/pkg/generated/clientset/versioned/fake"
+	"github.com/fission/fission/pkg/utils"
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func makeTestStowClient(t *testing.T) *StowClient {
+	t.Helper()
+	client, err := MakeStowClient(loggerfactory.GetLogger(), NewLocalStorage(t.TempDir()))
+	require.NoError(t, err)
+	return client
+}
+
+func putTestFile(t *testing.T, client *StowClient, content string) string {
+	t.Helper()
+	id, _, err := client.putFile(strings.NewReader(content), int64(len(content)), "", "", "test")
+	require.NoError(t, err)
+	return id
+}
+
+func TestPutFileDeduplicates(t *testing.T) {
+	client := makeTestStowClient(t)
+	content := "archive"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+
+	id, written, err := client.putFile(strings.NewReader(content), int64(len(content)), checksum, "", "fission-cli")
+	require.NoError(t, err)
+	require.True(t, written)
+	require.True(t, isContentAddressed(id))
+
+	meta, err := client.getMetadataByID(id)
+	require.NoError(t, err)
+	require.Equal(t, id, meta.ID)
+	require.Equal(t, checksum, meta.SHA256)
+	require.Equal(t, int64(len(content)), meta.Size)
+	require.Equal(t, "fission-cli", meta.Uploader)
+
+	sameID, written, err := client.putFile(strings.NewReader(content), int64(len(content)), "", "", "fetcher")
+	require.NoError(t, err)
+	require.False(t, written)
+	require.Equal(t, id, sameID)
+
+	reuploaded, err := client.getMetadataByID(id)
+	require.NoError(t, err)
+	require.Equal(t, "fission-cli", reuploaded.Uploader)
+	require.Equal(t, meta.Created, reuploaded.Created)
+	require.False(t, reuploaded.LastUploaded.Before(meta.LastUploaded))
+
+	_, _, err = client.putFile(strings.NewReader("tampered"), int64(len("tampered")), checksum, "", "fission-cli")
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	ids, err := client.getItemIDsWithFilter(client.filterAllItems, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{id}, ids)
+
+	foundID, err := client.getItemBySHA256("", checksum)
+	require.NoError(t, err)
+	require.Equal(t, id, foundID)
+
+	require.NoError(t, client.removeFileByID(id))
+	_, err = client.getItemBySHA256("", checksum)
+	require.ErrorIs(t, err, ErrNotFound)
+	ids, err = client.getItemIDsWithFilter(client.filterAllItems, false)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestUploadAndLookupHandlers(t *testing.T) {
+	ss := MakeStorageService(loggerfactory.GetLogger(), makeTestStowClient(t), 0)
+	server := httptest.NewServer(ss.Handler())
+	t.Cleanup(server.Close)
+
+	content := "archive"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+
+	upload := func(checksum string) *http.Response {
+		buf := &bytes.Buffer{}
+		bodyWriter := multipart.NewWriter(buf)
+		require.NoError(t, bodyWriter.WriteField(MetadataUploader, "fission-cli"))
+		fileWriter, err := bodyWriter.CreateFormFile("uploadfile", "archive.zip")
+		require.NoError(t, err)
+		_, err = fileWriter.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, bodyWriter.Close())
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, server.URL+"/v1/archive", buf)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", bodyWriter.FormDataContentType())
+		req.Header.Set("X-File-Size", fmt.Sprint(len(content)))
+		req.Header.Set("X-File-Sha256", checksum)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	lookup := func(checksum string) *http.Response {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/v1/archive/sha256/"+checksum, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := lookup(checksum)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = upload(strings.Repeat("0", sha256.Size*2))
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = upload(checksum)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var uploaded UploadResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploaded))
+
+	resp = lookup(checksum)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var found UploadResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&found))
+	require.Equal(t, uploaded.ID, found.ID)
+
+	meta, err := ss.storageClient.getMetadataByID(found.ID)
+	require.NoError(t, err)
+	require.Equal(t, "fission-cli", meta.Uploader)
+
+	resp = lookup("../../etc/passwd")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestArchivePrunerRefCounting(t *testing.T) {
+	client := makeTestStowClient(t)
+	pruner := &ArchivePruner{
+		logger:      loggerfactory.GetLogger(),
+		pkgInformer: utils.GetInformersForNamespaces(fissionfake.NewSimpleClientset(), time.Minute, fv1.PackagesResource),
+		stowClient:  client,
+	}
+	handler := pruner.packageInformerHandler()
+
+	shared := putTestFile(t, client, "shared")
+	unused := putTestFile(t, client, "unused")
+	stale := putTestFile(t, client, "stale")
+	require.NoError(t, client.addPackageRef(stale, metav1.NamespaceDefault+"/deleted"))
+	legacyItem, err := client.container.Put("legacy", strings.NewReader("legacy"), int64(len("legacy")), nil)
+	require.NoError(t, err)
+	legacy := legacyItem.ID()
+
+	archiveURL := func(id string) string {
+		return "http://storagesvc.fission/v1/archive?id=" + id
+	}
+	makePackage := func(name, archiveID string) *fv1.Package {
+		return &fv1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec: fv1.PackageSpec{
+				Deployment: fv1.Archive{Type: fv1.ArchiveTypeUrl, URL: archiveURL(archiveID)},
+			},
+		}
+	}
+	addPackage := func(pkg *fv1.Package) {
+		require.NoError(t, pruner.pkgInformer[metav1.NamespaceDefault].GetStore().Add(pkg))
+		handler.OnAdd(pkg, false)
+	}
+	deletePackage := func(pkg *fv1.Package) {
+		require.NoError(t, pruner.pkgInformer[metav1.NamespaceDefault].GetStore().Delete(pkg))
+		handler.OnDelete(pkg)
+	}
+
+	first := makePackage("first", shared)
+	second := makePackage("second", shared)
+	legacyPkg := makePackage("legacy", legacy)
+	addPackage(first)
+	addPackage(second)
+	addPackage(legacyPkg)
+
+	meta, err := client.getMetadataByID(shared)
+	require.NoError(t, err)
+	require.Equal(t, []string{"default/first", "default/second"}, meta.PackageRefs)
+
+	// archives within the grace period are kept
+	require.Empty(t, pruner.getOrphanArchives(time.Now()))
+
+	later := time.Now().Add(2 * archiveGracePeriod)
+	require.ElementsMatch(t, []string{unused, stale}, pruner.getOrphanArchives(later))
+	meta, err = client.getMetadataByID(stale)
+	require.NoError(t, err)
+	require.Empty(t, meta.PackageRefs)
+
+	deletePackage(first)
+	require.ElementsMatch(t, []string{unused, stale}, pruner.getOrphanArchives(later))
+
+	updated := second.DeepCopy()
+	updated.Spec.Deployment.URL = archiveURL(unused)
+	require.NoError(t, pruner.pkgInformer[metav1.NamespaceDefault].GetStore().Update(updated))
+	handler.OnUpdate(second, updated)
+	require.ElementsMatch(t, []string{shared, stale}, pruner.getOrphanArchives(later))
+
+	deletePackage(legacyPkg)
+	require.ElementsMatch(t, []string{shared, stale, legacy}, pruner.getOrphanArchives(later))
+}
+
+func TestDeleteHandlerKeepsReferencedArchives(t *testing.T) {
+	ss := MakeStorageService(loggerfactory.GetLogger(), makeTestStowClient(t), 0)
+	server := httptest.NewServer(ss.Handler())
+	t.Cleanup(server.Close)
+
+	id := putTestFile(t, ss.storageClient, "archive")
+	require.NoError(t, ss.storageClient.addPackageRef(id, "default/hello"))
+
+	remove := func() *http.Response {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodDelete, server.URL+"/v1/archive?id="+id, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := remove()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	_, err := ss.storageClient.getItem(id)
+	require.NoError(t, err)
+
+	require.NoError(t, ss.storageClient.removePackageRefs(id, func(pkgRef string) bool {
+		return pkgRef == "default/hello"
+	}))
+	resp = remove()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = ss.storageClient.getItem(id)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,562 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	otelUtils "github.com/fission/fission/pkg/utils/otel"
+	"github.com/fission/fission/pkg/utils/uuid"
+)
+
+// A resumable upload sends an archive in several requests:
+//
+//   - POST /v1/uploads starts an upload session, with the optional
+//     "X-File-Size" header declaring the size of the archive.
+//   - PATCH /v1/uploads/{id} appends the request body to the archive. The
+//     "X-Upload-Offset" header must match the size received so far.
+//   - HEAD /v1/uploads/{id} returns the size received so far in the
+//     "X-Upload-Offset" header, to resume an interrupted upload.
+//   - POST /v1/uploads/{id}/complete stores the archive once its SHA-256
+//     matches the "X-File-Sha256" header.
+//   - DELETE /v1/uploads/{id} aborts the upload.
+//
+// The parts are written to a staging file on the local disk, so neither
+// the parts nor the archive are held in memory. The state of the session is
+// kept next to the staging file, so that uploads survive a restart of the
+// storage service. Uploads can't exceed the maximum upload size, whether
+// their size is declared or not.
+
+const (
+	// UploadOffsetHeader holds the offset of an upload part
+	UploadOffsetHeader = "X-Upload-Offset"
+
+	// uploadSessionTTL is the time after which an idle upload session expires
+	uploadSessionTTL = 24 * time.Hour
+
+	// DefaultMaxUploadSize is the default size limit of uploaded archives
+	DefaultMaxUploadSize int64 = 1 << 30
+
+	// uploadStateSuffix is the suffix of the file holding the state of
+	// an upload session, next to its staging file
+	uploadStateSuffix = ".json"
+)
+
+var (
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadOffsetMismatch = errors.New("upload offset mismatch")
+	ErrUploadTooLarge       = errors.New("upload exceeds its size limit")
+	ErrUploadIncomplete     = errors.New("upload is incomplete")
+)
+
+type (
+	// UploadSession is the state of a resumable upload.
+	UploadSession struct {
+		ID     string `json:"id"`
+		Offset int64  `json:"offset"`
+	}
+
+	uploadSession struct {
+		// lock serializes the writes to the staging file
+		lock      sync.Mutex
+		id        string
+		path      string
+		size      int64
+		offset    int64
+		namespace string
+		uploader  string
+		lastUsed  time.Time
+		// limit is the size the upload can't exceed, the declared
+		// size if any, or else the maximum upload size
+		limit int64
+	}
+
+	// uploadSessionState is the state of an upload session stored next
+	// to its staging file. The offset is the size of the staging file.
+	uploadSessionState struct {
+		ID        string `json:"id"`
+		Size      int64  `json:"size,omitempty"`
+		Namespace string `json:"namespace,omitempty"`
+		Uploader  string `json:"uploader,omitempty"`
+	}
+
+	uploadManager struct {
+		logger   *zap.Logger
+		dir      string
+		maxSize  int64
+		lock     sync.Mutex
+		sessions map[string]*uploadSession
+	}
+)
+
+// makeUploadManager makes an upload manager staging uploads in dir, and
+// restores the upload sessions left there by a previous run.
+func makeUploadManager(logger *zap.Logger, dir string, maxSize int64) *uploadManager {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxUploadSize
+	}
+	um := &uploadManager{
+		logger:   logger.Named("upload_manager"),
+		dir:      dir,
+		maxSize:  maxSize,
+		sessions: make(map[string]*uploadSession),
+	}
+	um.restore()
+	return um
+}
+
+// create starts an upload session. A positive size is the declared size of the archive.
+func (um *uploadManager) create(size int64, namespace string, uploader string) (*uploadSession, error) {
+	if size > um.maxSize {
+		return nil, ErrUploadTooLarge
+	}
+	err := os.MkdirAll(um.dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("error creating upload directory: %w", err)
+	}
+	id := uuid.NewString()
+	path := filepath.Join(um.dir, id)
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating upload file: %w", err)
+	}
+	f.Close()
+
+	state, err := json.Marshal(&uploadSessionState{
+		ID:        id,
+		Size:      size,
+		Namespace: namespace,
+		Uploader:  uploader,
+	})
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	err = os.WriteFile(path+uploadStateSuffix, state, 0600)
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("error writing upload state: %w", err)
+	}
+
+	session := &uploadSession{
+		id:        id,
+		path:      path,
+		size:      size,
+		namespace: namespace,
+		uploader:  uploader,
+		lastUsed:  time.Now(),
+		limit:     um.limit(size),
+	}
+	um.lock.Lock()
+	um.sessions[id] = session
+	um.lock.Unlock()
+	return session, nil
+}
+
+// limit returns the size limit of an upload of the given declared size.
+func (um *uploadManager) limit(size int64) int64 {
+	if size > 0 && size < um.maxSize {
+		return size
+	}
+	return um.maxSize
+}
+
+// restore loads the upload sessions of the staging directory, and removes
+// the staging files without a session state and the other way around.
+func (um *uploadManager) restore() {
+	entries, err := os.ReadDir(um.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			um.logger.Error("error reading upload directory", zap.Error(err), zap.String("directory", um.dir))
+		}
+		return
+	}
+
+	states := make(map[string]bool)
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), uploadStateSuffix); ok {
+			states[name] = true
+		}
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(um.dir, name)
+		if entry.IsDir() || strings.HasSuffix(name, uploadStateSuffix) {
+			continue
+		}
+		if !states[name] {
+			um.logger.Info("removing upload file without state", zap.String("file", path))
+			um.removeFile(path)
+			continue
+		}
+		delete(states, name)
+
+		session, err := um.load(path)
+		if err != nil {
+			um.logger.Error("error restoring upload, removing it", zap.Error(err), zap.String("file", path))
+			um.removeFile(path)
+			um.removeFile(path + uploadStateSuffix)
+			continue
+		}
+		um.sessions[session.id] = session
+	}
+	// states without a staging file
+	for name := range states {
+		um.removeFile(filepath.Join(um.dir, name+uploadStateSuffix))
+	}
+	if len(um.sessions) > 0 {
+		um.logger.Info("restored uploads", zap.Int("count", len(um.sessions)))
+	}
+}
+
+// load returns the upload session of the staging file at path.
+func (um *uploadManager) load(path string) (*uploadSession, error) {
+	data, err := os.ReadFile(path + uploadStateSuffix)
+	if err != nil {
+		return nil, err
+	}
+	var state uploadSessionState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	if state.ID != filepath.Base(path) {
+		return nil, fmt.Errorf("upload state of %q doesn't match its file", state.ID)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadSession{
+		id:        state.ID,
+		path:      path,
+		size:      state.Size,
+		offset:    info.Size(),
+		namespace: state.Namespace,
+		uploader:  state.Uploader,
+		lastUsed:  info.ModTime(),
+		limit:     um.limit(state.Size),
+	}, nil
+}
+
+func (um *uploadManager) removeFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		um.logger.Error("error removing upload file", zap.Error(err), zap.String("file", path))
+	}
+}
+
+func (um *uploadManager) get(id string) (*uploadSession, error) {
+	um.lock.Lock()
+	defer um.lock.Unlock()
+	session, ok := um.sessions[id]
+	if !ok {
+		return nil, ErrUploadNotFound
+	}
+	return session, nil
+}
+
+// remove drops an upload session, its staging file and its state.
+func (um *uploadManager) remove(session *uploadSession) {
+	um.lock.Lock()
+	delete(um.sessions, session.id)
+	um.lock.Unlock()
+	um.removeFile(session.path)
+	um.removeFile(session.path + uploadStateSuffix)
+}
+
+// removeExpired drops the upload sessions idle for longer than ttl.
+func (um *uploadManager) removeExpired(now time.Time, ttl time.Duration) {
+	um.lock.Lock()
+	sessions := make([]*uploadSession, 0, len(um.sessions))
+	for _, session := range um.sessions {
+		sessions = append(sessions, session)
+	}
+	um.lock.Unlock()
+
+	for _, session := range sessions {
+		session.lock.Lock()
+		if now.Sub(session.lastUsed) > ttl {
+			um.logger.Info("removing expired upload", zap.String("upload_id", session.id))
+			um.remove(session)
+		}
+		session.lock.Unlock()
+	}
+}
+
+// write appends r to the staging file of the session at offset, which must
+// be the size received so far. Bytes received before a failure are kept, so
+// the upload can resume from the new offset.
+func (session *uploadSession) write(offset int64, r io.Reader) (int64, error) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	session.lastUsed = time.Now()
+
+	if offset != session.offset {
+		return session.offset, ErrUploadOffsetMismatch
+	}
+
+	f, err := os.OpenFile(session.path, os.O_WRONLY, 0600)
+	if err != nil {
+		return session.offset, err
+	}
+	defer f.Close()
+	_, err = f.Seek(session.offset, io.SeekStart)
+	if err != nil {
+		return session.offset, err
+	}
+
+	// read one byte past the limit to detect oversized uploads
+	n, err := io.Copy(f, io.LimitReader(r, session.limit-session.offset+1))
+	session.offset += n
+	if err != nil {
+		return session.offset, err
+	}
+	if session.offset > session.limit {
+		session.offset = session.limit
+		if err := f.Truncate(session.limit); err != nil {
+			return session.offset, err
+		}
+		return session.offset, ErrUploadTooLarge
+	}
+	return session.offset, nil
+}
+
+func (session *uploadSession) currentOffset() int64 {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	return session.offset
+}
+
+// Start removes the expired upload sessions until ctx is done.
+func (um *uploadManager) Start(ctx context.Context) {
+	// sessions restored may have expired while the service was down
+	um.removeExpired(time.Now(), uploadSessionTTL)
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			um.removeExpired(time.Now(), uploadSessionTTL)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ss *StorageService) writeUploadSession(w http.ResponseWriter, r *http.Request, offset int64, id string) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	resp, err := json.Marshal(&UploadSession{ID: id, Offset: offset})
+	if err != nil {
+		http.Error(w, "Error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(UploadOffsetHeader, strconv.FormatInt(offset, 10))
+	_, err = w.Write(resp)
+	if err != nil {
+		logger.Error(
+			"error writing HTTP response",
+			zap.Error(err),
+			zap.String("upload_id", id),
+		)
+	}
+}
+
+// getUploadSession returns the upload session of the request, if the
+// request can upload archives to the namespace of the session.
+func (ss *StorageService) getUploadSession(w http.ResponseWriter, r *http.Request) *uploadSession {
+	user, ok := ss.authenticate(w, r)
+	if !ok {
+		return nil
+	}
+	session, err := ss.uploads.get(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil
+	}
+	if !ss.authorize(w, r, user, session.namespace, verbCreate) {
+		return nil
+	}
+	return session
+}
+
+// createUploadHandler starts a resumable upload.
+func (ss *StorageService) createUploadHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	var size int64
+	if fileSize := r.Header.Get("X-File-Size"); len(fileSize) > 0 {
+		var err error
+		size, err = strconv.ParseInt(fileSize, 10, 64)
+		if err != nil || size < 0 {
+			http.Error(w, "bad X-File-Size header", http.StatusBadRequest)
+			return
+		}
+	}
+
+	namespace := r.URL.Query().Get(MetadataNamespace)
+	if !isValidNamespace(namespace) {
+		http.Error(w, "invalid namespace", http.StatusBadRequest)
+		return
+	}
+	if !ss.checkAccess(w, r, namespace, verbCreate) {
+		return
+	}
+
+	session, err := ss.uploads.create(size, namespace, r.URL.Query().Get(MetadataUploader))
+	if err == ErrUploadTooLarge {
+		http.Error(w, fmt.Sprintf("upload exceeds the maximum size of %d bytes", ss.uploads.maxSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		logger.Error("error creating upload", zap.Error(err))
+		http.Error(w, "Error creating upload", http.StatusInternalServerError)
+		return
+	}
+	logger.Debug("created upload", zap.String("upload_id", session.id), zap.Int64("size", size))
+
+	ss.writeUploadSession(w, r, 0, session.id)
+}
+
+// uploadPartHandler appends a part to a resumable upload.
+func (ss *StorageService) uploadPartHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	session := ss.getUploadSession(w, r)
+	if session == nil {
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get(UploadOffsetHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "missing or bad "+UploadOffsetHeader+" header", http.StatusBadRequest)
+		return
+	}
+
+	newOffset, err := session.write(offset, r.Body)
+	w.Header().Set(UploadOffsetHeader, strconv.FormatInt(newOffset, 10))
+	switch {
+	case err == ErrUploadOffsetMismatch:
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err == ErrUploadTooLarge:
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	case err != nil:
+		logger.Error("error writing upload part", zap.Error(err), zap.String("upload_id", session.id))
+		http.Error(w, "Error writing upload part", http.StatusInternalServerError)
+		return
+	}
+
+	ss.writeUploadSession(w, r, newOffset, session.id)
+}
+
+// uploadOffsetHandler returns the size received so far of a resumable upload.
+func (ss *StorageService) uploadOffsetHandler(w http.ResponseWriter, r *http.Request) {
+	session := ss.getUploadSession(w, r)
+	if session == nil {
+		return
+	}
+	w.Header().Set(UploadOffsetHeader, strconv.FormatInt(session.currentOffset(), 10))
+}
+
+// completeUploadHandler stores the archive of a resumable upload.
+func (ss *StorageService) completeUploadHandler(w http.ResponseWriter, r *http.Request) {
+	logger := otelUtils.LoggerWithTraceID(r.Context(), ss.logger)
+
+	session := ss.getUploadSession(w, r)
+	if session == nil {
+		return
+	}
+	checksum := r.Header.Get("X-File-Sha256")
+	if len(checksum) == 0 {
+		http.Error(w, "missing X-File-Sha256 header", http.StatusBadRequest)
+		return
+	}
+
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	if session.size > 0 && session.offset != session.size {
+		http.Error(w, ErrUploadIncomplete.Error(), http.StatusConflict)
+		return
+	}
+
+	f, err := os.Open(session.path)
+	if err != nil {
+		logger.Error("error opening upload file", zap.Error(err), zap.String("upload_id", session.id))
+		http.Error(w, "Error opening upload", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	id, written, err := ss.storageClient.putFile(f, session.offset, checksum, session.namespace, session.uploader)
+	if err == ErrChecksumMismatch {
+		// the upload can't be resumed past a corrupted part
+		ss.uploads.remove(session)
+		http.Error(w, "uploaded file doesn't match X-File-Sha256 header", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		logger.Error("error saving uploaded file", zap.Error(err), zap.String("upload_id", session.id))
+		http.Error(w, "Error saving uploaded file", http.StatusInternalServerError)
+		return
+	}
+	ss.uploads.remove(session)
+
+	if written {
+		totalArchives.WithLabelValues().Inc()
+		totalMemoryUsage.WithLabelValues().Add(float64(session.offset))
+	}
+
+	resp, err := json.Marshal(&UploadResponse{ID: id})
+	if err != nil {
+		http.Error(w, "Error marshaling response", http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		logger.Error(
+			"error writing HTTP response",
+			zap.Error(err),
+			zap.String("upload_id", session.id),
+		)
+	}
+}
+
+// abortUploadHandler aborts a resumable upload.
+func (ss *StorageService) abortUploadHandler(w http.ResponseWriter, r *http.Request) {
+	session := ss.getUploadSession(w, r)
+	if session == nil {
+		return
+	}
+	ss.uploads.remove(session)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagesvc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fission/fission/pkg/utils/loggerfactory"
+)
+
+func TestResumableUpload(t *testing.T) {
+	t.Setenv("STORAGE_UPLOAD_DIR", t.TempDir())
+	ss := MakeStorageService(loggerfactory.GetLogger(), makeTestStowClient(t), 0)
+	server := httptest.NewServer(ss.Handler())
+	t.Cleanup(server.Close)
+
+	content := "resumable archive"
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+
+	do := func(method, path string, body string, header map[string]string) *http.Response {
+		req, err := http.NewRequestWithContext(t.Context(), method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := do(http.MethodPost, "/v1/uploads?uploader=fission-cli", "", map[string]string{"X-File-Size": fmt.Sprint(len(content))})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var session UploadSession
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&session))
+	uploadPath := "/v1/uploads/" + session.ID
+
+	resp = do(http.MethodPatch, uploadPath, content[:8], map[string]string{UploadOffsetHeader: "0"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "8", resp.Header.Get(UploadOffsetHeader))
+
+	// a part at the wrong offset is rejected with the expected offset
+	resp = do(http.MethodPatch, uploadPath, content[4:], map[string]string{UploadOffsetHeader: "4"})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	require.Equal(t, "8", resp.Header.Get(UploadOffsetHeader))
+
+	resp = do(http.MethodPost, uploadPath+"/complete", "", map[string]string{"X-File-Sha256": checksum})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = do(http.MethodHead, uploadPath, "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "8", resp.Header.Get(UploadOffsetHeader))
+
+	resp = do(http.MethodPatch, uploadPath, content[8:]+"extra", map[string]string{UploadOffsetHeader: "8"})
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	require.Equal(t, fmt.Sprint(len(content)), resp.Header.Get(UploadOffsetHeader))
+
+	resp = do(http.MethodPost, uploadPath+"/complete", "", map[string]string{"X-File-Sha256": checksum})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var uploaded UploadResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&uploaded))
+
+	meta, err := ss.storageClient.getMetadataByID(uploaded.ID)
+	require.NoError(t, err)
+	require.Equal(t, checksum, meta.SHA256)
+	require.Equal(t, "fission-cli", meta.Uploader)
+
+	// completed uploads are gone
+	resp = do(http.MethodHead, uploadPath, "", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	download := func(rangeHeader string) *http.Response {
+		header := map[string]string{}
+		if rangeHeader != "" {
+			header["Range"] = rangeHeader
+		}
+		return do(http.MethodGet, "/v1/archive?id="+url.QueryEscape(uploaded.ID), "", header)
+	}
+	resp = download("")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, content, string(body))
+
+	resp = download("bytes=10-")
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, fmt.Sprintf("bytes 10-%d/%d", len(content)-1, len(content)), resp.Header.Get("Content-Range"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, content[10:], string(body))
+
+	resp = download(fmt.Sprintf("bytes=%d-", len(content)))
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+}
+
+func TestAbortAndExpireUploads(t *testing.T) {
+	um := makeUploadManager(loggerfactory.GetLogger(), t.TempDir(), 0)
+
+	aborted, err := um.create(0, "", "")
+	require.NoError(t, err)
+	um.remove(aborted)
+	_, err = um.get(aborted.id)
+	require.ErrorIs(t, err, ErrUploadNotFound)
+
+	idle, err := um.create(0, "", "")
+	require.NoError(t, err)
+	_, err = idle.write(0, strings.NewReader("part"))
+	require.NoError(t, err)
+
+	um.removeExpired(time.Now(), uploadSessionTTL)
+	_, err = um.get(idle.id)
+	require.NoError(t, err)
+
+	um.removeExpired(time.Now().Add(2*uploadSessionTTL), uploadSessionTTL)
+	_, err = um.get(idle.id)
+	require.ErrorIs(t, err, ErrUploadNotFound)
+}
+
+func TestRestoreUploads(t *testing.T) {
+	dir := t.TempDir()
+	um := makeUploadManager(loggerfactory.GetLogger(), dir, 0)
+
+	session, err := um.create(10, "default", "fission-cli")
+	require.NoError(t, err)
+	_, err = session.write(0, strings.NewReader("part"))
+	require.NoError(t, err)
+	// staging files of older versions have no state
+	orphan := filepath.Join(dir, "orphan")
+	require.NoError(t, os.WriteFile(orphan, []byte("orphan"), 0600))
+
+	// a restart of the storage service
+	um = makeUploadManager(loggerfactory.GetLogger(), dir, 0)
+	restored, err := um.get(session.id)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), restored.currentOffset())
+	require.Equal(t, int64(10), restored.size)
+	require.Equal(t, "default", restored.namespace)
+	require.Equal(t, "fission-cli", restored.uploader)
+	require.NoFileExists(t, orphan)
+
+	_, err = restored.write(4, strings.NewReader("resumed"))
+	require.ErrorIs(t, err, ErrUploadTooLarge)
+	require.Equal(t, int64(10), restored.currentOffset())
+
+	um.remove(restored)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestMaxUploadSize(t *testing.T) {
+	um := makeUploadManager(loggerfactory.GetLogger(), t.TempDir(), 8)
+
+	_, err := um.create(9, "", "")
+	require.ErrorIs(t, err, ErrUploadTooLarge)
+
+	// uploads without a declared size are bounded too
+	session, err := um.create(0, "", "")
+	require.NoError(t, err)
+	offset, err := session.write(0, strings.NewReader("undeclared size"))
+	require.ErrorIs(t, err, ErrUploadTooLarge)
+	require.Equal(t, int64(8), offset)
+}
+
+func TestParseRange(t *testing.T) {
+	for _, test := range []struct {
+		header string
+		start  int64
+		length int64
+		err    bool
+	}{
+		{header: "", start: 0, length: 100},
+		{header: "bytes=10-", start: 10, length: 90},
+		{header: "bytes=10-19", start: 10, length: 10},
+		{header: "bytes=90-200", start: 90, length: 10},
+		{header: "bytes=-20", start: 80, length: 20},
+		{header: "bytes=-200", start: 0, length: 100},
+		{header: "bytes=0-1,5-6", start: 0, length: 100},
+		{header: "items=0-1", start: 0, length: 100},
+		{header: "bytes=100-", err: true},
+		{header: "bytes=20-10", err: true},
+		{header: "bytes=abc", err: true},
+	} {
+		t.Run(test.header, func(t *testing.T) {
+			start, length, err := parseRange(test.header, 100)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.start, start)
+			require.Equal(t, test.length, length)
+		})
+	}
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package storagesvc
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 func getQueryParamValue(urlString string, queryParam string) (string, error) {
@@ -50,3 +53,41 @@ func getDifferenceOfLists(firstList []string, secondList []string) []string {
 
 	return differenceList
 }
+
+// parseRange parses the "Range" header of a request for a file of the given
+// size. It returns the start and the length of a single byte range, or the
+// whole file if the header is empty or holds several ranges.
+func parseRange(header string, size int64) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, size, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, errors.New("invalid range")
+	}
+
+	if first == "" {
+		// suffix range: the last bytes of the file
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, errors.New("invalid range")
+		}
+		n = min(n, size)
+		return size - n, n, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, errors.New("invalid range")
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errors.New("invalid range")
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, nil
+}
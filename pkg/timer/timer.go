@@ -80,3 +80,20 @@ func (timer *Timer) newCron(t fv1.TimeTrigger, routerUrl string) *cron.Cron {
 	timer.logger.Info("started cron for time trigger", zap.String("trigger_name", t.Name), zap.String("trigger_namespace", t.Namespace), zap.String("cron", t.Spec.Cron))
 	return c
 }
+
+// Sync replaces the crons of the timer with the ones of triggers, for running
+// time triggers that aren't watched on a cluster.
+func (timer *Timer) Sync(triggers []fv1.TimeTrigger) {
+	for key, item := range timer.triggers {
+		item.cron.Stop()
+		delete(timer.triggers, key)
+	}
+	for _, t := range triggers {
+		// triggers read from specs have no UID
+		key := types.UID(t.Namespace + "/" + t.Name)
+		timer.triggers[key] = &timerTriggerWithCron{
+			trigger: t,
+			cron:    timer.newCron(t, timer.routerUrl),
+		}
+	}
+}
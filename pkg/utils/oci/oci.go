@@ -0,0 +1,332 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oci pushes and pulls package archives as OCI artifacts. An
+// artifact holds a single layer, the archive file itself.
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	// URLScheme prefixes the references of OCI archives.
+	URLScheme = "oci://"
+
+	// ArtifactType is the artifact type of package archives.
+	ArtifactType = "application/vnd.fission.package.v1"
+
+	// LayerMediaType is the media type of the archive layer.
+	LayerMediaType = "application/vnd.fission.package.layer.v1"
+)
+
+// IsURL returns true if the URL references an OCI archive.
+func IsURL(url string) bool {
+	return strings.HasPrefix(url, URLScheme)
+}
+
+// ParseURL parses an "oci://registry/repository:tag@digest" URL. The tag is
+// ignored when a digest is given.
+func ParseURL(url string) (registry.Reference, error) {
+	if !IsURL(url) {
+		return registry.Reference{}, fmt.Errorf("%q is not an OCI reference, expected %sregistry/repository:tag@digest", url, URLScheme)
+	}
+	ref, err := registry.ParseReference(strings.TrimPrefix(url, URLScheme))
+	if err != nil {
+		return registry.Reference{}, fmt.Errorf("error parsing OCI reference %q: %w", url, err)
+	}
+	return ref, nil
+}
+
+// NewRepository returns the remote repository of a reference, accessed with
+// the given credentials. Without credentials, they are read from the docker
+// config file, see credentials.NewStoreFromDocker. Registries on the
+// loopback interface are accessed over plain HTTP.
+func NewRepository(ref registry.Reference, credential auth.CredentialFunc) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", ref.Registry, ref.Repository))
+	if err != nil {
+		return nil, err
+	}
+	repo.PlainHTTP = isLoopback(ref.Host())
+
+	client := &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credential,
+	}
+	if credential == nil {
+		store, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+		if err == nil {
+			client.Credential = credentials.Credential(store)
+		}
+		// without a docker config the registry is accessed anonymously
+	}
+	repo.Client = client
+	return repo, nil
+}
+
+// dockerConfigAuth is a registry entry of a docker config.
+type dockerConfigAuth struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// DockerConfigCredential returns the credentials of the registries of a
+// docker config, the content of a ".dockerconfigjson" or of a legacy
+// ".dockercfg" image pull secret. Registries missing from the config are
+// accessed anonymously.
+func DockerConfigCredential(config []byte) (auth.CredentialFunc, error) {
+	var cfg struct {
+		Auths map[string]dockerConfigAuth `json:"auths"`
+	}
+	err := json.Unmarshal(config, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding docker config: %w", err)
+	}
+	auths := cfg.Auths
+	if auths == nil {
+		// legacy configs hold the registries at the top level
+		err = json.Unmarshal(config, &auths)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding docker config: %w", err)
+		}
+	}
+
+	creds := make(map[string]auth.Credential, len(auths))
+	for server, a := range auths {
+		cred := auth.Credential{
+			Username:     a.Username,
+			Password:     a.Password,
+			RefreshToken: a.IdentityToken,
+			AccessToken:  a.RegistryToken,
+		}
+		if len(a.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding auth of registry %s: %w", server, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth of registry %s, expected username:password", server)
+			}
+			cred.Username, cred.Password = username, password
+		}
+		creds[registryHost(server)] = cred
+	}
+
+	return func(_ context.Context, hostport string) (auth.Credential, error) {
+		if cred, ok := creds[hostport]; ok {
+			return cred, nil
+		}
+		// docker hub is accessed at registry-1.docker.io
+		if hostport == "registry-1.docker.io" {
+			for _, host := range []string{"index.docker.io", "docker.io"} {
+				if cred, ok := creds[host]; ok {
+					return cred, nil
+				}
+			}
+		}
+		return auth.EmptyCredential, nil
+	}, nil
+}
+
+// registryHost returns the host of a docker config server address, which
+// may be a URL such as "https://index.docker.io/v1/".
+func registryHost(server string) string {
+	if _, rest, ok := strings.Cut(server, "://"); ok {
+		server = rest
+	}
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
+
+func isLoopback(host string) bool {
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+	if hostname == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(hostname)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Push pushes the archive file as an artifact to the target and tags it if
+// a tag is given. It returns the descriptor of the artifact manifest.
+func Push(ctx context.Context, target oras.Target, tag string, file string) (ocispec.Descriptor, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error computing digest of %s: %w", file, err)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	layer := ocispec.Descriptor{
+		MediaType: LayerMediaType,
+		Digest:    dgst,
+		Size:      fi.Size(),
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: filepath.Base(file),
+		},
+	}
+	exists, err := target.Exists(ctx, layer)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if !exists {
+		err = target.Push(ctx, layer, f)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("error pushing archive layer: %w", err)
+		}
+	}
+
+	manifest, err := oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, ArtifactType, oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error pushing archive manifest: %w", err)
+	}
+
+	if len(tag) > 0 {
+		err = target.Tag(ctx, manifest, tag)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("error tagging archive: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// Pull pulls the archive of the artifact referenced by a tag or digest from
+// the target and writes it to the file. The manifest and the layer are
+// verified against their digests.
+func Pull(ctx context.Context, target oras.ReadOnlyTarget, reference string, file string) (ocispec.Descriptor, error) {
+	manifestDesc, err := target.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error resolving %s: %w", reference, err)
+	}
+	if expected, err := digest.Parse(reference); err == nil && manifestDesc.Digest != expected {
+		return ocispec.Descriptor{}, fmt.Errorf("digest mismatch: expected %s, got %s", expected, manifestDesc.Digest)
+	}
+
+	// FetchAll verifies the manifest against its digest
+	manifestJSON, err := content.FetchAll(ctx, target, manifestDesc)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error fetching manifest of %s: %w", reference, err)
+	}
+	var manifest ocispec.Manifest
+	err = json.Unmarshal(manifestJSON, &manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error decoding manifest of %s: %w", reference, err)
+	}
+	if len(manifest.Layers) != 1 {
+		return ocispec.Descriptor{}, fmt.Errorf("expected a single layer in %s, found %d", reference, len(manifest.Layers))
+	}
+	layer := manifest.Layers[0]
+
+	rc, err := target.Fetch(ctx, layer)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("error fetching archive layer of %s: %w", reference, err)
+	}
+	defer rc.Close()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	vr := content.NewVerifyReader(rc, layer)
+	_, err = io.Copy(f, vr)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = vr.Verify()
+	}
+	if err != nil {
+		os.Remove(file)
+		return ocispec.Descriptor{}, fmt.Errorf("error writing archive layer of %s: %w", reference, err)
+	}
+	return manifestDesc, nil
+}
+
+// PushFile pushes the archive file to the repository of an OCI URL and
+// returns the URL pinned to the digest of the artifact.
+func PushFile(ctx context.Context, url string, file string, credential auth.CredentialFunc) (string, error) {
+	ref, err := ParseURL(url)
+	if err != nil {
+		return "", err
+	}
+	if _, err := ref.Digest(); err == nil {
+		return "", errors.New("cannot push to a digest reference, use a tag")
+	}
+	repo, err := NewRepository(ref, credential)
+	if err != nil {
+		return "", err
+	}
+	manifest, err := Push(ctx, repo, ref.ReferenceOrDefault(), file)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s/%s:%s@%s", URLScheme, ref.Registry, ref.Repository, ref.ReferenceOrDefault(), manifest.Digest), nil
+}
+
+// PullFile pulls the archive referenced by an OCI URL to the file.
+func PullFile(ctx context.Context, url string, file string, credential auth.CredentialFunc) error {
+	ref, err := ParseURL(url)
+	if err != nil {
+		return err
+	}
+	repo, err := NewRepository(ref, credential)
+	if err != nil {
+		return err
+	}
+	_, err = Pull(ctx, repo, ref.ReferenceOrDefault(), file)
+	return err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestParseURL(t *testing.T) {
+	dgst := digest.FromString("archive").String()
+
+	ref, err := ParseURL("oci://registry.example.com/fission/hello:v1@" + dgst)
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com", ref.Registry)
+	require.Equal(t, "fission/hello", ref.Repository)
+	require.Equal(t, dgst, ref.Reference)
+
+	ref, err = ParseURL("oci://localhost:5000/hello")
+	require.NoError(t, err)
+	require.Equal(t, "latest", ref.ReferenceOrDefault())
+
+	_, err = ParseURL("https://registry.example.com/hello:v1")
+	require.Error(t, err)
+	_, err = ParseURL("oci://hello")
+	require.Error(t, err)
+}
+
+func TestIsLoopback(t *testing.T) {
+	require.True(t, isLoopback("localhost:5000"))
+	require.True(t, isLoopback("127.0.0.1:5000"))
+	require.True(t, isLoopback("[::1]:5000"))
+	require.False(t, isLoopback("registry.example.com"))
+}
+
+func TestPushPull(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "hello.zip")
+	err := os.WriteFile(archive, []byte("archive"), 0644)
+	require.NoError(t, err)
+
+	store := memory.New()
+	manifest, err := Push(t.Context(), store, "v1", archive)
+	require.NoError(t, err)
+
+	pulled := filepath.Join(dir, "pulled.zip")
+	desc, err := Pull(t.Context(), store, "v1", pulled)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Digest, desc.Digest)
+
+	contents, err := os.ReadFile(pulled)
+	require.NoError(t, err)
+	require.Equal(t, "archive", string(contents))
+
+	// pushing the same archive again reuses the existing layer
+	_, err = Push(t.Context(), store, "v2", archive)
+	require.NoError(t, err)
+
+	_, err = Pull(t.Context(), store, "missing", filepath.Join(dir, "other.zip"))
+	require.Error(t, err)
+	require.NoFileExists(t, filepath.Join(dir, "other.zip"))
+}
+
+func TestDockerConfigCredential(t *testing.T) {
+	config := `{"auths": {
+		"registry.example.com": {"auth": "dXNlcjpwYXNz"},
+		"https://index.docker.io/v1/": {"username": "hub", "password": "secret"},
+		"tokens.example.com:5000": {"identitytoken": "refresh"}
+	}}`
+	credential, err := DockerConfigCredential([]byte(config))
+	require.NoError(t, err)
+
+	cred, err := credential(t.Context(), "registry.example.com")
+	require.NoError(t, err)
+	require.Equal(t, auth.Credential{Username: "user", Password: "pass"}, cred)
+
+	cred, err = credential(t.Context(), "registry-1.docker.io")
+	require.NoError(t, err)
+	require.Equal(t, auth.Credential{Username: "hub", Password: "secret"}, cred)
+
+	cred, err = credential(t.Context(), "tokens.example.com:5000")
+	require.NoError(t, err)
+	require.Equal(t, auth.Credential{RefreshToken: "refresh"}, cred)
+
+	cred, err = credential(t.Context(), "other.example.com")
+	require.NoError(t, err)
+	require.Equal(t, auth.EmptyCredential, cred)
+
+	// legacy .dockercfg secrets have no "auths"
+	credential, err = DockerConfigCredential([]byte(`{"registry.example.com": {"auth": "dXNlcjpwYXNz"}}`))
+	require.NoError(t, err)
+	cred, err = credential(t.Context(), "registry.example.com")
+	require.NoError(t, err)
+	require.Equal(t, auth.Credential{Username: "user", Password: "pass"}, cred)
+
+	_, err = DockerConfigCredential([]byte(`{"auths": {"registry.example.com": {"auth": "invalid"}}}`))
+	require.Error(t, err)
+}
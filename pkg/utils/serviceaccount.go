@@ -66,6 +66,10 @@ var (
 				gvr:  &schema.GroupVersionResource{Group: "fission.io", Version: "v1", Resource: "packages"},
 				verb: "get",
 			},
+			{
+				gvr:  &schema.GroupVersionResource{Group: "fission.io", Version: "v1", Resource: "environments"},
+				verb: "get",
+			},
 			{
 				gvr:  &schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"},
 				verb: "create",
@@ -87,6 +91,10 @@ var (
 				gvr:  &schema.GroupVersionResource{Group: "fission.io", Version: "v1", Resource: "packages"},
 				verb: "get",
 			},
+			{
+				gvr:  &schema.GroupVersionResource{Group: "fission.io", Version: "v1", Resource: "environments"},
+				verb: "get",
+			},
 		},
 	}
 )
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature signs and verifies package archives. The SHA-256 digest
+// of an archive file, or of the commit ID of a git source, is signed with an
+// ed25519 key, or with the ECDSA or RSA key of an x509 certificate.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// ParsePrivateKey parses a PEM encoded PKCS#8, EC or PKCS#1 private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// ParsePublicKeys parses the PEM encoded PKIX public keys and x509
+// certificates of data. Expired or not yet valid certificates are skipped.
+func ParsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	now := time.Now()
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing public key: %w", err)
+			}
+			keys = append(keys, key)
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing certificate: %w", err)
+			}
+			if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+				continue
+			}
+			keys = append(keys, cert.PublicKey)
+		}
+	}
+	return keys, nil
+}
+
+// KeyID returns the ID of a public key, the first 16 hex digits of the
+// SHA-256 of its PKIX encoding.
+func KeyID(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// GitCommitDigest returns the digest signed for a git source: the SHA-256
+// of the ID of the commit fetched.
+func GitCommitDigest(commit string) []byte {
+	sum := sha256.Sum256([]byte(commit))
+	return sum[:]
+}
+
+// Sign signs the SHA-256 digest of an archive.
+func Sign(key crypto.Signer, digest []byte) (*fv1.ArchiveSignature, error) {
+	var sig []byte
+	var err error
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, digest)
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, k, digest)
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error signing archive: %w", err)
+	}
+
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &fv1.ArchiveSignature{
+		Signature: base64.StdEncoding.EncodeToString(sig),
+		KeyID:     keyID,
+	}, nil
+}
+
+// Verify verifies the signature of the SHA-256 digest of an archive against
+// the trusted keys. It returns the ID of the key the archive was signed with.
+func Verify(keys []crypto.PublicKey, digest []byte, signature *fv1.ArchiveSignature) (string, error) {
+	if signature == nil {
+		return "", errors.New("archive is not signed")
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return "", fmt.Errorf("error decoding signature: %w", err)
+	}
+
+	for _, key := range keys {
+		keyID, err := KeyID(key)
+		if err != nil {
+			continue
+		}
+		if len(signature.KeyID) > 0 && signature.KeyID != keyID {
+			continue
+		}
+
+		var valid bool
+		switch k := key.(type) {
+		case ed25519.PublicKey:
+			valid = ed25519.Verify(k, digest, sig)
+		case *ecdsa.PublicKey:
+			valid = ecdsa.VerifyASN1(k, digest, sig)
+		case *rsa.PublicKey:
+			valid = rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil
+		}
+		if valid {
+			return keyID, nil
+		}
+	}
+	return "", errors.New("signature does not match any trusted key")
+}
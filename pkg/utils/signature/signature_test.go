@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodePrivateKey(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func encodeCertificate(t *testing.T, key crypto.Signer, notAfter time.Time) []byte {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fission package signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestSignVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var trusted []byte
+	trusted = append(trusted, encodePublicKey(t, edKey.Public())...)
+	trusted = append(trusted, encodeCertificate(t, ecKey, time.Now().Add(time.Hour))...)
+	trusted = append(trusted, encodeCertificate(t, rsaKey, time.Now().Add(time.Hour))...)
+	keys, err := ParsePublicKeys(trusted)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	digest := sha256.Sum256([]byte("archive"))
+	otherDigest := sha256.Sum256([]byte("tampered"))
+
+	for _, key := range []crypto.Signer{edKey, ecKey, rsaKey} {
+		signer, err := ParsePrivateKey(encodePrivateKey(t, key))
+		require.NoError(t, err)
+
+		sig, err := Sign(signer, digest[:])
+		require.NoError(t, err)
+
+		keyID, err := Verify(keys, digest[:], sig)
+		require.NoError(t, err)
+		require.Equal(t, sig.KeyID, keyID)
+
+		_, err = Verify(keys, otherDigest[:], sig)
+		require.Error(t, err)
+	}
+
+	sig, err := Sign(otherKey, digest[:])
+	require.NoError(t, err)
+	_, err = Verify(keys, digest[:], sig)
+	require.Error(t, err)
+
+	_, err = Verify(keys, digest[:], nil)
+	require.Error(t, err)
+}
+
+func TestParsePublicKeysSkipsExpiredCertificates(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys, err := ParsePublicKeys(encodeCertificate(t, key, time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
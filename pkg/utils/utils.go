@@ -51,6 +51,12 @@ func UrlForFunction(name, namespace string) string {
 	return fmt.Sprintf("%s/%s", prefix, name)
 }
 
+// UrlForJobInvocation returns the executor API path that submits
+// an invocation of a job function.
+func UrlForJobInvocation(name, namespace string) string {
+	return fmt.Sprintf("/v2/jobs/%s/%s", namespace, name)
+}
+
 // IsNetworkError returns true if an error is a network error, and false otherwise.
 func IsNetworkError(err error) bool {
 	_, ok := err.(net.Error)
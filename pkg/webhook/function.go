@@ -28,10 +28,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	v1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/quota"
+	"github.com/fission/fission/pkg/featureconfig"
 	"github.com/fission/fission/pkg/utils/loggerfactory"
 )
 
-type Function struct{}
+type Function struct {
+	QuotaConfig featureconfig.QuotaFeatureConfig
+}
 
 // log is for logging in this package.
 var functionlog = loggerfactory.GetLogger().Named("function-resource")
@@ -65,7 +69,7 @@ func (r *Function) ValidateCreate(_ context.Context, obj runtime.Object) (admiss
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Function but got a %T", obj))
 	}
 	functionlog.Debug("validate create", zap.String("name", new.Name))
-	return nil, r.validate(nil, new)
+	return quota.MaxScaleWarnings(r.QuotaConfig, new), r.validate(nil, new)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -75,7 +79,7 @@ func (r *Function) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Function but got a %T", newObj))
 	}
 	functionlog.Debug("validate update", zap.String("name", new.Name))
-	return nil, r.validate(nil, new)
+	return quota.MaxScaleWarnings(r.QuotaConfig, new), r.validate(nil, new)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/featureconfig"
 	"github.com/fission/fission/pkg/generated/clientset/versioned/scheme"
 	//+kubebuilder:scaffold:imports
 )
@@ -74,13 +75,19 @@ func Start(ctx context.Context, clientGen crd.ClientGeneratorInterface, logger *
 		return err
 	}
 
+	featureConfig, err := featureconfig.GetFeatureConfig(wLogger)
+	if err != nil {
+		wLogger.Error("unable to read feature config", zap.Error(err))
+		return err
+	}
+
 	// Setup webhooks
 
 	webhookInjectors := []WebhookInjector{
 		&CanaryConfig{},
 		&Environment{},
 		&Package{},
-		&Function{},
+		&Function{QuotaConfig: featureConfig.QuotaConfig},
 		&HTTPTrigger{},
 		&MessageQueueTrigger{},
 		&TimeTrigger{},